@@ -1,7 +1,10 @@
 package api
 
 import (
+	"crypto/subtle"
+
 	"notekeeper-electron-backend/account"
+	"notekeeper-electron-backend/codes"
 	"notekeeper-electron-backend/crypto"
 	"notekeeper-electron-backend/db"
 	"notekeeper-electron-backend/notebook"
@@ -12,6 +15,11 @@ import (
 
 // CreateAccount creates a new account
 func (api *API) CreateAccount(name string, email string, passphrase string) (*account.Account, error) {
+	err := api.CheckPassphrase(passphrase, name, email)
+	if err != nil {
+		return nil, err
+	}
+
 	// create account object
 	newAccount, err := account.New(api.DBRegistry, api.Logger, name)
 	if err != nil {
@@ -251,11 +259,11 @@ func (api *API) CreateAccountDefaults(acct *account.Account, currentUser *user.U
 		return err
 	}
 
-	userTrashShelf.EncryptedKey, err = acct.CreateEncryptedKey()
+	userTrashShelf.EncryptedKey, err = currentUser.CreateEncryptedKey(user.TypeUser)
 	if err != nil {
 		return err
 	}
-	userTrashShelfDBHandle.EncryptedKey = accountTrashShelf.EncryptedKey
+	userTrashShelfDBHandle.EncryptedKey = userTrashShelf.EncryptedKey
 
 	err = userShelfIndex.Save(userTrashShelf, unsealedUserKey)
 	if err != nil {
@@ -435,3 +443,110 @@ func (api *API) UnlockAccount(acct *account.Account, passphrase string) error {
 
 	return nil
 }
+
+// ChangePassphrase changes the passphrase of the active user of an account
+// The user & account keys are resealed with a key derived from the new
+// passphrase & a new salt.  Records are sealed with the user & account keys
+// rather than the passphrase key, so nothing else has to be re-encrypted.  The
+// user index entry is keyed by the salt, so the new entry is added before the
+// user is saved & the old one is removed afterwards.
+func (api *API) ChangePassphrase(acct *account.Account, currentPassphrase string, newPassphrase string) error {
+	if acct == nil || acct.ActiveUser == nil {
+		api.Logger.Warn("change passphrase missing account or user")
+		code := codes.New(codes.ScopeAccount, codes.ErrorUserMissing)
+		return code
+	}
+	activeUser := acct.ActiveUser
+
+	c := crypto.New(api.Logger)
+	currentKey, err := c.DeriveKey([]byte(currentPassphrase), activeUser.Salt)
+	if err != nil {
+		api.Logger.Warn("Error deriving key from passphrase - ", err)
+		code := codes.New(codes.ScopeUser, codes.ErrorDeriveKey)
+		return code
+	}
+	if subtle.ConstantTimeCompare(currentKey[:], activeUser.PassphraseKey) != 1 {
+		api.Logger.Info("Passphrase change refused - the current passphrase doesn't match")
+		code := codes.New(codes.ScopeUser, codes.ErrorUnauthorized)
+		return code
+	}
+
+	err = api.CheckPassphrase(newPassphrase, acct.Name, activeUser.Profile.Email)
+	if err != nil {
+		return err
+	}
+
+	userKey, err := activeUser.UnsealKey(user.TypePassphrase, activeUser.UserKey)
+	if err != nil {
+		return err
+	}
+	defer crypto.Zero(userKey)
+	accountKey, err := activeUser.UnsealKey(user.TypePassphrase, activeUser.AccountKey)
+	if err != nil {
+		return err
+	}
+	defer crypto.Zero(accountKey)
+
+	newKey, newSalt, err := c.DeriveKeyAndSalt([]byte(newPassphrase))
+	if err != nil {
+		api.Logger.Warn("Error deriving key from new passphrase - ", err)
+		code := codes.New(codes.ScopeUser, codes.ErrorDeriveKey)
+		return code
+	}
+	sealedUserKey, err := c.Seal(newKey[:], userKey)
+	if err != nil {
+		api.Logger.Warn("Error sealing user key - ", err)
+		code := codes.New(codes.ScopeUser, codes.ErrorEncrypt)
+		return code
+	}
+	sealedAccountKey, err := c.Seal(newKey[:], accountKey)
+	if err != nil {
+		api.Logger.Warn("Error sealing account key - ", err)
+		code := codes.New(codes.ScopeUser, codes.ErrorEncrypt)
+		return code
+	}
+
+	previous := *activeUser
+	restore := func() {
+		activeUser.Salt = previous.Salt
+		activeUser.PassphraseKey = previous.PassphraseKey
+		activeUser.UserKey = previous.UserKey
+		activeUser.AccountKey = previous.AccountKey
+	}
+	activeUser.Salt = newSalt
+	activeUser.PassphraseKey = newKey[:]
+	activeUser.UserKey = sealedUserKey
+	activeUser.AccountKey = sealedAccountKey
+
+	userIndex := user.NewIndex(acct.ID, api.DBRegistry, api.Logger)
+	err = userIndex.Save(activeUser, activeUser.PassphraseKey)
+	if err != nil {
+		restore()
+		return err
+	}
+	err = activeUser.Save()
+	if err != nil {
+		api.Logger.Warn("Error saving user with the new passphrase - ", err)
+		userIndex.Remove(activeUser, newSalt)
+		restore()
+		return err
+	}
+	err = userIndex.Remove(activeUser, previous.Salt)
+	if err != nil {
+		api.Logger.Warn("Error removing the user index entry for the old passphrase - ", err)
+		return err
+	}
+	crypto.Zero(previous.PassphraseKey)
+
+	// the open dbs hold their keys sealed with the passphrase key
+	userDBHandle, err := api.DBRegistry.GetHandle(db.Key{ID: activeUser.ID, Type: db.TypeUser})
+	if err == nil {
+		userDBHandle.EncryptedKey = activeUser.UserKey
+	}
+	accountDBHandle, err := api.DBRegistry.GetHandle(db.Key{ID: acct.ID, Type: db.TypeAccount})
+	if err == nil {
+		accountDBHandle.EncryptedKey = activeUser.AccountKey
+	}
+	acct.EncryptedKey = activeUser.AccountKey
+	return nil
+}
@@ -1,15 +1,18 @@
 package api
 
 import (
+	"notekeeper-electron-backend/codes"
 	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/passphrase"
 
 	"github.com/sirupsen/logrus"
 )
 
 // API interface
 type API struct {
-	DBRegistry *db.Registry
-	Logger     *logrus.Logger
+	DBRegistry       *db.Registry
+	Logger           *logrus.Logger
+	PassphrasePolicy *passphrase.Policy // PassphrasePolicy is checked for new passphrases (the default policy if nil)
 }
 
 // New creates a new API object
@@ -20,3 +23,22 @@ func New(registry *db.Registry, logger *logrus.Logger) *API {
 	}
 	return api
 }
+
+// CheckPassphrase checks a new passphrase against the passphrase policy
+// personal holds the account name & email address, which the passphrase can't contain.
+func (api *API) CheckPassphrase(newPassphrase string, personal ...string) error {
+	policy := api.PassphrasePolicy
+	if policy == nil {
+		policy = passphrase.DefaultPolicy()
+	}
+	err := policy.Check(newPassphrase, personal...)
+	if err != nil {
+		if internal, ok := err.(*codes.InternalError); ok {
+			api.Logger.Info("Passphrase refused by the passphrase policy - ", internal.Code.Reason())
+			return err
+		}
+		api.Logger.Warn("Error checking passphrase against the breach list - ", err)
+		return codes.New(codes.ScopeAccount, codes.ErrorLoad)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"notekeeper-electron-backend/account"
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/notebook"
+	"notekeeper-electron-backend/shelf"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OpenShelves loads the account & user shelves of the active user & opens their dbs
+// Shelf dbs that are already open are reused.  The shelves are returned with
+// account shelves first.  Moves that were interrupted are recovered as each
+// shelf is opened, so they don't wait for the next move out of the shelf.
+func (api *API) OpenShelves(acct *account.Account) ([]*shelf.Shelf, error) {
+	owners := []struct {
+		scope shelf.Scope
+		id    uuid.UUID
+	}{
+		{shelf.ScopeAccount, acct.ID},
+		{shelf.ScopeUser, acct.ActiveUser.ID},
+	}
+
+	var shelves []*shelf.Shelf
+	for _, owner := range owners {
+		index := shelf.NewIndex(owner.scope, owner.id, api.DBRegistry, api.Logger)
+		err := index.LoadAll(acct.ActiveUser.PassphraseKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range index.Shelves {
+			s.OwnerID = owner.id
+			key := db.Key{ID: s.ID, Type: db.TypeShelf}
+			handle, err := api.DBRegistry.GetHandle(key)
+			if err != nil {
+				handle, err = api.DBRegistry.NewHandle(key)
+				if err != nil {
+					api.Logger.Warn("could not open shelf db [", s.ID, "]")
+					return nil, err
+				}
+			}
+			handle.EncryptedKey = s.EncryptedKey
+			shelves = append(shelves, s)
+		}
+	}
+
+	for _, s := range shelves {
+		api.recoverTransfers(acct, s)
+	}
+	return shelves, nil
+}
+
+// recoverTransfers completes or rolls back interrupted moves out of a shelf & its open collections
+// Recovery is retried by the next move, so failures are logged rather than
+// stopping the shelves from opening.
+func (api *API) recoverTransfers(acct *account.Account, s *shelf.Shelf) {
+	notebookScope := notebook.ScopeAccount
+	collectionScope := collection.ScopeAccount
+	if s.Scope == shelf.ScopeUser {
+		notebookScope = notebook.ScopeUser
+		collectionScope = collection.ScopeUser
+	}
+	key, err := api.ShelfKey(acct, s.Scope)
+	if err != nil {
+		api.Logger.Warn("Unable to recover transfers for shelf [", s.ID, "] - ", err)
+		return
+	}
+	defer crypto.Zero(key)
+
+	containers := []*notebook.Notebook{{
+		Scope:         notebookScope,
+		OwnerID:       s.OwnerID,
+		ContainerType: notebook.ContainerTypeShelf,
+		ContainerID:   s.ID,
+	}}
+	index := collection.NewIndex(collectionScope, api.DBRegistry, api.Logger)
+	index.ShelfID = s.ID
+	index.OwnerID = s.OwnerID
+	err = index.LoadAll(key)
+	if err != nil {
+		api.Logger.Warn("Unable to load collections of shelf [", s.ID, "] - ", err)
+	}
+	for _, c := range index.Collections {
+		_, err := api.DBRegistry.GetHandle(db.Key{ID: c.ID, Type: db.TypeCollection})
+		if err != nil {
+			// collection dbs that aren't open are recovered when they are
+			continue
+		}
+		containers = append(containers, &notebook.Notebook{
+			Scope:         notebookScope,
+			OwnerID:       s.OwnerID,
+			ContainerType: notebook.ContainerTypeCollection,
+			ContainerID:   c.ID,
+		})
+	}
+
+	for _, container := range containers {
+		container.DBRegistry = api.DBRegistry
+		container.Logger = api.Logger
+		err = container.Recover(key)
+		if err != nil {
+			api.Logger.Warn("Unable to recover transfers for [", container.ContainerID, "] - ", err)
+		}
+	}
+}
+
+// ShelfKey returns the key that seals the db keys of shelves in scope
+// Shelf db keys are sealed with the account key for account shelves & the user
+// key for user shelves, so content stored in a shelf is opened with this key
+// rather than the passphrase key.
+func (api *API) ShelfKey(acct *account.Account, scope shelf.Scope) ([]byte, error) {
+	sealedKey := acct.EncryptedKey
+	if scope == shelf.ScopeUser {
+		sealedKey = acct.ActiveUser.UserKey
+	}
+	c := crypto.New(api.Logger)
+	key, err := c.Open(acct.ActiveUser.PassphraseKey, sealedKey)
+	if err != nil {
+		api.Logger.Warn("Error opening shelf owner key - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorOpenKey)
+		return nil, code
+	}
+	return key, nil
+}
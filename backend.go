@@ -3,20 +3,31 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
 	"notekeeper-electron-backend/handler"
+	"notekeeper-electron-backend/logfile"
+	"notekeeper-electron-backend/passphrase"
+	"notekeeper-electron-backend/redact"
 	"notekeeper-electron-backend/rpc"
 
 	"github.com/sirupsen/logrus"
 	"go.etcd.io/bbolt"
-	//	"google.golang.org/grpc/credentials"
 	//	"golang.org/x/crypto/nacl/box"
 )
 
 const (
-	// BackendPort is the GRPC service port
+	// BackendPort is the signed HTTP RPC service port
 	BackendPort = "localhost:53017"
-	// LogFile is the default log file name
+	// BackendGRPCPort is the gRPC service port
+	BackendGRPCPort = "localhost:53018"
+	// BackendSocket is the signed HTTP RPC socket name in the data directory
+	BackendSocket = "notekeeper.sock"
+	// BackendGRPCSocket is the gRPC socket name in the data directory
+	BackendGRPCSocket = "notekeeper-grpc.sock"
+	// LogFile is the default log file name in the data directory
 	LogFile = "notekeeper.log"
 )
 
@@ -27,48 +38,104 @@ type Backend struct {
 	RPC      *rpc.Server
 	Status   chan string
 	Shutdown chan bool
+	Config   *Config
+	LogFile  *logfile.File
+	// PassphrasePolicy is the configured policy for new passphrases
+	PassphrasePolicy *passphrase.Policy
 	//Account *Account
 }
 
 // NewBackend creates a new backend object
-func NewBackend() *Backend {
+func NewBackend(config *Config) *Backend {
+	logger, err := newLogger(config)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 	backend := &Backend{
-		Logger:   logrus.New(),
+		Logger:   logger,
 		Status:   make(chan string),
 		Shutdown: make(chan bool),
+		Config:   config,
 	}
-
-	backend.Logger.Formatter = &logrus.JSONFormatter{}
-	level, err := logrus.ParseLevel(LogLevel)
+	backend.LogFile, _ = logger.Out.(*logfile.File)
+	backend.PassphrasePolicy, err = config.PassphrasePolicy()
 	if err != nil {
-		fmt.Println("Invalid log level [", LogLevel, "]")
+		fmt.Println(err)
 		os.Exit(1)
 	}
-	backend.Logger.Level = level
+	return backend
+}
 
-	var file *os.File
-	file, err = os.OpenFile(LogFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+// newLogger creates a logger writing to the configured log file
+// The log file is rotated according to the configured policy.
+func newLogger(config *Config) (*logrus.Logger, error) {
+	logger := logrus.New()
+	if config.LogFormat == LogFormatText {
+		logger.Formatter = &logrus.TextFormatter{DisableColors: true}
+	} else {
+		logger.Formatter = &logrus.JSONFormatter{}
+	}
+	level, err := logrus.ParseLevel(config.LogLevel)
 	if err != nil {
-		fmt.Println("Unable to open log file [", LogFile, "] - ", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("invalid log level [%s]", config.LogLevel)
 	}
-	backend.Logger.Out = file
+	logger.Level = level
 
-	return backend
+	err = os.MkdirAll(filepath.Dir(config.LogFile), 0700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create log directory for [%s] - %v", config.LogFile, err)
+	}
+	file, err := logfile.Open(config.LogFile, config.LogPolicy())
+	if err != nil {
+		return nil, fmt.Errorf("unable to open log file [%s] - %v", config.LogFile, err)
+	}
+	logger.Out = file
+	redact.Install(logger)
+	return logger, nil
 }
 
 // Run is called when the application is started
+// The backend runs until a Service::shutdown request or SIGINT/SIGTERM, either of
+// which shuts it down cleanly.
 func (backend *Backend) Run() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
 	backend.RPC = rpc.NewServer(backend.Logger, backend.Status, backend.Shutdown)
-	backend.RPC.RegisterHandlers(handler.Handlers())
-	go backend.RPC.Start(BackendPort)
+	err := backend.RPC.Register(handler.Methods()...)
+	if err != nil {
+		backend.Logger.Error("Error registering RPC methods - ", err)
+		backend.LogFile.Close()
+		os.Exit(1)
+	}
+	backend.RPC.CheckPeerCredentials = *backend.Config.PeerCredentials
+	backend.RPC.CertificatePath = backend.Config.DataDir
+	backend.RPC.DataPath = backend.Config.DataDir
+	backend.RPC.Version = Version
+	backend.RPC.BuildType = BuildType
+	backend.RPC.Log = backend.LogFile
+	backend.RPC.PassphrasePolicy = backend.PassphrasePolicy
+	backend.RPC.ClientPolicy = backend.Config.ClientPolicy()
+	listener, grpcListener := backend.Config.Listeners()
+	go func() {
+		if !backend.RPC.Start(listener, grpcListener) {
+			backend.Shutdown <- false
+		}
+	}()
 	for {
 		select {
 		case msg := <-backend.Status:
 			fmt.Println(msg)
+		case sig := <-signals:
+			// a second signal gets the default behaviour & kills the process
+			backend.Logger.Info("Received signal [", sig, "]")
+			signal.Stop(signals)
+			backend.RPC.RequestShutdown()
 		case ok := <-backend.Shutdown:
 			backend.Logger.Info("Shutting down service...")
 			backend.RPC.Stop()
+			backend.LogFile.Close()
 			if !ok {
 				os.Exit(1)
 			} else {
@@ -0,0 +1,92 @@
+package codes
+
+// Field error reasons
+// These are stable so the frontend can choose what guidance to show.
+const (
+	FieldRequired = "required"  // FieldRequired means the field is empty
+	FieldInvalid  = "invalid"   // FieldInvalid means the field can't be parsed (e.g. a malformed id)
+	FieldUnknown  = "unknown"   // FieldUnknown means the field isn't one of the allowed values
+	FieldTooShort = "too_short" // FieldTooShort means the field is shorter than allowed
+	FieldTooLong  = "too_long"  // FieldTooLong means the field is longer than allowed
+	FieldWeak     = "weak"      // FieldWeak means a passphrase is too easy to guess
+	FieldBanned   = "banned"    // FieldBanned means a passphrase matches a banned pattern or personal details
+	FieldBreached = "breached"  // FieldBreached means a passphrase is on the breach list
+)
+
+// FieldError describes a request field that failed validation
+type FieldError struct {
+	Field   string
+	Reason  string
+	Message string
+}
+
+// reasons are the machine-readable names of the error codes
+// Codes are only ever added, so these never change.
+var reasons = map[Code]string{
+	ErrorOK:             "ok",
+	ErrorUnknown:        "unknown",
+	ErrorInternalEscape: "internal_escape",
+	ErrorUnauthorized:   "unauthorized",
+	ErrorInvalidType:    "invalid_type",
+	ErrorMissingDB:      "missing_db",
+	ErrorDbOpen:         "db_open",
+	ErrorDbClose:        "db_close",
+	ErrorCreateBucket:   "create_bucket",
+	ErrorMarshal:        "marshal",
+	ErrorOpenKey:        "open_key",
+	ErrorEncrypt:        "encrypt",
+	ErrorDecrypt:        "decrypt",
+	ErrorCrypto:         "crypto",
+	ErrorWriteBucket:    "write_bucket",
+	ErrorSave:           "save",
+	ErrorBucketMissing:  "bucket_missing",
+	ErrorDecode:         "decode",
+	ErrorDeriveKey:      "derive_key",
+	ErrorConvertID:      "convert_id",
+	ErrorLookup:         "lookup",
+	ErrorLoad:           "load",
+	ErrorLoadAll:        "load_all",
+	ErrorDelete:         "delete",
+	ErrorCreate:         "create",
+	ErrorUserMissing:    "user_missing",
+	ErrorRecordMissing:  "record_missing",
+	ErrorCycle:          "cycle",
+	ErrorLocked:         "locked",
+	ErrorShutdown:       "shutdown",
+	ErrorInvalid:        "invalid",
+
+	ErrorPassphraseTooShort: "passphrase_too_short",
+	ErrorPassphraseWeak:     "passphrase_weak",
+	ErrorPassphraseBanned:   "passphrase_banned",
+	ErrorPassphraseBreached: "passphrase_breached",
+
+	ErrorNotEmpty: "not_empty",
+	ErrorDbInUse:  "db_in_use",
+}
+
+// Reason returns the machine-readable name of a code
+func (e Code) Reason() string {
+	if reason, ok := reasons[e]; ok {
+		return reason
+	}
+	return reasons[ErrorUnknown]
+}
+
+// Retryable reports whether an error with this code may go away on its own
+// A db that is open in another process (e.g. a headless command) or a backend
+// that is shutting down will fail now but not necessarily later; invalid input
+// & missing records will fail every time.
+func (e Code) Retryable() bool {
+	switch e {
+	case ErrorDbOpen, ErrorDbInUse, ErrorSave, ErrorWriteBucket, ErrorShutdown:
+		return true
+	}
+	return false
+}
+
+// Invalid creates an application error for request fields that failed validation
+func Invalid(scope Scope, details ...FieldError) *InternalError {
+	err := NewApplication(scope, ErrorInvalid)
+	err.Details = details
+	return err
+}
@@ -16,10 +16,12 @@ type ErrorType int32
 
 // InternalError is a custom error type
 type InternalError struct {
-	Scope   Scope
-	Code    Code
-	Type    ErrorType
-	Message string
+	Scope     Scope
+	Code      Code
+	Type      ErrorType
+	Message   string
+	Details   []FieldError // Details are the request fields that failed validation
+	Retryable bool         // Retryable errors may go away if the request is made again later
 }
 
 // These are the status types that can be passed to the front end
@@ -57,6 +59,8 @@ const (
 	ScopeTitle
 	ScopeUIState
 	ScopeUser
+	ScopeStats
+	ScopeLock
 )
 
 // These are the error codes that can be passed to the front end
@@ -89,6 +93,19 @@ const (
 	ErrorCreate
 	ErrorUserMissing
 	ErrorRecordMissing
+	ErrorCycle
+	ErrorLocked   // ErrorLocked is an application error - the record or a container holding it is locked
+	ErrorShutdown // ErrorShutdown means the request arrived while the backend was shutting down
+	ErrorInvalid  // ErrorInvalid is an application error - request fields failed validation (see Details)
+
+	// Passphrase policy errors are application errors - the frontend shows guidance for each
+	ErrorPassphraseTooShort
+	ErrorPassphraseWeak
+	ErrorPassphraseBanned
+	ErrorPassphraseBreached
+
+	ErrorNotEmpty // ErrorNotEmpty is an application error - a notebook still holds notebooks or notes
+	ErrorDbInUse  // ErrorDbInUse means a db file is locked by another process, such as a running backend
 )
 
 // String converts error code to a string
@@ -106,14 +123,32 @@ func (scope Scope) String() string {
 func New(scope Scope, code Code) *InternalError {
 	msg := messageFromCode(scope, code)
 	err := &InternalError{
-		Type:    TypeSystem,
-		Code:    code,
-		Scope:   scope,
-		Message: msg,
+		Type:      TypeSystem,
+		Code:      code,
+		Scope:     scope,
+		Message:   msg,
+		Retryable: code.Retryable(),
 	}
 	return err
 }
 
+// NewApplication creates a new InternalError for an application-level error
+// Application errors are expected during normal use (e.g. editing a locked
+// note) rather than being a sign that something went wrong.
+func NewApplication(scope Scope, code Code) *InternalError {
+	err := New(scope, code)
+	err.Type = TypeApplication
+	return err
+}
+
+// IsApplicationError tests whether err is an application-level error
+func IsApplicationError(err error) bool {
+	if internal, ok := err.(*InternalError); ok {
+		return internal.Type == TypeApplication
+	}
+	return false
+}
+
 // Error satisfies the error type interface
 func (error *InternalError) Error() string {
 	return error.Message
@@ -169,6 +204,10 @@ func messageFromScope(scope Scope) string {
 		msgScope = "ui"
 	case ScopeUser:
 		msgScope = "user"
+	case ScopeStats:
+		msgScope = "stats"
+	case ScopeLock:
+		msgScope = "lock"
 	default:
 		msgScope = "default"
 	}
@@ -235,6 +274,26 @@ func defaultMessageFromCode(code Code) string {
 		msg = "error missing user"
 	case ErrorRecordMissing:
 		msg = "error missing record"
+	case ErrorCycle:
+		msg = "error cycle detected"
+	case ErrorLocked:
+		msg = "error locked"
+	case ErrorShutdown:
+		msg = "error shutting down"
+	case ErrorInvalid:
+		msg = "error invalid request"
+	case ErrorPassphraseTooShort:
+		msg = "error passphrase too short"
+	case ErrorPassphraseWeak:
+		msg = "error passphrase too weak"
+	case ErrorPassphraseBanned:
+		msg = "error passphrase banned"
+	case ErrorPassphraseBreached:
+		msg = "error passphrase breached"
+	case ErrorNotEmpty:
+		msg = "error not empty"
+	case ErrorDbInUse:
+		msg = "error db in use"
 	}
 
 	return msg
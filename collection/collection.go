@@ -5,6 +5,7 @@ import (
 
 	"notekeeper-electron-backend/db"
 	"notekeeper-electron-backend/notebook"
+	"notekeeper-electron-backend/stats"
 	"notekeeper-electron-backend/tag"
 	"notekeeper-electron-backend/title"
 
@@ -35,6 +36,7 @@ type Collection struct {
 	Created      time.Time            `json:"created"`        // Created is the time when the collection was first created
 	Updated      time.Time            `json:"updated"`        // Updated is the time when the collection was last updated
 	Locked       bool                 `json:"locked"`         // Locked indicates whether the collection can be modified
+	Stats        *stats.Stats         `json:"stats"`          // Stats is the last known summary of the notes in the collection
 	DBRegistry   *db.Registry         `json:"-"`              // DBRegistry provides database access
 	Logger       *logrus.Logger       `json:"-"`              // Logger is the logging facility
 }
@@ -94,14 +94,6 @@ func (index *Index) Save(collection *Collection, passphraseKey []byte) error {
 			return code
 		}
 
-		// serialize collection data
-		data, err := json.Marshal(collection)
-		if err != nil {
-			index.Logger.Warn("Error marshaling collection - ", err)
-			code := codes.New(codes.ScopeCollection, codes.ErrorMarshal)
-			return code
-		}
-
 		// retrieve the encryption key
 		c := crypto.New(index.Logger)
 		decryptedKey, err := c.Open(passphraseKey, shelfDBHandle.EncryptedKey)
@@ -111,6 +103,19 @@ func (index *Index) Save(collection *Collection, passphraseKey []byte) error {
 			return code
 		}
 
+		err = index.checkUnlocked(tx, decryptedKey, collection.ID, collection)
+		if err != nil {
+			return err
+		}
+
+		// serialize collection data
+		data, err := json.Marshal(collection)
+		if err != nil {
+			index.Logger.Warn("Error marshaling collection - ", err)
+			code := codes.New(codes.ScopeCollection, codes.ErrorMarshal)
+			return code
+		}
+
 		// encrypt the data
 		encryptedData, err := c.Seal(decryptedKey, data)
 		if err != nil {
@@ -138,7 +143,45 @@ func (index *Index) Save(collection *Collection, passphraseKey []byte) error {
 		return code
 	}
 
-	return nil
+	// the shelf can't be locked at this point, or the save would have failed
+	return index.saveLock(collection, false, passphraseKey)
+}
+
+// Load a single collection from the collection index
+func (index *Index) Load(id uuid.UUID, passphraseKey []byte) (*Collection, error) {
+	shelfDBHandle, err := index.getDBHandle()
+	if err != nil {
+		return nil, err
+	}
+
+	c := crypto.New(index.Logger)
+	shelfKey, err := c.Open(passphraseKey, shelfDBHandle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening collection key - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorOpenKey)
+		return nil, code
+	}
+
+	var collection *Collection
+	err = shelfDBHandle.DB.View(func(tx *bbolt.Tx) error {
+		collection, err = index.readRecord(tx, shelfKey, id)
+		if err != nil {
+			return err
+		}
+		if collection == nil {
+			index.Logger.Debug("Collection [", id, "] is not in the index")
+			code := codes.New(codes.ScopeCollection, codes.ErrorRecordMissing)
+			return code
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	collection.DBRegistry = index.DBRegistry
+	collection.Logger = index.Logger
+	return collection, nil
 }
 
 // LoadAll collections
@@ -203,6 +246,13 @@ func (index *Index) Delete(collection *Collection, passphraseKey []byte) error {
 	if err != nil {
 		return err
 	}
+	c := crypto.New(index.Logger)
+	shelfKey, err := c.Open(passphraseKey, shelfDBHandle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error retrieving collection key - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorOpenKey)
+		return code
+	}
 
 	err = shelfDBHandle.DB.Update(func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket([]byte("collection_index"))
@@ -212,7 +262,12 @@ func (index *Index) Delete(collection *Collection, passphraseKey []byte) error {
 			return code
 		}
 
-		err := bucket.Delete(collection.ID.Bytes())
+		err := index.checkUnlocked(tx, shelfKey, collection.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		err = bucket.Delete(collection.ID.Bytes())
 		if err != nil {
 			index.Logger.Warn("Error deleting collection - ", err)
 			code := codes.New(codes.ScopeCollection, codes.ErrorDelete)
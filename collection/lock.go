@@ -0,0 +1,150 @@
+package collection
+
+import (
+	"encoding/json"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/lock"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// readRecord decodes a collection from the collection index of an open transaction
+// A nil result means that the collection isn't in the index.
+func (index *Index) readRecord(tx *bbolt.Tx, shelfKey []byte, id uuid.UUID) (*Collection, error) {
+	bucket := tx.Bucket([]byte("collection_index"))
+	if bucket == nil {
+		return nil, nil
+	}
+	value := bucket.Get(id.Bytes())
+	if value == nil {
+		return nil, nil
+	}
+	c := crypto.New(index.Logger)
+	decryptedData, err := c.Open(shelfKey, value)
+	if err != nil {
+		index.Logger.Warn("Error decrypting collection data - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorDecrypt)
+		return nil, code
+	}
+	collection := &Collection{}
+	err = json.Unmarshal(decryptedData, collection)
+	if err != nil {
+		index.Logger.Warn("Error decoding collection json - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorDecode)
+		return nil, code
+	}
+	return collection, nil
+}
+
+// checkUnlocked fails with ErrorLocked when a collection can't be changed
+// A locked collection can still be saved in order to unlock it, as long as the
+// shelf holding it isn't locked. current is nil for a delete.
+func (index *Index) checkUnlocked(tx *bbolt.Tx, shelfKey []byte, id uuid.UUID, current *Collection) error {
+	locked, err := lock.Locked(tx, shelfKey, index.ShelfID, index.Logger)
+	if err != nil {
+		return err
+	}
+	if locked {
+		index.Logger.Info("Shelf [", index.ShelfID, "] holding collection [", id, "] is locked")
+		code := codes.NewApplication(codes.ScopeCollection, codes.ErrorLocked)
+		return code
+	}
+
+	existing, err := index.readRecord(tx, shelfKey, id)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Locked && (current == nil || current.Locked) {
+		index.Logger.Info("Collection [", id, "] is locked")
+		code := codes.NewApplication(codes.ScopeCollection, codes.ErrorLocked)
+		return code
+	}
+	return nil
+}
+
+// saveLock records the lock state of a collection in the collection's own db
+// The record is what the notebooks & notes in the collection inherit from, so it
+// is locked when either the collection or the shelf holding it is locked.
+// Collections whose db isn't open are skipped.
+func (index *Index) saveLock(collection *Collection, shelfLocked bool, ownerKey []byte) error {
+	handle, err := index.DBRegistry.GetHandle(db.Key{ID: collection.ID, Type: db.TypeCollection})
+	if err != nil {
+		return nil
+	}
+	c := crypto.New(index.Logger)
+	collectionKey, err := c.Open(ownerKey, handle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening collection key - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorOpenKey)
+		return code
+	}
+
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		l := &lock.Lock{Locked: collection.Locked || shelfLocked}
+		return lock.Save(tx, collectionKey, collection.ID, l, index.Logger)
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		index.Logger.Warn("Error saving collection lock - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
+
+// SaveLocks brings the lock records of every collection in the shelf up to date
+// This is called when the lock state of the shelf changes, so that everything
+// stored in the shelf's collections inherits it. ownerKey is the account or user
+// key that the shelf & collection dbs are sealed with.
+func (index *Index) SaveLocks(shelfLocked bool, ownerKey []byte) error {
+	handle, err := index.getDBHandle()
+	if err != nil {
+		return err
+	}
+	c := crypto.New(index.Logger)
+	shelfKey, err := c.Open(ownerKey, handle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening collection key - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorOpenKey)
+		return code
+	}
+
+	var collections []*Collection
+	err = handle.DB.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("collection_index"))
+		if bucket == nil {
+			return nil
+		}
+		cursor := bucket.Cursor()
+		for key, _ := cursor.First(); key != nil; key, _ = cursor.Next() {
+			collection, err := index.readRecord(tx, shelfKey, uuid.FromBytesOrNil(key))
+			if err != nil {
+				return err
+			}
+			collections = append(collections, collection)
+		}
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		index.Logger.Warn("Error loading collections - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorLoadAll)
+		return code
+	}
+
+	for _, collection := range collections {
+		err = index.saveLock(collection, shelfLocked, ownerKey)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package collection
+
+import (
+	"encoding/json"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/stats"
+
+	"go.etcd.io/bbolt"
+)
+
+// loadStats reads the note stats of a collection from the collection's own db
+// A nil result means the collection db isn't open, so its stats can't be read.
+// ownerKey is the account or user key that the shelf & collection dbs are sealed with.
+func (index *Index) loadStats(collection *Collection, ownerKey []byte) (*stats.Stats, error) {
+	handle, err := index.DBRegistry.GetHandle(db.Key{ID: collection.ID, Type: db.TypeCollection})
+	if err != nil {
+		return nil, nil
+	}
+	c := crypto.New(index.Logger)
+	collectionKey, err := c.Open(ownerKey, handle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening collection key - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorOpenKey)
+		return nil, code
+	}
+	return stats.LoadContainer(handle.DB, collectionKey, collection.ID, index.Logger)
+}
+
+// RefreshStats loads the collections in the index & updates their note stats
+// The stats kept in each collection db are authoritative - the copy in the
+// index record is brought up to date here so it can be listed without opening
+// every collection. Collections whose db isn't open keep their last known stats.
+func (index *Index) RefreshStats(ownerKey []byte) error {
+	shelfDBHandle, err := index.getDBHandle()
+	if err != nil {
+		return err
+	}
+	c := crypto.New(index.Logger)
+	shelfKey, err := c.Open(ownerKey, shelfDBHandle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening collection key - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorOpenKey)
+		return code
+	}
+
+	index.Collections = nil
+	err = shelfDBHandle.DB.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("collection_index"))
+		if bucket == nil {
+			// no collections in this shelf yet
+			return nil
+		}
+
+		cursor := bucket.Cursor()
+		for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+			newCollection := &Collection{
+				DBRegistry: index.DBRegistry,
+				Logger:     index.Logger,
+			}
+			decryptedData, err := c.Open(shelfKey, value)
+			if err != nil {
+				index.Logger.Warn("Error decrypting collection data - ", err)
+				code := codes.New(codes.ScopeCollection, codes.ErrorDecrypt)
+				return code
+			}
+			err = json.Unmarshal(decryptedData, newCollection)
+			if err != nil {
+				index.Logger.Warn("Error decoding collection json - ", err)
+				code := codes.New(codes.ScopeCollection, codes.ErrorDecode)
+				return code
+			}
+			index.Collections = append(index.Collections, newCollection)
+		}
+
+		// records are rewritten after the cursor is done with the bucket
+		for _, collection := range index.Collections {
+			current, err := index.loadStats(collection, ownerKey)
+			if err != nil {
+				return err
+			}
+			if current == nil {
+				continue
+			}
+			collection.Stats = current
+
+			data, err := json.Marshal(collection)
+			if err != nil {
+				index.Logger.Warn("Error marshaling collection - ", err)
+				code := codes.New(codes.ScopeCollection, codes.ErrorMarshal)
+				return code
+			}
+			encryptedData, err := c.Seal(shelfKey, data)
+			if err != nil {
+				index.Logger.Warn("Error encrypting collection data - ", err)
+				code := codes.New(codes.ScopeCollection, codes.ErrorEncrypt)
+				return code
+			}
+			err = bucket.Put(collection.ID.Bytes(), encryptedData)
+			if err != nil {
+				index.Logger.Warn("Error writing collection - ", err)
+				code := codes.New(codes.ScopeCollection, codes.ErrorWriteBucket)
+				return code
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		index.Logger.Warn("Error refreshing collection stats - ", err)
+		code := codes.New(codes.ScopeCollection, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
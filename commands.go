@@ -0,0 +1,377 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/query"
+	"notekeeper-electron-backend/shelf"
+	"notekeeper-electron-backend/title"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/urfave/cli"
+)
+
+// commands are the headless subcommands
+// They work directly on the dbs in the data directory, so they can't be run
+// while the backend is serving the same data directory.  Only notes &
+// notebooks stored directly in shelves are covered - collections are skipped.
+var commands = []cli.Command{
+	{
+		Name:   "shelves",
+		Usage:  "list shelves",
+		Flags:  signinFlags,
+		Action: withSession(listShelves),
+	},
+	{
+		Name:   "notebooks",
+		Usage:  "list notebooks",
+		Flags:  append([]cli.Flag{shelfFlag}, signinFlags...),
+		Action: withSession(listNotebooks),
+	},
+	{
+		Name:  "notes",
+		Usage: "list notes",
+		Flags: append([]cli.Flag{
+			shelfFlag,
+			cli.StringFlag{Name: "notebook", Usage: "only list notes in the notebook with this `id`"},
+		}, signinFlags...),
+		Action: withSession(listNotes),
+	},
+	{
+		Name:      "cat",
+		Usage:     "print the content of a note",
+		ArgsUsage: "<note id>",
+		Flags:     signinFlags,
+		Action:    withSession(catNote),
+	},
+	{
+		Name:      "edit",
+		Usage:     "edit the content of a note with $EDITOR",
+		ArgsUsage: "<note id>",
+		Flags:     signinFlags,
+		Action:    withSession(editNote),
+	},
+	{
+		Name:  "create",
+		Usage: "create a note with content read from stdin",
+		Flags: append([]cli.Flag{
+			cli.StringFlag{Name: "title", Usage: "note `title` (required)"},
+			cli.StringFlag{Name: "shelf", Usage: "shelf `id` (default: the user's default shelf)"},
+			cli.StringFlag{Name: "notebook", Usage: "notebook `id` (default: the shelf's default notebook)"},
+			cli.StringFlag{Name: "type", Value: "plaintext", Usage: "note `type`, e.g. plaintext or markdown"},
+		}, signinFlags...),
+		Action: withSession(createNote),
+	},
+	{
+		Name:      "search",
+		Usage:     "list notes whose title or content contains text, ignoring case",
+		ArgsUsage: "<text>",
+		Flags:     append([]cli.Flag{shelfFlag}, signinFlags...),
+		Action:    withSession(searchNotes),
+	},
+	{
+		Name:  "doctor",
+		Usage: "check the dbs in the data directory for damage",
+		Description: "Walks the master, account, user, shelf & collection dbs through their index buckets,\n" +
+			"   reporting orphaned & missing db files and bucket stats.  Signing in also checks that\n" +
+			"   the records of that account can be decrypted, and allows --repair.",
+		Flags: append([]cli.Flag{
+			cli.BoolFlag{Name: "repair", Usage: "quarantine bad records & orphaned dbs, recreate missing shelf dbs and rebuild stats"},
+			cli.BoolFlag{Name: "json", Usage: "print the report as json"},
+		}, signinFlags...),
+		Action: runDoctor,
+	},
+}
+
+var shelfFlag = cli.StringFlag{Name: "shelf", Usage: "only include the shelf with this `id`"}
+
+// withSession signs in before running a command & signs out afterwards
+// Errors are reported with a non-zero exit status.
+func withSession(action func(c *cli.Context, session *Session) error) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		session, err := openSession(c)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		defer session.Close()
+		err = action(c, session)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		return nil
+	}
+}
+
+// eachShelf calls f for each shelf selected by the --shelf flag
+// When listing every shelf, shelves that can't be opened are reported & skipped.
+// The collections in each shelf are reported as skipped too.
+func eachShelf(c *cli.Context, session *Session, f func(s *shelf.Shelf) error) error {
+	shelves, err := session.findShelves(c.String("shelf"))
+	if err != nil {
+		return err
+	}
+	for _, s := range shelves {
+		err = f(s)
+		if err != nil && len(shelves) == 1 {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintln(c.App.ErrWriter, "Skipping shelf [", s.ID, "] -", err)
+		}
+		collections, _ := session.collections(s)
+		if len(collections) > 0 {
+			fmt.Fprintln(c.App.ErrWriter, "Skipping [", len(collections), "] collections in shelf [", s.ID, "] - collections aren't supported")
+		}
+	}
+	return nil
+}
+
+func scopeName(scope shelf.Scope) string {
+	if scope == shelf.ScopeAccount {
+		return "account"
+	}
+	return "user"
+}
+
+func titleText(t *title.Title) string {
+	if t == nil {
+		return ""
+	}
+	return t.Title
+}
+
+func listShelves(c *cli.Context, session *Session) error {
+	w := tabwriter.NewWriter(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSCOPE\tTITLE\t")
+	for _, s := range session.Shelves {
+		var flags []string
+		if s.Default {
+			flags = append(flags, "default")
+		}
+		if s.Trash {
+			flags = append(flags, "trash")
+		}
+		if s.Locked {
+			flags = append(flags, "locked")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.ID, scopeName(s.Scope), titleText(s.Title), strings.Join(flags, ","))
+	}
+	return w.Flush()
+}
+
+func listNotebooks(c *cli.Context, session *Session) error {
+	w := tabwriter.NewWriter(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSHELF\tPARENT\tTITLE")
+	err := eachShelf(c, session, func(s *shelf.Shelf) error {
+		notebooks, err := session.notebooks(s)
+		if err != nil {
+			return err
+		}
+		for _, n := range notebooks {
+			parent := ""
+			if n.ParentID != uuid.Nil {
+				parent = n.ParentID.String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", n.ID, s.ID, parent, titleText(n.Title))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// printNotes lists the notes in each shelf that match q
+func printNotes(c *cli.Context, session *Session, q *query.Query) error {
+	w := tabwriter.NewWriter(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNOTEBOOK\tUPDATED\tTITLE")
+	err := eachShelf(c, session, func(s *shelf.Shelf) error {
+		key, err := session.API.ShelfKey(session.Account, s.Scope)
+		if err != nil {
+			return err
+		}
+		proxy, err := session.noteProxy(s)
+		if err != nil {
+			return err
+		}
+		notes, _, err := proxy.LoadPage(q, key)
+		if bucketMissing(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, n := range notes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", n.ID, n.NotebookID, n.Updated.Format(time.RFC3339), titleText(n.Title))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func listNotes(c *cli.Context, session *Session) error {
+	q := &query.Query{}
+	if c.String("notebook") != "" {
+		notebookID, err := uuid.FromString(c.String("notebook"))
+		if err != nil {
+			return fmt.Errorf("invalid notebook id [%s]", c.String("notebook"))
+		}
+		q.Extra = func(item query.Item) bool {
+			return item.(*note.Note).NotebookID == notebookID
+		}
+	}
+	return printNotes(c, session, q)
+}
+
+func searchNotes(c *cli.Context, session *Session) error {
+	text := strings.ToLower(strings.Join(c.Args(), " "))
+	if text == "" {
+		return fmt.Errorf("search text is required")
+	}
+	q := &query.Query{
+		Extra: func(item query.Item) bool {
+			n := item.(*note.Note)
+			return strings.Contains(strings.ToLower(titleText(n.Title)), text) ||
+				strings.Contains(strings.ToLower(n.Content), text)
+		},
+	}
+	return printNotes(c, session, q)
+}
+
+func catNote(c *cli.Context, session *Session) error {
+	_, n, err := session.findNote(c.Args().First())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(c.App.Writer, n.Content)
+	return err
+}
+
+// editor returns the command line used to edit notes
+func editor() []string {
+	command := strings.Fields(os.Getenv("EDITOR"))
+	if len(command) == 0 {
+		command = []string{"vi"}
+	}
+	return command
+}
+
+func editNote(c *cli.Context, session *Session) error {
+	s, n, err := session.findNote(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile("", "notekeeper-*.txt")
+	if err != nil {
+		return err
+	}
+	path := file.Name()
+	defer os.Remove(path)
+	_, err = file.WriteString(n.Content)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	command := editor()
+	cmd := exec.Command(command[0], append(command[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("editor failed - %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if string(content) == n.Content {
+		fmt.Fprintln(c.App.ErrWriter, "Note unchanged")
+		return nil
+	}
+	n.Content = string(content)
+	n.Updated = time.Now()
+	return session.saveNote(s, n)
+}
+
+func createNote(c *cli.Context, session *Session) error {
+	if c.String("title") == "" {
+		return fmt.Errorf("--title is required")
+	}
+	noteType, ok := note.TypeFromString(c.String("type"))
+	if !ok {
+		return fmt.Errorf("invalid note type [%s]", c.String("type"))
+	}
+
+	var s *shelf.Shelf
+	if c.String("shelf") == "" {
+		var err error
+		s, err = session.defaultShelf()
+		if err != nil {
+			return err
+		}
+	} else {
+		shelves, err := session.findShelves(c.String("shelf"))
+		if err != nil {
+			return err
+		}
+		s = shelves[0]
+	}
+
+	notebookID, err := createNotebookID(c, session, s)
+	if err != nil {
+		return err
+	}
+
+	content, err := readContent()
+	if err != nil {
+		return err
+	}
+
+	n, err := session.noteProxy(s)
+	if err != nil {
+		return err
+	}
+	n.Title = title.New(c.String("title"))
+	n.Type = noteType
+	n.NotebookID = notebookID
+	n.Content = content
+	err = session.saveNote(s, n)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, n.ID)
+	return nil
+}
+
+// createNotebookID finds the notebook a new note is created in
+func createNotebookID(c *cli.Context, session *Session, s *shelf.Shelf) (uuid.UUID, error) {
+	notebooks, err := session.notebooks(s)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id := c.String("notebook")
+	for _, n := range notebooks {
+		if (id == "" && n.Default) || n.ID.String() == id {
+			return n.ID, nil
+		}
+	}
+	if id == "" {
+		return uuid.Nil, fmt.Errorf("shelf [%s] has no default notebook, use --notebook", s.ID)
+	}
+	return uuid.Nil, fmt.Errorf("notebook [%s] not found in shelf [%s]", id, s.ID)
+}
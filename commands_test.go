@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"notekeeper-electron-backend/api"
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/title"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"go.etcd.io/bbolt"
+)
+
+// createTestAccount creates an account in a new data directory
+func createTestAccount(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "notekeeper-commands")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	registry := db.NewRegistry(logger)
+	err = registry.OpenMaster(dir)
+	if err != nil {
+		t.Fatal("Error opening master db - ", err)
+	}
+	defer registry.CloseAll()
+	_, err = api.New(registry, logger).CreateAccount("test", "test@example.com", "sturdy lantern 42 orbit")
+	if err != nil {
+		t.Fatal("Error creating account - ", err)
+	}
+	return dir
+}
+
+// createTestCollection adds a collection to the default shelf of the test account
+func createTestCollection(t *testing.T, dir string) string {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	registry := db.NewRegistry(logger)
+	err := registry.OpenMaster(dir)
+	if err != nil {
+		t.Fatal("Error opening master db - ", err)
+	}
+	defer registry.CloseAll()
+	a := api.New(registry, logger)
+	acct, err := a.SigninAccount("test", "test@example.com", "sturdy lantern 42 orbit")
+	if err != nil {
+		t.Fatal("Error signing in - ", err)
+	}
+	defer a.SignoutAccount(acct)
+	shelves, err := a.OpenShelves(acct)
+	if err != nil {
+		t.Fatal("Error opening shelves - ", err)
+	}
+	session := &Session{Registry: registry, API: a, Account: acct, Shelves: shelves}
+	s, err := session.defaultShelf()
+	if err != nil {
+		t.Fatal("Error finding the default shelf - ", err)
+	}
+	key, err := a.ShelfKey(acct, s.Scope)
+	if err != nil {
+		t.Fatal("Error opening the shelf key - ", err)
+	}
+
+	c, _ := collection.New(title.New("Archive"), collection.ScopeUser, registry, logger)
+	c.OwnerID = s.OwnerID
+	c.ShelfID = s.ID
+	index := collection.NewIndex(collection.ScopeUser, registry, logger)
+	index.ShelfID = s.ID
+	index.OwnerID = s.OwnerID
+	// the collection's own db is never opened, so only its index record is written
+	err = index.Save(c, key)
+	if err != nil && codes.ToInternalError(err).Code != codes.ErrorMissingDB {
+		t.Fatal("Error saving collection - ", err)
+	}
+	return c.ID.String()
+}
+
+// runCommand runs a headless command against a data directory & returns its output
+func runCommand(t *testing.T, dir string, input string, args ...string) (string, error) {
+	var out bytes.Buffer
+	stdin = strings.NewReader(input)
+	defer func() { stdin = os.Stdin }()
+	exiter := cli.OsExiter
+	cli.OsExiter = func(int) {}
+	defer func() { cli.OsExiter = exiter }()
+	errWriter := cli.ErrWriter
+	cli.ErrWriter = ioutil.Discard
+	defer func() { cli.ErrWriter = errWriter }()
+
+	app := newApp()
+	app.Writer = &out
+	app.ErrWriter = ioutil.Discard
+	command := append([]string{"notekeeper", "--data-dir", dir, "--log-file", filepath.Join(dir, "test.log")}, args...)
+	command = append(command, "--account", "test", "--email", "test@example.com")
+	err := app.Run(command)
+	return out.String(), err
+}
+
+func TestCommands(t *testing.T) {
+	dir := createTestAccount(t)
+	defer os.RemoveAll(dir)
+	os.Setenv(PassphraseEnv, "sturdy lantern 42 orbit")
+	defer os.Unsetenv(PassphraseEnv)
+
+	out, err := runCommand(t, dir, "", "shelves")
+	if err != nil || !strings.Contains(out, "default") {
+		t.Fatal("Expected the default shelves to be listed - ", err, out)
+	}
+
+	out, err = runCommand(t, dir, "Remember the milk", "create", "--title", "Shopping")
+	if err != nil {
+		t.Fatal("Expected the note to be created - ", err)
+	}
+	id := strings.TrimSpace(out)
+
+	out, err = runCommand(t, dir, "", "notes")
+	if err != nil || !strings.Contains(out, id) || !strings.Contains(out, "Shopping") {
+		t.Error("Expected the note to be listed - ", err, out)
+	}
+
+	out, err = runCommand(t, dir, "", "cat", id)
+	if err != nil || out != "Remember the milk" {
+		t.Error("Expected the note content but got [", out, "] - ", err)
+	}
+
+	out, err = runCommand(t, dir, "", "search", "MILK")
+	if err != nil || !strings.Contains(out, id) {
+		t.Error("Expected search to match the content - ", err, out)
+	}
+	out, err = runCommand(t, dir, "", "search", "bread")
+	if err != nil || strings.Contains(out, id) {
+		t.Error("Expected search not to match - ", err, out)
+	}
+
+	if runtime.GOOS != "windows" {
+		script := filepath.Join(dir, "editor.sh")
+		err = ioutil.WriteFile(script, []byte("#!/bin/sh\necho 'and eggs' >> \"$1\"\n"), 0700)
+		if err != nil {
+			t.Fatal("Error writing editor script - ", err)
+		}
+		os.Setenv("EDITOR", script)
+		defer os.Unsetenv("EDITOR")
+		_, err = runCommand(t, dir, "", "edit", id)
+		if err != nil {
+			t.Fatal("Expected the note to be edited - ", err)
+		}
+		out, _ = runCommand(t, dir, "", "cat", id)
+		if out != "Remember the milkand eggs\n" {
+			t.Error("Expected the edited content but got [", out, "]")
+		}
+	}
+
+	_, err = runCommand(t, dir, "", "cat", "00000000-0000-0000-0000-000000000001")
+	if err == nil {
+		t.Error("Expected a missing note to be an error")
+	}
+
+	out, err = runCommand(t, dir, "", "doctor")
+	if err != nil || !strings.Contains(out, "No problems found") {
+		t.Error("Expected the doctor to find no problems - ", err, out)
+	}
+
+	os.Setenv(PassphraseEnv, "wrong")
+	_, err = runCommand(t, dir, "", "shelves")
+	if err == nil {
+		t.Error("Expected signin with the wrong passphrase to fail")
+	}
+}
+
+func TestCommandCollections(t *testing.T) {
+	dir := createTestAccount(t)
+	defer os.RemoveAll(dir)
+	os.Setenv(PassphraseEnv, "sturdy lantern 42 orbit")
+	defer os.Unsetenv(PassphraseEnv)
+	id := createTestCollection(t, dir)
+
+	_, err := runCommand(t, dir, "", "notes", "--shelf", id)
+	if err == nil || !strings.Contains(err.Error(), "is a collection") {
+		t.Error("Expected a collection passed as a shelf to be refused - ", err)
+	}
+	_, err = runCommand(t, dir, "", "notes")
+	if err != nil {
+		t.Error("Expected the shelves to be listed alongside a collection - ", err)
+	}
+}
+
+func TestCommandsWhileInUse(t *testing.T) {
+	dir := createTestAccount(t)
+	defer os.RemoveAll(dir)
+	os.Setenv(PassphraseEnv, "sturdy lantern 42 orbit")
+	defer os.Unsetenv(PassphraseEnv)
+
+	// a running backend holds the master db open
+	held, err := bbolt.Open(filepath.Join(dir, db.MasterDbFile), 0600, nil)
+	if err != nil {
+		t.Fatal("Error opening master db - ", err)
+	}
+	defer held.Close()
+
+	_, err = runCommand(t, dir, "", "shelves")
+	if err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Error("Expected a master db held by another process to be reported as in use - ", err)
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"notekeeper-electron-backend/appdir"
+	"notekeeper-electron-backend/logfile"
+	"notekeeper-electron-backend/passphrase"
+	"notekeeper-electron-backend/rpc"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// ConfigFile is the default config file name in the app data directory
+const ConfigFile = "config.json"
+
+const megabyte = 1024 * 1024
+
+// Log formats
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// Config contains the backend settings
+// Settings are taken from command line flags, then NOTEKEEPER_* environment
+// variables, then the config file, then the defaults.  Paths that aren't set
+// default to names inside the data directory.
+type Config struct {
+	Transport       string `json:"transport"`
+	Listen          string `json:"listen"`
+	GRPCListen      string `json:"grpcListen"`
+	Socket          string `json:"socket"`
+	GRPCSocket      string `json:"grpcSocket"`
+	PeerCredentials *bool  `json:"peerCredentials"`
+	DataDir         string `json:"dataDir"`
+	LogFile         string `json:"logFile"`
+	LogLevel        string `json:"logLevel"`
+	LogFormat       string `json:"logFormat"`
+	LogMaxSize      int    `json:"logMaxSize"`    // LogMaxSize is in megabytes
+	LogMaxAge       string `json:"logMaxAge"`     // LogMaxAge is a duration such as 24h
+	LogMaxBackups   int    `json:"logMaxBackups"` // LogMaxBackups is the number of rotated logs kept
+	LogCompress     *bool  `json:"logCompress"`
+
+	PassphraseMinLength      int      `json:"passphraseMinLength"`
+	PassphraseMinEntropy     float64  `json:"passphraseMinEntropy"`     // PassphraseMinEntropy is in bits
+	PassphraseBannedPatterns []string `json:"passphraseBannedPatterns"` // PassphraseBannedPatterns are added to the built-in patterns
+	BreachList               string   `json:"breachList"`               // BreachList is a file or directory of breached passphrase hashes
+
+	ClientLifetime         string `json:"clientLifetime"`    // ClientLifetime is a duration such as 24h
+	ClientIdleTimeout      string `json:"clientIdleTimeout"` // ClientIdleTimeout is a duration such as 1h
+	RevokeClientsOnSignout *bool  `json:"revokeClientsOnSignout"`
+	RevokeClientsOnLock    bool   `json:"revokeClientsOnLock"`
+}
+
+// configFlags are the flags that override config file settings
+// Each flag can also be set with its environment variable.
+var configFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "config",
+		EnvVar: "NOTEKEEPER_CONFIG",
+		Usage:  "config file `path` (default: config.json in the app data directory, if it exists)",
+	},
+	cli.StringFlag{
+		Name:   "transport",
+		EnvVar: "NOTEKEEPER_TRANSPORT",
+		Usage:  "`name` of the transport - tcp for localhost ports or unix for sockets (default: tcp)",
+	},
+	cli.StringFlag{
+		Name:   "listen",
+		EnvVar: "NOTEKEEPER_LISTEN",
+		Usage:  "RPC listen `address` for the tcp transport (default: " + BackendPort + ")",
+	},
+	cli.StringFlag{
+		Name:   "grpc-listen",
+		EnvVar: "NOTEKEEPER_GRPC_LISTEN",
+		Usage:  "gRPC listen `address` for the tcp transport (default: " + BackendGRPCPort + ")",
+	},
+	cli.StringFlag{
+		Name:   "socket",
+		EnvVar: "NOTEKEEPER_SOCKET",
+		Usage:  "RPC socket `path` for the unix transport (default: " + BackendSocket + " in the data directory)",
+	},
+	cli.StringFlag{
+		Name:   "grpc-socket",
+		EnvVar: "NOTEKEEPER_GRPC_SOCKET",
+		Usage:  "gRPC socket `path` for the unix transport (default: " + BackendGRPCSocket + " in the data directory)",
+	},
+	cli.BoolTFlag{
+		Name:   "peer-credentials",
+		EnvVar: "NOTEKEEPER_PEER_CREDENTIALS",
+		Usage:  "only accept socket connections from the current user (linux only, set =false to disable)",
+	},
+	cli.StringFlag{
+		Name:   "data-dir",
+		EnvVar: "NOTEKEEPER_DATA_DIR",
+		Usage:  "`path` for the certificate, sockets & logs, and the default master db directory (default: the app data directory)",
+	},
+	cli.StringFlag{
+		Name:   "log-file",
+		EnvVar: "NOTEKEEPER_LOG_FILE",
+		Usage:  "log file `path` (default: " + LogFile + " in the data directory)",
+	},
+	cli.StringFlag{
+		Name:   "log-level",
+		EnvVar: "NOTEKEEPER_LOG_LEVEL",
+		Usage:  "log `level` - debug, info, warn or error (default: " + LogLevel + ")",
+	},
+	cli.StringFlag{
+		Name:   "log-format",
+		EnvVar: "NOTEKEEPER_LOG_FORMAT",
+		Usage:  "log `format` - json or text (default: json)",
+	},
+	cli.IntFlag{
+		Name:   "log-max-size",
+		EnvVar: "NOTEKEEPER_LOG_MAX_SIZE",
+		Usage:  "`megabytes` the log file is rotated at, 0 for no limit (default: 10)",
+	},
+	cli.StringFlag{
+		Name:   "log-max-age",
+		EnvVar: "NOTEKEEPER_LOG_MAX_AGE",
+		Usage:  "`duration` the log file is written before it is rotated, 0 for no limit (default: 168h)",
+	},
+	cli.IntFlag{
+		Name:   "log-max-backups",
+		EnvVar: "NOTEKEEPER_LOG_MAX_BACKUPS",
+		Usage:  "`number` of rotated log files kept, 0 to keep them all (default: 5)",
+	},
+	cli.BoolTFlag{
+		Name:   "log-compress",
+		EnvVar: "NOTEKEEPER_LOG_COMPRESS",
+		Usage:  "gzip rotated log files (set =false to disable)",
+	},
+	cli.IntFlag{
+		Name:   "passphrase-min-length",
+		EnvVar: "NOTEKEEPER_PASSPHRASE_MIN_LENGTH",
+		Usage:  "minimum `characters` in a new passphrase (default: 8)",
+	},
+	cli.Float64Flag{
+		Name:   "passphrase-min-entropy",
+		EnvVar: "NOTEKEEPER_PASSPHRASE_MIN_ENTROPY",
+		Usage:  "minimum estimated entropy of a new passphrase in `bits` (default: 40)",
+	},
+	cli.StringFlag{
+		Name:   "breach-list",
+		EnvVar: "NOTEKEEPER_BREACH_LIST",
+		Usage:  "`path` of a file or directory of SHA-1 hashes of breached passphrases, checked along with the bundled list",
+	},
+	cli.StringFlag{
+		Name:   "client-lifetime",
+		EnvVar: "NOTEKEEPER_CLIENT_LIFETIME",
+		Usage:  "`duration` a client token is valid after key exchange, 0 for no limit (default: 24h)",
+	},
+	cli.StringFlag{
+		Name:   "client-idle-timeout",
+		EnvVar: "NOTEKEEPER_CLIENT_IDLE_TIMEOUT",
+		Usage:  "`duration` a client token is valid after its last request, 0 for no limit (default: 1h)",
+	},
+	cli.BoolTFlag{
+		Name:   "revoke-clients-on-signout",
+		EnvVar: "NOTEKEEPER_REVOKE_CLIENTS_ON_SIGNOUT",
+		Usage:  "revoke every client token when the account is signed out (set =false to disable)",
+	},
+	cli.BoolFlag{
+		Name:   "revoke-clients-on-lock",
+		EnvVar: "NOTEKEEPER_REVOKE_CLIENTS_ON_LOCK",
+		Usage:  "revoke every client token when the account is locked",
+	},
+}
+
+// DefaultConfig returns the settings used when nothing else is configured
+func DefaultConfig() *Config {
+	peerCredentials := true
+	logCompress := true
+	policy := logfile.DefaultPolicy()
+	clientPolicy := rpc.DefaultClientPolicy()
+	config := &Config{
+		Transport:       rpc.NetworkTCP,
+		Listen:          BackendPort,
+		GRPCListen:      BackendGRPCPort,
+		PeerCredentials: &peerCredentials,
+		DataDir:         appdir.AppDataPath(),
+		LogLevel:        LogLevel,
+		LogFormat:       LogFormatJSON,
+		LogMaxSize:      int(policy.MaxSize / megabyte),
+		LogMaxAge:       policy.MaxAge.String(),
+		LogMaxBackups:   policy.MaxBackups,
+		LogCompress:     &logCompress,
+
+		PassphraseMinLength:  passphrase.DefaultMinLength,
+		PassphraseMinEntropy: passphrase.DefaultMinEntropy,
+
+		ClientLifetime:         clientPolicy.Lifetime.String(),
+		ClientIdleTimeout:      clientPolicy.IdleTimeout.String(),
+		RevokeClientsOnSignout: &clientPolicy.RevokeOnSignout,
+		RevokeClientsOnLock:    clientPolicy.RevokeOnLock,
+	}
+	return config
+}
+
+// LoadConfig builds the config for a command line
+func LoadConfig(c *cli.Context) (*Config, error) {
+	config := DefaultConfig()
+
+	path := c.GlobalString("config")
+	required := path != ""
+	if !required {
+		path = filepath.Join(appdir.AppDataPath(), ConfigFile)
+	}
+	err := config.readFile(path, required)
+	if err != nil {
+		return nil, err
+	}
+
+	set := func(name string, value *string) {
+		if c.GlobalIsSet(name) {
+			*value = c.GlobalString(name)
+		}
+	}
+	set("transport", &config.Transport)
+	set("listen", &config.Listen)
+	set("grpc-listen", &config.GRPCListen)
+	set("socket", &config.Socket)
+	set("grpc-socket", &config.GRPCSocket)
+	set("data-dir", &config.DataDir)
+	set("log-file", &config.LogFile)
+	set("log-level", &config.LogLevel)
+	set("log-format", &config.LogFormat)
+	set("log-max-age", &config.LogMaxAge)
+	set("breach-list", &config.BreachList)
+	set("client-lifetime", &config.ClientLifetime)
+	set("client-idle-timeout", &config.ClientIdleTimeout)
+	setInt := func(name string, value *int) {
+		if c.GlobalIsSet(name) {
+			*value = c.GlobalInt(name)
+		}
+	}
+	setInt("log-max-size", &config.LogMaxSize)
+	setInt("log-max-backups", &config.LogMaxBackups)
+	setInt("passphrase-min-length", &config.PassphraseMinLength)
+	if c.GlobalIsSet("passphrase-min-entropy") {
+		config.PassphraseMinEntropy = c.GlobalFloat64("passphrase-min-entropy")
+	}
+	if c.GlobalIsSet("peer-credentials") {
+		peerCredentials := c.GlobalBoolT("peer-credentials")
+		config.PeerCredentials = &peerCredentials
+	}
+	if c.GlobalIsSet("log-compress") {
+		logCompress := c.GlobalBoolT("log-compress")
+		config.LogCompress = &logCompress
+	}
+	if c.GlobalIsSet("revoke-clients-on-signout") {
+		revoke := c.GlobalBoolT("revoke-clients-on-signout")
+		config.RevokeClientsOnSignout = &revoke
+	}
+	if c.GlobalIsSet("revoke-clients-on-lock") {
+		config.RevokeClientsOnLock = c.GlobalBool("revoke-clients-on-lock")
+	}
+
+	if config.Socket == "" {
+		config.Socket = filepath.Join(config.DataDir, BackendSocket)
+	}
+	if config.GRPCSocket == "" {
+		config.GRPCSocket = filepath.Join(config.DataDir, BackendGRPCSocket)
+	}
+	if config.LogFile == "" {
+		config.LogFile = filepath.Join(config.DataDir, LogFile)
+	}
+	return config, config.validate()
+}
+
+// readFile overlays the settings in a config file, which only has to exist if it's required
+func (config *Config) readFile(path string, required bool) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read config file [%s] - %v", path, err)
+	}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return fmt.Errorf("invalid config file [%s] - %v", path, err)
+	}
+	return nil
+}
+
+func (config *Config) validate() error {
+	if config.Transport != rpc.NetworkTCP && config.Transport != rpc.NetworkUnix {
+		return fmt.Errorf("invalid transport [%s]", config.Transport)
+	}
+	if _, err := logrus.ParseLevel(config.LogLevel); err != nil {
+		return fmt.Errorf("invalid log level [%s]", config.LogLevel)
+	}
+	if config.LogFormat != LogFormatJSON && config.LogFormat != LogFormatText {
+		return fmt.Errorf("invalid log format [%s]", config.LogFormat)
+	}
+	if config.LogMaxSize < 0 {
+		return fmt.Errorf("invalid log max size [%d]", config.LogMaxSize)
+	}
+	if age, err := time.ParseDuration(config.LogMaxAge); err != nil || age < 0 {
+		return fmt.Errorf("invalid log max age [%s]", config.LogMaxAge)
+	}
+	if config.LogMaxBackups < 0 {
+		return fmt.Errorf("invalid log max backups [%d]", config.LogMaxBackups)
+	}
+	if config.PassphraseMinLength < 1 {
+		return fmt.Errorf("invalid passphrase min length [%d]", config.PassphraseMinLength)
+	}
+	if config.PassphraseMinEntropy < 0 {
+		return fmt.Errorf("invalid passphrase min entropy [%v]", config.PassphraseMinEntropy)
+	}
+	if _, err := passphrase.NewPolicy(config.PassphraseMinLength, config.PassphraseMinEntropy, config.PassphraseBannedPatterns); err != nil {
+		return err
+	}
+	if lifetime, err := time.ParseDuration(config.ClientLifetime); err != nil || lifetime < 0 {
+		return fmt.Errorf("invalid client lifetime [%s]", config.ClientLifetime)
+	}
+	if timeout, err := time.ParseDuration(config.ClientIdleTimeout); err != nil || timeout < 0 {
+		return fmt.Errorf("invalid client idle timeout [%s]", config.ClientIdleTimeout)
+	}
+	if config.BreachList != "" {
+		if _, err := os.Stat(config.BreachList); err != nil {
+			return fmt.Errorf("unable to read breach list [%s] - %v", config.BreachList, err)
+		}
+	}
+	return nil
+}
+
+// LogPolicy returns the rotation policy for the log file
+func (config *Config) LogPolicy() logfile.Policy {
+	age, _ := time.ParseDuration(config.LogMaxAge)
+	policy := logfile.Policy{
+		MaxSize:    int64(config.LogMaxSize) * megabyte,
+		MaxAge:     age,
+		MaxBackups: config.LogMaxBackups,
+		Compress:   config.LogCompress == nil || *config.LogCompress,
+	}
+	return policy
+}
+
+// ClientPolicy returns how long client tokens stay valid & when they're revoked
+func (config *Config) ClientPolicy() rpc.ClientPolicy {
+	policy := rpc.DefaultClientPolicy()
+	policy.Lifetime, _ = time.ParseDuration(config.ClientLifetime)
+	policy.IdleTimeout, _ = time.ParseDuration(config.ClientIdleTimeout)
+	policy.RevokeOnSignout = config.RevokeClientsOnSignout == nil || *config.RevokeClientsOnSignout
+	policy.RevokeOnLock = config.RevokeClientsOnLock
+	return policy
+}
+
+// PassphrasePolicy returns the policy new passphrases are checked against
+// The breach list, if one is configured, is loaded along with the bundled list.
+func (config *Config) PassphrasePolicy() (*passphrase.Policy, error) {
+	policy, err := passphrase.NewPolicy(config.PassphraseMinLength, config.PassphraseMinEntropy, config.PassphraseBannedPatterns)
+	if err != nil {
+		return nil, err
+	}
+	if config.BreachList != "" {
+		list, err := passphrase.LoadBreachList(config.BreachList)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load breach list [%s] - %v", config.BreachList, err)
+		}
+		policy.Breached = append(policy.Breached, list)
+	}
+	return policy, nil
+}
+
+// Listeners returns the RPC & gRPC listeners for the configured transport
+func (config *Config) Listeners() (rpc.Listener, rpc.Listener) {
+	if config.Transport == rpc.NetworkUnix {
+		return rpc.Listener{Network: rpc.NetworkUnix, Address: config.Socket},
+			rpc.Listener{Network: rpc.NetworkUnix, Address: config.GRPCSocket}
+	}
+	return rpc.Listener{Network: rpc.NetworkTCP, Address: config.Listen},
+		rpc.Listener{Network: rpc.NetworkTCP, Address: config.GRPCListen}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"notekeeper-electron-backend/rpc"
+
+	"github.com/urfave/cli"
+)
+
+// loadConfig parses a command line & loads its config
+func loadConfig(t *testing.T, args ...string) (*Config, error) {
+	var config *Config
+	var err error
+	app := cli.NewApp()
+	app.Flags = configFlags
+	app.Action = func(c *cli.Context) {
+		config, err = LoadConfig(c)
+	}
+	runErr := app.Run(append([]string{"notekeeper"}, args...))
+	if runErr != nil {
+		t.Fatal("Error parsing command line - ", runErr)
+	}
+	return config, err
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-config")
+	if err != nil {
+		t.Fatal("Error creating config directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	data := `{"transport": "unix", "listen": "localhost:1000", "dataDir": "` + dir + `", "logLevel": "warn", "peerCredentials": false}`
+	err = ioutil.WriteFile(path, []byte(data), 0600)
+	if err != nil {
+		t.Fatal("Error writing config file - ", err)
+	}
+
+	os.Setenv("NOTEKEEPER_LOG_LEVEL", "info")
+	defer os.Unsetenv("NOTEKEEPER_LOG_LEVEL")
+	config, err := loadConfig(t, "--config", path, "--listen", "localhost:2000", "--log-format", "text")
+	if err != nil {
+		t.Fatal("Expected the config to load - ", err)
+	}
+
+	if config.Transport != rpc.NetworkUnix || *config.PeerCredentials {
+		t.Error("Expected settings from the config file")
+	}
+	if config.Listen != "localhost:2000" || config.LogFormat != LogFormatText {
+		t.Error("Expected flags to override the config file")
+	}
+	if config.LogLevel != "info" {
+		t.Error("Expected the environment to override the config file")
+	}
+	if config.GRPCListen != BackendGRPCPort {
+		t.Error("Expected defaults for settings that aren't configured")
+	}
+	if config.Socket != filepath.Join(dir, BackendSocket) || config.LogFile != filepath.Join(dir, LogFile) {
+		t.Error("Expected paths to default to the data directory")
+	}
+	policy := config.LogPolicy()
+	if policy.MaxSize != 10*megabyte || policy.MaxAge != 7*24*time.Hour || policy.MaxBackups != 5 || !policy.Compress {
+		t.Error("Expected the default log rotation policy but got ", policy)
+	}
+	listener, _ := config.Listeners()
+	if listener.Network != rpc.NetworkUnix || listener.Address != config.Socket {
+		t.Error("Expected a unix socket listener but got ", listener)
+	}
+}
+
+func TestLogPolicy(t *testing.T) {
+	config, err := loadConfig(t, "--log-max-size", "1", "--log-max-age", "24h", "--log-max-backups", "0", "--log-compress=false")
+	if err != nil {
+		t.Fatal("Expected the config to load - ", err)
+	}
+	policy := config.LogPolicy()
+	if policy.MaxSize != megabyte || policy.MaxAge != 24*time.Hour || policy.MaxBackups != 0 || policy.Compress {
+		t.Error("Expected the log rotation flags to set the policy but got ", policy)
+	}
+}
+
+func TestClientPolicy(t *testing.T) {
+	config, err := loadConfig(t)
+	if err != nil {
+		t.Fatal("Expected the config to load - ", err)
+	}
+	policy := config.ClientPolicy()
+	if policy.Lifetime != 24*time.Hour || policy.IdleTimeout != time.Hour || !policy.RevokeOnSignout || policy.RevokeOnLock {
+		t.Error("Expected the default client policy but got ", policy)
+	}
+
+	config, err = loadConfig(t, "--client-lifetime", "8h", "--client-idle-timeout", "0", "--revoke-clients-on-signout=false", "--revoke-clients-on-lock")
+	if err != nil {
+		t.Fatal("Expected the config to load - ", err)
+	}
+	policy = config.ClientPolicy()
+	if policy.Lifetime != 8*time.Hour || policy.IdleTimeout != 0 || policy.RevokeOnSignout || !policy.RevokeOnLock {
+		t.Error("Expected the client flags to set the policy but got ", policy)
+	}
+	if policy.ReapInterval != rpc.DefaultClientPolicy().ReapInterval {
+		t.Error("Expected expired clients to be reaped at the default interval")
+	}
+}
+
+func TestPassphrasePolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-config")
+	if err != nil {
+		t.Fatal("Error creating config directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	breaches := filepath.Join(dir, "breaches.txt")
+	// the SHA-1 of "lantern orbit 7 harbour"
+	err = ioutil.WriteFile(breaches, []byte("2B52766FC3ECD5E2891A1BC4265D46FEFC37D255:1\n"), 0600)
+	if err != nil {
+		t.Fatal("Error writing breach list - ", err)
+	}
+	path := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(path, []byte(`{"passphraseBannedPatterns": ["giraffe"]}`), 0600)
+	if err != nil {
+		t.Fatal("Error writing config file - ", err)
+	}
+
+	config, err := loadConfig(t, "--config", path, "--passphrase-min-length", "12", "--passphrase-min-entropy", "50", "--breach-list", breaches)
+	if err != nil {
+		t.Fatal("Expected the config to load - ", err)
+	}
+	policy, err := config.PassphrasePolicy()
+	if err != nil {
+		t.Fatal("Expected the passphrase policy to load - ", err)
+	}
+	if policy.MinLength != 12 || policy.MinEntropy != 50 || len(policy.Breached) != 2 {
+		t.Error("Expected the passphrase flags to set the policy but got ", policy)
+	}
+	if policy.Check("a tall giraffe walks by") == nil {
+		t.Error("Expected banned patterns from the config file")
+	}
+	if policy.Check("lantern orbit 7 harbour") == nil {
+		t.Error("Expected passphrases on the configured breach list to be refused")
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"transport", []string{"--transport", "udp"}},
+		{"log level", []string{"--log-level", "loud"}},
+		{"log format", []string{"--log-format", "xml"}},
+		{"log max size", []string{"--log-max-size", "-1"}},
+		{"log max age", []string{"--log-max-age", "a week"}},
+		{"log max backups", []string{"--log-max-backups", "-2"}},
+		{"passphrase min length", []string{"--passphrase-min-length", "0"}},
+		{"client lifetime", []string{"--client-lifetime", "a day"}},
+		{"client idle timeout", []string{"--client-idle-timeout", "-1h"}},
+		{"passphrase min entropy", []string{"--passphrase-min-entropy", "-1"}},
+		{"breach list", []string{"--breach-list", filepath.Join(os.TempDir(), "notekeeper-missing-breaches.txt")}},
+		{"missing config file", []string{"--config", filepath.Join(os.TempDir(), "notekeeper-missing.json")}},
+	}
+	for _, test := range tests {
+		if _, err := loadConfig(t, test.args...); err == nil {
+			t.Error("Expected an invalid [", test.name, "] to be rejected")
+		}
+	}
+}
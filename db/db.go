@@ -4,6 +4,10 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// SchemaVersion is the version of the db layout described in docs/SCHEMA
+// It changes whenever existing dbs would need to be migrated.
+const SchemaVersion = 1
+
 // Type indicates the type of DB
 type Type int
 
@@ -18,18 +18,28 @@ type Handle struct {
 }
 
 // Open a database
+// bolt locks the file while it's open, so a db that's held by another process
+// fails with ErrorDbInUse once the timeout passes.
 func (handle *Handle) Open() error {
 	var err error
 	handle.DB, err = bbolt.Open(handle.Info.Filename, 0600, &bbolt.Options{Timeout: 1 * time.Second})
 	if err != nil {
 		handle.Logger.Warn("Error opening DB type [", handle.Info.Type, "] file [", handle.Info.Filename, "] - ", err)
 		scope := dbTypeToErrorCode(handle.Info.Type)
+		if err == bbolt.ErrTimeout {
+			return codes.New(scope, codes.ErrorDbInUse)
+		}
 		code := codes.New(scope, codes.ErrorDbOpen)
 		return code
 	}
 	return nil
 }
 
+// IsInUse reports whether err is because a db is held open by another process
+func IsInUse(err error) bool {
+	return err != nil && codes.IsInternalError(err) && codes.ToInternalError(err).Code == codes.ErrorDbInUse
+}
+
 // dbTypeToErrorCode takes a DB type and returns a error code scope
 func dbTypeToErrorCode(dbType Type) codes.Scope {
 	var scope codes.Scope
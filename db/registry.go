@@ -1,6 +1,8 @@
 package db
 
 import (
+	"sync"
+
 	"notekeeper-electron-backend/codes"
 
 	uuid "github.com/satori/go.uuid"
@@ -8,11 +10,14 @@ import (
 )
 
 // Registry of databases
+// The registry may be shared between goroutines; handles are looked up under a
+// read lock & opened or closed under a write lock.
 type Registry struct {
 	Factory *Factory
 	Handles []*Handle
 	Master  *Handle
 	Logger  *logrus.Logger
+	lock    sync.RWMutex
 }
 
 // NewRegistry returns a new registry object
@@ -27,7 +32,9 @@ func NewRegistry(logger *logrus.Logger) *Registry {
 
 // OpenMaster opens the master DB
 func (registry *Registry) OpenMaster(path string) error {
+	registry.lock.Lock()
 	registry.Factory = NewFactory(path, registry.Logger)
+	registry.lock.Unlock()
 
 	// This is the master index db
 	// There are additional databases where actual notebook data is stored
@@ -46,6 +53,9 @@ func (registry *Registry) OpenMaster(path string) error {
 
 // GetHandle to an already open database
 func (registry *Registry) GetHandle(key Key) (*Handle, error) {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
 	// db handle already opened?
 	for _, handle := range registry.Handles {
 		if handle.Info.ID == key.ID && handle.Info.Type == key.Type {
@@ -58,10 +68,27 @@ func (registry *Registry) GetHandle(key Key) (*Handle, error) {
 	return nil, code
 }
 
+// OpenHandles returns the info of every open database, master first
+func (registry *Registry) OpenHandles() []Info {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
+	var infos []Info
+	for _, handle := range registry.Handles {
+		if handle != nil && handle.DB != nil {
+			infos = append(infos, handle.Info)
+		}
+	}
+	return infos
+}
+
 // NewHandle creates a new database handle.
 // The database file will be opened and the handle registered, but the client
 // will be responsible for assigning the encryption key to the handle.
 func (registry *Registry) NewHandle(key Key) (*Handle, error) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
 	if registry.Factory == nil {
 		code := codes.New(codes.ScopeDB, codes.ErrorDbOpen)
 		return nil, code
@@ -85,7 +112,10 @@ func (registry *Registry) NewHandle(key Key) (*Handle, error) {
 
 // CloseAll opened DBs
 func (registry *Registry) CloseAll() error {
-	err := registry.CloseAccountDBs()
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	err := registry.closeAccountDBs()
 	if err != nil {
 		return err
 	}
@@ -105,6 +135,12 @@ func (registry *Registry) CloseAll() error {
 
 // CloseAccountDBs closes everything except the master DB
 func (registry *Registry) CloseAccountDBs() error {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	return registry.closeAccountDBs()
+}
+
+func (registry *Registry) closeAccountDBs() error {
 	for _, handle := range registry.Handles {
 		if handle.Info.Type != TypeMaster {
 			err := handle.Close()
@@ -3,6 +3,8 @@
 package main
 
 const (
+	// BuildType is reported by --version
+	BuildType = "debug"
 	// LogLevel is the default log level
 	LogLevel = "DEBUG"
 )
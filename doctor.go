@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"notekeeper-electron-backend/api"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/doctor"
+	"notekeeper-electron-backend/shelf"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// runDoctor checks the dbs in the data directory
+// The exit status is non-zero when problems are left unrepaired.
+func runDoctor(c *cli.Context) error {
+	config, err := LoadConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	logger, err := newLogger(config)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	d := doctor.New(config.DataDir, logger)
+	d.Repair = c.Bool("repair")
+	if c.String("account") != "" || c.String("email") != "" {
+		d.Keys, err = doctorKeys(c, config, logger)
+		if err != nil && d.Repair {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		if err != nil {
+			fmt.Fprintln(c.App.ErrWriter, err, "- only checking the structure of the dbs")
+		}
+	} else if d.Repair {
+		return cli.NewExitError("--repair needs --account & --email to sign in", 1)
+	}
+
+	report, err := d.Run()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	if c.Bool("json") {
+		data, err := json.MarshalIndent(report, "", "\t")
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		fmt.Fprintln(c.App.Writer, string(data))
+	} else {
+		printReport(c, report)
+	}
+
+	if problems := report.Problems(); problems > 0 {
+		return cli.NewExitError(fmt.Sprint(problems, " problem(s) found"), 1)
+	}
+	return nil
+}
+
+// doctorKeys signs in to find the keys of the signed in user
+// Signing in opens the account & user dbs through the registry, which creates
+// db files that are missing, so any file it creates is removed again to leave
+// the data directory as it was found.
+func doctorKeys(c *cli.Context, config *Config, logger *logrus.Logger) (*doctor.Keys, error) {
+	if c.String("account") == "" || c.String("email") == "" {
+		return nil, fmt.Errorf("--account and --email are required to sign in")
+	}
+	passphrase, err := readPassphrase(c)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	files, _ := ioutil.ReadDir(config.DataDir)
+	for _, info := range files {
+		existing[info.Name()] = true
+	}
+	registry := db.NewRegistry(logger)
+	defer func() {
+		registry.CloseAll()
+		files, _ := ioutil.ReadDir(config.DataDir)
+		for _, info := range files {
+			if !existing[info.Name()] && filepath.Ext(info.Name()) == ".db" {
+				os.Remove(filepath.Join(config.DataDir, info.Name()))
+			}
+		}
+	}()
+
+	err = registry.OpenMaster(config.DataDir)
+	if db.IsInUse(err) {
+		return nil, inUseError(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the master db in [%s] - %v", config.DataDir, err)
+	}
+	a := api.New(registry, logger)
+	acct, err := a.SigninAccount(c.String("account"), c.String("email"), passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign in - %v", err)
+	}
+	defer a.SignoutAccount(acct)
+
+	// the shelf owner keys are the unsealed account & user keys
+	accountKey, err := a.ShelfKey(acct, shelf.ScopeAccount)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the account key - %v", err)
+	}
+	userKey, err := a.ShelfKey(acct, shelf.ScopeUser)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the user key - %v", err)
+	}
+	keys := &doctor.Keys{
+		AccountID:     acct.ID,
+		UserID:        acct.ActiveUser.ID,
+		PassphraseKey: append([]byte{}, acct.ActiveUser.PassphraseKey...),
+		AccountKey:    accountKey,
+		UserKey:       userKey,
+	}
+	return keys, nil
+}
+
+func printReport(c *cli.Context, report *doctor.Report) {
+	w := tabwriter.NewWriter(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "DB\tTYPE\tSIZE\tDECRYPTED")
+	for _, r := range report.DBs {
+		decrypted := "no"
+		if r.Decrypted {
+			decrypted = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", r.File, r.Type, r.Size, decrypted)
+		for _, bucket := range r.Buckets {
+			fmt.Fprintf(w, "  %s\t\t%d records\t%d bytes\n", bucket.Name, bucket.Records, bucket.Bytes)
+		}
+	}
+	w.Flush()
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(c.App.Writer, "\nNo problems found")
+		return
+	}
+	fmt.Fprintln(c.App.Writer)
+	w = tabwriter.NewWriter(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PROBLEM\tDB\tBUCKET\tKEY\tDETAIL")
+	for _, finding := range report.Findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", finding.Kind, finding.File, finding.Bucket, finding.Key, finding.Detail)
+	}
+	w.Flush()
+}
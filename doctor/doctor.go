@@ -0,0 +1,528 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+)
+
+// Kind of problem found in the data directory
+type Kind string
+
+const (
+	// KindDamagedDB indicates a db file that can't be opened or fails bolt's consistency check
+	KindDamagedDB Kind = "damaged-db"
+	// KindOrphanedDB indicates a db file that isn't referenced by any index
+	KindOrphanedDB Kind = "orphaned-db"
+	// KindDanglingIndex indicates an index entry for a db file that doesn't exist
+	KindDanglingIndex Kind = "dangling-index"
+	// KindUndecryptable indicates a record that can't be opened with any of the db's keys
+	KindUndecryptable Kind = "undecryptable"
+	// KindUndecodable indicates a record that decrypts but isn't valid json
+	KindUndecodable Kind = "undecodable"
+	// KindStaleStats indicates stats that don't match the notes stored in a db
+	KindStaleStats Kind = "stale-stats"
+)
+
+// QuarantineBucket holds records moved out of the way by repairs
+// Each quarantined record is kept under a nested bucket named after the bucket
+// it was moved from, so it can be restored by hand.
+const QuarantineBucket = "quarantine"
+
+// QuarantineDir is the directory in the data directory that orphaned db files are moved to
+const QuarantineDir = "quarantine"
+
+// plainBuckets are buckets whose values aren't encrypted, by db type
+var plainBuckets = map[db.Type][]string{
+	db.TypeMaster:  {"ui_state", "account_index"},
+	db.TypeAccount: {"user_index"},
+}
+
+// indexBuckets are the buckets that list the dbs below a db, by db type
+// Account & user index entries hold the id in the value; shelf & collection
+// index entries are keyed by id.
+var indexBuckets = map[db.Type][]struct {
+	bucket  string
+	child   db.Type
+	inValue bool
+}{
+	db.TypeMaster:  {{"account_index", db.TypeAccount, true}},
+	db.TypeAccount: {{"user_index", db.TypeUser, true}, {"shelf_index", db.TypeShelf, false}},
+	db.TypeUser:    {{"shelf_index", db.TypeShelf, false}},
+	db.TypeShelf:   {{"collection_index", db.TypeCollection, false}},
+}
+
+// Keys are the unsealed keys of a signed in user
+// They let the doctor decrypt the records of that user's account, user, shelf
+// & collection dbs.  Dbs belonging to other accounts are only checked for
+// structure.
+type Keys struct {
+	AccountID     uuid.UUID
+	UserID        uuid.UUID
+	PassphraseKey []byte
+	AccountKey    []byte
+	UserKey       []byte
+}
+
+// Finding is a problem found in the data directory
+type Finding struct {
+	Kind     Kind   `json:"kind"`
+	File     string `json:"file"`             // File is the db file name, relative to the data directory
+	Bucket   string `json:"bucket,omitempty"` // Bucket holding the record, if the finding is about a record
+	Key      string `json:"key,omitempty"`    // Key of the record, as a uuid where possible
+	Detail   string `json:"detail"`
+	Repaired bool   `json:"repaired"`
+}
+
+// BucketStats summarizes the records in a bucket
+type BucketStats struct {
+	Name    string `json:"name"`
+	Records int    `json:"records"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// DBReport describes a db file that was checked
+type DBReport struct {
+	ID        uuid.UUID      `json:"id"`
+	Type      string         `json:"type"`
+	File      string         `json:"file"`
+	Size      int64          `json:"size"`
+	Decrypted bool           `json:"decrypted"` // Decrypted indicates whether the records were checked
+	Buckets   []*BucketStats `json:"buckets"`
+}
+
+// Report is the result of checking a data directory
+type Report struct {
+	DataPath string      `json:"data_path"`
+	DBs      []*DBReport `json:"dbs"`
+	Findings []*Finding  `json:"findings"`
+}
+
+// Problems returns the number of findings that weren't repaired
+func (report *Report) Problems() int {
+	problems := 0
+	for _, finding := range report.Findings {
+		if !finding.Repaired {
+			problems++
+		}
+	}
+	return problems
+}
+
+// Doctor checks the dbs in a data directory without going through the registry
+// It walks the dbs from the master db down through the index buckets, so it
+// must not be run while a backend is serving the same data directory.
+type Doctor struct {
+	DataPath string
+	Keys     *Keys // Keys are optional; without them records aren't decrypted
+	Repair   bool  // Repair makes safe repairs, which needs Keys
+	Logger   *logrus.Logger
+	report   *Report
+	visited  map[uuid.UUID]bool
+}
+
+// New creates a doctor for a data directory
+func New(dataPath string, logger *logrus.Logger) *Doctor {
+	doctor := &Doctor{
+		DataPath: filepath.Clean(dataPath),
+		Logger:   logger,
+	}
+	return doctor
+}
+
+// node is a db waiting to be checked
+type node struct {
+	key  db.Key
+	keys [][]byte // keys are the candidate keys for the db's records, nil when unknown
+}
+
+// Run checks the data directory, making repairs if requested
+func (doctor *Doctor) Run() (*Report, error) {
+	if doctor.Repair && doctor.Keys == nil {
+		return nil, fmt.Errorf("repairs need a signed in user")
+	}
+	doctor.report = &Report{DataPath: doctor.DataPath}
+	doctor.visited = make(map[uuid.UUID]bool)
+
+	master := filepath.Join(doctor.DataPath, db.MasterDbFile)
+	if _, err := os.Stat(master); err != nil {
+		return nil, fmt.Errorf("no master db in [%s]", doctor.DataPath)
+	}
+
+	pending := []node{{key: db.Key{ID: uuid.Nil, Type: db.TypeMaster}}}
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+		children, err := doctor.check(next)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, children...)
+	}
+
+	err := doctor.findOrphans()
+	if err != nil {
+		return nil, err
+	}
+	return doctor.report, nil
+}
+
+func (doctor *Doctor) filename(key db.Key) string {
+	if key.Type == db.TypeMaster {
+		return db.MasterDbFile
+	}
+	return fmt.Sprint(key.ID.String(), ".db")
+}
+
+func (doctor *Doctor) addFinding(finding *Finding) *Finding {
+	doctor.report.Findings = append(doctor.report.Findings, finding)
+	return finding
+}
+
+// open opens a db file, read only unless repairs are being made
+func (doctor *Doctor) open(path string) (*bbolt.DB, error) {
+	options := &bbolt.Options{Timeout: 1 * time.Second, ReadOnly: !doctor.Repair}
+	return bbolt.Open(path, 0600, options)
+}
+
+// check checks a single db & returns the dbs indexed in it
+// Bolt panics on some kinds of page damage, which is reported as a finding.
+func (doctor *Doctor) check(n node) (children []node, err error) {
+	doctor.visited[n.key.ID] = true
+	file := doctor.filename(n.key)
+	defer func() {
+		if r := recover(); r != nil {
+			doctor.Logger.Warn("Error reading db [", file, "] - ", r)
+			doctor.addFinding(&Finding{Kind: KindDamagedDB, File: file, Detail: fmt.Sprint(r)})
+			children, err = nil, nil
+		}
+	}()
+	path := filepath.Join(doctor.DataPath, file)
+	dbReport := &DBReport{
+		ID:   n.key.ID,
+		Type: db.TypeToStr(n.key.Type),
+		File: file,
+	}
+	doctor.report.DBs = append(doctor.report.DBs, dbReport)
+	if info, err := os.Stat(path); err == nil {
+		dbReport.Size = info.Size()
+	}
+
+	handle, err := doctor.open(path)
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("db [%s] is in use - stop the backend before running the doctor", path)
+	}
+	if err != nil {
+		doctor.Logger.Warn("Error opening db [", path, "] - ", err)
+		doctor.addFinding(&Finding{Kind: KindDamagedDB, File: file, Detail: err.Error()})
+		return nil, nil
+	}
+	defer handle.Close()
+
+	err = handle.View(func(tx *bbolt.Tx) error {
+		for checkErr := range tx.Check() {
+			doctor.addFinding(&Finding{Kind: KindDamagedDB, File: file, Detail: checkErr.Error()})
+		}
+		dbReport.Buckets = bucketStats(tx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var records map[string]map[string][]byte
+	if n.keys != nil {
+		dbReport.Decrypted = true
+		records, err = doctor.checkRecords(handle, n, file)
+		if err != nil {
+			return nil, err
+		}
+		if n.key.Type == db.TypeShelf || n.key.Type == db.TypeCollection {
+			err = doctor.checkStats(handle, n, file, records)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return doctor.children(handle, n, file, records)
+}
+
+// bucketStats counts the records in every bucket, including nested buckets
+func bucketStats(tx *bbolt.Tx) []*BucketStats {
+	var all []*BucketStats
+	var walk func(name string, bucket *bbolt.Bucket)
+	walk = func(name string, bucket *bbolt.Bucket) {
+		stats := &BucketStats{Name: name}
+		all = append(all, stats)
+		bucket.ForEach(func(k, v []byte) error {
+			if v == nil {
+				walk(name+"/"+string(k), bucket.Bucket(k))
+				return nil
+			}
+			stats.Records++
+			stats.Bytes += int64(len(k) + len(v))
+			return nil
+		})
+	}
+	tx.ForEach(func(name []byte, bucket *bbolt.Bucket) error {
+		walk(string(name), bucket)
+		return nil
+	})
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+func isPlainBucket(dbType db.Type, name string) bool {
+	if name == QuarantineBucket {
+		return true
+	}
+	for _, plain := range plainBuckets[dbType] {
+		if plain == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatKey shows a record key as a uuid where possible
+func formatKey(key []byte) string {
+	if id, err := uuid.FromBytes(key); err == nil {
+		return id.String()
+	}
+	return fmt.Sprintf("%x", key)
+}
+
+// openRecord opens a sealed value with the first candidate key that works
+func (doctor *Doctor) openRecord(keys [][]byte, value []byte) ([]byte, bool) {
+	c := crypto.New(doctor.Logger)
+	for _, key := range keys {
+		data, err := c.Open(key, value)
+		if err == nil {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// checkRecords decrypts every encrypted record in a db
+// The decrypted records are returned by bucket & key.  Records that can't be
+// read are quarantined when repairing.
+func (doctor *Doctor) checkRecords(handle *bbolt.DB, n node, file string) (map[string]map[string][]byte, error) {
+	records := make(map[string]map[string][]byte)
+	type badRecord struct {
+		bucket  string
+		key     []byte
+		finding *Finding
+	}
+	var bad []badRecord
+
+	err := handle.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bbolt.Bucket) error {
+			if isPlainBucket(n.key.Type, string(name)) {
+				return nil
+			}
+			decrypted := make(map[string][]byte)
+			records[string(name)] = decrypted
+			return bucket.ForEach(func(k, v []byte) error {
+				if v == nil {
+					return nil
+				}
+				finding := &Finding{File: file, Bucket: string(name), Key: formatKey(k)}
+				data, ok := doctor.openRecord(n.keys, v)
+				if !ok {
+					finding.Kind = KindUndecryptable
+					finding.Detail = "record can't be opened with the db key"
+				} else if !json.Valid(data) {
+					finding.Kind = KindUndecodable
+					finding.Detail = "record isn't valid json"
+				} else {
+					decrypted[string(k)] = data
+					return nil
+				}
+				key := append([]byte{}, k...)
+				bad = append(bad, badRecord{string(name), key, doctor.addFinding(finding)})
+				return nil
+			})
+		})
+	})
+	if err != nil || !doctor.Repair {
+		return records, err
+	}
+
+	for _, record := range bad {
+		err = quarantine(handle, record.bucket, record.key)
+		if err != nil {
+			doctor.Logger.Warn("Error quarantining record [", record.finding.Key, "] in [", file, "] - ", err)
+			continue
+		}
+		record.finding.Repaired = true
+		record.finding.Detail += " - moved to the " + QuarantineBucket + " bucket"
+	}
+	return records, nil
+}
+
+// childKeys finds the candidate keys for the records of a db listed in an index
+func (doctor *Doctor) childKeys(n node, child db.Key, record []byte) [][]byte {
+	keys := doctor.Keys
+	if keys == nil {
+		return nil
+	}
+	switch child.Type {
+	case db.TypeAccount:
+		if child.ID == keys.AccountID {
+			return [][]byte{keys.AccountKey, keys.PassphraseKey}
+		}
+	case db.TypeUser:
+		if child.ID == keys.UserID {
+			return [][]byte{keys.UserKey, keys.PassphraseKey}
+		}
+	case db.TypeShelf, db.TypeCollection:
+		// shelf & collection db keys are sealed with the key of whoever owns
+		// them, which isn't always the db holding the index
+		entry := &db.IndexEntry{}
+		if record == nil || json.Unmarshal(record, entry) != nil {
+			return nil
+		}
+		owners := append(append([][]byte{}, n.keys...), keys.AccountKey, keys.UserKey)
+		key, ok := doctor.openRecord(owners, entry.EncryptedKey)
+		if ok {
+			return [][]byte{key}
+		}
+	}
+	return nil
+}
+
+// children lists the dbs in a db's index buckets
+// Entries for missing db files are reported; shelf & collection dbs are
+// recreated empty when repairing, since their records live in the index.
+func (doctor *Doctor) children(handle *bbolt.DB, n node, file string, records map[string]map[string][]byte) ([]node, error) {
+	var children []node
+	err := handle.View(func(tx *bbolt.Tx) error {
+		for _, index := range indexBuckets[n.key.Type] {
+			bucket := tx.Bucket([]byte(index.bucket))
+			if bucket == nil {
+				continue
+			}
+			err := bucket.ForEach(func(k, v []byte) error {
+				if v == nil {
+					return nil
+				}
+				idBytes := k
+				if index.inValue {
+					idBytes = v
+				}
+				id, err := uuid.FromBytes(idBytes)
+				if err != nil {
+					doctor.addFinding(&Finding{
+						Kind:   KindDanglingIndex,
+						File:   file,
+						Bucket: index.bucket,
+						Key:    formatKey(k),
+						Detail: "index entry doesn't hold a valid db id",
+					})
+					return nil
+				}
+				child := db.Key{ID: id, Type: index.child}
+				if doctor.visited[id] {
+					return nil
+				}
+				children = append(children, node{
+					key:  child,
+					keys: doctor.childKeys(n, child, records[index.bucket][string(k)]),
+				})
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []node
+	for _, child := range children {
+		childFile := doctor.filename(child.key)
+		if _, err := os.Stat(filepath.Join(doctor.DataPath, childFile)); err == nil {
+			existing = append(existing, child)
+			continue
+		}
+		doctor.visited[child.key.ID] = true
+		finding := doctor.addFinding(&Finding{
+			Kind:   KindDanglingIndex,
+			File:   file,
+			Bucket: indexBucketFor(n.key.Type, child.key.Type),
+			Key:    child.key.ID.String(),
+			Detail: fmt.Sprint(db.TypeToStr(child.key.Type), " db [", childFile, "] is missing"),
+		})
+		if doctor.Repair && (child.key.Type == db.TypeShelf || child.key.Type == db.TypeCollection) {
+			err = doctor.recreate(childFile)
+			if err != nil {
+				doctor.Logger.Warn("Error recreating db [", childFile, "] - ", err)
+				continue
+			}
+			finding.Repaired = true
+			finding.Detail += " - recreated empty"
+		}
+	}
+	return existing, nil
+}
+
+func indexBucketFor(parent db.Type, child db.Type) string {
+	for _, index := range indexBuckets[parent] {
+		if index.child == child {
+			return index.bucket
+		}
+	}
+	return ""
+}
+
+// findOrphans reports <uuid>.db files that no index refers to
+func (doctor *Doctor) findOrphans() error {
+	files, err := ioutil.ReadDir(doctor.DataPath)
+	if err != nil {
+		return err
+	}
+	for _, info := range files {
+		name := info.Name()
+		if info.IsDir() || filepath.Ext(name) != ".db" {
+			continue
+		}
+		id, err := uuid.FromString(strings.TrimSuffix(name, ".db"))
+		if err != nil || doctor.visited[id] {
+			continue
+		}
+		doctor.report.DBs = append(doctor.report.DBs, &DBReport{ID: id, Type: "unknown", File: name, Size: info.Size()})
+		finding := doctor.addFinding(&Finding{
+			Kind:   KindOrphanedDB,
+			File:   name,
+			Detail: "no index refers to this db",
+		})
+		if !doctor.Repair {
+			continue
+		}
+		err = doctor.quarantineFile(name)
+		if err != nil {
+			doctor.Logger.Warn("Error quarantining db [", name, "] - ", err)
+			continue
+		}
+		finding.Repaired = true
+		finding.Detail += " - moved to the " + QuarantineDir + " directory"
+	}
+	return nil
+}
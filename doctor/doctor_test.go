@@ -0,0 +1,170 @@
+package doctor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"notekeeper-electron-backend/api"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/shelf"
+	"notekeeper-electron-backend/stats"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+)
+
+// damagedDataDir creates an account & then damages its dbs
+// The account trash shelf db is deleted, an orphaned db is added, a notebook
+// record is overwritten with garbage & the user default shelf gets stale stats.
+func damagedDataDir(t *testing.T, logger *logrus.Logger) (string, *Keys) {
+	dir, err := ioutil.TempDir("", "notekeeper-doctor")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	registry := db.NewRegistry(logger)
+	err = registry.OpenMaster(dir)
+	if err != nil {
+		t.Fatal("Error opening master db - ", err)
+	}
+	a := api.New(registry, logger)
+	acct, err := a.CreateAccount("test", "test@example.com", "sturdy lantern 42 orbit")
+	if err != nil {
+		t.Fatal("Error creating account - ", err)
+	}
+	accountKey, _ := a.ShelfKey(acct, shelf.ScopeAccount)
+	userKey, _ := a.ShelfKey(acct, shelf.ScopeUser)
+	keys := &Keys{
+		AccountID:     acct.ID,
+		UserID:        acct.ActiveUser.ID,
+		PassphraseKey: append([]byte{}, acct.ActiveUser.PassphraseKey...),
+		AccountKey:    accountKey,
+		UserKey:       userKey,
+	}
+	shelves, err := a.OpenShelves(acct)
+	if err != nil {
+		t.Fatal("Error opening shelves - ", err)
+	}
+
+	c := crypto.New(logger)
+	var trash uuid.UUID
+	for _, s := range shelves {
+		handle, _ := registry.GetHandle(db.Key{ID: s.ID, Type: db.TypeShelf})
+		switch {
+		case s.Trash && s.Scope == shelf.ScopeAccount:
+			trash = s.ID
+		case s.Default && s.Scope == shelf.ScopeAccount:
+			err = handle.DB.Update(func(tx *bbolt.Tx) error {
+				return tx.Bucket([]byte("notebooks")).Put(uuid.NewV4().Bytes(), []byte("garbage"))
+			})
+		case s.Default && s.Scope == shelf.ScopeUser:
+			shelfKey, _ := c.Open(userKey, s.EncryptedKey)
+			err = handle.DB.Update(func(tx *bbolt.Tx) error {
+				return stats.Save(tx, shelfKey, s.ID, &stats.Stats{NoteCount: 3, ContentBytes: 42}, logger)
+			})
+		}
+		if err != nil {
+			t.Fatal("Error damaging shelf - ", err)
+		}
+	}
+	registry.CloseAll()
+
+	err = os.Remove(filepath.Join(dir, trash.String()+".db"))
+	if err != nil {
+		t.Fatal("Error removing trash shelf db - ", err)
+	}
+	orphan, err := bbolt.Open(filepath.Join(dir, uuid.NewV4().String()+".db"), 0600, nil)
+	if err != nil {
+		t.Fatal("Error creating orphaned db - ", err)
+	}
+	orphan.Close()
+	return dir, keys
+}
+
+// countKinds counts the findings of each kind, skipping repaired findings if asked
+func countKinds(report *Report, unrepairedOnly bool) map[Kind]int {
+	counts := make(map[Kind]int)
+	for _, finding := range report.Findings {
+		if !unrepairedOnly || !finding.Repaired {
+			counts[finding.Kind]++
+		}
+	}
+	return counts
+}
+
+func TestDoctor(t *testing.T) {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	dir, keys := damagedDataDir(t, logger)
+	defer os.RemoveAll(dir)
+
+	d := New(dir, logger)
+	report, err := d.Run()
+	if err != nil {
+		t.Fatal("Expected the doctor to run - ", err)
+	}
+	counts := countKinds(report, false)
+	if len(report.Findings) != 2 || counts[KindOrphanedDB] != 1 || counts[KindDanglingIndex] != 1 {
+		t.Error("Expected an orphaned db & a dangling index entry but got ", counts)
+	}
+	if len(report.DBs) != 7 {
+		t.Error("Expected the master, account, user, 3 remaining shelf & the orphaned dbs but got ", len(report.DBs))
+	}
+	for _, r := range report.DBs {
+		if r.Decrypted {
+			t.Error("Expected no records to be decrypted without keys")
+		}
+	}
+
+	d.Repair = true
+	_, err = d.Run()
+	if err == nil {
+		t.Error("Expected repairs without keys to be refused")
+	}
+
+	d = New(dir, logger)
+	d.Keys = keys
+	report, err = d.Run()
+	if err != nil {
+		t.Fatal("Expected the doctor to run with keys - ", err)
+	}
+	counts = countKinds(report, false)
+	if counts[KindUndecryptable] != 1 || counts[KindStaleStats] != 1 || len(report.Findings) != 4 {
+		t.Error("Expected an undecryptable notebook & stale stats but got ", counts)
+	}
+
+	d.Repair = true
+	report, err = d.Run()
+	if err != nil {
+		t.Fatal("Expected the doctor to repair - ", err)
+	}
+	if report.Problems() != 0 {
+		t.Error("Expected every problem to be repaired but got ", countKinds(report, true))
+	}
+	if _, err := os.Stat(filepath.Join(dir, QuarantineDir)); err != nil {
+		t.Error("Expected the orphaned db to be quarantined")
+	}
+
+	d.Repair = false
+	report, err = d.Run()
+	if err != nil {
+		t.Fatal("Expected the doctor to run after repairs - ", err)
+	}
+	if len(report.Findings) != 0 {
+		t.Error("Expected no findings after repairs but got ", countKinds(report, false))
+	}
+	quarantined := 0
+	for _, r := range report.DBs {
+		for _, bucket := range r.Buckets {
+			if bucket.Name == QuarantineBucket+"/notebooks" {
+				quarantined += bucket.Records
+			}
+		}
+	}
+	if quarantined != 1 {
+		t.Error("Expected the bad notebook in the quarantine bucket")
+	}
+}
@@ -0,0 +1,154 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/stats"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// quarantine moves a record into the quarantine bucket of its db
+func quarantine(handle *bbolt.DB, bucketName string, key []byte) error {
+	return handle.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketName))
+		if bucket == nil {
+			return fmt.Errorf("bucket [%s] is missing", bucketName)
+		}
+		value := bucket.Get(key)
+		if value == nil {
+			return nil
+		}
+		quarantine, err := tx.CreateBucketIfNotExists([]byte(QuarantineBucket))
+		if err != nil {
+			return err
+		}
+		moved, err := quarantine.CreateBucketIfNotExists([]byte(bucketName))
+		if err != nil {
+			return err
+		}
+		err = moved.Put(key, append([]byte{}, value...))
+		if err != nil {
+			return err
+		}
+		return bucket.Delete(key)
+	})
+}
+
+// quarantineFile moves a db file into the quarantine directory
+func (doctor *Doctor) quarantineFile(name string) error {
+	dir := filepath.Join(doctor.DataPath, QuarantineDir)
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(doctor.DataPath, name), filepath.Join(dir, name))
+}
+
+// recreate creates an empty db file
+func (doctor *Doctor) recreate(name string) error {
+	handle, err := doctor.open(filepath.Join(doctor.DataPath, name))
+	if err != nil {
+		return err
+	}
+	return handle.Close()
+}
+
+// checkStats compares the stats bucket of a shelf or collection db with its notes
+// The stats are an index over the notes, so when they have drifted they are
+// rebuilt from the notes when repairing.
+func (doctor *Doctor) checkStats(handle *bbolt.DB, n node, file string, records map[string]map[string][]byte) error {
+	expected := make(map[uuid.UUID]*stats.Stats)
+	add := func(id uuid.UUID, size int64, updated time.Time) {
+		if id == uuid.Nil {
+			return
+		}
+		if expected[id] == nil {
+			expected[id] = &stats.Stats{}
+		}
+		expected[id].Add(&stats.Stats{NoteCount: 1, ContentBytes: size, LastModified: updated})
+	}
+	for _, data := range records["notes"] {
+		var stored note.Note
+		if json.Unmarshal(data, &stored) != nil {
+			continue
+		}
+		size := int64(len(stored.Content))
+		add(stored.NotebookID, size, stored.Updated)
+		add(n.key.ID, size, stored.Updated)
+	}
+
+	actual := make(map[uuid.UUID]*stats.Stats)
+	for key, data := range records[stats.Bucket] {
+		id, err := uuid.FromBytes([]byte(key))
+		if err != nil {
+			continue
+		}
+		stored := &stats.Stats{}
+		if json.Unmarshal(data, stored) == nil {
+			actual[id] = stored
+		}
+	}
+
+	var stale []*Finding
+	check := func(id uuid.UUID) {
+		want, got := expected[id], actual[id]
+		if want == nil {
+			want = &stats.Stats{}
+		}
+		if got == nil {
+			got = &stats.Stats{}
+		}
+		if want.NoteCount == got.NoteCount && want.ContentBytes == got.ContentBytes {
+			return
+		}
+		stale = append(stale, doctor.addFinding(&Finding{
+			Kind:   KindStaleStats,
+			File:   file,
+			Bucket: stats.Bucket,
+			Key:    id.String(),
+			Detail: fmt.Sprintf("stats hold %d notes & %d bytes but the notes hold %d notes & %d bytes",
+				got.NoteCount, got.ContentBytes, want.NoteCount, want.ContentBytes),
+		}))
+	}
+	for id := range expected {
+		check(id)
+	}
+	for id := range actual {
+		if expected[id] == nil {
+			check(id)
+		}
+	}
+	if len(stale) == 0 || !doctor.Repair {
+		return nil
+	}
+
+	err := handle.Update(func(tx *bbolt.Tx) error {
+		err := tx.DeleteBucket([]byte(stats.Bucket))
+		if err != nil && err != bbolt.ErrBucketNotFound {
+			return err
+		}
+		for id, rebuilt := range expected {
+			err = stats.Save(tx, n.keys[0], id, rebuilt, doctor.Logger)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		doctor.Logger.Warn("Error rebuilding stats in [", file, "] - ", err)
+		return nil
+	}
+	for _, finding := range stale {
+		finding.Repaired = true
+		finding.Detail += " - rebuilt from the notes"
+	}
+	return nil
+}
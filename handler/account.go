@@ -3,21 +3,14 @@ package handler
 import (
 	"notekeeper-electron-backend/account"
 	"notekeeper-electron-backend/api"
-	"notekeeper-electron-backend/codes"
 	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
-
-	"github.com/golang/protobuf/proto"
 )
 
 // GetAccountState returns the accessible state of the account
-func GetAccountState(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.AccountStateResponse{
-		Header:   rpc.NewResponseHeader(),
-		SignedIn: false,
-		Locked:   true,
-		Exists:   false,
-	}
+func GetAccountState(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.AccountStateResponse)
+	response.Locked = true
 
 	if server.Account != nil {
 		response.SignedIn = true
@@ -29,33 +22,25 @@ func GetAccountState(server *rpc.Server, message []byte, context *rpc.RequestCon
 		if count > 0 {
 			response.Exists = true
 		}
-		server.Logger.Debug("Account state counted [", count, "] accounts")
+		call.Context.Logger.Debug("Account state counted [", count, "] accounts")
 	}
 
-	return response, nil
+	return nil
 }
 
 // CreateAccount is the RPC method to create a new account
-func CreateAccount(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.UserIdResponse{
-		Header: rpc.NewResponseHeader(),
-		User:   &messages.UserId{},
-	}
+func CreateAccount(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.UserIdResponse)
+	response.User = &messages.UserId{}
 
-	request := messages.CreateAccountRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling create account request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
+	request := call.Request.(*messages.CreateAccountRequest)
 
 	// create the account
 	api := api.New(server.DBRegistry, server.Logger)
+	api.PassphrasePolicy = server.PassphrasePolicy
 	newAccount, err := api.CreateAccount(request.Name, request.Email, request.Passphrase)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
 
 	// make this the active account
@@ -65,29 +50,27 @@ func CreateAccount(server *rpc.Server, message []byte, context *rpc.RequestConte
 	response.User.AccountId = newAccount.ID.String()
 	response.User.UserId = newAccount.ActiveUser.ID.String()
 
-	return response, nil
+	return nil
 }
 
 // SigninAccount is the RPC method to sign in to an existing account
-func SigninAccount(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.UserIdResponse{
-		Header: rpc.NewResponseHeader(),
-		User:   &messages.UserId{},
-	}
+func SigninAccount(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.UserIdResponse)
+	response.User = &messages.UserId{}
 
-	request := messages.SigninAccountRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling signin account request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
+	request := call.Request.(*messages.SigninAccountRequest)
 
 	api := api.New(server.DBRegistry, server.Logger)
 	newAccount, err := api.SigninAccount(request.Name, request.Email, request.Passphrase)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
+	}
+
+	// open the shelf dbs, which also recovers any moves that were interrupted
+	_, err = api.OpenShelves(newAccount)
+	if err != nil {
+		api.SignoutAccount(newAccount)
+		return err
 	}
 
 	server.Account = newAccount
@@ -96,61 +79,51 @@ func SigninAccount(server *rpc.Server, message []byte, context *rpc.RequestConte
 	response.User.AccountId = newAccount.ID.String()
 	response.User.UserId = newAccount.ActiveUser.ID.String()
 
-	return response, nil
+	return nil
 }
 
 // SignoutAccount is the RPC method to sign out from the active account
-func SignoutAccount(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
+func SignoutAccount(server *rpc.Server, call *rpc.Call) error {
 	api := api.New(server.DBRegistry, server.Logger)
 	err := api.SignoutAccount(server.Account)
 	server.Account = nil
 	server.UserState = rpc.UserStateSignedOut
-	if err != nil {
-		rpc.SetInternalError(response.Header, err)
+	if server.ClientPolicy.RevokeOnSignout {
+		server.RevokeClients()
 	}
-	return response, nil
+	return err
 }
 
 // LockAccount is the RPC method to lock the active account
-func LockAccount(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
+func LockAccount(server *rpc.Server, call *rpc.Call) error {
 	api := api.New(server.DBRegistry, server.Logger)
 	err := api.LockAccount(server.Account)
 	server.UserState = rpc.UserStateLocked
-	if err != nil {
-		rpc.SetInternalError(response.Header, err)
+	if server.ClientPolicy.RevokeOnLock {
+		server.RevokeClients()
 	}
-	return response, nil
+	return err
 }
 
 // UnlockAccount is the RPC method to unlock the current account
-func UnlockAccount(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
-	request := messages.UnlockAccountRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling unlock account request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
+func UnlockAccount(server *rpc.Server, call *rpc.Call) error {
+	request := call.Request.(*messages.UnlockAccountRequest)
 
 	api := api.New(server.DBRegistry, server.Logger)
-	err = api.UnlockAccount(server.Account, request.Passphrase)
+	err := api.UnlockAccount(server.Account, request.Passphrase)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-	} else {
-		server.UserState = rpc.UserStateSignedIn
+		return err
 	}
+	server.UserState = rpc.UserStateSignedIn
+
+	return nil
+}
+
+// ChangePassphrase is the RPC method to change the passphrase of the signed in user
+func ChangePassphrase(server *rpc.Server, call *rpc.Call) error {
+	request := call.Request.(*messages.ChangePassphraseRequest)
 
-	return response, nil
+	api := api.New(server.DBRegistry, server.Logger)
+	api.PassphrasePolicy = server.PassphrasePolicy
+	return api.ChangePassphrase(server.Account, request.Passphrase, request.NewPassphrase)
 }
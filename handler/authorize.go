@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"notekeeper-electron-backend/rpc"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// idRequest is a request naming its owner or container in the id field
+type idRequest interface {
+	GetId() string
+}
+
+// ownerRequest is a request naming its owner in the ownerId field
+type ownerRequest interface {
+	GetOwnerId() string
+}
+
+// shelfRequest is a request naming the shelf it acts on
+type shelfRequest interface {
+	GetShelfId() string
+}
+
+// containerRequest is a notebook request naming the container it acts on
+type containerRequest interface {
+	GetOwnerId() string
+	GetContainer() string
+	GetContainerId() string
+}
+
+// transferNotebookRequest is a notebook move or copy request
+type transferNotebookRequest interface {
+	containerRequest
+	GetDestinationScope() string
+	GetDestinationOwnerId() string
+	GetDestinationContainer() string
+	GetDestinationContainerId() string
+}
+
+// storeRequest is a note request naming the store it acts on
+type storeRequest interface {
+	GetOwnerId() string
+	GetStore() string
+	GetStoreId() string
+}
+
+// transferNoteRequest is a note move or copy request
+type transferNoteRequest interface {
+	storeRequest
+	GetDestinationScope() string
+	GetDestinationOwnerId() string
+	GetDestinationStore() string
+	GetDestinationStoreId() string
+}
+
+// idOrDefault parses an optional id, falling back on a default when it is empty
+func idOrDefault(id string, defaultID uuid.UUID) (uuid.UUID, error) {
+	if id == "" {
+		return defaultID, nil
+	}
+	return uuid.FromString(id)
+}
+
+func stringOrDefault(s string, defaultString string) string {
+	if s == "" {
+		return defaultString
+	}
+	return s
+}
+
+// idOwnerTargets resolves requests that name their owner in the id field
+func idOwnerTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	request := call.Request.(idRequest)
+	ownerID, err := uuid.FromString(request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return []*rpc.Target{{Scope: call.Scope, OwnerID: ownerID}}, nil
+}
+
+// ownerTargets resolves requests that name their owner in the ownerId field
+func ownerTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	request := call.Request.(ownerRequest)
+	ownerID, err := uuid.FromString(request.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+	return []*rpc.Target{{Scope: call.Scope, OwnerID: ownerID}}, nil
+}
+
+// shelfTargets resolves requests acting on an existing shelf
+func shelfTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	ownerID, err := uuid.FromString(call.Request.(ownerRequest).GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+	shelfID, err := uuid.FromString(call.Request.(idRequest).GetId())
+	if err != nil {
+		return nil, err
+	}
+	target := &rpc.Target{
+		Scope:       call.Scope,
+		OwnerID:     ownerID,
+		Container:   "shelf",
+		ContainerID: shelfID,
+	}
+	return []*rpc.Target{target}, nil
+}
+
+// collectionShelfTargets resolves collection requests acting on the shelf holding the collections
+func collectionShelfTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	shelfID, err := uuid.FromString(call.Request.(shelfRequest).GetShelfId())
+	if err != nil {
+		return nil, err
+	}
+	target := &rpc.Target{
+		Scope:       call.Scope,
+		Container:   "shelf",
+		ContainerID: shelfID,
+	}
+	return []*rpc.Target{target}, nil
+}
+
+// collectionTargets resolves requests acting on an existing collection
+func collectionTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	shelfID, err := uuid.FromString(call.Request.(shelfRequest).GetShelfId())
+	if err != nil {
+		return nil, err
+	}
+	collectionID, err := uuid.FromString(call.Request.(idRequest).GetId())
+	if err != nil {
+		return nil, err
+	}
+	target := &rpc.Target{
+		Scope:       call.Scope,
+		Container:   "collection",
+		ContainerID: collectionID,
+		ShelfID:     shelfID,
+	}
+	return []*rpc.Target{target}, nil
+}
+
+// containerTarget builds the target for a shelf or collection named by a request
+func containerTarget(scope string, ownerID string, container string, containerID string) (*rpc.Target, error) {
+	var err error
+	target := &rpc.Target{
+		Scope:     scope,
+		Container: container,
+	}
+	target.OwnerID, err = uuid.FromString(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	target.ContainerID, err = uuid.FromString(containerID)
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// destinationTarget builds the target for the destination of a move or copy
+// Any part of the destination that isn't given defaults to the source, the
+// same as when the request is handled.
+func destinationTarget(source *rpc.Target, scope string, ownerID string, container string, containerID string) (*rpc.Target, error) {
+	var err error
+	target := &rpc.Target{
+		Scope:     stringOrDefault(scope, source.Scope),
+		Container: stringOrDefault(container, source.Container),
+	}
+	target.OwnerID, err = idOrDefault(ownerID, source.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	target.ContainerID, err = idOrDefault(containerID, source.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// notebookTargets resolves notebook requests acting on a single container
+func notebookTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	request := call.Request.(containerRequest)
+	target, err := containerTarget(call.Scope, request.GetOwnerId(), request.GetContainer(), request.GetContainerId())
+	if err != nil {
+		return nil, err
+	}
+	return []*rpc.Target{target}, nil
+}
+
+// notebookTransferTargets resolves notebook moves & copies, which act on both the source & destination
+func notebookTransferTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	request := call.Request.(transferNotebookRequest)
+	source, err := containerTarget(call.Scope, request.GetOwnerId(), request.GetContainer(), request.GetContainerId())
+	if err != nil {
+		return nil, err
+	}
+	destination, err := destinationTarget(source, request.GetDestinationScope(), request.GetDestinationOwnerId(), request.GetDestinationContainer(), request.GetDestinationContainerId())
+	if err != nil {
+		return nil, err
+	}
+	return []*rpc.Target{source, destination}, nil
+}
+
+// noteTargets resolves note & tag assignment requests acting on a single store
+func noteTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	request := call.Request.(storeRequest)
+	target, err := containerTarget(call.Scope, request.GetOwnerId(), request.GetStore(), request.GetStoreId())
+	if err != nil {
+		return nil, err
+	}
+	return []*rpc.Target{target}, nil
+}
+
+// noteTransferTargets resolves note moves & copies, which act on both the source & destination
+func noteTransferTargets(call *rpc.Call) ([]*rpc.Target, error) {
+	request := call.Request.(transferNoteRequest)
+	source, err := containerTarget(call.Scope, request.GetOwnerId(), request.GetStore(), request.GetStoreId())
+	if err != nil {
+		return nil, err
+	}
+	destination, err := destinationTarget(source, request.GetDestinationScope(), request.GetDestinationOwnerId(), request.GetDestinationStore(), request.GetDestinationStoreId())
+	if err != nil {
+		return nil, err
+	}
+	return []*rpc.Target{source, destination}, nil
+}
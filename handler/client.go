@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"sort"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+)
+
+// GetClients lists the clients that have completed a key exchange, oldest first
+func GetClients(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.GetClientsResponse)
+
+	clients := server.ListClients()
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Created.Before(clients[j].Created)
+	})
+	for _, client := range clients {
+		m := &messages.Client{
+			Id:       client.ID,
+			Created:  rpc.TimeToMessage(client.Created),
+			LastUsed: rpc.TimeToMessage(client.LastUsed),
+			Current:  client.ID == call.Context.Token.ID,
+		}
+		expires := client.Expires(server.ClientPolicy)
+		if !expires.IsZero() {
+			m.Expires = rpc.TimeToMessage(expires)
+		}
+		response.Clients = append(response.Clients, m)
+	}
+	return nil
+}
+
+// RevokeClient revokes a client's token so it can no longer make requests
+// A client may revoke its own token; the response is still signed with it.
+func RevokeClient(server *rpc.Server, call *rpc.Call) error {
+	request := call.Request.(*messages.IdRequest)
+
+	if !server.RevokeClient(request.Id) {
+		call.Context.Logger.Warn("Unable to revoke unknown client [", request.Id, "]")
+		return rpc.NewError(codes.ErrorRecordMissing)
+	}
+	return nil
+}
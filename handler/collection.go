@@ -1,38 +1,26 @@
 package handler
 
 import (
+	"time"
+
 	"notekeeper-electron-backend/codes"
 	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/crypto"
 	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
 
-	"github.com/golang/protobuf/proto"
 	uuid "github.com/satori/go.uuid"
 )
 
-func getCollections(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.GetCollectionsResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
-	request := messages.GetCollectionsRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling get collections request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
-	}
+func getCollections(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.GetCollectionsResponse)
+	request := call.Request.(*messages.GetCollectionsRequest)
 
 	shelfID, err := uuid.FromString(request.ShelfId)
 	if err != nil {
-		server.Logger.Warn("Invalid shelf id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid shelf id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	var ownerID uuid.UUID
@@ -44,7 +32,7 @@ func getCollections(server *rpc.Server, message []byte, scope string, context *r
 		ownerID = server.Account.ActiveUser.ID
 		collectionScope = collection.ScopeUser
 	} else {
-		return response, nil
+		return invalidField(call, "scope", scope)
 	}
 
 	// create a new collection instance to act as a proxy
@@ -52,10 +40,16 @@ func getCollections(server *rpc.Server, message []byte, scope string, context *r
 	index.ShelfID = shelfID
 	index.OwnerID = ownerID
 
-	err = index.LoadAll(server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
+	}
+	defer crypto.Zero(key)
+
+	// loads the collections along with up to date note stats
+	err = index.RefreshStats(key)
+	if err != nil {
+		return err
 	}
 
 	for _, c := range index.Collections {
@@ -67,35 +61,22 @@ func getCollections(server *rpc.Server, message []byte, scope string, context *r
 			Created: rpc.TimeToMessage(c.Created),
 			Updated: rpc.TimeToMessage(c.Updated),
 		}
+		m.NoteCount, m.ContentBytes, m.LastModified = statsToMessage(c.Stats)
 		response.Collections = append(response.Collections, m)
 	}
 
-	return response, nil
+	return nil
 }
 
-func createCollection(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.IdResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
-	request := messages.CreateCollectionRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling create collection request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
-	}
+func createCollection(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.IdResponse)
+	request := call.Request.(*messages.CreateCollectionRequest)
 
 	shelfID, err := uuid.FromString(request.ShelfId)
 	if err != nil {
-		server.Logger.Warn("Invalid shelf id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid shelf id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	var ownerID uuid.UUID
@@ -107,15 +88,14 @@ func createCollection(server *rpc.Server, message []byte, scope string, context
 		ownerID = server.Account.ActiveUser.ID
 		collectionScope = collection.ScopeUser
 	} else {
-		return response, nil
+		return invalidField(call, "scope", scope)
 	}
 
 	t := rpc.MessageToTitle(request.Name)
 	c, err := collection.New(t, collectionScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating collection - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating collection - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 
 	c.ShelfID = shelfID
@@ -125,39 +105,35 @@ func createCollection(server *rpc.Server, message []byte, scope string, context
 	index.ShelfID = shelfID
 	index.OwnerID = ownerID
 
-	err = index.Save(c, server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-	} else {
-		response.Id = c.ID.String()
+		return err
 	}
+	defer crypto.Zero(key)
 
-	return response, nil
+	err = index.Save(c, key)
+	if err != nil {
+		return err
+	}
+	response.Id = c.ID.String()
+
+	return nil
 }
 
-func saveCollection(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
-	}
+func saveCollection(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.SaveCollectionRequest)
 
-	request := messages.SaveCollectionRequest{}
-	err := proto.Unmarshal(message, &request)
+	shelfID, err := uuid.FromString(request.ShelfId)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling save collection request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+		call.Context.Logger.Warn("Invalid shelf id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	shelfID, err := uuid.FromString(request.ShelfId)
+	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid shelf id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	var ownerID uuid.UUID
@@ -169,62 +145,46 @@ func saveCollection(server *rpc.Server, message []byte, scope string, context *r
 		ownerID = server.Account.ActiveUser.ID
 		collectionScope = collection.ScopeUser
 	} else {
-		return response, nil
+		return invalidField(call, "scope", scope)
 	}
 
-	t := rpc.MessageToTitle(request.Name)
-	c, err := collection.New(t, collectionScope, server.DBRegistry, server.Logger)
-	if err != nil {
-		server.Logger.Warn("Error creating collection - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
-	}
-	c.ShelfID = shelfID
-	c.OwnerID = ownerID
-	c.Locked = request.Locked
-
 	index := collection.NewIndex(collectionScope, server.DBRegistry, server.Logger)
 	index.ShelfID = shelfID
 	index.OwnerID = ownerID
 
-	err = index.Save(c, server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-	}
-
-	return response, nil
-}
-
-func deleteCollection(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+		return err
 	}
+	defer crypto.Zero(key)
 
-	request := messages.DeleteCollectionRequest{}
-	err := proto.Unmarshal(message, &request)
+	// load the existing record so that fields not carried by the request (stats, tags, etc.) are kept
+	c, err := index.Load(id, key)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling delete collection request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
 	}
+	c.Title = rpc.MessageToTitle(request.Name)
+	c.Locked = request.Locked
+	c.Updated = time.Now()
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
-	}
+	err = index.Save(c, key)
+	return err
+}
+
+func deleteCollection(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.DeleteCollectionRequest)
 
 	shelfID, err := uuid.FromString(request.ShelfId)
 	if err != nil {
-		server.Logger.Warn("Invalid shelf id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid shelf id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	var ownerID uuid.UUID
@@ -236,14 +196,13 @@ func deleteCollection(server *rpc.Server, message []byte, scope string, context
 		ownerID = server.Account.ActiveUser.ID
 		collectionScope = collection.ScopeUser
 	} else {
-		return response, nil
+		return invalidField(call, "scope", scope)
 	}
 
 	c, err := collection.New(nil, collectionScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating collection - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating collection - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	c.ID = id
 	c.ShelfID = shelfID
@@ -253,10 +212,12 @@ func deleteCollection(server *rpc.Server, message []byte, scope string, context
 	index.ShelfID = shelfID
 	index.OwnerID = ownerID
 
-	err = index.Delete(c, server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
+		return err
 	}
+	defer crypto.Zero(key)
 
-	return response, nil
+	err = index.Delete(c, key)
+	return err
 }
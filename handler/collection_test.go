@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestCreateCollection(t *testing.T) {
+	server, c, _, cleanup := newAccountServer(t)
+	defer cleanup()
+	nb, _ := defaultNotebook(t, server)
+	shelfID := nb.ContainerID.String()
+
+	// the collection index lives in the shelf db, so it's opened with the user key
+	created := &messages.IdResponse{}
+	c.call("User::Collection::create", &messages.CreateCollectionRequest{Name: &messages.Title{Text: "Recipes"}, ShelfId: shelfID}, created)
+
+	collections := &messages.GetCollectionsResponse{}
+	c.call("User::collections", &messages.GetCollectionsRequest{ShelfId: shelfID}, collections)
+	if len(collections.Collections) != 1 || collections.Collections[0].Id != created.Id || collections.Collections[0].Name.Text != "Recipes" {
+		t.Error("Expected to list the created collection but got ", collections.Collections)
+	}
+}
+
+func TestCollectionAuthorization(t *testing.T) {
+	server, c, _, cleanup := newAccountServer(t)
+	defer cleanup()
+	nb, _ := defaultNotebook(t, server)
+	shelfID := nb.ContainerID.String()
+	created := &messages.IdResponse{}
+	c.call("User::Collection::create", &messages.CreateCollectionRequest{Name: &messages.Title{Text: "Recipes"}, ShelfId: shelfID}, created)
+
+	// requests for a collection are authorized against the collection index in the shelf db
+	header := c.send("User::Collection::delete", &messages.DeleteCollectionRequest{Id: uuid.NewV4().String(), ShelfId: shelfID}, &messages.EmptyResponse{})
+	if codes.Code(header.Code) != codes.ErrorUnauthorized {
+		t.Error("Expected a collection that isn't in the shelf to be unauthorized but got ", header)
+	}
+	c.call("User::Collection::delete", &messages.DeleteCollectionRequest{Id: created.Id, ShelfId: shelfID}, &messages.EmptyResponse{})
+
+	collections := &messages.GetCollectionsResponse{}
+	c.call("User::collections", &messages.GetCollectionsRequest{ShelfId: shelfID}, collections)
+	if len(collections.Collections) != 0 {
+		t.Error("Expected the collection to be deleted but got ", collections.Collections)
+	}
+}
+
+func TestSaveCollection(t *testing.T) {
+	server, c, _, cleanup := newAccountServer(t)
+	defer cleanup()
+	nb, _ := defaultNotebook(t, server)
+	shelfID := nb.ContainerID.String()
+	created := &messages.IdResponse{}
+	c.call("User::Collection::create", &messages.CreateCollectionRequest{Name: &messages.Title{Text: "Recipes"}, ShelfId: shelfID}, created)
+	save := func(name string, locked bool) *messages.SaveCollectionRequest {
+		return &messages.SaveCollectionRequest{Id: created.Id, ShelfId: shelfID, Name: &messages.Title{Text: name}, Locked: locked}
+	}
+	load := func() []*messages.Collection {
+		collections := &messages.GetCollectionsResponse{}
+		c.call("User::collections", &messages.GetCollectionsRequest{ShelfId: shelfID}, collections)
+		return collections.Collections
+	}
+
+	// a save changes the stored collection rather than adding another
+	c.call("User::Collection::save", save("Cookbooks", true), &messages.EmptyResponse{})
+	collections := load()
+	if len(collections) != 1 || collections[0].Id != created.Id || collections[0].Name.Text != "Cookbooks" || !collections[0].Locked {
+		t.Error("Expected the collection to be renamed & locked but got ", collections)
+	}
+
+	header := c.send("User::Collection::save", save("Renamed", true), &messages.EmptyResponse{})
+	if codes.Code(header.Code) != codes.ErrorLocked {
+		t.Error("Expected saving a locked collection to fail with ErrorLocked but got ", header)
+	}
+	c.call("User::Collection::save", save("Renamed", false), &messages.EmptyResponse{})
+	if collections := load(); len(collections) != 1 || collections[0].Name.Text != "Renamed" || collections[0].Locked {
+		t.Error("Expected the collection to be renamed & unlocked but got ", collections)
+	}
+}
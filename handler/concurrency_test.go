@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+
+	"github.com/agl/ed25519"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// client makes signed POST /rpc requests the way the frontend does
+type client struct {
+	t          *testing.T
+	server     *rpc.Server
+	token      string
+	privateKey *[ed25519.PrivateKeySize]byte
+	sequence   int32
+}
+
+func newClient(t *testing.T, server *rpc.Server) *client {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal("Error generating client keys - ", err)
+	}
+	c := &client{t: t, server: server, privateKey: privateKey}
+	response := &messages.KeyExchangeResponse{}
+	c.call("KeyExchange", &messages.KeyExchangeRequest{
+		PublicKey: publicKey[:],
+		Proof:     rpc.BootstrapProof(server.BootstrapSecret, publicKey[:]),
+	}, response)
+	c.token = response.Token
+	return c
+}
+
+// call sends a request & decodes the response, failing the test unless the response is OK
+func (c *client) call(method string, request proto.Message, response proto.Message) {
+	header := c.send(method, request, response)
+	if codes.Code(header.Code) != codes.ErrorOK {
+		c.t.Error("Expected [", method, "] to succeed but got - ", header)
+	}
+}
+
+// send sends a request & decodes the response, returning the response header
+func (c *client) send(method string, request proto.Message, response proto.Message) *messages.ResponseHeader {
+	body, err := proto.Marshal(request)
+	if err != nil {
+		c.t.Fatal("Error marshaling [", method, "] request - ", err)
+	}
+	c.sequence++
+	envelope := &rpc.Envelope{
+		Direction: rpc.EnvelopeRequest,
+		Method:    method,
+		Sequence:  c.sequence,
+		Token:     c.token,
+		Body:      body,
+	}
+	signature := ed25519.Sign(c.privateKey, envelope.Bytes())
+
+	req := httptest.NewRequest("POST", "/rpc", bytes.NewReader(body))
+	req.Header.Set(rpc.HeaderProtocolVersion, strconv.Itoa(rpc.ProtocolVersion))
+	req.Header.Set(rpc.HeaderRequestMethod, method)
+	req.Header.Set(rpc.HeaderClientToken, c.token)
+	req.Header.Set(rpc.HeaderMessageSignature, base64.StdEncoding.EncodeToString(signature[:]))
+	req.Header.Set(rpc.HeaderMessageSequence, strconv.FormatInt(int64(c.sequence), 10))
+	resp := httptest.NewRecorder()
+	c.server.ServeHTTP(resp, req)
+
+	responseData, err := base64.StdEncoding.DecodeString(resp.Body.String())
+	if err != nil || len(responseData) == 0 {
+		c.t.Fatal("Expected [", method, "] to be answered - ", err)
+	}
+	err = proto.Unmarshal(responseData, response)
+	if err != nil {
+		c.t.Fatal("Error decoding [", method, "] response - ", err)
+	}
+	header := proto.Clone(response).(interface {
+		GetHeader() *messages.ResponseHeader
+	}).GetHeader()
+	return header
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-concurrency")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	server := rpc.NewServer(logger, nil, nil)
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+	defer server.Stop()
+
+	admin := newClient(t, server)
+	admin.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+	user := &messages.UserIdResponse{}
+	admin.call("Account::create", &messages.CreateAccountRequest{Name: "account", Email: "user@example.com", Passphrase: "sturdy lantern 42 orbit"}, user)
+
+	// every shelf operation derives keys with scrypt, so keep the load small
+	workers := 4
+	iterations := 2
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			c := newClient(t, server)
+			for i := 0; i < iterations; i++ {
+				name := &messages.Title{Text: fmt.Sprint("Shelf ", w, "-", i)}
+				c.call("User::Shelf::create", &messages.CreateShelfRequest{Name: name, Id: user.User.UserId}, &messages.IdResponse{})
+				c.call("Account::Shelf::create", &messages.CreateShelfRequest{Name: name, Id: user.User.AccountId}, &messages.IdResponse{})
+				c.call("User::Tag::create", &messages.CreateTagRequest{Name: name, Id: user.User.UserId}, &messages.IdResponse{})
+				c.call("User::tags", &messages.GetTagsRequest{Id: user.User.UserId}, &messages.GetTagsResponse{})
+				c.call("AccountState::get", &messages.EmptyRequest{}, &messages.AccountStateResponse{})
+				c.call("UIState::save", &messages.SaveUIStateRequest{WindowWidth: int32(w)}, &messages.EmptyResponse{})
+				c.call("UIState::load", &messages.EmptyRequest{}, &messages.LoadUIStateResponse{})
+				c.call("Client::list", &messages.EmptyRequest{}, &messages.GetClientsResponse{})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	tags := &messages.GetTagsResponse{}
+	admin.call("User::tags", &messages.GetTagsRequest{Id: user.User.UserId}, tags)
+	if len(tags.Tags) != workers*iterations {
+		t.Error("Expected [", workers*iterations, "] user tags but got [", len(tags.Tags), "]")
+	}
+}
+
+// newAccountServer starts a server with a fresh master db & a signed in account
+func newAccountServer(t *testing.T) (*rpc.Server, *client, *messages.UserIdResponse, func()) {
+	dir, err := ioutil.TempDir("", "notekeeper-handler")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	server := rpc.NewServer(logger, nil, nil)
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+
+	c := newClient(t, server)
+	c.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+	user := &messages.UserIdResponse{}
+	c.call("Account::create", &messages.CreateAccountRequest{Name: "account", Email: "user@example.com", Passphrase: "sturdy lantern 42 orbit"}, user)
+
+	cleanup := func() {
+		server.Stop()
+		os.RemoveAll(dir)
+	}
+	return server, c, user, cleanup
+}
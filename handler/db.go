@@ -5,14 +5,16 @@ import (
 	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
 	"notekeeper-electron-backend/uistate"
-
-	"github.com/golang/protobuf/proto"
 )
 
 // OpenMasterDb opens the master database in the requested directory
-func OpenMasterDb(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+// The backend's data directory is used when no directory is given.  Opening
+// closes every open db, so it's refused while an account is signed in or
+// locked - sign out first.
+func OpenMasterDb(server *rpc.Server, call *rpc.Call) error {
+	if server.Account != nil || server.UserState != rpc.UserStateSignedOut {
+		call.Context.Logger.Warn("Refused to open the master db in user state [", server.UserState, "]")
+		return rpc.NewError(codes.ErrorUnauthorized)
 	}
 
 	// need to close any existing db
@@ -20,25 +22,19 @@ func OpenMasterDb(server *rpc.Server, message []byte, context *rpc.RequestContex
 		server.DBRegistry.CloseAll()
 	}
 
-	request := messages.OpenMasterDbRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling open master db request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
+	request := call.Request.(*messages.OpenMasterDbRequest)
 
-	err = server.DBRegistry.OpenMaster(request.Path)
+	path := request.Path
+	if path == "" {
+		path = server.DataPath
+	}
+	err := server.DBRegistry.OpenMaster(path)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
 
 	// make sure DB has a default UIState saved
 	state := uistate.NewUIState(server.DBRegistry, server.Logger)
 	err = state.Create()
-	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-	}
-	return response, nil
+	return err
 }
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+)
+
+func TestOpenMasterDb(t *testing.T) {
+	server, c, _, cleanup := newAccountServer(t)
+	defer cleanup()
+
+	for _, method := range []string{"", "Account::lock"} {
+		if method != "" {
+			c.call(method, &messages.EmptyRequest{}, &messages.EmptyResponse{})
+		}
+		header := c.send("MasterDb::open", &messages.OpenMasterDbRequest{}, &messages.EmptyResponse{})
+		if codes.Code(header.Code) != codes.ErrorUnauthorized {
+			t.Error("Expected opening the master db while [", server.UserState, "] to be refused but got ", header)
+		}
+		if server.Account == nil {
+			t.Error("Expected the account to stay open after a refused MasterDb::open")
+		}
+	}
+	c.call("Account::unlock", &messages.UnlockAccountRequest{Passphrase: "sturdy lantern 42 orbit"}, &messages.EmptyResponse{})
+	if !server.IsSignedIn() {
+		t.Error("Expected the account to unlock after a refused MasterDb::open")
+	}
+
+	c.call("Account::signout", &messages.EmptyRequest{}, &messages.EmptyResponse{})
+	// signing out revokes every client token
+	c = newClient(t, server)
+	dir, err := ioutil.TempDir("", "notekeeper-handler")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	c.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+	if server.UserState != rpc.UserStateSignedOut {
+		t.Error("Expected to stay signed out after opening the master db")
+	}
+}
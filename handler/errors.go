@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/rpc"
+)
+
+// invalidField is the error for a request field that doesn't hold one of its allowed values
+// Scope is included as a field since scoped methods act on it like a request field.
+func invalidField(call *rpc.Call, field string, value string) error {
+	call.Context.Logger.Warn("Invalid ", field, " [", value, "] for [", call.Method, "]")
+	return rpc.NewInvalid(codes.FieldError{
+		Field:   field,
+		Reason:  codes.FieldUnknown,
+		Message: "unknown " + field + " [" + value + "]",
+	})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+	"notekeeper-electron-backend/validate"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestInvalidRequests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-errors")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	server := rpc.NewServer(logger, nil, nil)
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+	defer server.Stop()
+
+	c := newClient(t, server)
+	c.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+	user := &messages.UserIdResponse{}
+	c.call("Account::create", &messages.CreateAccountRequest{Name: "account", Email: "user@example.com", Passphrase: "sturdy lantern 42 orbit"}, user)
+	userID := user.User.UserId
+
+	tests := []struct {
+		name    string
+		method  string
+		request *messages.GetShelvesRequest
+		field   string
+	}{
+		{"sort", "User::shelves", &messages.GetShelvesRequest{Id: userID, Options: &messages.ListOptions{Sort: "size"}}, "sort"},
+		{"locked", "User::shelves", &messages.GetShelvesRequest{Id: userID, Options: &messages.ListOptions{Locked: "maybe"}}, "locked"},
+		{"type", "User::shelves", &messages.GetShelvesRequest{Id: userID, Options: &messages.ListOptions{Type: "spreadsheet"}}, "type"},
+		{"limit", "User::shelves", &messages.GetShelvesRequest{Id: userID, Options: &messages.ListOptions{Limit: -1}}, "limit"},
+	}
+	for _, test := range tests {
+		response := &messages.GetShelvesResponse{}
+		header := c.send(test.method, test.request, response)
+		if codes.Code(header.Code) != codes.ErrorInvalid || header.Type != int32(codes.TypeApplication) || header.Reason != "invalid" {
+			t.Error("Expected an invalid [", test.name, "] to be an invalid request application error but got ", header)
+			continue
+		}
+		if header.Status != codes.StatusAppError || header.Retryable || len(response.Shelves) != 0 {
+			t.Error("Expected an invalid [", test.name, "] to fail without being retryable but got ", header)
+		}
+		if len(header.Details) != 1 || header.Details[0].Field != test.field || header.Details[0].Reason == "" {
+			t.Error("Expected the details to name the [", test.field, "] field but got ", header.Details)
+		}
+	}
+
+	response := &messages.GetShelvesResponse{}
+	header := c.send("Account::shelves", &messages.GetShelvesRequest{Id: userID}, response)
+	if codes.Code(header.Code) != codes.ErrorUnauthorized || header.Reason != "unauthorized" || header.Type != int32(codes.TypeSystem) {
+		t.Error("Expected a user id in the account scope to be unauthorized but got ", header)
+	}
+
+	header = c.send("Account::Shelf::create", &messages.CreateShelfRequest{Id: "abc", Name: &messages.Title{Color: "blue"}}, &messages.IdResponse{})
+	if codes.Code(header.Code) != codes.ErrorInvalid || len(header.Details) != 3 {
+		t.Error("Expected every invalid field to be reported before authorizing but got ", header)
+	}
+
+	header = c.send("Client::list", &messages.EmptyRequest{}, &messages.GetClientsResponse{})
+	if header.Type != int32(codes.TypeOK) || header.Reason != "ok" || len(header.Details) != 0 {
+		t.Error("Expected a successful response to have no error details but got ", header)
+	}
+}
+
+func TestValidatedMethods(t *testing.T) {
+	for _, method := range Methods() {
+		if method.Validate == nil {
+			t.Error("Expected [", method.Name, "] to validate its requests")
+		}
+		if method.Request != nil && !validate.Known(method.Request()) {
+			t.Error("Expected a validation rule for the [", method.Name, "] request")
+		}
+	}
+}
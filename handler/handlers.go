@@ -1,78 +1,416 @@
 package handler
 
 import (
+	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
-)
-
-// Handlers returns all available rpc handlers
-func Handlers() map[string]rpc.Handler {
-	handlers := make(map[string]rpc.Handler, 0)
-	handlers["KeyExchange"] = KeyExchange
+	"notekeeper-electron-backend/validate"
 
-	handlers["MasterDb::open"] = OpenMasterDb
-
-	handlers["Account::create"] = CreateAccount
-	handlers["Account::unlock"] = UnlockAccount
-	handlers["Account::signin"] = SigninAccount
-	handlers["Account::signout"] = SignoutAccount
-	handlers["Account::lock"] = LockAccount
+	"github.com/golang/protobuf/proto"
+)
 
-	handlers["AccountState::get"] = GetAccountState
+// Methods returns the declarations of all available rpc methods
+// Scoped methods are registered as both a User:: & an Account:: method.  Every
+// request is checked by the validate package before it's authorized.
+func Methods() []*rpc.Method {
+	methods := []*rpc.Method{
+		{
+			Name:     "KeyExchange",
+			Request:  func() proto.Message { return &messages.KeyExchangeRequest{} },
+			Response: func() proto.Message { return &messages.KeyExchangeResponse{} },
+			Handler:  KeyExchange,
+		},
 
-	handlers["UIState::load"] = LoadUIState
-	handlers["UIState::save"] = SaveUIState
+		{
+			Name:     "Service::shutdown",
+			Request:  func() proto.Message { return &messages.EmptyRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  Shutdown,
+		},
+		{
+			Name:     "Service::status",
+			Request:  func() proto.Message { return &messages.EmptyRequest{} },
+			Response: func() proto.Message { return &messages.ServiceStatusResponse{} },
+			Handler:  GetStatus,
+		},
+		{
+			Name:     "Service::logs",
+			Request:  func() proto.Message { return &messages.ServiceLogsRequest{} },
+			Response: func() proto.Message { return &messages.ServiceLogsResponse{} },
+			Handler:  GetLogs,
+		},
 
-	handlers["User::shelves"] = GetUserShelves
-	handlers["User::Shelf::create"] = CreateUserShelf
-	handlers["User::Shelf::save"] = SaveUserShelf
-	handlers["User::Shelf::delete"] = DeleteUserShelf
+		{
+			Name:     "Client::list",
+			Request:  func() proto.Message { return &messages.EmptyRequest{} },
+			Response: func() proto.Message { return &messages.GetClientsResponse{} },
+			Handler:  GetClients,
+		},
+		{
+			Name:     "Client::revoke",
+			Request:  func() proto.Message { return &messages.IdRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  RevokeClient,
+		},
 
-	handlers["Account::shelves"] = GetAccountShelves
-	handlers["Account::Shelf::create"] = CreateAccountShelf
-	handlers["Account::Shelf::save"] = SaveAccountShelf
-	handlers["Account::Shelf::delete"] = DeleteAccountShelf
+		{
+			Name:     "MasterDb::open",
+			Request:  func() proto.Message { return &messages.OpenMasterDbRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  OpenMasterDb,
+		},
 
-	handlers["User::collections"] = GetUserCollections
-	handlers["User::Collection::create"] = CreateUserCollection
-	handlers["User::Collection::save"] = SaveUserCollection
-	handlers["User::Collection::delete"] = DeleteUserCollection
+		{
+			Name:     "Account::create",
+			Request:  func() proto.Message { return &messages.CreateAccountRequest{} },
+			Response: func() proto.Message { return &messages.UserIdResponse{} },
+			Handler:  CreateAccount,
+		},
+		{
+			Name:     "Account::unlock",
+			Request:  func() proto.Message { return &messages.UnlockAccountRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  UnlockAccount,
+		},
+		{
+			Name:     "Account::signin",
+			Request:  func() proto.Message { return &messages.SigninAccountRequest{} },
+			Response: func() proto.Message { return &messages.UserIdResponse{} },
+			Handler:  SigninAccount,
+		},
+		{
+			Name:     "Account::signout",
+			Request:  func() proto.Message { return &messages.EmptyRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  SignoutAccount,
+		},
+		{
+			Name:     "Account::lock",
+			Request:  func() proto.Message { return &messages.EmptyRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  LockAccount,
+		},
+		{
+			Name:     "Account::changePassphrase",
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.ChangePassphraseRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  ChangePassphrase,
+		},
 
-	handlers["Account::collections"] = GetAccountCollections
-	handlers["Account::Collection::create"] = CreateAccountCollection
-	handlers["Account::Collection::save"] = SaveAccountCollection
-	handlers["Account::Collection::delete"] = DeleteAccountCollection
+		{
+			Name:     "AccountState::get",
+			Request:  func() proto.Message { return &messages.EmptyRequest{} },
+			Response: func() proto.Message { return &messages.AccountStateResponse{} },
+			Handler:  GetAccountState,
+		},
 
-	handlers["User::tags"] = GetUserTags
-	handlers["User::Tag::create"] = CreateUserTag
-	handlers["User::Tag::save"] = SaveUserTag
-	handlers["User::Tag::delete"] = DeleteUserTag
+		{
+			Name:     "Stats::account",
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.AccountStatsRequest{} },
+			Response: func() proto.Message { return &messages.AccountStatsResponse{} },
+			Handler:  GetAccountStats,
+		},
 
-	handlers["Account::tags"] = GetAccountTags
-	handlers["Account::Tag::create"] = CreateAccountTag
-	handlers["Account::Tag::save"] = SaveAccountTag
-	handlers["Account::Tag::delete"] = DeleteAccountTag
+		{
+			Name:     "UIState::load",
+			Request:  func() proto.Message { return &messages.EmptyRequest{} },
+			Response: func() proto.Message { return &messages.LoadUIStateResponse{} },
+			Handler:  LoadUIState,
+		},
+		{
+			Name:     "UIState::save",
+			Request:  func() proto.Message { return &messages.SaveUIStateRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  SaveUIState,
+		},
 
-	handlers["User::notebooks"] = GetUserNotebooks
-	handlers["User::Notebook::create"] = CreateUserNotebook
-	handlers["User::Notebook::save"] = SaveUserNotebook
-	handlers["User::Notebook::delete"] = DeleteUserNotebook
+		{
+			Name:     "shelves",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.GetShelvesRequest{} },
+			Response: func() proto.Message { return &messages.GetShelvesResponse{} },
+			Handler:  getShelves,
+			Resolver: idOwnerTargets,
+		},
+		{
+			Name:     "Shelf::create",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.CreateShelfRequest{} },
+			Response: func() proto.Message { return &messages.IdResponse{} },
+			Handler:  createShelf,
+			Resolver: idOwnerTargets,
+		},
+		{
+			Name:     "Shelf::save",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.SaveShelfRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  saveShelf,
+			Resolver: shelfTargets,
+		},
+		{
+			Name:     "Shelf::delete",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.DeleteShelfRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  deleteShelf,
+			Resolver: shelfTargets,
+		},
 
-	handlers["Account::notebooks"] = GetAccountNotebooks
-	handlers["Account::Notebook::create"] = CreateAccountNotebook
-	handlers["Account::Notebook::save"] = SaveAccountNotebook
-	handlers["Account::Notebook::delete"] = DeleteAccountNotebook
+		{
+			Name:     "collections",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.GetCollectionsRequest{} },
+			Response: func() proto.Message { return &messages.GetCollectionsResponse{} },
+			Handler:  getCollections,
+			Resolver: collectionShelfTargets,
+		},
+		{
+			Name:     "Collection::create",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.CreateCollectionRequest{} },
+			Response: func() proto.Message { return &messages.IdResponse{} },
+			Handler:  createCollection,
+			Resolver: collectionShelfTargets,
+		},
+		{
+			Name:     "Collection::save",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.SaveCollectionRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  saveCollection,
+			Resolver: collectionTargets,
+		},
+		{
+			Name:     "Collection::delete",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.DeleteCollectionRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  deleteCollection,
+			Resolver: collectionTargets,
+		},
 
-	handlers["User::notes"] = GetUserNotes
-	handlers["User::Note::load"] = LoadUserNote
-	handlers["User::Note::create"] = CreateUserNote
-	handlers["User::Note::save"] = SaveUserNote
-	handlers["User::Note::delete"] = DeleteUserNote
+		{
+			Name:     "tags",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.GetTagsRequest{} },
+			Response: func() proto.Message { return &messages.GetTagsResponse{} },
+			Handler:  getTags,
+			Resolver: idOwnerTargets,
+		},
+		{
+			Name:     "Tag::create",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.CreateTagRequest{} },
+			Response: func() proto.Message { return &messages.IdResponse{} },
+			Handler:  createTag,
+			Resolver: idOwnerTargets,
+		},
+		{
+			Name:     "Tag::save",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.SaveTagRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  saveTag,
+			Resolver: ownerTargets,
+		},
+		{
+			Name:     "Tag::delete",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.DeleteTagRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  deleteTag,
+			Resolver: ownerTargets,
+		},
+		{
+			Name:     "Tag::move",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.MoveTagRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  moveTag,
+			Resolver: ownerTargets,
+		},
+		{
+			Name:     "Tag::merge",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.MergeTagRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  mergeTag,
+			Resolver: ownerTargets,
+		},
+		{
+			Name:     "Tag::assign",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.AssignTagRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  assignTag,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Tag::unassign",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.UnassignTagRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  unassignTag,
+			Resolver: ownerTargets,
+		},
 
-	handlers["Account::notes"] = GetAccountNotes
-	handlers["Account::Note::load"] = LoadAccountNote
-	handlers["Account::Note::create"] = CreateAccountNote
-	handlers["Account::Note::save"] = SaveAccountNote
-	handlers["Account::Note::delete"] = DeleteAccountNote
+		{
+			Name:     "notebooks",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.GetNotebooksRequest{} },
+			Response: func() proto.Message { return &messages.GetNotebooksResponse{} },
+			Handler:  getNotebooks,
+			Resolver: notebookTargets,
+		},
+		{
+			Name:     "Notebook::create",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.CreateNotebookRequest{} },
+			Response: func() proto.Message { return &messages.IdResponse{} },
+			Handler:  createNotebook,
+			Resolver: notebookTargets,
+		},
+		{
+			Name:     "Notebook::save",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.SaveNotebookRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  saveNotebook,
+			Resolver: notebookTargets,
+		},
+		{
+			Name:     "Notebook::delete",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.DeleteNotebookRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  deleteNotebook,
+			Resolver: notebookTargets,
+		},
+		{
+			Name:     "Notebook::move",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.MoveNotebookRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  moveNotebook,
+			Resolver: notebookTransferTargets,
+		},
+		{
+			Name:     "Notebook::copy",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.CopyNotebookRequest{} },
+			Response: func() proto.Message { return &messages.IdResponse{} },
+			Handler:  copyNotebook,
+			Resolver: notebookTransferTargets,
+		},
 
-	return handlers
+		{
+			Name:     "notes",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.GetNotesRequest{} },
+			Response: func() proto.Message { return &messages.GetNotesResponse{} },
+			Handler:  getNotes,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Note::load",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.LoadNoteRequest{} },
+			Response: func() proto.Message { return &messages.LoadNoteResponse{} },
+			Handler:  loadNote,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Note::create",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.CreateNoteRequest{} },
+			Response: func() proto.Message { return &messages.IdResponse{} },
+			Handler:  createNote,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Note::save",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.SaveNoteRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  saveNote,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Note::delete",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.DeleteNoteRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  deleteNote,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Note::lock",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.LockNoteRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  lockNote,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Note::unlock",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.LockNoteRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  unlockNote,
+			Resolver: noteTargets,
+		},
+		{
+			Name:     "Note::move",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.MoveNoteRequest{} },
+			Response: func() proto.Message { return &messages.EmptyResponse{} },
+			Handler:  moveNote,
+			Resolver: noteTransferTargets,
+		},
+		{
+			Name:     "Note::copy",
+			Scoped:   true,
+			Auth:     rpc.AuthSignedIn,
+			Request:  func() proto.Message { return &messages.CopyNoteRequest{} },
+			Response: func() proto.Message { return &messages.IdResponse{} },
+			Handler:  copyNote,
+			Resolver: noteTransferTargets,
+		},
+	}
+	for _, method := range methods {
+		method.Validate = validate.Request
+	}
+	return methods
 }
@@ -6,35 +6,34 @@ import (
 	"notekeeper-electron-backend/rpc"
 
 	"github.com/agl/ed25519"
-	"github.com/golang/protobuf/proto"
 )
 
 // KeyExchange performs a key exchange between client & server
-func KeyExchange(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.KeyExchangeResponse{
-		Header: rpc.NewResponseHeader(),
+func KeyExchange(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.KeyExchangeResponse)
+	request := call.Request.(*messages.KeyExchangeRequest)
+	context := call.Context
+
+	if len(request.PublicKey) != ed25519.PublicKeySize {
+		call.Context.Logger.Warn("Invalid key exchange public key length [", len(request.PublicKey), "]")
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.KeyExchangeRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling message - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+	// the signature & verification key are contained in the same message body,
+	// so they only show that the key works.  The proof shows the key belongs
+	// to the frontend that spawned us & was handed the bootstrap secret.
+	if !server.VerifyBootstrapProof(request.PublicKey, request.Proof) {
+		return rpc.NewError(codes.ErrorUnauthorized)
 	}
 
 	// create a new client token
-	context.Token, err = rpc.NewClientToken(server.Logger)
+	token, err := rpc.NewClientToken(server.Logger)
 	if err != nil {
-		rpc.SetRPCError(response.Header, codes.ErrorCrypto)
-		return response, nil
+		return rpc.NewError(codes.ErrorCrypto)
 	}
-	// [FIXME] - assert request key length matches our target array size
+	context.Token = token
 
 	// client sent its own public key so we can verify requests it sends us later
-	// this is a bit weak sauce wrt security since signature & verification key
-	// are contained in the same message body, but it does give us assurance
-	// that we at least have a functional verification key.
 	context.Token.VerifyPublicKey = new([ed25519.PublicKeySize]byte)
 	copy(context.Token.VerifyPublicKey[:], request.PublicKey)
 
@@ -47,15 +46,15 @@ func KeyExchange(server *rpc.Server, message []byte, context *rpc.RequestContext
 	context.Token.SendCounter = 0
 	context.Token.RecvCounter = 1
 
-	server.Clients[context.Token.Token] = context.Token
+	server.AddClient(context.Token)
 
-	return response, nil
+	return nil
 	/*
 		ok := rpc.VerifyRequest(message)
 		if !ok {
 			response.Code = int(codes.ErrorVerifyRequestSignature)
 			response.Status = codes.StatusError
-			return response, nil
+			return nil
 		}
 	*/
 }
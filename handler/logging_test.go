@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"notekeeper-electron-backend/logfile"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLogRedaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-logging")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.Out = out
+	logger.Level = logrus.DebugLevel
+	logger.Formatter = &logrus.TextFormatter{DisableColors: true}
+	server := rpc.NewServer(logger, nil, nil)
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+	defer server.Stop()
+
+	c := newClient(t, server)
+	c.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+	c.call("Account::create", &messages.CreateAccountRequest{Name: "account", Email: "writer@example.com", Passphrase: "correct horse battery"}, &messages.UserIdResponse{})
+	c.call("Account::signout", &messages.EmptyRequest{}, &messages.EmptyResponse{})
+	// signing out revokes every client token
+	c = newClient(t, server)
+	c.call("Account::signin", &messages.SigninAccountRequest{Name: "account", Email: "writer@example.com", Passphrase: "correct horse battery"}, &messages.UserIdResponse{})
+
+	logged := out.String()
+	for _, secret := range []string{"writer@example.com", "correct horse battery", c.token} {
+		if strings.Contains(logged, secret) {
+			t.Error("Expected [", secret, "] never to be logged")
+		}
+	}
+	if strings.Count(logged, "Request handled") != 6 || !strings.Contains(logged, "client="+rpc.TokenHash(c.token)) {
+		t.Error("Expected a line with the client for each of the 6 requests - ", logged)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-logs")
+	if err != nil {
+		t.Fatal("Error creating log directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	log, err := logfile.Open(filepath.Join(dir, "notekeeper.log"), logfile.Policy{MaxSize: 1024, Compress: true})
+	if err != nil {
+		t.Fatal("Error opening log file - ", err)
+	}
+	defer log.Close()
+
+	logger := logrus.New()
+	logger.Out = log
+	logger.Level = logrus.InfoLevel
+	logger.Formatter = &logrus.JSONFormatter{}
+	server := rpc.NewServer(logger, nil, nil)
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+	server.Log = log
+
+	c := newClient(t, server)
+	for i := 0; i < 10; i++ {
+		c.call("Service::status", &messages.EmptyRequest{}, &messages.ServiceStatusResponse{})
+	}
+	response := &messages.ServiceLogsResponse{}
+	c.call("Service::logs", &messages.ServiceLogsRequest{Lines: 8}, response)
+	if len(response.Lines) != 8 {
+		t.Fatal("Expected 8 log lines but got ", len(response.Lines))
+	}
+	for _, line := range response.Lines {
+		if !strings.Contains(line, `"method":"Service::status"`) {
+			t.Error("Expected the lines logged for the earlier requests but got ", line)
+		}
+	}
+
+	response = &messages.ServiceLogsResponse{}
+	c.call("Service::logs", &messages.ServiceLogsRequest{}, response)
+	if len(response.Lines) != 12 {
+		t.Error("Expected every line across the rotated files but got ", len(response.Lines))
+	}
+}
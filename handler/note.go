@@ -1,397 +1,446 @@
 package handler
 
 import (
+	"time"
+
 	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
 	"notekeeper-electron-backend/note"
 	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/query"
 	"notekeeper-electron-backend/rpc"
 
-	"github.com/golang/protobuf/proto"
 	uuid "github.com/satori/go.uuid"
 )
 
-func getNotes(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.GetNotesResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
-	request := messages.GetNotesRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling get notes request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
-	}
-
-	var noteScope note.Scope
-	var store note.StoreType
-	if scope == "account" {
-		noteScope = note.ScopeAccount
-	} else if scope == "user" {
-		noteScope = note.ScopeUser
-	} else {
-		return response, nil
-	}
-
-	if request.Store == "collection" {
-		store = note.StoreTypeCollection
-	} else if request.Store == "shelf" {
-		store = note.StoreTypeShelf
-	} else {
-		return response, nil
-	}
+func getNotes(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.GetNotesResponse)
+	request := call.Request.(*messages.GetNotesRequest)
 
 	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid note owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	storeID, err := uuid.FromString(request.StoreId)
 	if err != nil {
-		server.Logger.Warn("Invalid note store id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note store id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	// create a new note instance to act as a proxy
-	n, err := note.New(nil, noteScope, store, server.DBRegistry, server.Logger)
+	n, err := note.New(nil, noteScope(call), noteStore(call), server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating note - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating note - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	n.OwnerID = ownerID
 	n.StoreID = storeID
 
-	notes, err := n.LoadAll(server.Account.ActiveUser.PassphraseKey)
+	q, err := queryFromMessage(call, request.Options)
+	if err != nil {
+		return err
+	}
+	if request.NotebookId != "" {
+		notebookID, err := uuid.FromString(request.NotebookId)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid notebook id - ", err)
+			return rpc.NewError(codes.ErrorDecode)
+		}
+		q.Extra = func(item query.Item) bool {
+			return item.(*note.Note).NotebookID == notebookID
+		}
+	}
+	if q.TagID != uuid.Nil {
+		q.Tagged, err = taggedObjects(server, scope, ownerID, q.TagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	key, err := storeKey(server, scope)
+	if err != nil {
+		return err
+	}
+	defer crypto.Zero(key)
+
+	notes, nextCursor, err := n.LoadPage(q, key)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
+	response.NextCursor = nextCursor
 
 	for _, n := range notes {
 		m := &messages.Note{
-			Id:      n.ID.String(),
-			Scope:   request.Scope,
-			Store:   request.Store,
-			OwnerId: request.OwnerId,
-			StoreId: request.StoreId,
-			Name:    rpc.TitleToMessage(n.Title),
-			Locked:  n.Locked,
-			Created: rpc.TimeToMessage(n.Created),
-			Updated: rpc.TimeToMessage(n.Updated),
+			Id:         n.ID.String(),
+			NotebookId: n.NotebookID.String(),
+			Scope:      request.Scope,
+			Store:      request.Store,
+			OwnerId:    request.OwnerId,
+			StoreId:    request.StoreId,
+			Name:       rpc.TitleToMessage(n.Title),
+			Locked:     n.Locked,
+			Created:    rpc.TimeToMessage(n.Created),
+			Updated:    rpc.TimeToMessage(n.Updated),
 		}
 		response.Notes = append(response.Notes, m)
 	}
 
-	return response, nil
+	return nil
 }
 
-func loadNote(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.LoadNoteResponse{
-		Header: rpc.NewResponseHeader(),
+func loadNote(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.LoadNoteResponse)
+	request := call.Request.(*messages.LoadNoteRequest)
+
+	ownerID, err := uuid.FromString(request.OwnerId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.LoadNoteRequest{}
-	err := proto.Unmarshal(message, &request)
+	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling load note request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	storeID, err := uuid.FromString(request.StoreId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note store id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	var noteScope note.Scope
-	var store note.StoreType
-	if scope == "account" {
-		noteScope = note.ScopeAccount
-	} else if scope == "user" {
-		noteScope = note.ScopeUser
-	} else {
-		return response, nil
+	// create a new note instance to act as a proxy
+	n, err := note.New(nil, noteScope(call), noteStore(call), server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating note - ", err)
+		return rpc.NewError(codes.ErrorCreate)
+	}
+	n.ID = id
+	n.OwnerID = ownerID
+	n.StoreID = storeID
+
+	key, err := storeKey(server, scope)
+	if err != nil {
+		return err
 	}
+	defer crypto.Zero(key)
 
-	if request.Store == "collection" {
-		store = note.StoreTypeCollection
-	} else if request.Store == "shelf" {
-		store = note.StoreTypeShelf
-	} else {
-		return response, nil
+	err = n.Load(key)
+	if err != nil {
+		return err
 	}
 
+	response.Note = &messages.Note{
+		Id:         n.ID.String(),
+		NotebookId: n.NotebookID.String(),
+		Store:      request.Store,
+		OwnerId:    request.OwnerId,
+		StoreId:    request.StoreId,
+		Name:       rpc.TitleToMessage(n.Title),
+		Locked:     n.Locked,
+		Created:    rpc.TimeToMessage(n.Created),
+		Updated:    rpc.TimeToMessage(n.Updated),
+	}
+
+	return nil
+}
+
+func createNote(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.IdResponse)
+	request := call.Request.(*messages.CreateNoteRequest)
+
 	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid note owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	storeID, err := uuid.FromString(request.StoreId)
 	if err != nil {
-		server.Logger.Warn("Invalid note store id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note store id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	// create a new note instance to act as a proxy
-	n, err := note.New(nil, noteScope, store, server.DBRegistry, server.Logger)
+	notebookID, err := uuid.FromString(request.NotebookId)
 	if err != nil {
-		server.Logger.Warn("Error creating note - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note notebook id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
+
+	t := rpc.MessageToTitle(request.Name)
+	n, err := note.New(t, noteScope(call), noteStore(call), server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating note - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	n.OwnerID = ownerID
 	n.StoreID = storeID
+	n.NotebookID = notebookID
 
-	err = n.Load(server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
+	defer crypto.Zero(key)
 
-	response.Note = &messages.Note{
-		Id:      n.ID.String(),
-		Store:   request.Store,
-		OwnerId: request.OwnerId,
-		StoreId: request.StoreId,
-		Name:    rpc.TitleToMessage(n.Title),
-		Locked:  n.Locked,
-		Created: rpc.TimeToMessage(n.Created),
-		Updated: rpc.TimeToMessage(n.Updated),
+	err = n.Save(key)
+	if err != nil {
+		return err
 	}
 
-	return response, nil
+	response.Id = n.ID.String()
+
+	return nil
 }
 
-func createNote(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.IdResponse{
-		Header: rpc.NewResponseHeader(),
-	}
+func saveNote(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.SaveNoteRequest)
 
-	request := messages.CreateNoteRequest{}
-	err := proto.Unmarshal(message, &request)
+	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling create note request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	ownerID, err := uuid.FromString(request.OwnerId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	var noteScope note.Scope
-	var store note.StoreType
-	if scope == "account" {
-		noteScope = note.ScopeAccount
-	} else if scope == "user" {
-		noteScope = note.ScopeUser
-	} else {
-		return response, nil
+	storeID, err := uuid.FromString(request.StoreId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note store id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if request.Store == "collection" {
-		store = note.StoreTypeCollection
-	} else if request.Store == "shelf" {
-		store = note.StoreTypeShelf
-	} else {
-		return response, nil
+	notebookID, err := uuid.FromString(request.NotebookId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note notebook id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	ownerID, err := uuid.FromString(request.OwnerId)
+	n, err := note.New(nil, noteScope(call), noteStore(call), server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Invalid note owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Error creating note - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
+	n.ID = id
+	n.OwnerID = ownerID
+	n.StoreID = storeID
 
-	storeID, err := uuid.FromString(request.StoreId)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		server.Logger.Warn("Invalid note store id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
 	}
+	defer crypto.Zero(key)
 
-	t := rpc.MessageToTitle(request.Name)
-	n, err := note.New(t, noteScope, store, server.DBRegistry, server.Logger)
+	// load the existing record so that fields not carried by the request (content, type, lock, etc.) are kept
+	err = n.Load(key)
 	if err != nil {
-		server.Logger.Warn("Error creating note - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		return err
 	}
-	n.OwnerID = ownerID
-	n.StoreID = storeID
+	n.Title = rpc.MessageToTitle(request.Name)
+	n.NotebookID = notebookID
+	n.Updated = time.Now()
 
-	err = n.Save(server.Account.ActiveUser.PassphraseKey)
+	err = n.Save(key)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
+	return nil
+}
 
-	response.Id = n.ID.String()
+func deleteNote(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.DeleteNoteRequest)
 
-	return response, nil
-}
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
 
-func saveNote(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+	ownerID, err := uuid.FromString(request.OwnerId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.SaveNoteRequest{}
-	err := proto.Unmarshal(message, &request)
+	storeID, err := uuid.FromString(request.StoreId)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling save note request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note store id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	n, err := note.New(nil, noteScope(call), noteStore(call), server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating note - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
+	n.ID = id
+	n.OwnerID = ownerID
+	n.StoreID = storeID
 
-	var noteScope note.Scope
-	var store note.StoreType
-	if scope == "account" {
-		noteScope = note.ScopeAccount
-	} else if scope == "user" {
-		noteScope = note.ScopeUser
-	} else {
-		return response, nil
+	key, err := storeKey(server, scope)
+	if err != nil {
+		return err
 	}
+	defer crypto.Zero(key)
 
-	if request.Store == "collection" {
-		store = note.StoreTypeCollection
-	} else if request.Store == "shelf" {
-		store = note.StoreTypeShelf
-	} else {
-		return response, nil
+	err = n.Delete(key)
+	if err != nil {
+		return err
 	}
+	return nil
+}
+
+// setNoteLock loads the note named by a lock request & changes only its lock state
+func setNoteLock(server *rpc.Server, call *rpc.Call, locked bool) error {
+	scope := call.Scope
+	request := call.Request.(*messages.LockNoteRequest)
 
 	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid note id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid note owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	storeID, err := uuid.FromString(request.StoreId)
 	if err != nil {
-		server.Logger.Warn("Invalid note store id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note store id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	t := rpc.MessageToTitle(request.Name)
-	n, err := note.New(t, noteScope, store, server.DBRegistry, server.Logger)
+	n, err := note.New(nil, noteScope(call), noteStore(call), server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating note - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating note - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	n.ID = id
 	n.OwnerID = ownerID
 	n.StoreID = storeID
 
-	err = n.Save(server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
-	return response, nil
+	defer crypto.Zero(key)
+
+	if locked {
+		return n.Lock(key)
+	}
+	return n.Unlock(key)
+}
+
+func lockNote(server *rpc.Server, call *rpc.Call) error {
+	return setNoteLock(server, call, true)
 }
 
-func deleteNote(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+func unlockNote(server *rpc.Server, call *rpc.Call) error {
+	return setNoteLock(server, call, false)
+}
+
+func moveNote(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.MoveNoteRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.DeleteNoteRequest{}
-	err := proto.Unmarshal(message, &request)
+	// notes are moved through a proxy for the container that holds them
+	source, err := notebookFromCall(server, call, "", request.StoreId, request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling load note request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note store - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	destination, err := destinationFromMessage(source, request.DestinationScope, request.DestinationStore, request.DestinationStoreId, request.DestinationOwnerId, request.DestinationNotebookId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid note destination - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	var noteScope note.Scope
-	var store note.StoreType
-	if scope == "account" {
-		noteScope = note.ScopeAccount
-	} else if scope == "user" {
-		noteScope = note.ScopeUser
-	} else {
-		return response, nil
+	key, err := storeKey(server, scope)
+	if err != nil {
+		return err
 	}
+	defer crypto.Zero(key)
 
-	if request.Store == "collection" {
-		store = note.StoreTypeCollection
-	} else if request.Store == "shelf" {
-		store = note.StoreTypeShelf
-	} else {
-		return response, nil
+	err = source.MoveNote(id, destination, key)
+	if err != nil {
+		return err
 	}
+	return nil
+}
+
+func copyNote(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.IdResponse)
+	request := call.Request.(*messages.CopyNoteRequest)
 
 	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid note id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	ownerID, err := uuid.FromString(request.OwnerId)
+	source, err := notebookFromCall(server, call, "", request.StoreId, request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid note owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note store - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	storeID, err := uuid.FromString(request.StoreId)
+	destination, err := destinationFromMessage(source, request.DestinationScope, request.DestinationStore, request.DestinationStoreId, request.DestinationOwnerId, request.DestinationNotebookId)
 	if err != nil {
-		server.Logger.Warn("Invalid note store id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid note destination - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	n, err := note.New(nil, noteScope, store, server.DBRegistry, server.Logger)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		server.Logger.Warn("Error creating note - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		return err
 	}
-	n.ID = id
-	n.OwnerID = ownerID
-	n.StoreID = storeID
+	defer crypto.Zero(key)
 
-	err = n.Delete(server.Account.ActiveUser.PassphraseKey)
+	copied, err := source.CopyNote(id, destination, key)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
+	}
+
+	response.Id = copied.ID.String()
+
+	return nil
+}
+
+// noteScope converts the scope of a call to the scope of the notes it acts on
+func noteScope(call *rpc.Call) note.Scope {
+	if call.Scope == rpc.ScopeAccount {
+		return note.ScopeAccount
+	}
+	return note.ScopeUser
+}
+
+// noteStore converts the store resolved for a call to the type of store holding its notes
+func noteStore(call *rpc.Call) note.StoreType {
+	if call.Store == rpc.StoreCollection {
+		return note.StoreTypeCollection
 	}
-	return response, nil
+	return note.StoreTypeShelf
 }
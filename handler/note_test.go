@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"testing"
+
+	"notekeeper-electron-backend/api"
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/notebook"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+	"notekeeper-electron-backend/shelf"
+	"notekeeper-electron-backend/title"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// defaultNotebook returns the default notebook of the default user shelf & the key its shelf is opened with
+func defaultNotebook(t *testing.T, server *rpc.Server) (*notebook.Notebook, []byte) {
+	userID := server.Account.ActiveUser.ID
+	index := shelf.NewIndex(shelf.ScopeUser, userID, server.DBRegistry, server.Logger)
+	err := index.LoadAll(server.Account.ActiveUser.PassphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load the user shelves - ", err)
+	}
+	var shelfID uuid.UUID
+	for _, s := range index.Shelves {
+		if s.Default {
+			shelfID = s.ID
+		}
+	}
+	key, err := api.New(server.DBRegistry, server.Logger).ShelfKey(server.Account, shelf.ScopeUser)
+	if err != nil {
+		t.Fatal("Expected to open the user shelf key - ", err)
+	}
+
+	proxy, _ := notebook.New(nil, notebook.ScopeUser, notebook.ContainerTypeShelf, server.DBRegistry, server.Logger)
+	proxy.OwnerID = userID
+	proxy.ContainerID = shelfID
+	notebooks, err := proxy.LoadAll(key)
+	if err != nil || len(notebooks) == 0 {
+		t.Fatal("Expected to load the default notebook - ", err)
+	}
+	notebooks[0].OwnerID = userID
+	notebooks[0].ContainerID = proxy.ContainerID
+	return notebooks[0], key
+}
+
+// storeNote saves a note with content straight to the store, as the RPC methods don't carry content
+func storeNote(t *testing.T, server *rpc.Server, nb *notebook.Notebook, key []byte, content string, locked bool) *note.Note {
+	n, err := note.New(title.New("Secret"), note.ScopeUser, note.StoreTypeShelf, server.DBRegistry, server.Logger)
+	if err != nil {
+		t.Fatal("Expected to create note - ", err)
+	}
+	n.OwnerID = nb.OwnerID
+	n.StoreID = nb.ContainerID
+	n.NotebookID = nb.ID
+	n.Type = note.TypeMarkdown
+	n.Content = content
+	n.Locked = locked
+	err = n.Save(key)
+	if err != nil {
+		t.Fatal("Expected to save note - ", err)
+	}
+	return n
+}
+
+// renameNote builds a request that saves a note with a new title
+func renameNote(n *note.Note, name string) *messages.SaveNoteRequest {
+	return &messages.SaveNoteRequest{
+		Id:         n.ID.String(),
+		NotebookId: n.NotebookID.String(),
+		OwnerId:    n.OwnerID.String(),
+		Store:      "shelf",
+		StoreId:    n.StoreID.String(),
+		Name:       &messages.Title{Text: name},
+	}
+}
+
+// lockRequest builds a request that locks or unlocks a note
+func lockRequest(n *note.Note) *messages.LockNoteRequest {
+	return &messages.LockNoteRequest{
+		Id:      n.ID.String(),
+		OwnerId: n.OwnerID.String(),
+		Store:   "shelf",
+		StoreId: n.StoreID.String(),
+	}
+}
+
+func TestSaveNote(t *testing.T) {
+	server, c, _, cleanup := newAccountServer(t)
+	defer cleanup()
+	nb, key := defaultNotebook(t, server)
+	n := storeNote(t, server, nb, key, "the combination is 12-34-56", true)
+	save := renameNote(n, "Renamed")
+	load := func() *note.Note {
+		stored, _ := note.New(nil, note.ScopeUser, note.StoreTypeShelf, server.DBRegistry, server.Logger)
+		stored.ID = n.ID
+		stored.StoreID = n.StoreID
+		err := stored.Load(key)
+		if err != nil {
+			t.Fatal("Expected to load note - ", err)
+		}
+		return stored
+	}
+
+	// saving a locked note is refused rather than unlocking it
+	header := c.send("User::Note::save", save, &messages.EmptyResponse{})
+	if codes.Code(header.Code) != codes.ErrorLocked {
+		t.Error("Expected saving a locked note to fail with ErrorLocked but got ", header)
+	}
+	stored := load()
+	if !stored.Locked || stored.Content != n.Content || stored.Title.Title != "Secret" {
+		t.Error("Expected the locked note to be unchanged but got ", stored)
+	}
+
+	// once unlocked, a save only changes the fields carried by the request
+	c.call("User::Note::unlock", lockRequest(n), &messages.EmptyResponse{})
+	c.call("User::Note::save", save, &messages.EmptyResponse{})
+	stored = load()
+	if stored.Title.Title != "Renamed" || stored.Content != n.Content || stored.Type != note.TypeMarkdown || stored.Locked {
+		t.Error("Expected only the title to change but got ", stored)
+	}
+
+	// locking only changes the lock state
+	c.call("User::Note::lock", lockRequest(n), &messages.EmptyResponse{})
+	stored = load()
+	if !stored.Locked || stored.Title.Title != "Renamed" || stored.Content != n.Content {
+		t.Error("Expected only the lock state to change but got ", stored)
+	}
+	c.call("User::Note::lock", lockRequest(n), &messages.EmptyResponse{})
+
+	// saving a note that doesn't exist doesn't touch any other note
+	save.Id = uuid.NewV4().String()
+	header = c.send("User::Note::save", save, &messages.EmptyResponse{})
+	if codes.Code(header.Code) != codes.ErrorRecordMissing {
+		t.Error("Expected saving a missing note to fail with ErrorRecordMissing but got ", header)
+	}
+}
+
+func TestSaveNoteStats(t *testing.T) {
+	server, c, _, cleanup := newAccountServer(t)
+	defer cleanup()
+	nb, key := defaultNotebook(t, server)
+	content := "twenty-six bytes of text.."
+	n := storeNote(t, server, nb, key, content, false)
+
+	expectStats := func(when string) {
+		response := &messages.GetNotebooksResponse{}
+		c.call("User::notebooks", &messages.GetNotebooksRequest{
+			Container:   "shelf",
+			OwnerId:     nb.OwnerID.String(),
+			ContainerId: nb.ContainerID.String(),
+		}, response)
+		for _, m := range response.Notebooks {
+			if m.Id != nb.ID.String() {
+				continue
+			}
+			if m.NoteCount != 1 || m.ContentBytes != int64(len(content)) {
+				t.Error("Expected 1 note of [", len(content), "] bytes ", when, " but got [", m.NoteCount, "] notes of [", m.ContentBytes, "] bytes")
+			}
+			return
+		}
+		t.Error("Expected to list the default notebook ", when)
+	}
+
+	expectStats("after creating the note")
+	c.call("User::Note::save", renameNote(n, "Renamed"), &messages.EmptyResponse{})
+	expectStats("after saving the note over RPC")
+	c.call("User::Note::save", renameNote(n, "Renamed again"), &messages.EmptyResponse{})
+	expectStats("after saving the note twice")
+}
@@ -1,320 +1,432 @@
 package handler
 
 import (
+	"errors"
+	"time"
+
 	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
 	"notekeeper-electron-backend/notebook"
 	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
 
-	"github.com/golang/protobuf/proto"
 	uuid "github.com/satori/go.uuid"
 )
 
-func createNotebook(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.IdResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
-	request := messages.CreateNotebookRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling create notebook request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
-	}
-
-	var notebookScope notebook.Scope
-	var container notebook.ContainerType
-	if scope == "account" {
-		notebookScope = notebook.ScopeAccount
-	} else if scope == "user" {
-		notebookScope = notebook.ScopeUser
-	} else {
-		return response, nil
-	}
-
-	if request.Container == "collection" {
-		container = notebook.ContainerTypeCollection
-	} else if request.Container == "shelf" {
-		container = notebook.ContainerTypeShelf
-	} else {
-		return response, nil
-	}
+func createNotebook(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.IdResponse)
+	request := call.Request.(*messages.CreateNotebookRequest)
 
 	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	containerID, err := uuid.FromString(request.ContainerId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook container id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook container id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	t := rpc.MessageToTitle(request.Name)
-	notebook, err := notebook.New(t, notebookScope, container, server.DBRegistry, server.Logger)
+	notebook, err := notebook.New(t, notebookScope(call), notebookContainer(call), server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating notebook - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating notebook - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	notebook.OwnerID = ownerID
 	notebook.ContainerID = containerID
 
-	err = notebook.Save(server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
+	defer crypto.Zero(key)
 
-	response.Id = notebook.ID.String()
-
-	return response, nil
-}
-
-// GetNotebooks gets all of the notebooks
-func getNotebooks(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.GetNotebooksResponse{
-		Header: rpc.NewResponseHeader(),
+	if request.ParentId != "" {
+		parentID, err := uuid.FromString(request.ParentId)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid notebook parent id - ", err)
+			return rpc.NewError(codes.ErrorDecode)
+		}
+		// make sure the parent exists in the same container
+		parent := *notebook
+		parent.ID = parentID
+		err = parent.Load(key)
+		if err != nil {
+			return err
+		}
+		notebook.ParentID = parentID
 	}
 
-	request := messages.GetNotebooksRequest{}
-	err := proto.Unmarshal(message, &request)
+	notebookKey, err := notebook.UnsealKey(key)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling get notebooks request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
 	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	err = notebook.Save(notebookKey)
+	if err != nil {
+		return err
 	}
 
-	var notebookScope notebook.Scope
-	var container notebook.ContainerType
-	if scope == "account" {
-		notebookScope = notebook.ScopeAccount
-	} else if scope == "user" {
-		notebookScope = notebook.ScopeUser
-	} else {
-		return response, nil
-	}
+	response.Id = notebook.ID.String()
 
-	if request.Container == "collection" {
-		container = notebook.ContainerTypeCollection
-	} else if request.Container == "shelf" {
-		container = notebook.ContainerTypeShelf
-	} else {
-		return response, nil
-	}
+	return nil
+}
+
+// GetNotebooks gets all of the notebooks
+func getNotebooks(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.GetNotebooksResponse)
+	request := call.Request.(*messages.GetNotebooksRequest)
 
 	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	containerID, err := uuid.FromString(request.ContainerId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook container id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook container id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	// create a new notebook instance to act as a proxy
-	nb, err := notebook.New(nil, notebookScope, container, server.DBRegistry, server.Logger)
+	nb, err := notebook.New(nil, notebookScope(call), notebookContainer(call), server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating notebook - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating notebook - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	nb.OwnerID = ownerID
 	nb.ContainerID = containerID
 
-	notebooks, err := nb.LoadAll(server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
+	defer crypto.Zero(key)
 
-	for _, n := range notebooks {
-		m := &messages.Notebook{
-			Id:          n.ID.String(),
-			Container:   request.Container,
-			OwnerId:     request.OwnerId,
-			ContainerId: request.ContainerId,
-			Name:        rpc.TitleToMessage(n.Title),
-			Locked:      n.Locked,
-			Default:     n.Default,
-			Created:     rpc.TimeToMessage(n.Created),
-			Updated:     rpc.TimeToMessage(n.Updated),
+	// finish off any moves out of this container that were interrupted
+	err = nb.Recover(key)
+	if err != nil {
+		return err
+	}
+
+	q, err := queryFromMessage(call, request.Options)
+	if err != nil {
+		return err
+	}
+	if q.TagID != uuid.Nil {
+		q.Tagged, err = taggedObjects(server, scope, ownerID, q.TagID)
+		if err != nil {
+			return err
 		}
-		response.Notebooks = append(response.Notebooks, m)
 	}
 
-	return response, nil
+	tree, nextCursor, err := nb.LoadPage(q, key)
+	if err != nil {
+		return err
+	}
+	response.NextCursor = nextCursor
+
+	for _, node := range tree {
+		response.Notebooks = append(response.Notebooks, notebookNodeToMessage(node, request.Scope, request.Container))
+	}
+
+	return nil
+}
+
+// notebookNodeToMessage converts a notebook and everything nested below it
+func notebookNodeToMessage(node *notebook.Node, scope string, container string) *messages.Notebook {
+	n := node.Notebook
+	m := &messages.Notebook{
+		Id:          n.ID.String(),
+		Scope:       scope,
+		Container:   container,
+		OwnerId:     n.OwnerID.String(),
+		ContainerId: n.ContainerID.String(),
+		Name:        rpc.TitleToMessage(n.Title),
+		Locked:      n.Locked,
+		Default:     n.Default,
+		NoteCount:   int32(n.NoteCount),
+		Created:     rpc.TimeToMessage(n.Created),
+		Updated:     rpc.TimeToMessage(n.Updated),
+	}
+	_, m.ContentBytes, m.LastModified = statsToMessage(n.Stats)
+	if n.ParentID != uuid.Nil {
+		m.ParentId = n.ParentID.String()
+	}
+	for _, child := range node.Children {
+		m.Children = append(m.Children, notebookNodeToMessage(child, scope, container))
+	}
+	return m
 }
 
-func saveNotebook(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+func saveNotebook(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.SaveNotebookRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid notebook id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
+
+	ownerID, err := uuid.FromString(request.OwnerId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid notebook owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.SaveNotebookRequest{}
-	err := proto.Unmarshal(message, &request)
+	containerID, err := uuid.FromString(request.ContainerId)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling save notebook request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook container id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	notebook, err := notebook.New(nil, notebookScope(call), notebookContainer(call), server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating notebook - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
+	notebook.ID = id
+	notebook.OwnerID = ownerID
+	notebook.ContainerID = containerID
 
-	var notebookScope notebook.Scope
-	var container notebook.ContainerType
-	if scope == "account" {
-		notebookScope = notebook.ScopeAccount
-	} else if scope == "user" {
-		notebookScope = notebook.ScopeUser
-	} else {
-		return response, nil
+	key, err := storeKey(server, scope)
+	if err != nil {
+		return err
 	}
+	defer crypto.Zero(key)
+
+	// load the existing record so that fields not carried by the request (parent, created, etc.) are kept
+	err = notebook.Load(key)
+	if err != nil {
+		return err
+	}
+	notebook.Title = rpc.MessageToTitle(request.Name)
+	notebook.Default = request.Default
+	notebook.Locked = request.Locked
+	notebook.Updated = time.Now()
 
-	if request.Container == "collection" {
-		container = notebook.ContainerTypeCollection
-	} else if request.Container == "shelf" {
-		container = notebook.ContainerTypeShelf
-	} else {
-		return response, nil
+	notebookKey, err := notebook.UnsealKey(key)
+	if err != nil {
+		return err
+	}
+	err = notebook.Save(notebookKey)
+	if err != nil {
+		return err
 	}
+	return nil
+}
+
+func deleteNotebook(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.DeleteNotebookRequest)
 
 	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	containerID, err := uuid.FromString(request.ContainerId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook container id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook container id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	t := rpc.MessageToTitle(request.Name)
-	notebook, err := notebook.New(t, notebookScope, container, server.DBRegistry, server.Logger)
+	notebook, err := notebook.New(nil, notebookScope(call), notebookContainer(call), server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating notebook - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating notebook - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	notebook.ID = id
-	notebook.Default = request.Default
-	notebook.Locked = request.Locked
 	notebook.OwnerID = ownerID
 	notebook.ContainerID = containerID
 
-	err = notebook.Save(server.Account.ActiveUser.PassphraseKey)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
-	return response, nil
-}
+	defer crypto.Zero(key)
 
-func deleteNotebook(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+	err = notebook.Delete(key)
+	if err != nil {
+		return err
 	}
+	return nil
+}
 
-	request := messages.DeleteNotebookRequest{}
-	err := proto.Unmarshal(message, &request)
+func moveNotebook(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.MoveNotebookRequest)
+
+	source, err := notebookFromCall(server, call, request.Id, request.ContainerId, request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling create notebook request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	destination, err := destinationFromMessage(source, request.DestinationScope, request.DestinationContainer, request.DestinationContainerId, request.DestinationOwnerId, request.ParentId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid notebook destination - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	var notebookScope notebook.Scope
-	var container notebook.ContainerType
-	if scope == "account" {
-		notebookScope = notebook.ScopeAccount
-	} else if scope == "user" {
-		notebookScope = notebook.ScopeUser
-	} else {
-		return response, nil
+	key, err := storeKey(server, scope)
+	if err != nil {
+		return err
 	}
+	defer crypto.Zero(key)
 
-	if request.Container == "collection" {
-		container = notebook.ContainerTypeCollection
-	} else if request.Container == "shelf" {
-		container = notebook.ContainerTypeShelf
-	} else {
-		return response, nil
+	err = source.Move(destination, key)
+	if err != nil {
+		return err
 	}
+	return nil
+}
 
-	id, err := uuid.FromString(request.Id)
+func copyNotebook(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.IdResponse)
+	request := call.Request.(*messages.CopyNotebookRequest)
+
+	source, err := notebookFromCall(server, call, request.Id, request.ContainerId, request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	ownerID, err := uuid.FromString(request.OwnerId)
+	destination, err := destinationFromMessage(source, request.DestinationScope, request.DestinationContainer, request.DestinationContainerId, request.DestinationOwnerId, request.ParentId)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid notebook destination - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	containerID, err := uuid.FromString(request.ContainerId)
+	key, err := storeKey(server, scope)
 	if err != nil {
-		server.Logger.Warn("Invalid notebook container id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
 	}
+	defer crypto.Zero(key)
 
-	notebook, err := notebook.New(nil, notebookScope, container, server.DBRegistry, server.Logger)
+	copied, err := source.Copy(destination, key)
 	if err != nil {
-		server.Logger.Warn("Error creating notebook - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		return err
 	}
-	notebook.ID = id
-	notebook.OwnerID = ownerID
-	notebook.ContainerID = containerID
 
-	err = notebook.Delete(server.Account.ActiveUser.PassphraseKey)
+	response.Id = copied.ID.String()
+
+	return nil
+}
+
+// notebookFromCall creates a proxy for an existing notebook in the scope & container of a call
+// An empty id is allowed, giving a proxy for the container alone.
+func notebookFromCall(server *rpc.Server, call *rpc.Call, id string, containerID string, ownerID string) (*notebook.Notebook, error) {
+	nb, err := notebook.New(nil, notebookScope(call), notebookContainer(call), server.DBRegistry, server.Logger)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return nil, err
+	}
+	if id != "" {
+		nb.ID, err = uuid.FromString(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nb.OwnerID, err = uuid.FromString(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	nb.ContainerID, err = uuid.FromString(containerID)
+	if err != nil {
+		return nil, err
+	}
+	return nb, nil
+}
+
+// destinationFromMessage builds the destination of a move or copy
+// Any part of the destination that isn't given defaults to the source.
+func destinationFromMessage(source *notebook.Notebook, scope string, container string, containerID string, ownerID string, parentID string) (*notebook.Destination, error) {
+	var err error
+	destination := &notebook.Destination{
+		Scope:         source.Scope,
+		OwnerID:       source.OwnerID,
+		ContainerType: source.ContainerType,
+		ContainerID:   source.ContainerID,
+	}
+	if scope != "" {
+		var ok bool
+		destination.Scope, ok = scopeFromMessage(scope)
+		if !ok {
+			return nil, errors.New("invalid scope [" + scope + "]")
+		}
+	}
+	if container != "" {
+		var ok bool
+		destination.ContainerType, ok = containerTypeFromMessage(container)
+		if !ok {
+			return nil, errors.New("invalid container [" + container + "]")
+		}
+	}
+	if containerID != "" {
+		destination.ContainerID, err = uuid.FromString(containerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ownerID != "" {
+		destination.OwnerID, err = uuid.FromString(ownerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if parentID != "" {
+		destination.ParentID, err = uuid.FromString(parentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return destination, nil
+}
+
+// notebookScope converts the scope of a call to the scope of the notebooks it acts on
+func notebookScope(call *rpc.Call) notebook.Scope {
+	if call.Scope == rpc.ScopeAccount {
+		return notebook.ScopeAccount
+	}
+	return notebook.ScopeUser
+}
+
+// notebookContainer converts the store resolved for a call to the type of container holding its notebooks
+func notebookContainer(call *rpc.Call) notebook.ContainerType {
+	if call.Store == rpc.StoreCollection {
+		return notebook.ContainerTypeCollection
+	}
+	return notebook.ContainerTypeShelf
+}
+
+func scopeFromMessage(scope string) (notebook.Scope, bool) {
+	if scope == "account" {
+		return notebook.ScopeAccount, true
+	} else if scope == "user" {
+		return notebook.ScopeUser, true
+	}
+	return notebook.ScopeUser, false
+}
+
+func containerTypeFromMessage(container string) (notebook.ContainerType, bool) {
+	if container == "collection" {
+		return notebook.ContainerTypeCollection, true
+	} else if container == "shelf" {
+		return notebook.ContainerTypeShelf, true
 	}
-	return response, nil
+	return notebook.ContainerTypeShelf, false
 }
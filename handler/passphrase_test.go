@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestPassphrasePolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-passphrase")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	server := rpc.NewServer(logger, nil, nil)
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+	defer server.Stop()
+
+	c := newClient(t, server)
+	c.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+
+	// refused passphrases are application errors naming the passphrase field
+	refused := func(method string, request interface{}, code codes.Code) {
+		var header *messages.ResponseHeader
+		switch r := request.(type) {
+		case *messages.CreateAccountRequest:
+			header = c.send(method, r, &messages.UserIdResponse{})
+		case *messages.ChangePassphraseRequest:
+			header = c.send(method, r, &messages.EmptyResponse{})
+		}
+		if codes.Code(header.Code) != code || header.Type != int32(codes.TypeApplication) || header.Reason != code.Reason() {
+			t.Error("Expected [", method, "] to fail with [", code.Reason(), "] but got ", header)
+			return
+		}
+		if len(header.Details) != 1 || header.Details[0].Field != "passphrase" || header.Details[0].Message == "" {
+			t.Error("Expected guidance for the passphrase field but got ", header.Details)
+		}
+	}
+	create := func(passphrase string) *messages.CreateAccountRequest {
+		return &messages.CreateAccountRequest{Name: "account", Email: "user@example.com", Passphrase: passphrase}
+	}
+	refused("Account::create", create("short"), codes.ErrorPassphraseTooShort)
+	refused("Account::create", create("qwertyuiop1234"), codes.ErrorPassphraseBanned)
+	refused("Account::create", create("my user account"), codes.ErrorPassphraseBanned)
+	refused("Account::create", create("sunshine1"), codes.ErrorPassphraseBreached)
+	refused("Account::create", create("abcdefghij"), codes.ErrorPassphraseWeak)
+	state := &messages.AccountStateResponse{}
+	c.call("AccountState::get", &messages.EmptyRequest{}, state)
+	if state.Exists {
+		t.Error("Expected no account to be created with a refused passphrase")
+	}
+
+	current := "sturdy lantern 42 orbit"
+	next := "quiet meadow 17 comet"
+	c.call("Account::create", create(current), &messages.UserIdResponse{})
+
+	header := c.send("Account::changePassphrase", &messages.ChangePassphraseRequest{Passphrase: next, NewPassphrase: next}, &messages.EmptyResponse{})
+	if codes.Code(header.Code) != codes.ErrorUnauthorized {
+		t.Error("Expected a change with the wrong current passphrase to be unauthorized but got ", header)
+	}
+	refused("Account::changePassphrase", &messages.ChangePassphraseRequest{Passphrase: current, NewPassphrase: "iloveyou"}, codes.ErrorPassphraseBanned)
+	c.call("Account::changePassphrase", &messages.ChangePassphraseRequest{Passphrase: current, NewPassphrase: next}, &messages.EmptyResponse{})
+	c.call("Client::list", &messages.EmptyRequest{}, &messages.GetClientsResponse{})
+
+	c.call("Account::signout", &messages.EmptyRequest{}, &messages.EmptyResponse{})
+	// signing out revokes every client token
+	c = newClient(t, server)
+	header = c.send("Account::signin", &messages.SigninAccountRequest{Name: "account", Email: "user@example.com", Passphrase: current}, &messages.UserIdResponse{})
+	if header.Code == int32(codes.ErrorOK) {
+		t.Error("Expected the old passphrase to stop working")
+	}
+	c.call("Account::signin", &messages.SigninAccountRequest{Name: "account", Email: "user@example.com", Passphrase: next}, &messages.UserIdResponse{})
+	c.call("Account::lock", &messages.EmptyRequest{}, &messages.EmptyResponse{})
+	c.call("Account::unlock", &messages.UnlockAccountRequest{Passphrase: next}, &messages.EmptyResponse{})
+}
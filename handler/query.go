@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/note"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/query"
+	"notekeeper-electron-backend/rpc"
+	"notekeeper-electron-backend/tag"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// queryFromMessage converts the list options of a request into a query
+// Missing options return everything sorted by title. An invalid request error
+// naming the option is returned if any of the enum options are invalid.
+func queryFromMessage(call *rpc.Call, options *messages.ListOptions) (*query.Query, error) {
+	q := &query.Query{}
+	if options == nil {
+		return q, nil
+	}
+
+	switch options.Sort {
+	case "", "title":
+		q.Sort = query.SortTitle
+	case "created":
+		q.Sort = query.SortCreated
+	case "updated":
+		q.Sort = query.SortUpdated
+	default:
+		return nil, invalidField(call, "sort", options.Sort)
+	}
+	if options.Descending {
+		q.Direction = query.Descending
+	}
+	if options.Limit < 0 {
+		call.Context.Logger.Warn("Invalid page limit - ", options.Limit)
+		return nil, rpc.NewInvalid(codes.FieldError{Field: "limit", Reason: codes.FieldInvalid, Message: "negative page limit"})
+	}
+	q.Limit = int(options.Limit)
+	q.Cursor = options.Cursor
+
+	switch options.Locked {
+	case "":
+		q.Locked = query.LockedAny
+	case "locked":
+		q.Locked = query.LockedOnly
+	case "unlocked":
+		q.Locked = query.UnlockedOnly
+	default:
+		return nil, invalidField(call, "locked", options.Locked)
+	}
+
+	if options.Type != "" {
+		t, ok := note.TypeFromString(options.Type)
+		if !ok {
+			return nil, invalidField(call, "type", options.Type)
+		}
+		noteType := int(t)
+		q.Type = &noteType
+	}
+
+	if options.TagId != "" {
+		tagID, err := uuid.FromString(options.TagId)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid tag id - ", err)
+			return nil, rpc.NewError(codes.ErrorDecode)
+		}
+		q.TagID = tagID
+	}
+
+	var err error
+	if options.From != "" {
+		q.From, err = time.Parse(time.RFC3339, options.From)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid from time - ", err)
+			return nil, rpc.NewError(codes.ErrorDecode)
+		}
+	}
+	if options.Until != "" {
+		q.Until, err = time.Parse(time.RFC3339, options.Until)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid until time - ", err)
+			return nil, rpc.NewError(codes.ErrorDecode)
+		}
+	}
+
+	return q, nil
+}
+
+// taggedObjects returns the ids of the objects assigned a tag or any of its descendants
+func taggedObjects(server *rpc.Server, scope string, ownerID uuid.UUID, tagID uuid.UUID) (map[uuid.UUID]bool, error) {
+	tagScope := tag.ScopeUser
+	if scope == "account" {
+		tagScope = tag.ScopeAccount
+	}
+	t, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
+	if err != nil {
+		return nil, err
+	}
+	t.ID = tagID
+	t.OwnerID = ownerID
+
+	assignments, err := t.Assignments(true, server.Account.ActiveUser.PassphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	tagged := make(map[uuid.UUID]bool, len(assignments))
+	for _, a := range assignments {
+		tagged[a.ObjectID] = true
+	}
+	return tagged, nil
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"os"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/db"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Log line limits for Service::logs
+const (
+	DefaultLogLines = 200
+	MaxLogLines     = 5000
+)
+
+// Shutdown is the RPC method to shut the backend down
+// The response is sent before the backend stops accepting requests, signs out
+// & closes the dbs.
+func Shutdown(server *rpc.Server, call *rpc.Call) error {
+	call.Context.Logger.Info("Shutdown requested")
+	server.RequestShutdown()
+	return nil
+}
+
+// GetStatus is the RPC method to report the health of the backend
+// The request being answered isn't counted until it has finished, so it
+// doesn't appear in the method stats until the next request.
+func GetStatus(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.ServiceStatusResponse)
+	now := time.Now()
+
+	response.Version = server.Version
+	response.BuildType = server.BuildType
+	response.ProtocolVersion = rpc.ProtocolVersion
+	response.SchemaVersion = db.SchemaVersion
+	response.Started = rpc.TimeToMessage(server.Started)
+	response.UptimeSeconds = int64(server.Uptime(now) / time.Second)
+	response.UserState = server.UserState.String()
+	response.Clients = int32(len(server.ListClients()))
+
+	if server.DBRegistry != nil {
+		for _, info := range server.DBRegistry.OpenHandles() {
+			handle := &messages.DbHandle{Type: db.TypeToStr(info.Type)}
+			if info.ID != uuid.Nil {
+				handle.Id = info.ID.String()
+			}
+			if stat, err := os.Stat(info.Filename); err == nil {
+				handle.Size = stat.Size()
+			}
+			response.Dbs = append(response.Dbs, handle)
+		}
+	}
+
+	for _, stats := range server.MethodStats() {
+		response.Methods = append(response.Methods, &messages.MethodStatus{
+			Method:    stats.Method,
+			Count:     stats.Count,
+			Errors:    stats.Errors,
+			AverageMs: milliseconds(stats.Average()),
+			MaxMs:     milliseconds(stats.Max),
+		})
+	}
+	for _, scopeError := range server.LastErrors() {
+		response.Errors = append(response.Errors, &messages.ScopeError{
+			Scope:   int32(scopeError.Scope),
+			Code:    int32(scopeError.Code),
+			Message: codes.New(scopeError.Scope, scopeError.Code).Error(),
+			Method:  scopeError.Method,
+			Time:    rpc.TimeToMessage(scopeError.Time),
+		})
+	}
+	return nil
+}
+
+// GetLogs is the RPC method to fetch the most recent lines of the log for bug reports
+// The lines have already been redacted when they were written.
+func GetLogs(server *rpc.Server, call *rpc.Call) error {
+	request := call.Request.(*messages.ServiceLogsRequest)
+	response := call.Response.(*messages.ServiceLogsResponse)
+
+	lines := int(request.Lines)
+	if lines == 0 {
+		lines = DefaultLogLines
+	}
+	if lines < 0 || lines > MaxLogLines {
+		call.Context.Logger.Warn("Invalid number of log lines [", request.Lines, "]")
+		return rpc.NewError(codes.ErrorDecode)
+	}
+	if server.Log == nil {
+		return nil
+	}
+
+	var err error
+	response.Lines, err = server.Log.Tail(lines)
+	if err != nil {
+		call.Context.Logger.Warn("Error reading log file - ", err)
+		return codes.New(codes.ScopeGeneral, codes.ErrorLoad)
+	}
+	return nil
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestShutdown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-shutdown")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	shutdown := make(chan bool)
+	server := rpc.NewServer(logger, nil, shutdown)
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+
+	c := newClient(t, server)
+	c.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+	c.call("Account::create", &messages.CreateAccountRequest{Name: "account", Email: "user@example.com", Passphrase: "sturdy lantern 42 orbit"}, &messages.UserIdResponse{})
+	key := server.Account.ActiveUser.PassphraseKey
+
+	c.call("Service::shutdown", &messages.EmptyRequest{}, &messages.EmptyResponse{})
+	select {
+	case ok := <-shutdown:
+		if !ok {
+			t.Error("Expected a clean shutdown to be requested")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Service::shutdown to ask the backend to shut down")
+	}
+
+	server.Stop()
+	if server.Account != nil || server.IsSignedIn() {
+		t.Error("Expected the account to be signed out")
+	}
+	if !bytes.Equal(key, make([]byte, len(key))) {
+		t.Error("Expected the passphrase key to be zeroed")
+	}
+	if server.DBRegistry != nil {
+		t.Error("Expected the dbs to be closed")
+	}
+}
+
+func TestStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-status")
+	if err != nil {
+		t.Fatal("Error creating data directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	server := rpc.NewServer(logger, nil, nil)
+	server.Version = "1.2.3"
+	server.Register(Methods()...)
+	server.BootstrapSecret = make([]byte, rpc.BootstrapSecretSize)
+	rand.Read(server.BootstrapSecret)
+	defer server.Stop()
+
+	c := newClient(t, server)
+	status := &messages.ServiceStatusResponse{}
+	c.call("Service::status", &messages.EmptyRequest{}, status)
+	if status.Version != "1.2.3" || status.UserState != "signedOut" || len(status.Dbs) != 0 || status.Clients != 1 {
+		t.Error("Expected a signed out backend with no dbs & 1 client but got ", status)
+	}
+
+	c.call("MasterDb::open", &messages.OpenMasterDbRequest{Path: dir}, &messages.EmptyResponse{})
+	c.call("Account::create", &messages.CreateAccountRequest{Name: "account", Email: "user@example.com", Passphrase: "sturdy lantern 42 orbit"}, &messages.UserIdResponse{})
+
+	status = &messages.ServiceStatusResponse{}
+	c.call("Service::status", &messages.EmptyRequest{}, status)
+	if status.UserState != "signedIn" {
+		t.Error("Expected the user to be signed in but got ", status.UserState)
+	}
+	types := make(map[string]int)
+	for _, handle := range status.Dbs {
+		types[handle.Type]++
+		if handle.Size == 0 {
+			t.Error("Expected the size of the ", handle.Type, " db")
+		}
+	}
+	if types["master"] != 1 || types["account"] != 1 || types["user"] != 1 {
+		t.Error("Expected the master, account & user dbs to be open but got ", types)
+	}
+	methods := make(map[string]int64)
+	for _, method := range status.Methods {
+		methods[method.Method] = method.Count
+	}
+	if methods["Service::status"] != 1 || methods["Account::create"] != 1 || methods["KeyExchange"] != 1 {
+		t.Error("Expected each earlier request to be counted but got ", methods)
+	}
+}
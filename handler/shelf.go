@@ -1,69 +1,89 @@
 package handler
 
 import (
+	"time"
+
+	"notekeeper-electron-backend/api"
 	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
 	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
 	"notekeeper-electron-backend/shelf"
 
-	"github.com/golang/protobuf/proto"
 	uuid "github.com/satori/go.uuid"
 )
 
-func strToShelfScope(server *rpc.Server, s string, id uuid.UUID) (shelf.Scope, bool) {
+func strToShelfScope(server *rpc.Server, call *rpc.Call, s string, id uuid.UUID) (shelf.Scope, error) {
 	var scope shelf.Scope
 	if s == "account" {
-		if server.Account.ID != id {
-			return scope, false
-		}
 		scope = shelf.ScopeAccount
-	} else if s == "user" {
-		if server.Account.ActiveUser.ID != id {
-			return scope, false
+		if server.Account.ID == id {
+			return scope, nil
 		}
+	} else if s == "user" {
 		scope = shelf.ScopeUser
+		if server.Account.ActiveUser.ID == id {
+			return scope, nil
+		}
 	} else {
-		return scope, false
+		return scope, invalidField(call, "scope", s)
 	}
-	return scope, true
+	call.Context.Logger.Warn("Unauthorized request for owner [", id, "] in scope [", s, "]")
+	return scope, rpc.NewError(codes.ErrorUnauthorized)
 }
 
-func getShelves(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.GetShelvesResponse{
-		Header: rpc.NewResponseHeader(),
+// storeKey opens the key that the shelf & collection dbs of a scope are sealed with
+// Notebooks & notes live in those dbs, which are sealed with the account key or
+// the user key rather than the passphrase key.
+func storeKey(server *rpc.Server, scope string) ([]byte, error) {
+	shelfScope := shelf.ScopeUser
+	if scope == "account" {
+		shelfScope = shelf.ScopeAccount
 	}
+	api := api.New(server.DBRegistry, server.Logger)
+	return api.ShelfKey(server.Account, shelfScope)
+}
 
-	request := messages.GetShelvesRequest{}
-	err := proto.Unmarshal(message, &request)
+func getShelves(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.GetShelvesResponse)
+	request := call.Request.(*messages.GetShelvesRequest)
+
+	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling get shelves request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	shelfScope, err := strToShelfScope(server, call, scope, id)
+	if err != nil {
+		return err
 	}
 
-	id, err := uuid.FromString(request.Id)
+	q, err := queryFromMessage(call, request.Options)
 	if err != nil {
-		server.Logger.Warn("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
+	}
+	if q.TagID != uuid.Nil {
+		q.Tagged, err = taggedObjects(server, scope, id, q.TagID)
+		if err != nil {
+			return err
+		}
 	}
 
-	shelfScope, ok := strToShelfScope(server, scope, id)
-	if !ok {
-		return response, nil
+	key, err := storeKey(server, scope)
+	if err != nil {
+		return err
 	}
+	defer crypto.Zero(key)
 
 	index := shelf.NewIndex(shelfScope, id, server.DBRegistry, server.Logger)
-	err = index.LoadAll(server.Account.ActiveUser.PassphraseKey)
+	// loads the shelves along with up to date note stats
+	nextCursor, err := index.LoadPage(q, server.Account.ActiveUser.PassphraseKey, key)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
+	response.NextCursor = nextCursor
 
 	for _, s := range index.Shelves {
 		m := &messages.Shelf{
@@ -74,171 +94,155 @@ func getShelves(server *rpc.Server, message []byte, scope string, context *rpc.R
 			Created: rpc.TimeToMessage(s.Created),
 			Updated: rpc.TimeToMessage(s.Updated),
 		}
+		m.NoteCount, m.ContentBytes, m.LastModified = statsToMessage(s.Stats)
 		response.Shelves = append(response.Shelves, m)
 	}
 
-	return response, nil
+	return nil
 }
 
-func createShelf(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.IdResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
-	request := messages.CreateShelfRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling create shelf request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
-	}
+func createShelf(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.IdResponse)
+	request := call.Request.(*messages.CreateShelfRequest)
 
 	ownerID, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	shelfScope, ok := strToShelfScope(server, scope, ownerID)
-	if !ok {
-		return response, nil
+	shelfScope, err := strToShelfScope(server, call, scope, ownerID)
+	if err != nil {
+		return err
 	}
 
 	t := rpc.MessageToTitle(request.Name)
 	s, err := shelf.New(t, shelfScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating shelf - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating shelf - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	s.OwnerID = ownerID
 
 	index := shelf.NewIndex(shelfScope, ownerID, server.DBRegistry, server.Logger)
-	err = index.Save(s, server.Account.ActiveUser.PassphraseKey)
+	indexKey, err := index.UnsealKey(server.Account.ActiveUser.PassphraseKey)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-	} else {
-		response.Id = s.ID.String()
+		return err
+	}
+	err = index.Save(s, indexKey)
+	if err != nil {
+		return err
 	}
+	response.Id = s.ID.String()
 
-	return response, nil
+	return nil
 }
 
 // save an existing shelf
-func saveShelf(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
-	}
+func saveShelf(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.SaveShelfRequest)
 
-	request := messages.SaveShelfRequest{}
-	err := proto.Unmarshal(message, &request)
+	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling save shelf request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+		call.Context.Logger.Debug("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	id, err := uuid.FromString(request.Id)
+	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Debug("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	ownerID, err := uuid.FromString(request.OwnerId)
+	shelfScope, err := strToShelfScope(server, call, scope, ownerID)
 	if err != nil {
-		server.Logger.Warn("Invalid owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
 	}
 
-	shelfScope, ok := strToShelfScope(server, scope, ownerID)
-	if !ok {
-		return response, nil
+	index := shelf.NewIndex(shelfScope, ownerID, server.DBRegistry, server.Logger)
+	indexKey, err := index.UnsealKey(server.Account.ActiveUser.PassphraseKey)
+	if err != nil {
+		return err
 	}
 
-	t := rpc.MessageToTitle(request.Name)
-	s, err := shelf.New(t, shelfScope, server.DBRegistry, server.Logger)
+	// load the existing record so that fields not carried by the request (default, stats, etc.) are kept
+	previous, err := index.Load(id, indexKey)
 	if err != nil {
-		server.Logger.Warn("Error creating shelf - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		return err
 	}
-	s.ID = id
-	s.OwnerID = ownerID
+	s := *previous
+	s.Title = rpc.MessageToTitle(request.Name)
 	s.Locked = request.Locked
+	s.Updated = time.Now()
 
-	index := shelf.NewIndex(shelfScope, ownerID, server.DBRegistry, server.Logger)
-	err = index.Save(s, server.Account.ActiveUser.PassphraseKey)
+	err = index.Save(&s, indexKey)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
+		return err
 	}
-
-	return response, nil
-}
-
-func deleteShelf(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+	if s.Locked == previous.Locked {
+		return nil
 	}
 
-	request := messages.SaveShelfRequest{}
-	err := proto.Unmarshal(message, &request)
+	// pass the lock state on to the notebooks & notes stored in the shelf
+	// The shelf db is separate from the index, so the index record is put back
+	// if the lock can't be passed on, rather than leaving the two out of step.
+	key, err := storeKey(server, scope)
+	if err == nil {
+		defer crypto.Zero(key)
+		err = index.SaveLock(&s, key)
+	}
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling delete shelf request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		rollbackErr := index.Save(previous, indexKey)
+		if rollbackErr != nil {
+			call.Context.Logger.Warn("Error restoring shelf [", id, "] after a failed lock - ", rollbackErr)
+		} else if key != nil {
+			// the lock may have been passed on to part of the shelf before failing
+			rollbackErr = index.SaveLock(previous, key)
+			if rollbackErr != nil {
+				call.Context.Logger.Warn("Error restoring the lock of shelf [", id, "] - ", rollbackErr)
+			}
+		}
+		return err
 	}
+	return nil
+}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
-	}
+func deleteShelf(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.DeleteShelfRequest)
 
 	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
 	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Invalid owner id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	shelfScope, ok := strToShelfScope(server, scope, ownerID)
-	if !ok {
-		return response, nil
+	shelfScope, err := strToShelfScope(server, call, scope, ownerID)
+	if err != nil {
+		return err
 	}
 
 	s, err := shelf.New(nil, shelfScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating shelf - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating shelf - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	s.ID = id
 	s.OwnerID = ownerID
 
 	index := shelf.NewIndex(shelfScope, ownerID, server.DBRegistry, server.Logger)
-	err = index.Delete(s)
+	indexKey, err := index.UnsealKey(server.Account.ActiveUser.PassphraseKey)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
+		return err
 	}
-
-	return response, nil
+	err = index.Delete(s, indexKey)
+	return err
 }
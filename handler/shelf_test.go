@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/db"
+	messages "notekeeper-electron-backend/proto"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestShelfStats(t *testing.T) {
+	server, c, user, cleanup := newAccountServer(t)
+	defer cleanup()
+	nb, key := defaultNotebook(t, server)
+	content := "twenty-six bytes of text.."
+	storeNote(t, server, nb, key, content, false)
+
+	// the shelves of a new account are listed with the stats of their notes
+	shelves := &messages.GetShelvesResponse{}
+	c.call("User::shelves", &messages.GetShelvesRequest{Id: user.User.UserId}, shelves)
+	found := false
+	for _, m := range shelves.Shelves {
+		if m.Id != nb.ContainerID.String() {
+			continue
+		}
+		found = true
+		if m.NoteCount != 1 || m.ContentBytes != int64(len(content)) {
+			t.Error("Expected the default shelf to hold 1 note of [", len(content), "] bytes but got [", m.NoteCount, "] notes of [", m.ContentBytes, "] bytes")
+		}
+	}
+	if !found {
+		t.Error("Expected to list the default user shelf")
+	}
+	c.call("Account::shelves", &messages.GetShelvesRequest{Id: user.User.AccountId}, &messages.GetShelvesResponse{})
+
+	stats := &messages.AccountStatsResponse{}
+	c.call("Stats::account", &messages.AccountStatsRequest{}, stats)
+	if stats.NoteCount != 1 || stats.ContentBytes != int64(len(content)) {
+		t.Error("Expected account stats of 1 note of [", len(content), "] bytes but got [", stats.NoteCount, "] notes of [", stats.ContentBytes, "] bytes")
+	}
+	found = false
+	for _, m := range stats.Containers {
+		if m.Id == nb.ContainerID.String() && m.Type == "shelf" && m.Scope == "user" && m.NoteCount == 1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected the account stats to list the default user shelf")
+	}
+}
+
+func TestSaveShelf(t *testing.T) {
+	server, c, user, cleanup := newAccountServer(t)
+	defer cleanup()
+	nb, key := defaultNotebook(t, server)
+	n := storeNote(t, server, nb, key, "kept in the default shelf", false)
+	load := func() *messages.Shelf {
+		shelves := &messages.GetShelvesResponse{}
+		c.call("User::shelves", &messages.GetShelvesRequest{Id: user.User.UserId}, shelves)
+		for _, m := range shelves.Shelves {
+			if m.Id == nb.ContainerID.String() {
+				return m
+			}
+		}
+		t.Fatal("Expected to list the default user shelf")
+		return nil
+	}
+	save := func(name string, locked bool) *messages.SaveShelfRequest {
+		return &messages.SaveShelfRequest{
+			Id:      nb.ContainerID.String(),
+			OwnerId: user.User.UserId,
+			Name:    &messages.Title{Text: name},
+			Locked:  locked,
+		}
+	}
+
+	// a save only changes the fields carried by the request
+	c.call("User::Shelf::save", save("Renamed", true), &messages.EmptyResponse{})
+	m := load()
+	if m.Name.Text != "Renamed" || !m.Locked || !m.Default || m.NoteCount != 1 {
+		t.Error("Expected the shelf to be renamed & locked but otherwise unchanged, but got ", m)
+	}
+
+	// the lock is passed on to the notes in the shelf
+	header := c.send("User::Note::save", renameNote(n, "Renamed"), &messages.EmptyResponse{})
+	if codes.Code(header.Code) != codes.ErrorLocked {
+		t.Error("Expected saving a note in a locked shelf to fail with ErrorLocked but got ", header)
+	}
+	header = c.send("User::Shelf::save", save("Renamed again", true), &messages.EmptyResponse{})
+	if codes.Code(header.Code) != codes.ErrorLocked {
+		t.Error("Expected saving a locked shelf to fail with ErrorLocked but got ", header)
+	}
+
+	c.call("User::Shelf::save", save("Renamed", false), &messages.EmptyResponse{})
+	c.call("User::Note::save", renameNote(n, "Renamed"), &messages.EmptyResponse{})
+
+	// the index record is put back when the lock can't be passed on to the shelf db
+	handle, err := server.DBRegistry.GetHandle(db.Key{ID: nb.ContainerID, Type: db.TypeShelf})
+	if err != nil {
+		t.Fatal("Expected the default shelf db to be open - ", err)
+	}
+	sealedKey := handle.EncryptedKey
+	handle.EncryptedKey = []byte("not a sealed key")
+	header = c.send("User::Shelf::save", save("Renamed", true), &messages.EmptyResponse{})
+	handle.EncryptedKey = sealedKey
+	if codes.Code(header.Code) == codes.ErrorOK {
+		t.Error("Expected locking a shelf to fail when its db can't be opened")
+	}
+	if m := load(); m.Locked {
+		t.Error("Expected the failed lock to leave the shelf unlocked")
+	}
+	c.call("User::Note::save", renameNote(n, "Renamed again"), &messages.EmptyResponse{})
+
+	// saving a shelf that doesn't exist doesn't add it to the index
+	missing := save("Missing", false)
+	missing.Id = uuid.NewV4().String()
+	header = c.send("User::Shelf::save", missing, &messages.EmptyResponse{})
+	if codes.Code(header.Code) == codes.ErrorOK {
+		t.Error("Expected saving a missing shelf to fail")
+	}
+	shelves := &messages.GetShelvesResponse{}
+	c.call("User::shelves", &messages.GetShelvesRequest{Id: user.User.UserId}, shelves)
+	for _, m := range shelves.Shelves {
+		if m.Id == missing.Id {
+			t.Error("Expected a missing shelf not to be added by a save")
+		}
+	}
+}
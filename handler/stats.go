@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"notekeeper-electron-backend/crypto"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/rpc"
+	"notekeeper-electron-backend/shelf"
+	"notekeeper-electron-backend/stats"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// statsToMessage splits stats into the fields used by the list responses
+// Stats that haven't been gathered yet are reported as empty.
+func statsToMessage(s *stats.Stats) (int32, int64, string) {
+	if s == nil {
+		return 0, 0, ""
+	}
+	lastModified := ""
+	if !s.LastModified.IsZero() {
+		lastModified = rpc.TimeToMessage(s.LastModified)
+	}
+	return int32(s.NoteCount), s.ContentBytes, lastModified
+}
+
+// GetAccountStats is the RPC method to summarise the notes across all account & user shelves
+func GetAccountStats(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.AccountStatsResponse)
+
+	owners := []struct {
+		scope shelf.Scope
+		name  string
+		id    uuid.UUID
+	}{
+		{shelf.ScopeAccount, "account", server.Account.ID},
+		{shelf.ScopeUser, "user", server.Account.ActiveUser.ID},
+	}
+
+	total := &stats.Stats{}
+	for _, owner := range owners {
+		key, err := storeKey(server, owner.name)
+		if err != nil {
+			return err
+		}
+		index := shelf.NewIndex(owner.scope, owner.id, server.DBRegistry, server.Logger)
+		err = index.RefreshStats(server.Account.ActiveUser.PassphraseKey, key)
+		crypto.Zero(key)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range index.Shelves {
+			m := &messages.ContainerStats{
+				Id:    s.ID.String(),
+				Name:  rpc.TitleToMessage(s.Title),
+				Scope: owner.name,
+				Type:  "shelf",
+			}
+			m.NoteCount, m.ContentBytes, m.LastModified = statsToMessage(s.Stats)
+			response.Containers = append(response.Containers, m)
+			if s.Stats != nil {
+				// shelf stats already include the collections stored in the shelf
+				total.Add(s.Stats)
+			}
+
+			for _, c := range s.Collections {
+				m := &messages.ContainerStats{
+					Id:      c.ID.String(),
+					Name:    rpc.TitleToMessage(c.Title),
+					Scope:   owner.name,
+					Type:    "collection",
+					ShelfId: s.ID.String(),
+				}
+				m.NoteCount, m.ContentBytes, m.LastModified = statsToMessage(c.Stats)
+				response.Containers = append(response.Containers, m)
+			}
+		}
+	}
+
+	response.NoteCount, response.ContentBytes, response.LastModified = statsToMessage(total)
+
+	return nil
+}
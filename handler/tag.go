@@ -1,224 +1,421 @@
 package handler
 
 import (
+	"time"
+
 	"notekeeper-electron-backend/codes"
 	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
 	"notekeeper-electron-backend/tag"
 
-	"github.com/golang/protobuf/proto"
 	uuid "github.com/satori/go.uuid"
 )
 
-func strToTagScope(server *rpc.Server, s string, id uuid.UUID) (tag.Scope, bool) {
+func strToTagScope(server *rpc.Server, call *rpc.Call, s string, id uuid.UUID) (tag.Scope, error) {
 	var scope tag.Scope
 	if s == "account" {
-		if server.Account.ID != id {
-			return scope, false
-		}
 		scope = tag.ScopeAccount
-	} else if s == "user" {
-		if server.Account.ActiveUser.ID != id {
-			return scope, false
+		if server.Account.ID == id {
+			return scope, nil
 		}
+	} else if s == "user" {
 		scope = tag.ScopeUser
+		if server.Account.ActiveUser.ID == id {
+			return scope, nil
+		}
 	} else {
-		return scope, false
+		return scope, invalidField(call, "scope", s)
 	}
-	return scope, true
+	call.Context.Logger.Warn("Unauthorized request for owner [", id, "] in scope [", s, "]")
+	return scope, rpc.NewError(codes.ErrorUnauthorized)
 }
 
-func getTags(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.GetTagsResponse{
-		Header: rpc.NewResponseHeader(),
-	}
+func getTags(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.GetTagsResponse)
+	request := call.Request.(*messages.GetTagsRequest)
 
-	request := messages.GetTagsRequest{}
-	err := proto.Unmarshal(message, &request)
+	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling get tags request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
-
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	id, err := uuid.FromString(request.Id)
+	tagScope, err := strToTagScope(server, call, scope, id)
 	if err != nil {
-		server.Logger.Warn("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
 	}
 
-	tagScope, ok := strToTagScope(server, scope, id)
-	if !ok {
-		return response, nil
+	var parentID uuid.UUID
+	if request.ParentId != "" {
+		parentID, err = uuid.FromString(request.ParentId)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid parent id - ", err)
+			return rpc.NewError(codes.ErrorDecode)
+		}
 	}
 
 	// create a new tag instance to act as a proxy
 	t, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating tag - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
+	t.OwnerID = id
 	tags, err := t.LoadAll(server.Account.ActiveUser.PassphraseKey)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
+	}
+
+	// the whole set is still needed to build paths, so filter a copy
+	results := tags
+	if parentID != uuid.Nil {
+		results = nil
+		for _, t := range tags {
+			if t.ID == parentID {
+				results = append(results, t)
+			}
+		}
+		if len(results) == 0 {
+			return codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+		}
+		results = append(results, tag.Descendants(tags, parentID)...)
 	}
 
-	for _, t := range tags {
+	q, err := queryFromMessage(call, request.Options)
+	if err != nil {
+		return err
+	}
+	results, nextCursor, err := t.Page(results, q)
+	if err != nil {
+		return err
+	}
+	response.NextCursor = nextCursor
+
+	for _, t := range results {
 		m := &messages.Tag{
 			Id:      t.ID.String(),
 			Name:    rpc.TitleToMessage(t.Title),
+			Scope:   scope,
 			Created: rpc.TimeToMessage(t.Created),
 			Updated: rpc.TimeToMessage(t.Updated),
+			Path:    tag.Path(tags, t),
+		}
+		if t.ParentID != uuid.Nil {
+			m.ParentId = t.ParentID.String()
 		}
 		response.Tags = append(response.Tags, m)
 	}
 
-	return response, nil
+	return nil
 }
 
 // CreateTag creates a new tag
-func createTag(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.IdResponse{
-		Header: rpc.NewResponseHeader(),
+func createTag(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	response := call.Response.(*messages.IdResponse)
+	request := call.Request.(*messages.CreateTagRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.CreateTagRequest{}
-	err := proto.Unmarshal(message, &request)
+	tagScope, err := strToTagScope(server, call, scope, id)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling create tag request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		return err
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	var parentID uuid.UUID
+	if request.ParentId != "" {
+		parentID, err = uuid.FromString(request.ParentId)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid parent id - ", err)
+			return rpc.NewError(codes.ErrorDecode)
+		}
+
+		// make sure the parent actually exists before we hang anything off of it
+		parent, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
+		if err != nil {
+			call.Context.Logger.Warn("Error creating tag - ", err)
+			return rpc.NewError(codes.ErrorCreate)
+		}
+		parent.ID = parentID
+		parent.OwnerID = id
+		err = parent.Load(server.Account.ActiveUser.PassphraseKey)
+		if err != nil {
+			return err
+		}
 	}
 
+	t := rpc.MessageToTitle(request.Name)
+	newTag, err := tag.New(t, tagScope, server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
+	}
+	newTag.OwnerID = id
+	newTag.ParentID = parentID
+	err = newTag.Save(server.Account.ActiveUser.PassphraseKey)
+	if err != nil {
+		return err
+	}
+	response.Id = newTag.ID.String()
+
+	return nil
+}
+
+func saveTag(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.SaveTagRequest)
+
 	id, err := uuid.FromString(request.Id)
 	if err != nil {
-		server.Logger.Warn("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	tagScope, ok := strToTagScope(server, scope, id)
-	if !ok {
-		return response, nil
+	ownerID, err := uuid.FromString(request.OwnerId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	t := rpc.MessageToTitle(request.Name)
-	newTag, err := tag.New(t, tagScope, server.DBRegistry, server.Logger)
+	tagScope, err := strToTagScope(server, call, scope, ownerID)
 	if err != nil {
-		server.Logger.Warn("Error creating tag - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		return err
 	}
-	err = newTag.Save(server.Account.ActiveUser.PassphraseKey)
+
+	// load the existing tag so that renaming keeps its place in the hierarchy
+	existingTag, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-	} else {
-		response.Id = newTag.ID.String()
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
+	}
+	existingTag.ID = id
+	existingTag.OwnerID = ownerID
+	err = existingTag.Load(server.Account.ActiveUser.PassphraseKey)
+	if err != nil {
+		return err
 	}
 
-	return response, nil
+	existingTag.Title = rpc.MessageToTitle(request.Name)
+	existingTag.Updated = time.Now()
+	err = existingTag.Save(server.Account.ActiveUser.PassphraseKey)
+	return err
 }
 
-func saveTag(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+func deleteTag(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.DeleteTagRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.SaveTagRequest{}
-	err := proto.Unmarshal(message, &request)
+	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling save tag request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	tagScope, err := strToTagScope(server, call, scope, ownerID)
+	if err != nil {
+		return err
 	}
 
-	id, err := uuid.FromString(request.OwnerId)
+	t, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
+	t.ID = id
+	t.OwnerID = ownerID
+	err = t.Delete(server.Account.ActiveUser.PassphraseKey)
+	return err
+}
 
-	tagScope, ok := strToTagScope(server, scope, id)
-	if !ok {
-		return response, nil
+func moveTag(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.MoveTagRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	t := rpc.MessageToTitle(request.Name)
-	newTag, err := tag.New(t, tagScope, server.DBRegistry, server.Logger)
+	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Error creating tag - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
-	newTag.ID = id
-	err = newTag.Save(server.Account.ActiveUser.PassphraseKey)
+
+	// an empty parent id moves the tag to the top level
+	var parentID uuid.UUID
+	if request.ParentId != "" {
+		parentID, err = uuid.FromString(request.ParentId)
+		if err != nil {
+			call.Context.Logger.Warn("Invalid parent id - ", err)
+			return rpc.NewError(codes.ErrorDecode)
+		}
+	}
+
+	tagScope, err := strToTagScope(server, call, scope, ownerID)
+	if err != nil {
+		return err
+	}
+
+	t, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
+	}
+	t.ID = id
+	t.OwnerID = ownerID
+	err = t.Move(parentID, server.Account.ActiveUser.PassphraseKey)
+	return err
+}
+
+func mergeTag(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.MergeTagRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
+
+	ownerID, err := uuid.FromString(request.OwnerId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
+
+	targetID, err := uuid.FromString(request.TargetId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid target id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
+
+	tagScope, err := strToTagScope(server, call, scope, ownerID)
+	if err != nil {
+		return err
+	}
+
+	source, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
+	source.ID = id
+	source.OwnerID = ownerID
 
-	return response, nil
+	target, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
+	}
+	target.ID = targetID
+	target.OwnerID = ownerID
+
+	err = source.Merge(target, server.Account.ActiveUser.PassphraseKey)
+	return err
 }
 
-func deleteTag(server *rpc.Server, message []byte, scope string, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
+func assignTag(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.AssignTagRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	request := messages.DeleteTagRequest{}
-	err := proto.Unmarshal(message, &request)
+	ownerID, err := uuid.FromString(request.OwnerId)
 	if err != nil {
-		server.Logger.Warn("Error unmarshaling delete tag request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	if !server.IsSignedIn() {
-		rpc.SetRPCError(response.Header, codes.ErrorUnauthorized)
-		return response, nil
+	objectID, err := uuid.FromString(request.ObjectId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid object id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	id, err := uuid.FromString(request.OwnerId)
+	storeID, err := uuid.FromString(request.StoreId)
 	if err != nil {
-		server.Logger.Warn("Invalid id - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
+		call.Context.Logger.Warn("Invalid store id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	tagScope, ok := strToTagScope(server, scope, id)
+	objectType, ok := tag.ObjectTypeFromString(request.ObjectType)
 	if !ok {
-		return response, nil
+		return invalidField(call, "objectType", request.ObjectType)
+	}
+
+	tagScope, err := strToTagScope(server, call, scope, ownerID)
+	if err != nil {
+		return err
 	}
 
 	t, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
 	if err != nil {
-		server.Logger.Warn("Error creating tag - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorCreate)
-		return response, nil
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
 	}
 	t.ID = id
-	err = t.Delete(server.Account.ActiveUser.PassphraseKey)
+	t.OwnerID = ownerID
+
+	assignment := &tag.Assignment{
+		ObjectID:   objectID,
+		ObjectType: objectType,
+		StoreID:    storeID,
+	}
+	err = t.Assign(assignment, server.Account.ActiveUser.PassphraseKey)
+	return err
+}
+
+func unassignTag(server *rpc.Server, call *rpc.Call) error {
+	scope := call.Scope
+	request := call.Request.(*messages.UnassignTagRequest)
+
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
+
+	ownerID, err := uuid.FromString(request.OwnerId)
+	if err != nil {
+		call.Context.Logger.Warn("Invalid owner id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
+	}
+
+	objectID, err := uuid.FromString(request.ObjectId)
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
+		call.Context.Logger.Warn("Invalid object id - ", err)
+		return rpc.NewError(codes.ErrorDecode)
 	}
 
-	return response, nil
+	tagScope, err := strToTagScope(server, call, scope, ownerID)
+	if err != nil {
+		return err
+	}
+
+	t, err := tag.New(nil, tagScope, server.DBRegistry, server.Logger)
+	if err != nil {
+		call.Context.Logger.Warn("Error creating tag - ", err)
+		return rpc.NewError(codes.ErrorCreate)
+	}
+	t.ID = id
+	t.OwnerID = ownerID
+	err = t.Unassign(objectID, server.Account.ActiveUser.PassphraseKey)
+	return err
 }
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"testing"
+
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/shelf"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestTagAssignment(t *testing.T) {
+	server, c, user, cleanup := newAccountServer(t)
+	defer cleanup()
+	userID := user.User.UserId
+
+	project := &messages.IdResponse{}
+	c.call("User::Tag::create", &messages.CreateTagRequest{Name: &messages.Title{Text: "project"}, Id: userID}, project)
+	alpha := &messages.IdResponse{}
+	c.call("User::Tag::create", &messages.CreateTagRequest{Name: &messages.Title{Text: "alpha"}, Id: userID, ParentId: project.Id}, alpha)
+
+	index := shelf.NewIndex(shelf.ScopeUser, uuid.FromStringOrNil(userID), server.DBRegistry, server.Logger)
+	err := index.LoadAll(server.Account.ActiveUser.PassphraseKey)
+	if err != nil || len(index.Shelves) == 0 {
+		t.Fatal("Expected to load the default user shelf - ", err)
+	}
+	shelfID := index.Shelves[0].ID.String()
+	notebookID := uuid.NewV4()
+	assign := &messages.AssignTagRequest{
+		Id:         alpha.Id,
+		OwnerId:    userID,
+		ObjectId:   notebookID.String(),
+		ObjectType: "notebook",
+		Store:      "shelf",
+		StoreId:    shelfID,
+	}
+	c.call("User::Tag::assign", assign, &messages.EmptyResponse{})
+
+	// the list filters include objects assigned the tag or one of its descendants
+	tagged := func(tagID string) map[uuid.UUID]bool {
+		objects, err := taggedObjects(server, "user", uuid.FromStringOrNil(userID), uuid.FromStringOrNil(tagID))
+		if err != nil {
+			t.Fatal("Expected to load tagged objects - ", err)
+		}
+		return objects
+	}
+	if objects := tagged(project.Id); len(objects) != 1 || !objects[notebookID] {
+		t.Error("Expected the parent tag to include the assigned notebook but got ", objects)
+	}
+
+	c.call("User::Tag::unassign", &messages.UnassignTagRequest{Id: alpha.Id, OwnerId: userID, ObjectId: notebookID.String()}, &messages.EmptyResponse{})
+	if objects := tagged(project.Id); len(objects) != 0 {
+		t.Error("Expected no tagged objects once the tag is unassigned but got ", objects)
+	}
+
+	// deleting a tag removes its assignments & moves its children up
+	c.call("User::Tag::assign", assign, &messages.EmptyResponse{})
+	c.call("User::Tag::assign", &messages.AssignTagRequest{
+		Id:         project.Id,
+		OwnerId:    userID,
+		ObjectId:   shelfID,
+		ObjectType: "shelf",
+		Store:      "shelf",
+		StoreId:    shelfID,
+	}, &messages.EmptyResponse{})
+	c.call("User::Tag::delete", &messages.DeleteTagRequest{Id: project.Id, OwnerId: userID}, &messages.EmptyResponse{})
+	tags := &messages.GetTagsResponse{}
+	c.call("User::tags", &messages.GetTagsRequest{Id: userID}, tags)
+	if len(tags.Tags) != 1 || tags.Tags[0].Id != alpha.Id || tags.Tags[0].ParentId != "" {
+		t.Error("Expected alpha to move to the top level but got ", tags.Tags)
+	}
+	if objects := tagged(project.Id); len(objects) != 0 {
+		t.Error("Expected the deleted tag's assignments to be removed but got ", objects)
+	}
+	if objects := tagged(alpha.Id); len(objects) != 1 || !objects[notebookID] {
+		t.Error("Expected alpha to keep its assignment but got ", objects)
+	}
+}
@@ -1,25 +1,19 @@
 package handler
 
 import (
-	"notekeeper-electron-backend/codes"
 	messages "notekeeper-electron-backend/proto"
 	"notekeeper-electron-backend/rpc"
 	"notekeeper-electron-backend/uistate"
-
-	"github.com/golang/protobuf/proto"
 )
 
 // LoadUIState returns the the UI state as saved by the master DB
-func LoadUIState(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.LoadUIStateResponse{
-		Header: rpc.NewResponseHeader(),
-	}
+func LoadUIState(server *rpc.Server, call *rpc.Call) error {
+	response := call.Response.(*messages.LoadUIStateResponse)
 
 	state := uistate.NewUIState(server.DBRegistry, server.Logger)
 	err := state.Load()
 	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
+		return err
 	}
 
 	response.WindowWidth = state.WindowWidth
@@ -34,24 +28,14 @@ func LoadUIState(server *rpc.Server, message []byte, context *rpc.RequestContext
 	response.DisplayXPosition = state.DisplayXPosition
 	response.DisplayYPosition = state.DisplayYPosition
 
-	return response, nil
+	return nil
 }
 
 // SaveUIState saves the current UI state to the master DB
-func SaveUIState(server *rpc.Server, message []byte, context *rpc.RequestContext) (proto.Message, error) {
-	response := &messages.EmptyResponse{
-		Header: rpc.NewResponseHeader(),
-	}
-
+func SaveUIState(server *rpc.Server, call *rpc.Call) error {
 	state := uistate.NewUIState(server.DBRegistry, server.Logger)
 
-	request := messages.SaveUIStateRequest{}
-	err := proto.Unmarshal(message, &request)
-	if err != nil {
-		server.Logger.Warn("Error unmarshaling save ui state request - ", err)
-		rpc.SetRPCError(response.Header, codes.ErrorDecode)
-		return response, nil
-	}
+	request := call.Request.(*messages.SaveUIStateRequest)
 
 	state.WindowWidth = request.WindowWidth
 	state.WindowHeight = request.WindowHeight
@@ -65,11 +49,6 @@ func SaveUIState(server *rpc.Server, message []byte, context *rpc.RequestContext
 	state.DisplayXPosition = request.DisplayXPosition
 	state.DisplayYPosition = request.DisplayYPosition
 
-	err = state.Save()
-	if err != nil {
-		rpc.SetInternalError(response.Header, err)
-		return response, nil
-	}
-
-	return response, nil
+	err := state.Save()
+	return err
 }
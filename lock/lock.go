@@ -0,0 +1,124 @@
+package lock
+
+import (
+	"encoding/json"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+)
+
+// Bucket is the name of the bucket that lock states are kept in
+// Every shelf & collection db has a locks bucket holding a record for the
+// container itself (keyed by the container id) and one for each notebook
+// stored in it (keyed by the notebook id). Each record points at whatever it is
+// nested within - a parent notebook, or the container for top level notebooks
+// - so the lock state of anything in the db can be found by walking up the
+// chain without leaving the db. The container record of a collection also
+// reflects the lock state of the shelf holding it.
+const Bucket = "locks"
+
+// Lock is the lock state of a notebook or container
+type Lock struct {
+	Locked   bool      `json:"locked"`    // Locked indicates whether the notebook or container is locked
+	ParentID uuid.UUID `json:"parent_id"` // ParentID is the notebook or container that this is nested within (uuid.Nil for a container)
+}
+
+// Load reads the lock record for id from an open transaction
+// A nil result means that there is no record for id.
+func Load(tx *bbolt.Tx, encryptionKey []byte, id uuid.UUID, logger *logrus.Logger) (*Lock, error) {
+	bucket := tx.Bucket([]byte(Bucket))
+	if bucket == nil {
+		return nil, nil
+	}
+	value := bucket.Get(id.Bytes())
+	if value == nil {
+		return nil, nil
+	}
+
+	c := crypto.New(logger)
+	decryptedData, err := c.Open(encryptionKey, value)
+	if err != nil {
+		logger.Warn("Error decrypting lock - ", err)
+		code := codes.New(codes.ScopeLock, codes.ErrorDecrypt)
+		return nil, code
+	}
+	lock := &Lock{}
+	err = json.Unmarshal(decryptedData, lock)
+	if err != nil {
+		logger.Warn("Error decoding lock json - ", err)
+		code := codes.New(codes.ScopeLock, codes.ErrorDecode)
+		return nil, code
+	}
+	return lock, nil
+}
+
+// Save writes the lock record for id in an open transaction
+func Save(tx *bbolt.Tx, encryptionKey []byte, id uuid.UUID, lock *Lock, logger *logrus.Logger) error {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(Bucket))
+	if err != nil {
+		logger.Warn("Error creating lock bucket - ", err)
+		code := codes.New(codes.ScopeLock, codes.ErrorCreateBucket)
+		return code
+	}
+	data, err := json.Marshal(lock)
+	if err != nil {
+		logger.Warn("Error marshaling lock - ", err)
+		code := codes.New(codes.ScopeLock, codes.ErrorMarshal)
+		return code
+	}
+	c := crypto.New(logger)
+	encryptedData, err := c.Seal(encryptionKey, data)
+	if err != nil {
+		logger.Warn("Error encrypting lock - ", err)
+		code := codes.New(codes.ScopeLock, codes.ErrorEncrypt)
+		return code
+	}
+	err = bucket.Put(id.Bytes(), encryptedData)
+	if err != nil {
+		logger.Warn("Error writing lock - ", err)
+		code := codes.New(codes.ScopeLock, codes.ErrorWriteBucket)
+		return code
+	}
+	return nil
+}
+
+// Delete removes the lock record for id in an open transaction
+func Delete(tx *bbolt.Tx, id uuid.UUID, logger *logrus.Logger) error {
+	bucket := tx.Bucket([]byte(Bucket))
+	if bucket == nil {
+		return nil
+	}
+	err := bucket.Delete(id.Bytes())
+	if err != nil {
+		logger.Warn("Error deleting lock - ", err)
+		code := codes.New(codes.ScopeLock, codes.ErrorDelete)
+		return code
+	}
+	return nil
+}
+
+// Locked tests whether id or anything it is nested within is locked
+// Missing records end the walk, so ids that have never been locked (including
+// uuid.Nil) are treated as unlocked.
+func Locked(tx *bbolt.Tx, encryptionKey []byte, id uuid.UUID, logger *logrus.Logger) (bool, error) {
+	visited := map[uuid.UUID]bool{}
+	for id != uuid.Nil && !visited[id] {
+		visited[id] = true
+		lock, err := Load(tx, encryptionKey, id, logger)
+		if err != nil {
+			return false, err
+		}
+		if lock == nil {
+			return false, nil
+		}
+		if lock.Locked {
+			return true, nil
+		}
+		id = lock.ParentID
+	}
+	return false, nil
+}
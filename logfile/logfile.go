@@ -0,0 +1,315 @@
+// Package logfile writes the log to a file that is rotated by size & age
+// Rotated files are renamed with the time they were rotated, optionally
+// compressed, and only the most recent are kept.  The log is written by logrus,
+// so a File only has to be an io.Writer; it doesn't care about the log format.
+package logfile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BackupTimeFormat is the format of the rotation time in backup file names
+const BackupTimeFormat = "20060102T150405.000"
+
+// compressedSuffix is added to the names of compressed backups
+const compressedSuffix = ".gz"
+
+// Policy controls when the log file is rotated & how many rotated files are kept
+type Policy struct {
+	MaxSize    int64         // MaxSize is the size in bytes the file is rotated at (0 for no limit)
+	MaxAge     time.Duration // MaxAge is how long the file is written before it is rotated (0 for no limit)
+	MaxBackups int           // MaxBackups is the number of rotated files kept (0 to keep them all)
+	Compress   bool          // Compress gzips rotated files
+}
+
+// DefaultPolicy returns the policy used unless the backend is configured otherwise
+func DefaultPolicy() Policy {
+	policy := Policy{
+		MaxSize:    10 * 1024 * 1024,
+		MaxAge:     7 * 24 * time.Hour,
+		MaxBackups: 5,
+		Compress:   true,
+	}
+	return policy
+}
+
+// File is a log file that rotates itself as it is written
+type File struct {
+	Path    string
+	Policy  Policy
+	lock    sync.Mutex
+	file    *os.File
+	size    int64
+	started time.Time
+	mill    sync.Mutex // mill serializes compressing & removing backups
+	milling sync.WaitGroup
+	now     func() time.Time
+}
+
+// Open opens the log file at path for appending
+// The current file is taken to have been started when the last backup was
+// rotated, so restarting the backend doesn't put off rotating by age.
+func Open(path string, policy Policy) (*File, error) {
+	f := &File{
+		Path:   path,
+		Policy: policy,
+		now:    time.Now,
+	}
+	err := f.open()
+	if err != nil {
+		return nil, err
+	}
+	f.started = f.now()
+	backups, _ := f.backups()
+	if len(backups) > 0 {
+		f.started = backups[0].rotated
+	}
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write writes a log entry, rotating the file first if the entry would take it past the policy limits
+func (f *File) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.size > 0 && f.due(int64(len(p))) {
+		err := f.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// due reports whether the file should be rotated before writing n more bytes
+func (f *File) due(n int64) bool {
+	if f.Policy.MaxSize > 0 && f.size+n > f.Policy.MaxSize {
+		return true
+	}
+	return f.Policy.MaxAge > 0 && f.now().Sub(f.started) >= f.Policy.MaxAge
+}
+
+// Rotate starts a new log file, keeping the current one as a backup
+func (f *File) Rotate() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+func (f *File) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return err
+	}
+	now := f.now()
+	backup := f.backupName(now)
+	renameErr := os.Rename(f.Path, backup)
+	// keep logging to the current file if it couldn't be renamed
+	err = f.open()
+	if renameErr != nil {
+		return renameErr
+	}
+	if err != nil {
+		return err
+	}
+	f.started = now
+
+	f.milling.Add(1)
+	go func() {
+		defer f.milling.Done()
+		f.mill.Lock()
+		defer f.mill.Unlock()
+		if f.Policy.Compress {
+			compress(backup)
+		}
+		f.prune()
+	}()
+	return nil
+}
+
+// Close closes the log file once any rotated files have been compressed
+func (f *File) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.milling.Wait()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// backupName returns the name of a backup rotated at a time
+// notekeeper.log is rotated to notekeeper-20200102T150405.000.log
+func (f *File) backupName(rotated time.Time) string {
+	ext := filepath.Ext(f.Path)
+	base := strings.TrimSuffix(f.Path, ext)
+	return base + "-" + rotated.UTC().Format(BackupTimeFormat) + ext
+}
+
+// backup is a rotated log file
+type backup struct {
+	path    string
+	rotated time.Time
+}
+
+// backups lists the rotated log files, newest first
+func (f *File) backups() ([]backup, error) {
+	ext := filepath.Ext(f.Path)
+	prefix := filepath.Base(strings.TrimSuffix(f.Path, ext)) + "-"
+	entries, err := ioutil.ReadDir(filepath.Dir(f.Path))
+	if err != nil {
+		return nil, err
+	}
+	var found []backup
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), compressedSuffix)
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		rotated, err := time.Parse(BackupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+		if err != nil {
+			continue
+		}
+		found = append(found, backup{filepath.Join(filepath.Dir(f.Path), entry.Name()), rotated})
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].rotated.After(found[j].rotated) })
+	return found, nil
+}
+
+// prune removes the oldest backups beyond the policy limit
+func (f *File) prune() {
+	if f.Policy.MaxBackups <= 0 {
+		return
+	}
+	backups, err := f.backups()
+	if err != nil {
+		return
+	}
+	for i := f.Policy.MaxBackups; i < len(backups); i++ {
+		os.Remove(backups[i].path)
+	}
+}
+
+// compress gzips a backup, removing the uncompressed file once it has been written
+func compress(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(path+compressedSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	writer := gzip.NewWriter(out)
+	_, err = io.Copy(writer, in)
+	if err == nil {
+		err = writer.Close()
+	}
+	closeErr := out.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + compressedSuffix)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// Tail returns up to n of the most recent lines in the log, oldest first
+// Backups are read, newest first, until there are enough lines.
+func (f *File) Tail(n int) ([]string, error) {
+	f.mill.Lock()
+	defer f.mill.Unlock()
+	backups, err := f.backups()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{f.Path}
+	for _, b := range backups {
+		paths = append(paths, b.path)
+	}
+	var lines []string
+	for _, path := range paths {
+		if len(lines) >= n {
+			break
+		}
+		read, err := readLines(path)
+		if os.IsNotExist(err) {
+			// the current file is being rotated
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		lines = append(read, lines...)
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
+// readLines reads the lines of a log file, decompressing it if needed
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, compressedSuffix) {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
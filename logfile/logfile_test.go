@@ -0,0 +1,115 @@
+package logfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clock is a fake time that moves on a second each time it is read
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) tick() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func openTestFile(t *testing.T, policy Policy) (*File, *clock, func()) {
+	dir, err := ioutil.TempDir("", "notekeeper-logfile")
+	if err != nil {
+		t.Fatal("Error creating log directory - ", err)
+	}
+	f, err := Open(filepath.Join(dir, "notekeeper.log"), policy)
+	if err != nil {
+		t.Fatal("Error opening log file - ", err)
+	}
+	c := &clock{now: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}
+	f.now = c.tick
+	f.started = c.now
+	return f, c, func() {
+		f.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func writeLines(t *testing.T, f *File, from int, to int) {
+	for i := from; i < to; i++ {
+		_, err := fmt.Fprintf(f, "line %02d\n", i)
+		if err != nil {
+			t.Fatal("Error writing log line - ", err)
+		}
+	}
+}
+
+func TestRotateBySize(t *testing.T) {
+	f, _, cleanup := openTestFile(t, Policy{MaxSize: 32, MaxBackups: 2, Compress: true})
+	defer cleanup()
+
+	// each line is 8 bytes, so every 4 lines fill a file
+	writeLines(t, f, 0, 20)
+	f.milling.Wait()
+
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatal("Error listing backups - ", err)
+	}
+	if len(backups) != 2 {
+		t.Fatal("Expected 2 backups to be kept but got ", len(backups))
+	}
+	for _, b := range backups {
+		if !strings.HasSuffix(b.path, compressedSuffix) {
+			t.Error("Expected backup [", b.path, "] to be compressed")
+		}
+	}
+	if f.size != 32 {
+		t.Error("Expected the current file to hold the last 4 lines but it has ", f.size, " bytes")
+	}
+
+	lines, err := f.Tail(6)
+	if err != nil {
+		t.Fatal("Error reading recent lines - ", err)
+	}
+	if len(lines) != 6 || lines[0] != "line 14" || lines[5] != "line 19" {
+		t.Error("Expected the last 6 lines across the current file & a backup but got ", lines)
+	}
+	lines, _ = f.Tail(100)
+	if len(lines) != 12 {
+		t.Error("Expected the lines of the current file & the kept backups but got ", len(lines))
+	}
+}
+
+func TestRotateByAge(t *testing.T) {
+	f, c, cleanup := openTestFile(t, Policy{MaxAge: time.Hour})
+	defer cleanup()
+
+	writeLines(t, f, 0, 2)
+	c.now = c.now.Add(time.Hour)
+	writeLines(t, f, 2, 3)
+	f.milling.Wait()
+
+	backups, _ := f.backups()
+	if len(backups) != 1 || strings.HasSuffix(backups[0].path, compressedSuffix) {
+		t.Fatal("Expected 1 uncompressed backup but got ", backups)
+	}
+	data, _ := ioutil.ReadFile(backups[0].path)
+	if string(data) != "line 00\nline 01\n" {
+		t.Error("Expected the backup to hold the lines written before the file was due - ", string(data))
+	}
+
+	// reopening carries on from the last rotation instead of starting the age again
+	f.Close()
+	reopened, err := Open(f.Path, f.Policy)
+	if err != nil {
+		t.Fatal("Error reopening log file - ", err)
+	}
+	defer reopened.Close()
+	if !reopened.started.Equal(backups[0].rotated) {
+		t.Error("Expected the reopened file to have been started at the last rotation but got ", reopened.started)
+	}
+}
@@ -1,21 +1,37 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/urfave/cli"
 )
 
 func runCli(c *cli.Context) {
-	backend := NewBackend()
+	config, err := LoadConfig(c)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	backend := NewBackend(config)
 	backend.Logger.Debug("Starting Service...")
 	backend.Run()
 }
 
-func main() {
+// newApp creates the command line app
+// Without a command the backend service is started.
+func newApp() *cli.App {
 	app := cli.NewApp()
 	app.Name = "notekeeper"
 	app.Usage = "NoteKeeper.io"
+	app.Version = Version
+	app.Flags = configFlags
+	app.Commands = commands
 	app.Action = runCli
-	app.Run(os.Args)
+	return app
+}
+
+func main() {
+	cli.VersionPrinter = printVersion
+	newApp().Run(os.Args)
 }
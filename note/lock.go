@@ -0,0 +1,71 @@
+package note
+
+import (
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/lock"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// checkUnlocked fails with ErrorLocked when a note can't be changed
+// A locked note can only be changed by Unlock, and only while the notebooks &
+// store holding it aren't locked. current is nil for a delete.
+func (note *Note) checkUnlocked(tx *bbolt.Tx, noteKey []byte, previous *Note, current *Note, unlocking bool) error {
+	ids := []uuid.UUID{note.StoreID}
+	if previous != nil {
+		if previous.Locked && !unlocking {
+			note.Logger.Info("Note [", note.ID, "] is locked")
+			code := codes.NewApplication(codes.ScopeNote, codes.ErrorLocked)
+			return code
+		}
+		ids = append(ids, previous.NotebookID)
+	}
+	if current != nil {
+		ids = append(ids, current.NotebookID)
+	}
+
+	for _, id := range ids {
+		locked, err := lock.Locked(tx, noteKey, id, note.Logger)
+		if err != nil {
+			return err
+		}
+		if locked {
+			note.Logger.Info("Notebook or store [", id, "] holding note [", note.ID, "] is locked")
+			code := codes.NewApplication(codes.ScopeNote, codes.ErrorLocked)
+			return code
+		}
+	}
+	return nil
+}
+
+// Unlock a locked note
+// The stored note is loaded & saved with only its lock state changed, so this
+// is the one way to unlock a note - saving a locked note is always refused.
+func (note *Note) Unlock(passphraseKey []byte) error {
+	err := note.Load(passphraseKey)
+	if err != nil {
+		return err
+	}
+	note.Locked = false
+	note.Updated = time.Now()
+	return note.save(passphraseKey, true)
+}
+
+// Lock a note
+// The stored note is loaded & saved with only its lock state changed. Locking a
+// note that is already locked leaves it as it is.
+func (note *Note) Lock(passphraseKey []byte) error {
+	err := note.Load(passphraseKey)
+	if err != nil {
+		return err
+	}
+	if note.Locked {
+		return nil
+	}
+	note.Locked = true
+	note.Updated = time.Now()
+	return note.save(passphraseKey, false)
+}
@@ -7,6 +7,7 @@ import (
 	"notekeeper-electron-backend/codes"
 	"notekeeper-electron-backend/crypto"
 	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/stats"
 	"notekeeper-electron-backend/tag"
 	"notekeeper-electron-backend/title"
 
@@ -41,6 +42,25 @@ const (
 	TypeList
 )
 
+var typeNames = map[string]Type{
+	"plaintext": TypePlainText,
+	"richtext":  TypeRichText,
+	"markdown":  TypeMarkdown,
+	"html":      TypeHTML,
+	"image":     TypeImage,
+	"file":      TypeFile,
+	"pdf":       TypePdf,
+	"audio":     TypeAudio,
+	"reminder":  TypeReminder,
+	"list":      TypeList,
+}
+
+// TypeFromString converts the name of a note type used by the front end to a Type
+func TypeFromString(s string) (Type, bool) {
+	t, ok := typeNames[s]
+	return t, ok
+}
+
 // Scope is the scope of the note (account or user)
 type Scope int
 
@@ -117,7 +137,13 @@ func (note *Note) getDBHandle() (*db.Handle, error) {
 }
 
 // Save a note
+// A locked note can't be saved, even to unlock it - use Unlock instead.
 func (note *Note) Save(passphraseKey []byte) error {
+	return note.save(passphraseKey, false)
+}
+
+// save writes the note, refusing to change a locked note unless it is being unlocked
+func (note *Note) save(passphraseKey []byte, unlocking bool) error {
 	noteDBHandle, err := note.getDBHandle()
 	if err != nil {
 		return err
@@ -157,6 +183,20 @@ func (note *Note) Save(passphraseKey []byte) error {
 			return code
 		}
 
+		// keep the notebook & store stats in step with the note
+		previous, err := note.previous(bucket, decryptedKey)
+		if err != nil {
+			return err
+		}
+		err = note.checkUnlocked(tx, decryptedKey, previous, note, unlocking)
+		if err != nil {
+			return err
+		}
+		err = note.updateStats(tx, decryptedKey, previous, note)
+		if err != nil {
+			return err
+		}
+
 		// finally, save it
 		err = bucket.Put(note.ID.Bytes(), encryptedData)
 		if err != nil {
@@ -267,12 +307,10 @@ func (note *Note) Load(passphraseKey []byte) error {
 			return code
 		}
 
-		cursor := bucket.Cursor()
-
-		key, value := cursor.Seek(note.ID.Bytes())
-		if key == nil {
-			note.Logger.Warn("Error loading note")
-			code := codes.New(codes.ScopeNote, codes.ErrorLoad)
+		value := bucket.Get(note.ID.Bytes())
+		if value == nil {
+			note.Logger.Warn("Note [", note.ID, "] does not exist")
+			code := codes.New(codes.ScopeNote, codes.ErrorRecordMissing)
 			return code
 		}
 
@@ -311,6 +349,13 @@ func (note *Note) Delete(passphraseKey []byte) error {
 	if err != nil {
 		return err
 	}
+	c := crypto.New(note.Logger)
+	noteKey, err := c.Open(passphraseKey, noteDBHandle.EncryptedKey)
+	if err != nil {
+		note.Logger.Warn("Error opening note key - ", err)
+		code := codes.New(codes.ScopeNote, codes.ErrorOpenKey)
+		return code
+	}
 	err = noteDBHandle.DB.Update(func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket([]byte("notes"))
 		if bucket == nil {
@@ -319,7 +364,20 @@ func (note *Note) Delete(passphraseKey []byte) error {
 			return code
 		}
 
-		err := bucket.Delete(note.ID.Bytes())
+		previous, err := note.previous(bucket, noteKey)
+		if err != nil {
+			return err
+		}
+		err = note.checkUnlocked(tx, noteKey, previous, nil, false)
+		if err != nil {
+			return err
+		}
+		err = note.updateStats(tx, noteKey, previous, nil)
+		if err != nil {
+			return err
+		}
+
+		err = bucket.Delete(note.ID.Bytes())
 		if err != nil {
 			note.Logger.Warn("Error deleting note - ", err)
 			code := codes.New(codes.ScopeNote, codes.ErrorDelete)
@@ -340,3 +398,54 @@ func (note *Note) Delete(passphraseKey []byte) error {
 
 	return nil
 }
+
+// previous returns the currently stored version of the note (nil for a new note)
+func (note *Note) previous(bucket *bbolt.Bucket, noteKey []byte) (*Note, error) {
+	value := bucket.Get(note.ID.Bytes())
+	if value == nil {
+		return nil, nil
+	}
+	c := crypto.New(note.Logger)
+	decryptedData, err := c.Open(noteKey, value)
+	if err != nil {
+		note.Logger.Warn("Error decrypting note data - ", err)
+		code := codes.New(codes.ScopeNote, codes.ErrorDecrypt)
+		return nil, code
+	}
+	previous := &Note{}
+	err = json.Unmarshal(decryptedData, previous)
+	if err != nil {
+		note.Logger.Warn("Error decoding note json - ", err)
+		code := codes.New(codes.ScopeNote, codes.ErrorDecode)
+		return nil, code
+	}
+	return previous, nil
+}
+
+// updateStats applies the change from previous to current to the notebook & store stats
+// Either side may be nil, for a newly created or a deleted note.
+func (note *Note) updateStats(tx *bbolt.Tx, noteKey []byte, previous *Note, current *Note) error {
+	if previous != nil {
+		size := int64(len(previous.Content))
+		err := stats.Adjust(tx, noteKey, previous.NotebookID, -1, -size, note.Logger)
+		if err != nil {
+			return err
+		}
+		err = stats.Adjust(tx, noteKey, note.StoreID, -1, -size, note.Logger)
+		if err != nil {
+			return err
+		}
+	}
+	if current != nil {
+		size := int64(len(current.Content))
+		err := stats.Adjust(tx, noteKey, current.NotebookID, 1, size, note.Logger)
+		if err != nil {
+			return err
+		}
+		err = stats.Adjust(tx, noteKey, note.StoreID, 1, size, note.Logger)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package note
+
+import (
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/query"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ItemID returns the note id for sorting & paging
+func (note *Note) ItemID() uuid.UUID {
+	return note.ID
+}
+
+// ItemTitle returns the note title text for sorting
+func (note *Note) ItemTitle() string {
+	if note.Title == nil {
+		return ""
+	}
+	return note.Title.Title
+}
+
+// ItemCreated returns the creation time for sorting
+func (note *Note) ItemCreated() time.Time {
+	return note.Created
+}
+
+// ItemUpdated returns the update time for sorting & date filters
+func (note *Note) ItemUpdated() time.Time {
+	return note.Updated
+}
+
+// ItemLocked returns whether the note is locked for the locked filter
+func (note *Note) ItemLocked() bool {
+	return note.Locked
+}
+
+// ItemType returns the note type for the type filter
+func (note *Note) ItemType() int {
+	return int(note.Type)
+}
+
+// ItemTags returns the ids of the tags stored with the note for the tag filter
+func (note *Note) ItemTags() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, t := range note.Tags {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// LoadPage loads a sorted & filtered page of notes
+// The cursor for the next page is returned along with the notes (empty on the
+// last page).
+func (note *Note) LoadPage(q *query.Query, passphraseKey []byte) ([]*Note, string, error) {
+	notes, err := note.LoadAll(passphraseKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items := make([]query.Item, 0, len(notes))
+	for _, n := range notes {
+		items = append(items, n)
+	}
+	page, err := q.Apply(items)
+	if err != nil {
+		note.Logger.Warn("Error applying note query - ", err)
+		code := codes.New(codes.ScopeNote, codes.ErrorDecode)
+		return nil, "", code
+	}
+
+	result := make([]*Note, 0, len(page.Items))
+	for _, item := range page.Items {
+		result = append(result, item.(*Note))
+	}
+	return result, page.Next, nil
+}
@@ -0,0 +1,107 @@
+package notebook
+
+import (
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/lock"
+	"notekeeper-electron-backend/note"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// enclosing returns the id that a notebook inherits its lock state from
+// Top level notebooks inherit from their container.
+func enclosing(n *Notebook) uuid.UUID {
+	if n.ParentID != uuid.Nil {
+		return n.ParentID
+	}
+	return n.ContainerID
+}
+
+// saveLock keeps the lock record of a notebook in step with the notebook record
+func (notebook *Notebook) saveLock(tx *bbolt.Tx, notebookKey []byte, n *Notebook) error {
+	l := &lock.Lock{
+		Locked:   n.Locked,
+		ParentID: enclosing(n),
+	}
+	return lock.Save(tx, notebookKey, n.ID, l, notebook.Logger)
+}
+
+// putNotebook writes a notebook record along with its lock record
+func (notebook *Notebook) putNotebook(tx *bbolt.Tx, n *Notebook, notebookKey []byte) error {
+	err := notebook.put(tx, "notebooks", n.ID, n, notebookKey)
+	if err != nil {
+		return err
+	}
+	return notebook.saveLock(tx, notebookKey, n)
+}
+
+// checkUnlocked fails with ErrorLocked when any of ids or anything they are nested within is locked
+// The container is normally reached by walking up from a notebook, but passing
+// it as well covers notebooks saved before lock records were kept.
+func (notebook *Notebook) checkUnlocked(tx *bbolt.Tx, notebookKey []byte, ids ...uuid.UUID) error {
+	for _, id := range ids {
+		locked, err := lock.Locked(tx, notebookKey, id, notebook.Logger)
+		if err != nil {
+			return err
+		}
+		if locked {
+			notebook.Logger.Info("Notebook or container [", id, "] is locked")
+			code := codes.NewApplication(codes.ScopeNotebook, codes.ErrorLocked)
+			return code
+		}
+	}
+	return nil
+}
+
+// checkNoteUnlocked fails with ErrorLocked when a note can't be changed
+// A note is locked by its own flag, or by the notebooks & container holding it.
+func (notebook *Notebook) checkNoteUnlocked(tx *bbolt.Tx, notebookKey []byte, containerID uuid.UUID, n *note.Note) error {
+	if n.Locked {
+		notebook.Logger.Info("Note [", n.ID, "] is locked")
+		code := codes.NewApplication(codes.ScopeNotebook, codes.ErrorLocked)
+		return code
+	}
+	return notebook.checkUnlocked(tx, notebookKey, n.NotebookID, containerID)
+}
+
+// checkSave fails with ErrorLocked when a notebook can't be saved
+// A locked notebook can still be saved in order to unlock it, as long as
+// nothing it is nested within is locked.
+func (notebook *Notebook) checkSave(tx *bbolt.Tx, notebookKey []byte) error {
+	notebooks, err := notebook.readAll(tx, notebookKey)
+	if err != nil {
+		return err
+	}
+	existing := findNotebook(notebooks, notebook.ID)
+	if existing != nil {
+		if existing.Locked && notebook.Locked {
+			notebook.Logger.Info("Notebook [", notebook.ID, "] is locked")
+			code := codes.NewApplication(codes.ScopeNotebook, codes.ErrorLocked)
+			return code
+		}
+		err = notebook.checkUnlocked(tx, notebookKey, enclosing(existing), existing.ContainerID)
+		if err != nil {
+			return err
+		}
+	}
+	return notebook.checkUnlocked(tx, notebookKey, enclosing(notebook), notebook.ContainerID)
+}
+
+// ensureUnlocked runs a lock check in a read transaction of the notebook's container
+func (notebook *Notebook) ensureUnlocked(check func(tx *bbolt.Tx) error) error {
+	handle, err := notebook.getDBHandle()
+	if err != nil {
+		return err
+	}
+	err = handle.DB.View(check)
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error checking notebook lock - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorLoad)
+		return code
+	}
+	return nil
+}
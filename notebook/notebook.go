@@ -7,7 +7,9 @@ import (
 	"notekeeper-electron-backend/codes"
 	"notekeeper-electron-backend/crypto"
 	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/lock"
 	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/stats"
 	"notekeeper-electron-backend/tag"
 	"notekeeper-electron-backend/title"
 
@@ -45,12 +47,14 @@ type Notebook struct {
 	OwnerID       uuid.UUID      `json:"owner_id"`       // OwnerID is the user or account that owns the notebook
 	ContainerID   uuid.UUID      `json:"container_id"`   // ContainerID is the collection or shelf that this notebook belongs to
 	ContainerType ContainerType  `json:"container_type"` // ContainerType is the type of container the notebook is stored within (shelf or collection)
+	ParentID      uuid.UUID      `json:"parent_id"`      // ParentID is the notebook that this notebook is nested within (uuid.Nil for top level notebooks)
 	Scope         Scope          `json:"scope"`          // Scope is whether the notebook is owned by a user or an account
 	Title         *title.Title   `json:"title"`          // Title is the title of the notebook
 	Default       bool           `json:"default"`        // Default indicates whether this is the default notebook
 	EncryptedKey  []byte         `json:"encryption_key"` // EncryptedKey is the encrypted version of the notebook's encryption key
 	Notes         []*note.Note   `json:"-"`              // Notes is the set of notes that belong to this notebook
-	NoteCount     int            `json:"note_count"`     // NoteCount keeps track of the number of notes in the notebook
+	NoteCount     int            `json:"-"`              // NoteCount keeps track of the number of notes in the notebook (loaded from the notebook stats)
+	Stats         *stats.Stats   `json:"-"`              // Stats summarises the notes in the notebook (kept in the stats bucket)
 	Tags          []*tag.Tag     `json:"tags"`           // Tags is the set of tags assigned to this notebook
 	Created       time.Time      `json:"created"`        // Created is the time when the notebook was created
 	Updated       time.Time      `json:"updated"`        // Updated is the time when the notebook was last updated
@@ -93,6 +97,22 @@ func (notebook *Notebook) getDBHandle() (*db.Handle, error) {
 	return notebookDBHandle, err
 }
 
+// UnsealKey opens the encryption key of the db that the notebook is stored in
+func (notebook *Notebook) UnsealKey(passphraseKey []byte) ([]byte, error) {
+	notebookDBHandle, err := notebook.getDBHandle()
+	if err != nil {
+		return nil, err
+	}
+	c := crypto.New(notebook.Logger)
+	notebookKey, err := c.Open(passphraseKey, notebookDBHandle.EncryptedKey)
+	if err != nil {
+		notebook.Logger.Warn("Error opening notebook key - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorOpenKey)
+		return nil, code
+	}
+	return notebookKey, nil
+}
+
 // Save a notebook to the database
 func (notebook *Notebook) Save(encryptionKey []byte) error {
 	notebookDBHandle, err := notebook.getDBHandle()
@@ -108,6 +128,11 @@ func (notebook *Notebook) Save(encryptionKey []byte) error {
 			return code
 		}
 
+		err = notebook.checkSave(tx, encryptionKey)
+		if err != nil {
+			return err
+		}
+
 		// serialize notebook data
 		data, err := json.Marshal(notebook)
 		if err != nil {
@@ -132,7 +157,7 @@ func (notebook *Notebook) Save(encryptionKey []byte) error {
 			code := codes.New(codes.ScopeNotebook, codes.ErrorWriteBucket)
 			return code
 		}
-		return nil
+		return notebook.saveLock(tx, encryptionKey, notebook)
 	})
 
 	if err != nil {
@@ -195,6 +220,11 @@ func (notebook *Notebook) LoadAll(passphraseKey []byte) ([]*Notebook, error) {
 				return code
 			}
 
+			err = newNotebook.loadStats(tx, notebookKey)
+			if err != nil {
+				return err
+			}
+
 			notebooks = append(notebooks, newNotebook)
 		}
 
@@ -212,12 +242,101 @@ func (notebook *Notebook) LoadAll(passphraseKey []byte) ([]*Notebook, error) {
 	return notebooks, nil
 }
 
+// loadStats fills in the note stats for the notebook from an open transaction
+func (notebook *Notebook) loadStats(tx *bbolt.Tx, notebookKey []byte) error {
+	s, err := stats.Load(tx, notebookKey, notebook.ID, notebook.Logger)
+	if err != nil {
+		return err
+	}
+	notebook.Stats = s
+	notebook.NoteCount = s.NoteCount
+	return nil
+}
+
+// Load a single notebook
+func (notebook *Notebook) Load(passphraseKey []byte) error {
+	notebookDBHandle, err := notebook.getDBHandle()
+	if err != nil {
+		return err
+	}
+	notebookKey, err := notebook.UnsealKey(passphraseKey)
+	if err != nil {
+		return err
+	}
+
+	err = notebookDBHandle.DB.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("notebooks"))
+		if bucket == nil {
+			notebook.Logger.Warn("notebook bucket does not exist")
+			code := codes.New(codes.ScopeNotebook, codes.ErrorBucketMissing)
+			return code
+		}
+
+		value := bucket.Get(notebook.ID.Bytes())
+		if value == nil {
+			notebook.Logger.Warn("Error loading notebook [", notebook.ID, "]")
+			code := codes.New(codes.ScopeNotebook, codes.ErrorRecordMissing)
+			return code
+		}
+
+		c := crypto.New(notebook.Logger)
+		decryptedData, err := c.Open(notebookKey, value)
+		if err != nil {
+			notebook.Logger.Warn("Error decrypting notebook data - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDecrypt)
+			return code
+		}
+
+		err = json.Unmarshal(decryptedData, notebook)
+		if err != nil {
+			notebook.Logger.Warn("Error decoding notebook json - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDecode)
+			return code
+		}
+		return notebook.loadStats(tx, notebookKey)
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error loading notebook - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorLoad)
+		return code
+	}
+
+	return nil
+}
+
+// checkEmpty fails with ErrorNotEmpty if any notebook or note is filed in the notebook
+func (notebook *Notebook) checkEmpty(tx *bbolt.Tx, notebookKey []byte) error {
+	notebooks, err := notebook.readAll(tx, notebookKey)
+	if err != nil {
+		return err
+	}
+	notes, err := notebook.readNotes(tx, notebookKey, map[uuid.UUID]bool{notebook.ID: true})
+	if err != nil {
+		return err
+	}
+	if len(Children(notebooks, notebook.ID)) > 0 || len(notes) > 0 {
+		notebook.Logger.Warn("Refusing to delete notebook [", notebook.ID, "] that is not empty")
+		code := codes.NewApplication(codes.ScopeNotebook, codes.ErrorNotEmpty)
+		return code
+	}
+	return nil
+}
+
 // Delete a notebook
+// Only empty notebooks can be deleted - child notebooks & notes have to be moved
+// or deleted first, otherwise they would be left pointing at a missing notebook.
 func (notebook *Notebook) Delete(passphraseKey []byte) error {
 	notebookDBHandle, err := notebook.getDBHandle()
 	if err != nil {
 		return err
 	}
+	notebookKey, err := notebook.UnsealKey(passphraseKey)
+	if err != nil {
+		return err
+	}
 	err = notebookDBHandle.DB.Update(func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket([]byte("notebooks"))
 		if bucket == nil {
@@ -226,14 +345,28 @@ func (notebook *Notebook) Delete(passphraseKey []byte) error {
 			return code
 		}
 
-		err := bucket.Delete(notebook.ID.Bytes())
+		err := notebook.checkUnlocked(tx, notebookKey, notebook.ID, notebook.ContainerID)
+		if err != nil {
+			return err
+		}
+
+		err = notebook.checkEmpty(tx, notebookKey)
+		if err != nil {
+			return err
+		}
+
+		err = bucket.Delete(notebook.ID.Bytes())
 		if err != nil {
 			notebook.Logger.Warn("Error deleting notebook - ", err)
 			code := codes.New(codes.ScopeNotebook, codes.ErrorDelete)
 			return code
 		}
 
-		return nil
+		err = lock.Delete(tx, notebook.ID, notebook.Logger)
+		if err != nil {
+			return err
+		}
+		return stats.Delete(tx, notebook.ID, notebook.Logger)
 	})
 
 	if err != nil {
@@ -1,9 +1,662 @@
 package notebook
 
 import (
+	"flag"
+	"os"
 	"testing"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/lock"
+	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/query"
+	"notekeeper-electron-backend/stats"
+	"notekeeper-electron-backend/title"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"go.etcd.io/bbolt"
 )
 
+var harness struct {
+	logger        *logrus.Logger
+	registry      *db.Registry
+	hook          *test.Hook
+	ownerID       uuid.UUID
+	passphraseKey []byte
+	source        uuid.UUID
+	destination   uuid.UUID
+	notebooks     map[string]*Notebook
+	notes         map[string]*note.Note
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+func setup(t *testing.T) {
+	harness.logger, harness.hook = test.NewNullLogger()
+	harness.registry = db.NewRegistry(harness.logger)
+	harness.notebooks = make(map[string]*Notebook)
+	harness.notes = make(map[string]*note.Note)
+	harness.ownerID = uuid.NewV4()
+	harness.passphraseKey = []byte("passphrase key")
+
+	err := harness.registry.OpenMaster("./")
+	if err != nil {
+		t.Fatal("Failed to open master db - ", err)
+	}
+
+	// two shelves, each with its own key
+	harness.source = createShelf(t)
+	harness.destination = createShelf(t)
+}
+
+func createShelf(t *testing.T) uuid.UUID {
+	id := uuid.NewV4()
+	handle, err := harness.registry.NewHandle(db.Key{ID: id, Type: db.TypeShelf})
+	if err != nil {
+		t.Fatal("Failed to create shelf db - ", err)
+	}
+	c := crypto.New(harness.logger)
+	shelfKey, err := c.GenerateKey()
+	if err != nil {
+		t.Fatal("Failed to generate shelf key - ", err)
+	}
+	handle.EncryptedKey, err = c.Seal(harness.passphraseKey, shelfKey[:])
+	if err != nil {
+		t.Fatal("Failed to seal shelf key - ", err)
+	}
+	return id
+}
+
+func teardown(t *testing.T) {
+	for _, handle := range harness.registry.Handles {
+		err := handle.Close()
+		if err != nil {
+			t.Error("Failed to close db - ", err)
+		}
+		err = os.Remove(handle.Info.Filename)
+		if err != nil {
+			t.Error("Failed to cleanup db - ", err)
+		}
+	}
+
+	harness.hook.Reset()
+}
+
+func createNotebook(t *testing.T, name string, parent string) *Notebook {
+	nb, err := New(title.New(name), ScopeUser, ContainerTypeShelf, harness.registry, harness.logger)
+	if err != nil {
+		t.Fatal("Expected to create notebook - ", err)
+	}
+	nb.OwnerID = harness.ownerID
+	nb.ContainerID = harness.source
+	if parent != "" {
+		nb.ParentID = harness.notebooks[parent].ID
+	}
+	key, err := nb.UnsealKey(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to unseal notebook key - ", err)
+	}
+	err = nb.Save(key)
+	if err != nil {
+		t.Fatal("Expected to save notebook - ", err)
+	}
+	harness.notebooks[name] = nb
+	return nb
+}
+
+func createNote(t *testing.T, name string, notebook string) *note.Note {
+	n, err := note.New(title.New(name), note.ScopeUser, note.StoreTypeShelf, harness.registry, harness.logger)
+	if err != nil {
+		t.Fatal("Expected to create note - ", err)
+	}
+	n.OwnerID = harness.ownerID
+	n.StoreID = harness.source
+	n.NotebookID = harness.notebooks[notebook].ID
+	n.Content = name + " content"
+	err = n.Save(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to save note - ", err)
+	}
+	harness.notes[name] = n
+	return n
+}
+
+func proxy(name string, containerID uuid.UUID) *Notebook {
+	p, _ := New(nil, ScopeUser, ContainerTypeShelf, harness.registry, harness.logger)
+	p.ID = harness.notebooks[name].ID
+	p.OwnerID = harness.ownerID
+	p.ContainerID = containerID
+	return p
+}
+
+func to(containerID uuid.UUID, parentID uuid.UUID) *Destination {
+	return &Destination{
+		Scope:         ScopeUser,
+		OwnerID:       harness.ownerID,
+		ContainerType: ContainerTypeShelf,
+		ContainerID:   containerID,
+		ParentID:      parentID,
+	}
+}
+
+func loadNotebook(t *testing.T, name string, containerID uuid.UUID) *Notebook {
+	nb := proxy(name, containerID)
+	err := nb.Load(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load notebook [", name, "] - ", err)
+	}
+	return nb
+}
+
+func loadNotes(t *testing.T, containerID uuid.UUID) []*note.Note {
+	p, _ := note.New(nil, note.ScopeUser, note.StoreTypeShelf, harness.registry, harness.logger)
+	p.StoreID = containerID
+	notes, err := p.LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load notes - ", err)
+	}
+	return notes
+}
+
+func expectCode(t *testing.T, err error, code codes.Code) {
+	if err == nil {
+		t.Error("Expected error code [", code, "] but got no error")
+		return
+	}
+	if codes.ToInternalError(err).Code != code {
+		t.Error("Expected error code [", code, "] but got [", err, "]")
+	}
+}
+
 func TestNotebook(t *testing.T) {
+	setup(t)
+
+	createNotebook(t, "work", "")
+	createNotebook(t, "projects", "work")
+	createNotebook(t, "alpha", "projects")
+	createNotebook(t, "personal", "")
+	createNote(t, "alpha notes", "alpha")
+	createNote(t, "project list", "projects")
+	createNote(t, "shopping", "personal")
+
+	testTree(t)
+	testPage(t)
+	testMove(t)
+	testMoveAcrossContainers(t)
+	testCopy(t)
+	testMoveNote(t)
+	testRecover(t)
+	testLocks(t)
+	testStats(t, harness.source)
+	testStats(t, harness.destination)
+	testDelete(t)
+
+	teardown(t)
+}
+
+func testTree(t *testing.T) {
+	tree, err := proxy("work", harness.source).LoadTree(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load notebook tree - ", err)
+	}
+	if len(tree) != 2 {
+		t.Fatal("Expected 2 top level notebooks but got ", len(tree))
+	}
+	for _, node := range tree {
+		if node.Notebook.ID != harness.notebooks["work"].ID {
+			continue
+		}
+		if len(node.Children) != 1 || len(node.Children[0].Children) != 1 {
+			t.Error("Expected work to contain projects/alpha")
+		} else if node.Children[0].Children[0].Notebook.ID != harness.notebooks["alpha"].ID {
+			t.Error("Expected alpha to be nested below projects")
+		}
+	}
+}
+
+func testPage(t *testing.T) {
+	// pages are made of top level notebooks in title order
+	q := &query.Query{Limit: 1}
+	page, next, err := proxy("work", harness.source).LoadPage(q, harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load notebook page - ", err)
+	}
+	if len(page) != 1 || page[0].Notebook.ID != harness.notebooks["personal"].ID || next == "" {
+		t.Fatal("Expected personal on the first page")
+	}
+	q.Cursor = next
+	page, next, err = proxy("work", harness.source).LoadPage(q, harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load notebook page - ", err)
+	}
+	if len(page) != 1 || page[0].Notebook.ID != harness.notebooks["work"].ID || next != "" {
+		t.Fatal("Expected work on the last page")
+	}
+	if len(page[0].Children) != 1 || len(page[0].Children[0].Children) != 1 {
+		t.Error("Expected the page to include the complete work subtree")
+	}
+
+	// filters return a flat list of matching notebooks
+	q = &query.Query{Extra: func(item query.Item) bool {
+		return item.(*Notebook).ParentID != uuid.Nil
+	}}
+	page, _, err = proxy("work", harness.source).LoadPage(q, harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load filtered notebooks - ", err)
+	}
+	if len(page) != 2 || page[0].Notebook.ID != harness.notebooks["alpha"].ID || len(page[1].Children) != 0 {
+		t.Error("Expected alpha & projects without children")
+	}
+
+	_, _, err = proxy("work", harness.source).LoadPage(&query.Query{Cursor: "bad"}, harness.passphraseKey)
+	expectCode(t, err, codes.ErrorDecode)
+}
+
+func testMove(t *testing.T) {
+	// a notebook can't be nested below itself or one of its descendants
+	err := proxy("work", harness.source).Move(to(harness.source, harness.notebooks["work"].ID), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorCycle)
+
+	err = proxy("work", harness.source).Move(to(harness.source, harness.notebooks["alpha"].ID), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorCycle)
+
+	err = proxy("work", harness.source).Move(to(harness.source, uuid.NewV4()), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorRecordMissing)
+
+	err = proxy("personal", harness.source).Move(to(harness.source, harness.notebooks["work"].ID), harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to move notebook - ", err)
+	}
+	moved := proxy("personal", harness.source)
+	err = moved.Load(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load moved notebook - ", err)
+	}
+	if moved.ParentID != harness.notebooks["work"].ID {
+		t.Error("Expected moved notebook to have a new parent")
+	}
+}
+
+func testMoveAcrossContainers(t *testing.T) {
+	// move projects (and alpha below it) to the top level of the other shelf
+	err := proxy("projects", harness.source).Move(to(harness.destination, uuid.Nil), harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to move notebook across shelves - ", err)
+	}
+
+	remaining, err := proxy("work", harness.source).LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load source notebooks - ", err)
+	}
+	if len(remaining) != 2 {
+		t.Error("Expected 2 notebooks to remain in the source shelf but got ", len(remaining))
+	}
+
+	moved, err := proxy("projects", harness.destination).LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load destination notebooks - ", err)
+	}
+	if len(moved) != 2 {
+		t.Error("Expected 2 notebooks in the destination shelf but got ", len(moved))
+	}
+	for _, n := range moved {
+		if n.ContainerID != harness.destination {
+			t.Error("Expected moved notebook to point at the destination shelf")
+		}
+		if n.ID == harness.notebooks["projects"].ID && n.ParentID != uuid.Nil {
+			t.Error("Expected projects to be a top level notebook")
+		}
+		if n.ID == harness.notebooks["alpha"].ID && n.ParentID != harness.notebooks["projects"].ID {
+			t.Error("Expected alpha to stay nested below projects")
+		}
+	}
+
+	// the notes follow their notebooks and are readable with the destination key
+	notes := loadNotes(t, harness.destination)
+	if len(notes) != 2 {
+		t.Error("Expected 2 notes in the destination shelf but got ", len(notes))
+	}
+	for _, n := range notes {
+		if n.StoreID != harness.destination {
+			t.Error("Expected moved note to point at the destination shelf")
+		}
+		if n.Content != n.Title.Title+" content" {
+			t.Error("Expected moved note content to survive re-encryption")
+		}
+	}
+
+	notes = loadNotes(t, harness.source)
+	if len(notes) != 1 || notes[0].ID != harness.notes["shopping"].ID {
+		t.Error("Expected only the shopping note to remain in the source shelf")
+	}
+}
+
+func testCopy(t *testing.T) {
+	// copy projects/alpha back into the original shelf, below work
+	copied, err := proxy("projects", harness.destination).Copy(to(harness.source, harness.notebooks["work"].ID), harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to copy notebook - ", err)
+	}
+	if copied.ID == harness.notebooks["projects"].ID {
+		t.Error("Expected the copy to have a new id")
+	}
+
+	tree, err := proxy("work", harness.source).LoadTree(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load notebook tree - ", err)
+	}
+	var found *Node
+	for _, node := range tree {
+		for _, child := range node.Children {
+			if child.Notebook.ID == copied.ID {
+				found = child
+			}
+		}
+	}
+	if found == nil || len(found.Children) != 1 {
+		t.Fatal("Expected the copy to be nested below work with its own copy of alpha")
+	}
+
+	// the originals are untouched
+	if len(loadNotes(t, harness.destination)) != 2 {
+		t.Error("Expected the original notes to remain after a copy")
+	}
+	notes := loadNotes(t, harness.source)
+	if len(notes) != 3 {
+		t.Error("Expected 3 notes in the source shelf after a copy but got ", len(notes))
+	}
+	for _, n := range notes {
+		if n.ID == harness.notes["alpha notes"].ID || n.ID == harness.notes["project list"].ID {
+			t.Error("Expected copied notes to have new ids")
+		}
+	}
+}
+
+func testMoveNote(t *testing.T) {
+	// a note can only be moved into a notebook that exists in the destination
+	err := proxy("projects", harness.destination).MoveNote(harness.notes["project list"].ID, to(harness.source, uuid.NewV4()), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorRecordMissing)
+
+	// a missing destination notebook is a validation error rather than a missing record
+	err = proxy("projects", harness.destination).MoveNote(harness.notes["project list"].ID, to(harness.source, uuid.Nil), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorInvalid)
+	if details := codes.ToInternalError(err).Details; len(details) != 1 || details[0].Field != "destinationNotebookId" {
+		t.Error("Expected a destinationNotebookId field error but got ", details)
+	}
+
+	// move into an account shelf, which changes the scope & owner of the note
+	accountID := uuid.NewV4()
+	destination := to(harness.source, harness.notebooks["personal"].ID)
+	destination.Scope = ScopeAccount
+	destination.OwnerID = accountID
+	err = proxy("projects", harness.destination).MoveNote(harness.notes["project list"].ID, destination, harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to move note - ", err)
+	}
+	var moved *note.Note
+	for _, n := range loadNotes(t, harness.source) {
+		if n.ID == harness.notes["project list"].ID {
+			moved = n
+		}
+	}
+	if moved == nil {
+		t.Fatal("Expected the moved note to keep its id")
+	}
+	if moved.Scope != note.ScopeAccount || moved.OwnerID != accountID || moved.NotebookID != harness.notebooks["personal"].ID {
+		t.Error("Expected the moved note to belong to the account notebook")
+	}
+	if len(loadNotes(t, harness.destination)) != 1 {
+		t.Error("Expected the moved note to be removed from its old shelf")
+	}
+	// personal already held the shopping note
+	if count := loadNotebook(t, "personal", harness.source).NoteCount; count != 2 {
+		t.Error("Expected destination note count of 2 but got ", count)
+	}
+
+	// re-file within the same shelf
+	err = proxy("personal", harness.source).MoveNote(harness.notes["shopping"].ID, to(harness.source, harness.notebooks["work"].ID), harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to re-file note - ", err)
+	}
+	if count := loadNotebook(t, "work", harness.source).NoteCount; count != 1 {
+		t.Error("Expected work note count of 1 but got ", count)
+	}
+
+	copied, err := proxy("work", harness.source).CopyNote(harness.notes["shopping"].ID, to(harness.destination, harness.notebooks["alpha"].ID), harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to copy note - ", err)
+	}
+	if copied.ID == harness.notes["shopping"].ID || copied.StoreID != harness.destination {
+		t.Error("Expected the copied note to get a new id in the destination shelf")
+	}
+	if count := loadNotebook(t, "alpha", harness.destination).NoteCount; count != 2 {
+		t.Error("Expected alpha note count of 2 but got ", count)
+	}
+}
+
+func testRecover(t *testing.T) {
+	source := proxy("work", harness.source)
+	key, err := source.UnsealKey(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to unseal key - ", err)
+	}
+	n, err := source.loadNote(harness.notes["shopping"].ID, key)
+	if err != nil {
+		t.Fatal("Expected to load note - ", err)
+	}
+	destination := to(harness.destination, harness.notebooks["alpha"].ID)
+	destination.placeNote(n)
+	n.NotebookID = destination.ParentID
+
+	// interrupted before anything reached the destination - the move is rolled back
+	pending := &transfer{ID: uuid.NewV4(), DestinationID: harness.destination, NoteIDs: []uuid.UUID{n.ID}}
+	err = source.beginTransfer(pending, key)
+	if err != nil {
+		t.Fatal("Expected to record transfer - ", err)
+	}
+	err = source.Recover(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to recover transfers - ", err)
+	}
+	if _, err = source.loadNote(n.ID, key); err != nil {
+		t.Error("Expected the note to stay in the source after a rollback - ", err)
+	}
+
+	// interrupted after the destination committed - the move is completed
+	err = source.beginTransfer(pending, key)
+	if err != nil {
+		t.Fatal("Expected to record transfer - ", err)
+	}
+	err = source.writeDestination(destination, nil, []*note.Note{n}, harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to write destination - ", err)
+	}
+	err = source.Recover(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to recover transfers - ", err)
+	}
+	_, err = source.loadNote(n.ID, key)
+	expectCode(t, err, codes.ErrorRecordMissing)
+	if count := loadNotebook(t, "work", harness.source).NoteCount; count != 0 {
+		t.Error("Expected work note count of 0 after recovery but got ", count)
+	}
+	if count := loadNotebook(t, "alpha", harness.destination).NoteCount; count != 3 {
+		t.Error("Expected alpha note count of 3 after recovery but got ", count)
+	}
+}
+
+// setContainerLock locks or unlocks a shelf the way the shelf index does
+func setContainerLock(t *testing.T, containerID uuid.UUID, locked bool) {
+	nb := proxy("work", containerID)
+	handle, err := nb.getDBHandle()
+	if err != nil {
+		t.Fatal("Expected to get shelf db - ", err)
+	}
+	key, err := nb.UnsealKey(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to unseal key - ", err)
+	}
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		return lock.Save(tx, key, containerID, &lock.Lock{Locked: locked}, harness.logger)
+	})
+	if err != nil {
+		t.Fatal("Expected to save shelf lock - ", err)
+	}
+}
+
+func saveNotebook(nb *Notebook) error {
+	key, err := nb.UnsealKey(harness.passphraseKey)
+	if err != nil {
+		return err
+	}
+	return nb.Save(key)
+}
+
+func testLocks(t *testing.T) {
+	createNotebook(t, "locked", "")
+	createNotebook(t, "inner", "locked")
+	createNotebook(t, "free", "")
+	secret := createNote(t, "secret", "inner")
+
+	locked := loadNotebook(t, "locked", harness.source)
+	locked.Locked = true
+	err := saveNotebook(locked)
+	if err != nil {
+		t.Fatal("Expected to lock notebook - ", err)
+	}
+	locked.Title = title.New("renamed")
+	err = saveNotebook(locked)
+	expectCode(t, err, codes.ErrorLocked)
+	if !codes.IsApplicationError(err) {
+		t.Error("Expected ErrorLocked to be an application error")
+	}
+
+	// everything nested inside a locked notebook is locked
+	inner := loadNotebook(t, "inner", harness.source)
+	inner.Title = title.New("renamed")
+	expectCode(t, saveNotebook(inner), codes.ErrorLocked)
+	expectCode(t, proxy("inner", harness.source).Delete(harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, proxy("inner", harness.source).Move(to(harness.source, uuid.Nil), harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, proxy("inner", harness.source).Move(to(harness.destination, uuid.Nil), harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, proxy("free", harness.source).Move(to(harness.source, harness.notebooks["inner"].ID), harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, proxy("free", harness.source).MoveNote(secret.ID, to(harness.source, harness.notebooks["free"].ID), harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, proxy("free", harness.source).MoveNote(secret.ID, to(harness.destination, harness.notebooks["alpha"].ID), harness.passphraseKey), codes.ErrorLocked)
+	secret.Content = "changed"
+	expectCode(t, secret.Save(harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, secret.Delete(harness.passphraseKey), codes.ErrorLocked)
+
+	// copying out of a locked notebook is fine, copying into one isn't
+	_, err = proxy("free", harness.source).CopyNote(secret.ID, to(harness.source, harness.notebooks["free"].ID), harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to copy a note out of a locked notebook - ", err)
+	}
+	_, err = proxy("free", harness.source).Copy(to(harness.source, harness.notebooks["inner"].ID), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorLocked)
+
+	// a locked notebook can be saved to unlock it
+	locked.Locked = false
+	err = saveNotebook(locked)
+	if err != nil {
+		t.Fatal("Expected to unlock notebook - ", err)
+	}
+	err = secret.Save(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to save note in an unlocked notebook - ", err)
+	}
+
+	// a locked note can only be saved to unlock it
+	secret.Locked = true
+	err = secret.Save(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to lock note - ", err)
+	}
+	expectCode(t, secret.Save(harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, secret.Delete(harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, proxy("free", harness.source).MoveNote(secret.ID, to(harness.source, harness.notebooks["free"].ID), harness.passphraseKey), codes.ErrorLocked)
+	secret.Locked = false
+	expectCode(t, secret.Save(harness.passphraseKey), codes.ErrorLocked)
+	err = secret.Unlock(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to unlock note - ", err)
+	}
+
+	// a locked shelf locks every notebook & note inside it
+	setContainerLock(t, harness.source, true)
+	expectCode(t, secret.Save(harness.passphraseKey), codes.ErrorLocked)
+	expectCode(t, saveNotebook(loadNotebook(t, "free", harness.source)), codes.ErrorLocked)
+	expectCode(t, proxy("free", harness.source).Delete(harness.passphraseKey), codes.ErrorLocked)
+	setContainerLock(t, harness.source, false)
+	err = secret.Save(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to save note once the shelf is unlocked - ", err)
+	}
+}
+
+// testStats checks that the stats kept for a shelf & its notebooks match its notes
+func testStats(t *testing.T, containerID uuid.UUID) {
+	expected := &stats.Stats{}
+	perNotebook := make(map[uuid.UUID]int)
+	for _, n := range loadNotes(t, containerID) {
+		expected.NoteCount++
+		expected.ContentBytes += int64(len(n.Content))
+		perNotebook[n.NotebookID]++
+	}
+
+	nb := proxy("work", containerID)
+	handle, err := nb.getDBHandle()
+	if err != nil {
+		t.Fatal("Expected to get shelf db - ", err)
+	}
+	key, err := nb.UnsealKey(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to unseal key - ", err)
+	}
+	actual, err := stats.LoadContainer(handle.DB, key, containerID, harness.logger)
+	if err != nil {
+		t.Fatal("Expected to load shelf stats - ", err)
+	}
+	if actual.NoteCount != expected.NoteCount || actual.ContentBytes != expected.ContentBytes {
+		t.Error("Expected shelf stats ", expected.NoteCount, "/", expected.ContentBytes, " but got ", actual.NoteCount, "/", actual.ContentBytes)
+	}
+
+	notebooks, err := nb.LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load notebooks - ", err)
+	}
+	for _, n := range notebooks {
+		if n.NoteCount != perNotebook[n.ID] {
+			t.Error("Expected notebook [", n.Title.Title, "] to have ", perNotebook[n.ID], " notes but got ", n.NoteCount)
+		}
+	}
+}
+
+func testDelete(t *testing.T) {
+	createNotebook(t, "archive", "")
+	createNotebook(t, "old", "archive")
+	createNotebook(t, "empty", "")
+	createNote(t, "old note", "old")
+
+	// notebooks holding notebooks or notes are refused rather than orphaning them
+	expectCode(t, proxy("archive", harness.source).Delete(harness.passphraseKey), codes.ErrorNotEmpty)
+	expectCode(t, proxy("old", harness.source).Delete(harness.passphraseKey), codes.ErrorNotEmpty)
+	loadNotebook(t, "archive", harness.source)
+	loadNotebook(t, "old", harness.source)
 
+	err := proxy("empty", harness.source).Delete(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to delete empty notebook - ", err)
+	}
+	err = proxy("empty", harness.source).Load(harness.passphraseKey)
+	if err == nil {
+		t.Error("Expected deleted notebook to be gone")
+	}
 }
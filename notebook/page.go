@@ -0,0 +1,103 @@
+package notebook
+
+import (
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/query"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ItemID returns the notebook id for sorting & paging
+func (notebook *Notebook) ItemID() uuid.UUID {
+	return notebook.ID
+}
+
+// ItemTitle returns the notebook title text for sorting
+func (notebook *Notebook) ItemTitle() string {
+	if notebook.Title == nil {
+		return ""
+	}
+	return notebook.Title.Title
+}
+
+// ItemCreated returns the creation time for sorting
+func (notebook *Notebook) ItemCreated() time.Time {
+	return notebook.Created
+}
+
+// ItemUpdated returns the update time for sorting & date filters
+func (notebook *Notebook) ItemUpdated() time.Time {
+	return notebook.Updated
+}
+
+// ItemLocked returns whether the notebook is locked for the locked filter
+func (notebook *Notebook) ItemLocked() bool {
+	return notebook.Locked
+}
+
+// ItemTags returns the ids of the tags stored with the notebook for the tag filter
+func (notebook *Notebook) ItemTags() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, t := range notebook.Tags {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// sortTree orders the children at every level of the tree
+func sortTree(q *query.Query, nodes []*Node) {
+	items := make([]query.Item, 0, len(nodes))
+	byID := make(map[uuid.UUID]*Node, len(nodes))
+	for _, node := range nodes {
+		items = append(items, node.Notebook)
+		byID[node.Notebook.ID] = node
+	}
+	q.Order(items)
+	for i, item := range items {
+		nodes[i] = byID[item.ItemID()]
+		sortTree(q, nodes[i].Children)
+	}
+}
+
+// LoadPage loads a sorted page of notebooks arranged as a tree
+// Pages are made up of top level notebooks, each with its complete (sorted)
+// subtree. When the query filters notebooks the tree can't be kept intact, so
+// the matching notebooks are returned as a flat page of nodes instead.
+func (notebook *Notebook) LoadPage(q *query.Query, passphraseKey []byte) ([]*Node, string, error) {
+	notebooks, err := notebook.LoadAll(passphraseKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nodes []*Node
+	if q.Filtered() {
+		for _, n := range notebooks {
+			nodes = append(nodes, &Node{Notebook: n})
+		}
+	} else {
+		nodes = BuildTree(notebooks)
+	}
+
+	items := make([]query.Item, 0, len(nodes))
+	byID := make(map[uuid.UUID]*Node, len(nodes))
+	for _, node := range nodes {
+		items = append(items, node.Notebook)
+		byID[node.Notebook.ID] = node
+	}
+	page, err := q.Apply(items)
+	if err != nil {
+		notebook.Logger.Warn("Error applying notebook query - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorDecode)
+		return nil, "", code
+	}
+
+	result := make([]*Node, 0, len(page.Items))
+	for _, item := range page.Items {
+		node := byID[item.ItemID()]
+		sortTree(q, node.Children)
+		result = append(result, node)
+	}
+	return result, page.Next, nil
+}
@@ -0,0 +1,697 @@
+package notebook
+
+import (
+	"encoding/json"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/lock"
+	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/stats"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// Destination describes where a notebook or note is moved or copied to
+type Destination struct {
+	Scope         Scope         // Scope is whether the destination container is owned by a user or an account
+	OwnerID       uuid.UUID     // OwnerID is the user or account that owns the destination container
+	ContainerType ContainerType // ContainerType is the type of the destination container
+	ContainerID   uuid.UUID     // ContainerID is the destination shelf or collection
+	ParentID      uuid.UUID     // ParentID is the notebook to nest a notebook below, or to file a note in
+}
+
+// transfer is a journal entry for a move between two containers
+// The entry is written to the source db before anything is written to the
+// destination. The source records & the entry are then removed together once
+// the destination transaction has committed. If the process stops in between,
+// Recover uses the entry to either finish or roll back the move.
+type transfer struct {
+	ID              uuid.UUID     `json:"id"`
+	DestinationID   uuid.UUID     `json:"destination_id"`
+	DestinationType ContainerType `json:"destination_type"`
+	NotebookIDs     []uuid.UUID   `json:"notebook_ids"` // NotebookIDs are the notebooks to remove from the source
+	NoteIDs         []uuid.UUID   `json:"note_ids"`     // NoteIDs are the notes to remove from the source
+}
+
+// container returns a notebook that acts as a proxy for the destination container
+func (destination *Destination) container(notebook *Notebook) *Notebook {
+	return &Notebook{
+		OwnerID:       destination.OwnerID,
+		Scope:         destination.Scope,
+		ContainerID:   destination.ContainerID,
+		ContainerType: destination.ContainerType,
+		DBRegistry:    notebook.DBRegistry,
+		Logger:        notebook.Logger,
+	}
+}
+
+func (destination *Destination) sameContainer(notebook *Notebook) bool {
+	return destination.ContainerType == notebook.ContainerType && destination.ContainerID == notebook.ContainerID
+}
+
+// noteScope maps a notebook scope onto the matching note scope
+func noteScope(scope Scope) note.Scope {
+	if scope == ScopeAccount {
+		return note.ScopeAccount
+	}
+	return note.ScopeUser
+}
+
+// place updates the location of a notebook to match the destination
+func (destination *Destination) place(n *Notebook) {
+	n.OwnerID = destination.OwnerID
+	n.Scope = destination.Scope
+	n.ContainerID = destination.ContainerID
+	n.ContainerType = destination.ContainerType
+}
+
+// placeNote updates the location of a note to match the destination
+func (destination *Destination) placeNote(n *note.Note) {
+	n.OwnerID = destination.OwnerID
+	n.Scope = noteScope(destination.Scope)
+	n.StoreID = destination.ContainerID
+	n.StoreType = storeType(destination.ContainerType)
+}
+
+// readNote decodes a single note from the notes bucket of an open transaction
+func (notebook *Notebook) readNote(tx *bbolt.Tx, notebookKey []byte, id uuid.UUID) (*note.Note, error) {
+	bucket := tx.Bucket([]byte("notes"))
+	if bucket == nil {
+		notebook.Logger.Warn("note bucket does not exist")
+		code := codes.New(codes.ScopeNotebook, codes.ErrorBucketMissing)
+		return nil, code
+	}
+	value := bucket.Get(id.Bytes())
+	if value == nil {
+		notebook.Logger.Warn("Error finding note [", id, "]")
+		code := codes.New(codes.ScopeNotebook, codes.ErrorRecordMissing)
+		return nil, code
+	}
+
+	c := crypto.New(notebook.Logger)
+	decryptedData, err := c.Open(notebookKey, value)
+	if err != nil {
+		notebook.Logger.Warn("Error decrypting note data - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorDecrypt)
+		return nil, code
+	}
+	n := &note.Note{}
+	err = json.Unmarshal(decryptedData, n)
+	if err != nil {
+		notebook.Logger.Warn("Error decoding note json - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorDecode)
+		return nil, code
+	}
+	return n, nil
+}
+
+// adjustStats applies the arrival (delta 1) or departure (delta -1) of a note to the stats
+func (notebook *Notebook) adjustStats(tx *bbolt.Tx, notebookKey []byte, containerID uuid.UUID, n *note.Note, delta int) error {
+	size := int64(delta) * int64(len(n.Content))
+	err := stats.Adjust(tx, notebookKey, n.NotebookID, delta, size, notebook.Logger)
+	if err != nil {
+		return err
+	}
+	return stats.Adjust(tx, notebookKey, containerID, delta, size, notebook.Logger)
+}
+
+// loadSubtree reads a notebook, every notebook below it and all of their notes
+func (notebook *Notebook) loadSubtree(notebookKey []byte) ([]*Notebook, []*note.Note, error) {
+	handle, err := notebook.getDBHandle()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var subtree []*Notebook
+	var notes []*note.Note
+	err = handle.DB.View(func(tx *bbolt.Tx) error {
+		notebooks, err := notebook.readAll(tx, notebookKey)
+		if err != nil {
+			return err
+		}
+		source := findNotebook(notebooks, notebook.ID)
+		if source == nil {
+			notebook.Logger.Warn("Error finding notebook [", notebook.ID, "]")
+			code := codes.New(codes.ScopeNotebook, codes.ErrorRecordMissing)
+			return code
+		}
+		subtree = append([]*Notebook{source}, Descendants(notebooks, source.ID)...)
+		ids := make(map[uuid.UUID]bool, len(subtree))
+		for _, n := range subtree {
+			ids[n.ID] = true
+		}
+		notes, err = notebook.readNotes(tx, notebookKey, ids)
+		return err
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return nil, nil, err
+		}
+		notebook.Logger.Warn("Error loading notebook - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorLoad)
+		return nil, nil, code
+	}
+	return subtree, notes, nil
+}
+
+// loadNote reads a single note from the container
+func (notebook *Notebook) loadNote(noteID uuid.UUID, notebookKey []byte) (*note.Note, error) {
+	handle, err := notebook.getDBHandle()
+	if err != nil {
+		return nil, err
+	}
+	var n *note.Note
+	err = handle.DB.View(func(tx *bbolt.Tx) error {
+		n, err = notebook.readNote(tx, notebookKey, noteID)
+		return err
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return nil, err
+		}
+		notebook.Logger.Warn("Error loading note - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorLoad)
+		return nil, code
+	}
+	return n, nil
+}
+
+// writeDestination stores notebooks & notes in the destination container in a single transaction
+func (notebook *Notebook) writeDestination(destination *Destination, notebooks []*Notebook, notes []*note.Note, passphraseKey []byte) error {
+	target := destination.container(notebook)
+	handle, err := target.getDBHandle()
+	if err != nil {
+		return err
+	}
+	destinationKey, err := target.UnsealKey(passphraseKey)
+	if err != nil {
+		return err
+	}
+
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		existing, err := notebook.readAll(tx, destinationKey)
+		if err != nil {
+			return err
+		}
+		if destination.ParentID != uuid.Nil && findNotebook(existing, destination.ParentID) == nil {
+			notebook.Logger.Warn("Error finding destination notebook [", destination.ParentID, "]")
+			code := codes.New(codes.ScopeNotebook, codes.ErrorRecordMissing)
+			return code
+		}
+
+		err = notebook.checkUnlocked(tx, destinationKey, destination.ParentID, destination.ContainerID)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range notebooks {
+			err := notebook.putNotebook(tx, n, destinationKey)
+			if err != nil {
+				return err
+			}
+		}
+		for _, n := range notes {
+			err := notebook.put(tx, "notes", n.ID, n, destinationKey)
+			if err != nil {
+				return err
+			}
+			err = notebook.adjustStats(tx, destinationKey, destination.ContainerID, n, 1)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error writing to destination container - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
+
+// beginTransfer records a journal entry for a move in the source container
+func (notebook *Notebook) beginTransfer(t *transfer, notebookKey []byte) error {
+	handle, err := notebook.getDBHandle()
+	if err != nil {
+		return err
+	}
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		return notebook.put(tx, "transfers", t.ID, t, notebookKey)
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error recording transfer - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
+
+// endTransfer removes the records that were transferred out of the source container
+// When rollback is true only the journal entry is removed, leaving the source intact.
+func (notebook *Notebook) endTransfer(t *transfer, rollback bool, notebookKey []byte) error {
+	handle, err := notebook.getDBHandle()
+	if err != nil {
+		return err
+	}
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		if !rollback {
+			err := notebook.removeTransferred(tx, t, notebookKey)
+			if err != nil {
+				return err
+			}
+		}
+		bucket := tx.Bucket([]byte("transfers"))
+		if bucket == nil {
+			return nil
+		}
+		err := bucket.Delete(t.ID.Bytes())
+		if err != nil {
+			notebook.Logger.Warn("Error deleting transfer - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDelete)
+			return code
+		}
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error completing transfer - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorDelete)
+		return code
+	}
+	return nil
+}
+
+func (notebook *Notebook) removeTransferred(tx *bbolt.Tx, t *transfer, notebookKey []byte) error {
+	notes := tx.Bucket([]byte("notes"))
+	for _, id := range t.NoteIDs {
+		if notes == nil || notes.Get(id.Bytes()) == nil {
+			continue
+		}
+		n, err := notebook.readNote(tx, notebookKey, id)
+		if err != nil {
+			return err
+		}
+		err = notebook.adjustStats(tx, notebookKey, notebook.ContainerID, n, -1)
+		if err != nil {
+			return err
+		}
+		err = notes.Delete(id.Bytes())
+		if err != nil {
+			notebook.Logger.Warn("Error deleting transferred note - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDelete)
+			return code
+		}
+	}
+
+	bucket := tx.Bucket([]byte("notebooks"))
+	for _, id := range t.NotebookIDs {
+		if bucket == nil {
+			break
+		}
+		err := bucket.Delete(id.Bytes())
+		if err != nil {
+			notebook.Logger.Warn("Error deleting transferred notebook - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDelete)
+			return code
+		}
+		err = stats.Delete(tx, id, notebook.Logger)
+		if err != nil {
+			return err
+		}
+		err = lock.Delete(tx, id, notebook.Logger)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferOut moves records into a container that uses a different db
+func (notebook *Notebook) transferOut(destination *Destination, notebooks []*Notebook, notes []*note.Note, passphraseKey []byte, notebookKey []byte) error {
+	t := &transfer{
+		ID:              uuid.NewV4(),
+		DestinationID:   destination.ContainerID,
+		DestinationType: destination.ContainerType,
+	}
+	for _, n := range notebooks {
+		t.NotebookIDs = append(t.NotebookIDs, n.ID)
+	}
+	for _, n := range notes {
+		t.NoteIDs = append(t.NoteIDs, n.ID)
+	}
+
+	err := notebook.beginTransfer(t, notebookKey)
+	if err != nil {
+		return err
+	}
+	err = notebook.writeDestination(destination, notebooks, notes, passphraseKey)
+	if err != nil {
+		// nothing reached the destination so the source is still authoritative
+		rollbackErr := notebook.endTransfer(t, true, notebookKey)
+		if rollbackErr != nil {
+			notebook.Logger.Warn("Error rolling back transfer - ", rollbackErr)
+		}
+		return err
+	}
+	return notebook.endTransfer(t, false, notebookKey)
+}
+
+// Recover completes or rolls back any moves out of this container that were interrupted
+// A move is complete once its records exist in the destination container, in
+// which case the source copies are removed. Otherwise the journal entry is
+// simply dropped and the records stay where they were.
+func (notebook *Notebook) Recover(passphraseKey []byte) error {
+	handle, err := notebook.getDBHandle()
+	if err != nil {
+		return err
+	}
+	notebookKey, err := notebook.UnsealKey(passphraseKey)
+	if err != nil {
+		return err
+	}
+
+	var pending []*transfer
+	err = handle.DB.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("transfers"))
+		if bucket == nil {
+			return nil
+		}
+		c := crypto.New(notebook.Logger)
+		cursor := bucket.Cursor()
+		for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+			decryptedData, err := c.Open(notebookKey, value)
+			if err != nil {
+				notebook.Logger.Warn("Error decrypting transfer - ", err)
+				code := codes.New(codes.ScopeNotebook, codes.ErrorDecrypt)
+				return code
+			}
+			t := &transfer{}
+			err = json.Unmarshal(decryptedData, t)
+			if err != nil {
+				notebook.Logger.Warn("Error decoding transfer json - ", err)
+				code := codes.New(codes.ScopeNotebook, codes.ErrorDecode)
+				return code
+			}
+			pending = append(pending, t)
+		}
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error loading transfers - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorLoadAll)
+		return code
+	}
+
+	for _, t := range pending {
+		target := &Notebook{
+			ContainerID:   t.DestinationID,
+			ContainerType: t.DestinationType,
+			DBRegistry:    notebook.DBRegistry,
+			Logger:        notebook.Logger,
+		}
+		targetHandle, err := target.getDBHandle()
+		if err != nil {
+			// the destination isn't open, so there's no way to tell yet
+			notebook.Logger.Warn("Skipping transfer [", t.ID, "] - destination unavailable")
+			continue
+		}
+
+		arrived := false
+		err = targetHandle.DB.View(func(tx *bbolt.Tx) error {
+			arrived = containsAny(tx, "notebooks", t.NotebookIDs) || containsAny(tx, "notes", t.NoteIDs)
+			return nil
+		})
+		if err != nil {
+			notebook.Logger.Warn("Error checking transfer destination - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorLoad)
+			return code
+		}
+
+		err = notebook.endTransfer(t, !arrived, notebookKey)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsAny(tx *bbolt.Tx, bucketName string, ids []uuid.UUID) bool {
+	bucket := tx.Bucket([]byte(bucketName))
+	if bucket == nil {
+		return false
+	}
+	for _, id := range ids {
+		if bucket.Get(id.Bytes()) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Move re-parents a notebook, optionally into another container
+// The notebook is nested below destination.ParentID (or moved to the top level
+// when it is uuid.Nil). When the destination is a different container, the
+// notebook, every notebook nested below it and all of their notes keep their
+// ids but are re-encrypted with the destination db key.
+func (notebook *Notebook) Move(destination *Destination, passphraseKey []byte) error {
+	notebookKey, err := notebook.UnsealKey(passphraseKey)
+	if err != nil {
+		return err
+	}
+	if destination.sameContainer(notebook) {
+		sourceHandle, err := notebook.getDBHandle()
+		if err != nil {
+			return err
+		}
+		return notebook.moveWithin(sourceHandle.DB, destination.ParentID, notebookKey)
+	}
+
+	err = notebook.Recover(passphraseKey)
+	if err != nil {
+		return err
+	}
+	err = notebook.ensureUnlocked(func(tx *bbolt.Tx) error {
+		return notebook.checkUnlocked(tx, notebookKey, notebook.ID, notebook.ContainerID)
+	})
+	if err != nil {
+		return err
+	}
+	subtree, notes, err := notebook.loadSubtree(notebookKey)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, n := range subtree {
+		destination.place(n)
+		if n.ID == notebook.ID {
+			n.ParentID = destination.ParentID
+			n.Updated = now
+		}
+	}
+	for _, n := range notes {
+		destination.placeNote(n)
+	}
+
+	err = notebook.transferOut(destination, subtree, notes, passphraseKey, notebookKey)
+	if err != nil {
+		return err
+	}
+
+	destination.place(notebook)
+	notebook.ParentID = destination.ParentID
+	notebook.Updated = now
+	return nil
+}
+
+// Copy duplicates a notebook, the notebooks nested below it and all of their notes
+// The copies are given new ids. The copy of this notebook is returned.
+func (notebook *Notebook) Copy(destination *Destination, passphraseKey []byte) (*Notebook, error) {
+	notebookKey, err := notebook.UnsealKey(passphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	subtree, notes, err := notebook.loadSubtree(notebookKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ids := make(map[uuid.UUID]uuid.UUID, len(subtree))
+	for _, n := range subtree {
+		ids[n.ID] = uuid.NewV4()
+	}
+	for _, n := range subtree {
+		destination.place(n)
+		n.ID = ids[n.ID]
+		if n.ID == ids[notebook.ID] {
+			n.ParentID = destination.ParentID
+			n.Default = false
+		} else {
+			n.ParentID = ids[n.ParentID]
+		}
+		n.Created = now
+		n.Updated = now
+	}
+	for _, n := range notes {
+		destination.placeNote(n)
+		n.ID = uuid.NewV4()
+		n.NotebookID = ids[n.NotebookID]
+		n.Created = now
+		n.Updated = now
+	}
+
+	err = notebook.writeDestination(destination, subtree, notes, passphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	return subtree[0], nil
+}
+
+// missingNotebook is the validation error for a note move or copy without a destination notebook
+var missingNotebook = codes.FieldError{
+	Field:   "destinationNotebookId",
+	Reason:  codes.FieldRequired,
+	Message: "destinationNotebookId is required",
+}
+
+// MoveNote moves one of the notes in this notebook's container
+// The note is filed in the destination.ParentID notebook and keeps its id.
+func (notebook *Notebook) MoveNote(noteID uuid.UUID, destination *Destination, passphraseKey []byte) error {
+	if destination.ParentID == uuid.Nil {
+		notebook.Logger.Warn("A note must be moved into a notebook")
+		code := codes.Invalid(codes.ScopeNotebook, missingNotebook)
+		return code
+	}
+	notebookKey, err := notebook.UnsealKey(passphraseKey)
+	if err != nil {
+		return err
+	}
+
+	if destination.sameContainer(notebook) {
+		return notebook.refileNote(noteID, destination.ParentID, notebookKey)
+	}
+
+	err = notebook.Recover(passphraseKey)
+	if err != nil {
+		return err
+	}
+	n, err := notebook.loadNote(noteID, notebookKey)
+	if err != nil {
+		return err
+	}
+	err = notebook.ensureUnlocked(func(tx *bbolt.Tx) error {
+		return notebook.checkNoteUnlocked(tx, notebookKey, notebook.ContainerID, n)
+	})
+	if err != nil {
+		return err
+	}
+	destination.placeNote(n)
+	n.NotebookID = destination.ParentID
+	n.Updated = time.Now()
+
+	return notebook.transferOut(destination, nil, []*note.Note{n}, passphraseKey, notebookKey)
+}
+
+// CopyNote duplicates one of the notes in this notebook's container
+// The copy is filed in the destination.ParentID notebook and is returned.
+func (notebook *Notebook) CopyNote(noteID uuid.UUID, destination *Destination, passphraseKey []byte) (*note.Note, error) {
+	if destination.ParentID == uuid.Nil {
+		notebook.Logger.Warn("A note must be copied into a notebook")
+		code := codes.Invalid(codes.ScopeNotebook, missingNotebook)
+		return nil, code
+	}
+	notebookKey, err := notebook.UnsealKey(passphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	n, err := notebook.loadNote(noteID, notebookKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	destination.placeNote(n)
+	n.ID = uuid.NewV4()
+	n.NotebookID = destination.ParentID
+	n.Created = now
+	n.Updated = now
+
+	err = notebook.writeDestination(destination, nil, []*note.Note{n}, passphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// refileNote moves a note between two notebooks in the same container
+func (notebook *Notebook) refileNote(noteID uuid.UUID, notebookID uuid.UUID, notebookKey []byte) error {
+	handle, err := notebook.getDBHandle()
+	if err != nil {
+		return err
+	}
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		notebooks, err := notebook.readAll(tx, notebookKey)
+		if err != nil {
+			return err
+		}
+		if findNotebook(notebooks, notebookID) == nil {
+			notebook.Logger.Warn("Error finding destination notebook [", notebookID, "]")
+			code := codes.New(codes.ScopeNotebook, codes.ErrorRecordMissing)
+			return code
+		}
+		n, err := notebook.readNote(tx, notebookKey, noteID)
+		if err != nil {
+			return err
+		}
+		if n.NotebookID == notebookID {
+			return nil
+		}
+		err = notebook.checkNoteUnlocked(tx, notebookKey, notebook.ContainerID, n)
+		if err != nil {
+			return err
+		}
+		err = notebook.checkUnlocked(tx, notebookKey, notebookID)
+		if err != nil {
+			return err
+		}
+
+		// the note stays in the same container, so only the notebook stats change
+		size := int64(len(n.Content))
+		err = stats.Adjust(tx, notebookKey, n.NotebookID, -1, -size, notebook.Logger)
+		if err != nil {
+			return err
+		}
+		err = stats.Adjust(tx, notebookKey, notebookID, 1, size, notebook.Logger)
+		if err != nil {
+			return err
+		}
+		n.NotebookID = notebookID
+		n.Updated = time.Now()
+		return notebook.put(tx, "notes", n.ID, n, notebookKey)
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error moving note - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
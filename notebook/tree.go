@@ -0,0 +1,260 @@
+package notebook
+
+import (
+	"encoding/json"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/note"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// Node is a notebook along with the notebooks nested inside of it
+type Node struct {
+	Notebook *Notebook
+	Children []*Node
+}
+
+// Children returns the notebooks that are directly below parentID
+func Children(notebooks []*Notebook, parentID uuid.UUID) []*Notebook {
+	var children []*Notebook
+	for _, n := range notebooks {
+		if n.ParentID == parentID && n.ID != parentID {
+			children = append(children, n)
+		}
+	}
+	return children
+}
+
+// Descendants returns every notebook below id (depth first)
+// The notebook identified by id is not included in the result.
+func Descendants(notebooks []*Notebook, id uuid.UUID) []*Notebook {
+	var descendants []*Notebook
+	visited := map[uuid.UUID]bool{id: true}
+	var walk func(parentID uuid.UUID)
+	walk = func(parentID uuid.UUID) {
+		for _, child := range Children(notebooks, parentID) {
+			// guard against corrupt records that already form a loop
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			descendants = append(descendants, child)
+			walk(child.ID)
+		}
+	}
+	walk(id)
+	return descendants
+}
+
+// BuildTree arranges a flat list of notebooks into a tree
+// Notebooks whose parent can't be found in the list are treated as top level
+// notebooks so that nothing goes missing if a parent has been deleted.
+func BuildTree(notebooks []*Notebook) []*Node {
+	nodes := make(map[uuid.UUID]*Node, len(notebooks))
+	for _, n := range notebooks {
+		nodes[n.ID] = &Node{Notebook: n}
+	}
+
+	var roots []*Node
+	for _, n := range notebooks {
+		node := nodes[n.ID]
+		parent, ok := nodes[n.ParentID]
+		if n.ParentID == uuid.Nil || !ok || isDescendant(notebooks, n.ID, n.ParentID) {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots
+}
+
+// isDescendant tests whether candidate is id or any notebook below id
+func isDescendant(notebooks []*Notebook, id uuid.UUID, candidate uuid.UUID) bool {
+	if id == candidate {
+		return true
+	}
+	for _, n := range Descendants(notebooks, id) {
+		if n.ID == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func findNotebook(notebooks []*Notebook, id uuid.UUID) *Notebook {
+	for _, n := range notebooks {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// storeType maps a notebook container type onto the matching note store type
+func storeType(container ContainerType) note.StoreType {
+	if container == ContainerTypeCollection {
+		return note.StoreTypeCollection
+	}
+	return note.StoreTypeShelf
+}
+
+// readAll decodes every notebook in the notebook bucket of an open transaction
+func (notebook *Notebook) readAll(tx *bbolt.Tx, notebookKey []byte) ([]*Notebook, error) {
+	var notebooks []*Notebook
+	bucket := tx.Bucket([]byte("notebooks"))
+	if bucket == nil {
+		return notebooks, nil
+	}
+	c := crypto.New(notebook.Logger)
+	cursor := bucket.Cursor()
+	for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+		newNotebook := &Notebook{
+			DBRegistry: notebook.DBRegistry,
+			Logger:     notebook.Logger,
+		}
+
+		decryptedData, err := c.Open(notebookKey, value)
+		if err != nil {
+			notebook.Logger.Warn("Error decrypting notebook data - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDecrypt)
+			return nil, code
+		}
+
+		err = json.Unmarshal(decryptedData, newNotebook)
+		if err != nil {
+			notebook.Logger.Warn("Error decoding notebook json - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDecode)
+			return nil, code
+		}
+		notebooks = append(notebooks, newNotebook)
+	}
+	return notebooks, nil
+}
+
+// readNotes decodes the notes that belong to any of the given notebooks
+func (notebook *Notebook) readNotes(tx *bbolt.Tx, notebookKey []byte, notebookIDs map[uuid.UUID]bool) ([]*note.Note, error) {
+	var notes []*note.Note
+	bucket := tx.Bucket([]byte("notes"))
+	if bucket == nil {
+		return notes, nil
+	}
+	c := crypto.New(notebook.Logger)
+	cursor := bucket.Cursor()
+	for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+		decryptedData, err := c.Open(notebookKey, value)
+		if err != nil {
+			notebook.Logger.Warn("Error decrypting note data - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDecrypt)
+			return nil, code
+		}
+
+		n := &note.Note{}
+		err = json.Unmarshal(decryptedData, n)
+		if err != nil {
+			notebook.Logger.Warn("Error decoding note json - ", err)
+			code := codes.New(codes.ScopeNotebook, codes.ErrorDecode)
+			return nil, code
+		}
+		if notebookIDs[n.NotebookID] {
+			notes = append(notes, n)
+		}
+	}
+	return notes, nil
+}
+
+// put encodes a record and writes it into a bucket, creating the bucket if needed
+func (notebook *Notebook) put(tx *bbolt.Tx, bucketName string, id uuid.UUID, record interface{}, encryptionKey []byte) error {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(bucketName))
+	if err != nil {
+		notebook.Logger.Warn("Error creating ", bucketName, " bucket - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorCreateBucket)
+		return code
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		notebook.Logger.Warn("Error marshaling ", bucketName, " record - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorMarshal)
+		return code
+	}
+
+	c := crypto.New(notebook.Logger)
+	encryptedData, err := c.Seal(encryptionKey, data)
+	if err != nil {
+		notebook.Logger.Warn("Error encrypting ", bucketName, " record - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorEncrypt)
+		return code
+	}
+
+	err = bucket.Put(id.Bytes(), encryptedData)
+	if err != nil {
+		notebook.Logger.Warn("Error writing ", bucketName, " record - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorWriteBucket)
+		return code
+	}
+	return nil
+}
+
+// LoadTree loads all of the notebooks in the container arranged as a tree
+func (notebook *Notebook) LoadTree(passphraseKey []byte) ([]*Node, error) {
+	notebooks, err := notebook.LoadAll(passphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTree(notebooks), nil
+}
+
+// moveWithin re-parents a notebook without leaving its container
+func (notebook *Notebook) moveWithin(handle *bbolt.DB, parentID uuid.UUID, notebookKey []byte) error {
+	err := handle.Update(func(tx *bbolt.Tx) error {
+		notebooks, err := notebook.readAll(tx, notebookKey)
+		if err != nil {
+			return err
+		}
+		source := findNotebook(notebooks, notebook.ID)
+		if source == nil {
+			notebook.Logger.Warn("Error finding notebook to move [", notebook.ID, "]")
+			code := codes.New(codes.ScopeNotebook, codes.ErrorRecordMissing)
+			return code
+		}
+		err = notebook.checkUnlocked(tx, notebookKey, source.ID, source.ContainerID, parentID)
+		if err != nil {
+			return err
+		}
+		if parentID != uuid.Nil {
+			if findNotebook(notebooks, parentID) == nil {
+				notebook.Logger.Warn("Error finding new parent notebook [", parentID, "]")
+				code := codes.New(codes.ScopeNotebook, codes.ErrorRecordMissing)
+				return code
+			}
+			if isDescendant(notebooks, source.ID, parentID) {
+				notebook.Logger.Warn("Moving notebook [", source.ID, "] below [", parentID, "] would create a cycle")
+				code := codes.New(codes.ScopeNotebook, codes.ErrorCycle)
+				return code
+			}
+		}
+
+		source.ParentID = parentID
+		source.Updated = time.Now()
+		err = notebook.putNotebook(tx, source, notebookKey)
+		if err != nil {
+			return err
+		}
+		notebook.ParentID = source.ParentID
+		notebook.Updated = source.Updated
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		notebook.Logger.Warn("Error moving notebook - ", err)
+		code := codes.New(codes.ScopeNotebook, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package passphrase
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// PrefixLength is the number of hex characters of a hash used to pick its range
+const PrefixLength = 5
+
+// BreachList is a list of the SHA-1 hashes of passphrases known from breaches
+// Hashes are grouped into ranges by their first 5 hex characters, the same
+// k-anonymity scheme as the Pwned Passwords range API.  A list can be loaded
+// from a single file of full hashes, or from a directory of range files named
+// by prefix (e.g. 5BAA6.txt) holding the remaining 35 characters of each hash,
+// which is the form the range API returns.  Range files are only read when a
+// passphrase with their prefix is checked, so a full copy of the list doesn't
+// have to fit in memory.
+type BreachList struct {
+	Path   string // Path is the file or directory the list was loaded from (empty for the bundled list)
+	dir    bool
+	lock   sync.Mutex
+	ranges map[string]map[string]bool
+}
+
+// Bundled returns the built-in list of the most common breached passwords
+func Bundled() *BreachList {
+	list := &BreachList{ranges: make(map[string]map[string]bool)}
+	for _, hash := range bundledHashes {
+		list.add(hash)
+	}
+	return list
+}
+
+// LoadBreachList loads a breach list from a file or a directory of range files
+func LoadBreachList(path string) (*BreachList, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	list := &BreachList{Path: path, dir: info.IsDir(), ranges: make(map[string]map[string]bool)}
+	if list.dir {
+		return list, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	err = readHashes(file, "", list.add)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Contains is whether a passphrase is in the list
+func (list *BreachList) Contains(passphrase string) (bool, error) {
+	sum := sha1.Sum([]byte(passphrase))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:PrefixLength], hash[PrefixLength:]
+
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	suffixes, ok := list.ranges[prefix]
+	if !ok && list.dir {
+		var err error
+		suffixes, err = list.loadRange(prefix)
+		if err != nil {
+			return false, err
+		}
+	}
+	return suffixes[suffix], nil
+}
+
+// loadRange reads the range file for a prefix, caching it for later checks
+// A missing range file is an empty range.
+func (list *BreachList) loadRange(prefix string) (map[string]bool, error) {
+	suffixes := make(map[string]bool)
+	for _, name := range []string{prefix + ".txt", prefix} {
+		file, err := os.Open(filepath.Join(list.Path, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = readHashes(file, prefix, func(hash string) {
+			suffixes[hash[PrefixLength:]] = true
+		})
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	list.ranges[prefix] = suffixes
+	return suffixes, nil
+}
+
+// add adds a full hash to the list
+func (list *BreachList) add(hash string) {
+	prefix := hash[:PrefixLength]
+	if list.ranges[prefix] == nil {
+		list.ranges[prefix] = make(map[string]bool)
+	}
+	list.ranges[prefix][hash[PrefixLength:]] = true
+}
+
+// readHashes reads one hash per line, ignoring blank lines & anything after a colon (the breach count)
+// The prefix is added to the start of each line, for range files.
+func readHashes(reader io.Reader, prefix string, add func(hash string)) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			line = line[:i]
+		}
+		hash := strings.ToUpper(prefix + line)
+		if len(hash) != sha1.Size*2 {
+			continue
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			continue
+		}
+		add(hash)
+	}
+	return scanner.Err()
+}
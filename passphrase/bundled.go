@@ -0,0 +1,135 @@
+package passphrase
+
+// bundledHashes are the SHA-1 hashes of the most common passwords in public breach corpora
+// They are checked even when no breach list is configured, so the passwords
+// every guessing tool tries first are always refused.
+var bundledHashes = []string{
+	"011C945F30CE2CBAFC452F39840F025693339C42",
+	"019DB0BFD5F85951CB46E4452E9642858C004155",
+	"01B307ACBA4F54F55AAFC33BB06BBBF6CA803E9A",
+	"02E0A999C50B1F88DF7A8F5A04E1B76B35EA6A88",
+	"043A558250409758B64F73D07D7F06B3DF654BC0",
+	"05FE7461C607C33229772D402505601016A7D0EA",
+	"08B314F0E1E2C41EC92C3735910658E5A82C6BA7",
+	"091B5035885C00170FEC9ECF24224933E3DE3FCC",
+	"0F12541AFCCE175FB34BB05A79C95B76E765488B",
+	"12E9293EC6B30C7FA8A0926AF42807E929C1684F",
+	"1411678A0B9E25EE2F7C8B2F7AC92B6A74B3F9C5",
+	"17B9E1C64588C7FA6419B4D29DC1F4426279BA01",
+	"18C28604DD31094A8D69DAE60F1BCD347F1AFC5A",
+	"1999E4893F732BA38B948DBE8D34ED48CD54F058",
+	"1CB5BD5A9E45420321F44C72DA5D90D7F0432FFB",
+	"20EABE5D64B0E216796E834F52D61FD0B70332FC",
+	"2394EEAC9FC3DB56189A894E221220B6089E78D3",
+	"23F2916E01209D6282F226BE9677AFFAEC44A8D6",
+	"2736FAB291F04E69B62D490C3C09361F5B82461A",
+	"2D27B62C597EC858F6E7B54E7E58525E6A95E6D8",
+	"3179A65EFF2523BBDE53C99B299B719C10A35235",
+	"327156AB287C6AA52C8670E13163FC1BF660ADD4",
+	"3ACD0BE86DE7DCCCDBF91B20F94A68CEA535922D",
+	"3D0F3B9DDCACEC30C4008C5E030E6C13A478CB4F",
+	"3D4F2BF07DC1BE38B20CD6E46949A1071F9D0E3D",
+	"3F3C58AE42B9B422897FFC175014A2A4FCF16D7B",
+	"3FCFC1F7F34E78A937E81171BA51DC39538DB993",
+	"40123E9C6273385EA69892C48C80AA6CB25B9113",
+	"48058E0C99BF7D689CE71C360699A14CE2F99774",
+	"48EFC4851E15940AF5D477D3C0CE99211A70A3BE",
+	"4BE30D9814C6D4E9800E0D2EA9EC9FB00EFA887B",
+	"4D9012B4A77A9524D675DAD27C3276AB5705E5E8",
+	"4F26AEAFDB2367620A393C973EDDBE8F8B846EBD",
+	"57B2AD99044D337197C0C39FD3823568FF81E48A",
+	"59033478180D07080D5E4F3BAA0099996C364162",
+	"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8",
+	"5C17FA03E6D5FC247565E1CD8FFA70E1BFE5B8D9",
+	"5C6D9EDC3A951CDA763F650235CFC41A3FC23FE8",
+	"5CEC175B165E3D5E62C9E13CE848EF6FEAC81BFF",
+	"5D74AE093A16A00E5AF127763F2DC7E13988F162",
+	"5F50A84C1FA3BCFF146405017F36AEC1A10A9E38",
+	"5FA339BBBB1EEACED3B52E54F44576AAF0D77D96",
+	"5FEE00239940F883D4C2854E41C7F989E75278A3",
+	"601F1889667EFAEBB33B8C12572835DA3F027F78",
+	"6210274434052F20FCB83E1D0F492204FCB11884",
+	"6367C48DD193D56EA7B0BAAD25B19455E529F5EE",
+	"6420ED4D831B436D1E92D25605D18297296374E3",
+	"64356BCFAE350C970263C1CE575185B289F7B836",
+	"6C616F7C2D2FDE9018A09F06EAEFCFC7582BC7BA",
+	"6E2F9E6111E77EDD0C446EA7A84E25323D137A61",
+	"701B389B848A2B1CFAB867093101D8D5AC56ADDD",
+	"7110EDA4D09E062AA5E4A390B0A572AC0D2C0220",
+	"7212A9E01329EA93A57F574BD9BF77695D5FDCA4",
+	"721D65122734734800A1EDD6E68C03210E7B2ACA",
+	"74A871ACBF060DDA5FC7260D05A5924A34E4C0E7",
+	"775BB961B81DA1CA49217A48E533C832C337154A",
+	"782F9B10621E362D5BD0DEF3A279B5E0908C9EBB",
+	"7AB515D12BD2CF431745511AC4EE13FED15AB578",
+	"7C222FB2927D828AF22F592134E8932480637C0D",
+	"7C4A8D09CA3762AF61E59520943DC26494F8941B",
+	"7C6A61C68EF8B9B6B061B28C348BC1ED7921CB53",
+	"7D8F4B4B4613DC7E15333E6449692AD4AF502D1D",
+	"7EA35D812706D9213868749011AF1ED4FA2F6AA0",
+	"7ECFD8F97B4729C6FF0799B0B4D40F870083B461",
+	"8C258085654083B891CB5125CB6DCB740C8A73F8",
+	"8CB2237D0679CA88DB6464EAC60DA96345513964",
+	"8D6E34F987851AA599257D3831A1AF040886842F",
+	"92119E2C63E9366ACFEFE818B50537A85577E2DB",
+	"93EC71B22793A81569C94CA17E4D9C293D8E201F",
+	"97BBC79679FE1CFD9AFB52FD6F01D033B479555D",
+	"99996B911567C83CCE17CDF194F314975C57DDF1",
+	"9D4E1E23BD5B727046A9E3B4B7DB57BD8D6EE684",
+	"9F2FEB0F1EF425B292F2F94BC8482494DF430413",
+	"9FD8DE5FC2A7C2C0D469B2FFF1AFDE4E5DEF37BA",
+	"A2C901C8C6DEA98958C219F6F2D038C44DC5D362",
+	"A4AC914C09D7C097FE1F4F96B897E625B6922069",
+	"A642A77ABD7D4F51BF9226CEAF891FCBB5B299B8",
+	"A6F375A196CD4C89C41DBB4500553EBF3BAB0A41",
+	"AB87D24BDC7452E55738DEB5F868E1F16DEA5ACE",
+	"AC137C6AE0947718332991E7CB2F50EB20B62AAA",
+	"AF8978B1797B72ACFFF9595A5A2A373EC3D9106D",
+	"B0399D2029F64D445BD131FFAA399A42D2F8E7DC",
+	"B1B3773A05C0ED0176787A4F1574FF0075F7521E",
+	"B7A875FC1EA228B9061041B7CEC4BD3C52AB3CE3",
+	"B7C40B9C66BC88D38A59E554C639D743E77F1B65",
+	"B80A9AED8AF17118E51D4D0C2D7872AE26E2109E",
+	"BADCFA3C62742B3BCC1DCD893E78713BD36AA430",
+	"BCEF7A046258082993759BADE995B3AE8BEE26C7",
+	"BDE4FCFE6CC9FBF17E4812357CF570F80AE4718B",
+	"BF2F749E80C970F50552E9D5F3E8434E78B88D35",
+	"BFD3617727EAB0E800E62A776C76381DEFBC4145",
+	"BFE54CAA6D483CC3887DCE9D1B8EB91408F1EA7A",
+	"C0B137FE2D792459F26FF763CCE44574A5B5AB03",
+	"C53255317BB11707D0F614696B3CE6F221D0E2F2",
+	"C60266A8ADAD2F8EE67D793B4FD3FD0FFD73CC61",
+	"C6922B6BA9E0939583F973BC1682493351AD4FE8",
+	"C984AED014AEC7623A54F0591DA07A85FD4B762D",
+	"CB45C671CBC500627EA424EEA5F91996221B5935",
+	"CBFDAC6008F9CAB4083784CBD1874F76618D2A97",
+	"CDF547ED4C64E6994AF35CFCD69C4204C9227A97",
+	"CEDF41FCCB586DC39E1CE34BB482F0AFE557B49F",
+	"D033E22AE348AEB5660FC2140AEC35850C4DA997",
+	"D04C1675B232C6ECE69ED95E189E95D589F217B0",
+	"D6955D9721560531274CB8F50FF595A9BD39D66F",
+	"D8CD10B920DCBDB5163CA0185E402357BC27C265",
+	"DD08B58E1D30DAD48D37A35A8760CFFE8D756CFA",
+	"DD5FEF9C1C1DA1394D6D34B248C51BE2AD740840",
+	"E0C95748A455C27A80FD289269120D4944D1F318",
+	"E35BECE6C5E6E0E86CA51D0440E92282A9D6AC8A",
+	"E38AD214943DAAD1D64C102FAEC29DE4AFE9DA3D",
+	"E3CD9F6469FC3E1ACFB9F2BDBFC5A3D2BBB8E2AD",
+	"E5E9FA1BA31ECD1AE84F75CAAA474F3A663F05F4",
+	"E6852777C0260493DE41FB43918AB07BBB3A659C",
+	"E68E11BE8B70E435C65AEF8BA9798FF7775C361E",
+	"E8126C64C3486E84081FFFAD6A0AB22D4267BB41",
+	"ED9D3D832AF899035363A69FD53CD3BE8F71501C",
+	"EE8D8728F435FD550F83852AABAB5234CE1DA528",
+	"F2847B1BD9624F927E979C1846D9FE17DD65F518",
+	"F32157A45887E4FE5ADC0B5198F7EC4920A526D7",
+	"F4EE7415066B23ED0C5555E3A10AA76726A995D7",
+	"F7A9E24777EC23212C54D7A350BC5BEA5477FDBB",
+	"F7C3BC1D808E04732ADF679965CCC34CA7AE3441",
+	"F80D0CA101E967B50B730DDF8E8ACA0DE85E8DF6",
+	"F865B53623B121FD34EE5426C792E5C33AF8C227",
+	"FA9BEB99E4029AD5A6615399E7BBAE21356086B3",
+	"FAC673092FBDCAB2CD92EFC19675F2750ED97CA1",
+	"FBA9F1C9AE2A8AFE7815C9CDD492512622A66302",
+	"FC84AAA687374AED41957693F32664E5F4981862",
+}
@@ -0,0 +1,130 @@
+package passphrase
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+)
+
+// hashOf returns the upper case hex SHA-1 of a passphrase
+func hashOf(passphrase string) string {
+	sum := sha1.Sum([]byte(passphrase))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func TestCheck(t *testing.T) {
+	policy, err := NewPolicy(DefaultMinLength, DefaultMinEntropy, []string{`giraffe`})
+	if err != nil {
+		t.Fatal("Error creating policy - ", err)
+	}
+	tests := []struct {
+		passphrase string
+		code       codes.Code
+		reason     string
+	}{
+		{"", codes.ErrorPassphraseTooShort, codes.FieldTooShort},
+		{"Xk9#p", codes.ErrorPassphraseTooShort, codes.FieldTooShort},
+		{"12345678901234", codes.ErrorPassphraseBanned, codes.FieldBanned},
+		{"my P@ssw0rd is long", codes.ErrorPassphraseBanned, codes.FieldBanned},
+		{"tall Giraffe 42 walks", codes.ErrorPassphraseBanned, codes.FieldBanned},
+		{"Dana's notes on Mars", codes.ErrorPassphraseBanned, codes.FieldBanned},
+		{"notes by mr Smith", codes.ErrorPassphraseBanned, codes.FieldBanned},
+		{"example of a good one", codes.ErrorOK, ""},
+		{"passphrase", codes.ErrorPassphraseBreached, codes.FieldBreached},
+		{"abcdefghijkl", codes.ErrorPassphraseWeak, codes.FieldWeak},
+		{"zzzzzzzzzzzzzzzz", codes.ErrorPassphraseWeak, codes.FieldWeak},
+		{"lantern orbit 7 harbour", codes.ErrorOK, ""},
+	}
+	for _, test := range tests {
+		err := policy.Check(test.passphrase, "dana", "dana.smith@example.com")
+		if test.code == codes.ErrorOK {
+			if err != nil {
+				t.Error("Expected [", test.passphrase, "] to be accepted but got ", err)
+			}
+			continue
+		}
+		internal, ok := err.(*codes.InternalError)
+		if !ok || internal.Code != test.code || internal.Type != codes.TypeApplication {
+			t.Error("Expected [", test.passphrase, "] to fail with [", test.code.Reason(), "] but got ", err)
+			continue
+		}
+		if len(internal.Details) != 1 || internal.Details[0].Field != "passphrase" || internal.Details[0].Reason != test.reason {
+			t.Error("Expected [", test.passphrase, "] to be reported as [", test.reason, "] but got ", internal.Details)
+		}
+	}
+
+	_, err = NewPolicy(DefaultMinLength, DefaultMinEntropy, []string{`(`})
+	if err == nil {
+		t.Error("Expected an invalid banned pattern to be refused")
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	if Entropy("") != 0 {
+		t.Error("Expected no entropy for an empty passphrase")
+	}
+	if Entropy("aaaaaaaaaaaa") >= Entropy("ahfjqowmzpxb") {
+		t.Error("Expected repeated characters to be worth less than varied ones")
+	}
+	if Entropy("abcdefgh") >= Entropy("agdbhcfe") || Entropy("87654321") >= Entropy("82746153") {
+		t.Error("Expected runs of characters to be worth less than shuffled ones")
+	}
+	if Entropy("Lantern7!") <= Entropy("lantern7!") {
+		t.Error("Expected more character classes to be worth more")
+	}
+}
+
+func TestBreachList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-breach")
+	if err != nil {
+		t.Fatal("Error creating breach list directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	breached := "lantern orbit 7 harbour"
+	hash := hashOf(breached)
+	file := filepath.Join(dir, "hashes.txt")
+	err = ioutil.WriteFile(file, []byte("not a hash\n"+strings.ToLower(hash)+":12\n"), 0600)
+	if err != nil {
+		t.Fatal("Error writing breach list - ", err)
+	}
+	ranges := filepath.Join(dir, "ranges")
+	os.Mkdir(ranges, 0700)
+	err = ioutil.WriteFile(filepath.Join(ranges, hash[:PrefixLength]+".txt"), []byte(hash[PrefixLength:]+":3\r\n"), 0600)
+	if err != nil {
+		t.Fatal("Error writing range file - ", err)
+	}
+
+	for _, path := range []string{file, ranges} {
+		list, err := LoadBreachList(path)
+		if err != nil {
+			t.Fatal("Error loading breach list [", path, "] - ", err)
+		}
+		for passphrase, want := range map[string]bool{breached: true, "lantern orbit 8 harbour": false} {
+			got, err := list.Contains(passphrase)
+			if err != nil || got != want {
+				t.Error("Expected [", passphrase, "] in [", path, "] to be ", want, " but got ", got, " - ", err)
+			}
+		}
+	}
+	if _, err := LoadBreachList(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected a missing breach list to fail to load")
+	}
+
+	policy := DefaultPolicy()
+	list, _ := LoadBreachList(ranges)
+	policy.Breached = append(policy.Breached, list)
+	err = policy.Check(breached)
+	if internal, ok := err.(*codes.InternalError); !ok || internal.Code != codes.ErrorPassphraseBreached {
+		t.Error("Expected a passphrase on a loaded breach list to be refused but got ", err)
+	}
+	if ok, _ := Bundled().Contains("password123"); !ok {
+		t.Error("Expected common passwords in the bundled list")
+	}
+}
@@ -0,0 +1,188 @@
+// Package passphrase decides whether a passphrase is strong enough to protect an account
+// A passphrase is checked for length, matched against banned patterns & the
+// user's own details, looked up in the breach lists & finally given a rough
+// entropy estimate.  The first check that fails is returned as an application
+// error with its own code, so the frontend can show guidance for it.
+package passphrase
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"notekeeper-electron-backend/codes"
+)
+
+// Policy defaults
+const (
+	DefaultMinLength  = 8
+	DefaultMinEntropy = 40
+	// MinPersonalLength is the shortest name or email part that a passphrase can't contain
+	MinPersonalLength = 3
+)
+
+// DefaultBannedPatterns are the patterns of passphrases that are easy to guess despite their length
+// Patterns are matched case-insensitively anywhere in the passphrase.
+var DefaultBannedPatterns = []string{
+	`^[0-9]+$`,
+	`p[a@4][s$5][s$5]w[o0]rd`,
+	`qwert|asdfg|zxcvb|qazwsx|1qaz2wsx`,
+	`letmein|iloveyou|trustno1|welcome|admin`,
+	`notekeeper`,
+}
+
+// Policy is the set of rules a new passphrase has to follow
+type Policy struct {
+	MinLength      int              // MinLength is the minimum number of characters
+	MinEntropy     float64          // MinEntropy is the minimum estimated entropy in bits
+	BannedPatterns []*regexp.Regexp // BannedPatterns are matched against the whole passphrase
+	Breached       []*BreachList    // Breached are the lists of breached passphrases to refuse
+}
+
+// DefaultPolicy returns the policy used when none is configured
+func DefaultPolicy() *Policy {
+	policy, _ := NewPolicy(DefaultMinLength, DefaultMinEntropy, nil)
+	return policy
+}
+
+// NewPolicy creates a policy with the default banned patterns & breach list along with any extra patterns
+func NewPolicy(minLength int, minEntropy float64, patterns []string) (*Policy, error) {
+	policy := &Policy{
+		MinLength:  minLength,
+		MinEntropy: minEntropy,
+		Breached:   []*BreachList{Bundled()},
+	}
+	for _, pattern := range append(append([]string{}, DefaultBannedPatterns...), patterns...) {
+		regex, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid banned pattern [%s] - %v", pattern, err)
+		}
+		policy.BannedPatterns = append(policy.BannedPatterns, regex)
+	}
+	return policy, nil
+}
+
+// Check checks a new passphrase against the policy
+// personal holds the account name & email address, which the passphrase
+// can't contain.  A breach list that can't be read is an error rather than
+// a pass, so a passphrase is never accepted without being checked.
+func (policy *Policy) Check(passphrase string, personal ...string) error {
+	if utf8.RuneCountInString(passphrase) < policy.MinLength {
+		return rejected(codes.ErrorPassphraseTooShort, codes.FieldTooShort,
+			fmt.Sprintf("passphrase must be at least %d characters", policy.MinLength))
+	}
+
+	lower := strings.ToLower(passphrase)
+	for _, pattern := range policy.BannedPatterns {
+		if pattern.MatchString(passphrase) {
+			return rejected(codes.ErrorPassphraseBanned, codes.FieldBanned,
+				"passphrase contains a common word or keyboard pattern")
+		}
+	}
+	for _, part := range personalParts(personal) {
+		if strings.Contains(lower, part) {
+			return rejected(codes.ErrorPassphraseBanned, codes.FieldBanned,
+				"passphrase can't contain the account name or email address")
+		}
+	}
+
+	for _, list := range policy.Breached {
+		breached, err := list.Contains(passphrase)
+		if err != nil {
+			return err
+		}
+		if breached {
+			return rejected(codes.ErrorPassphraseBreached, codes.FieldBreached,
+				"passphrase has appeared in a data breach")
+		}
+	}
+
+	if Entropy(passphrase) < policy.MinEntropy {
+		return rejected(codes.ErrorPassphraseWeak, codes.FieldWeak,
+			"passphrase is too easy to guess - try a longer one mixing words, numbers & symbols")
+	}
+	return nil
+}
+
+// rejected creates the application error for a passphrase that fails the policy
+func rejected(code codes.Code, reason string, message string) error {
+	err := codes.NewApplication(codes.ScopeAccount, code)
+	err.Details = []codes.FieldError{{Field: "passphrase", Reason: reason, Message: message}}
+	return err
+}
+
+// personalParts splits names & email addresses into the lower case parts a passphrase can't contain
+// Only the local part of an email address is used, as the domain is usually shared.
+func personalParts(personal []string) []string {
+	var parts []string
+	for _, p := range personal {
+		if at := strings.LastIndex(p, "@"); at >= 0 {
+			p = p[:at]
+		}
+		for _, part := range strings.FieldsFunc(strings.ToLower(p), func(r rune) bool {
+			return r == '@' || r == '.' || r == '_' || r == '-' || r == '+' || unicode.IsSpace(r)
+		}) {
+			if utf8.RuneCountInString(part) >= MinPersonalLength {
+				parts = append(parts, part)
+			}
+		}
+	}
+	return parts
+}
+
+// Entropy estimates the entropy of a passphrase in bits
+// Each character is worth the bits needed to pick it from the character
+// classes the passphrase uses (lower & upper case letters, digits, symbols &
+// everything else), except that a character repeating the one before it or
+// continuing a run (abc, 321) is only worth a single bit.  This is a rough
+// estimate that rewards length, not a measure of how a guesser would do.
+func Entropy(passphrase string) float64 {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range passphrase {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < utf8.RuneSelf && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	bits := math.Log2(float64(pool))
+	entropy := 0.0
+	previous, step := rune(-1), rune(0)
+	for _, r := range passphrase {
+		switch {
+		case r == previous:
+			entropy++
+		case previous >= 0 && (r-previous == 1 || r-previous == -1) && (step == 0 || step == r-previous):
+			entropy++
+			step = r - previous
+		default:
+			entropy += bits
+			step = 0
+		}
+		previous = r
+	}
+	return entropy
+}
@@ -295,6 +295,61 @@ func (m *UnlockAccountRequest) GetPassphrase() string {
 	return ""
 }
 
+type ChangePassphraseRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Passphrase           string         `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	NewPassphrase        string         `protobuf:"bytes,3,opt,name=newPassphrase,proto3" json:"newPassphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ChangePassphraseRequest) Reset()         { *m = ChangePassphraseRequest{} }
+func (m *ChangePassphraseRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangePassphraseRequest) ProtoMessage()    {}
+func (*ChangePassphraseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8e28828dcb8d24f0, []int{5}
+}
+
+func (m *ChangePassphraseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangePassphraseRequest.Unmarshal(m, b)
+}
+func (m *ChangePassphraseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangePassphraseRequest.Marshal(b, m, deterministic)
+}
+func (m *ChangePassphraseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangePassphraseRequest.Merge(m, src)
+}
+func (m *ChangePassphraseRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangePassphraseRequest.Size(m)
+}
+func (m *ChangePassphraseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangePassphraseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangePassphraseRequest proto.InternalMessageInfo
+
+func (m *ChangePassphraseRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ChangePassphraseRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *ChangePassphraseRequest) GetNewPassphrase() string {
+	if m != nil {
+		return m.NewPassphrase
+	}
+	return ""
+}
+
 type SigninAccountRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Name                 string         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -309,7 +364,7 @@ func (m *SigninAccountRequest) Reset()         { *m = SigninAccountRequest{} }
 func (m *SigninAccountRequest) String() string { return proto.CompactTextString(m) }
 func (*SigninAccountRequest) ProtoMessage()    {}
 func (*SigninAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8e28828dcb8d24f0, []int{5}
+	return fileDescriptor_8e28828dcb8d24f0, []int{6}
 }
 
 func (m *SigninAccountRequest) XXX_Unmarshal(b []byte) error {
@@ -364,32 +419,34 @@ func init() {
 	proto.RegisterType((*UserId)(nil), "notekeeper.UserId")
 	proto.RegisterType((*UserIdResponse)(nil), "notekeeper.UserIdResponse")
 	proto.RegisterType((*UnlockAccountRequest)(nil), "notekeeper.UnlockAccountRequest")
+	proto.RegisterType((*ChangePassphraseRequest)(nil), "notekeeper.ChangePassphraseRequest")
 	proto.RegisterType((*SigninAccountRequest)(nil), "notekeeper.SigninAccountRequest")
 }
 
 func init() { proto.RegisterFile("account.proto", fileDescriptor_8e28828dcb8d24f0) }
 
 var fileDescriptor_8e28828dcb8d24f0 = []byte{
-	// 324 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x92, 0xc1, 0x4e, 0x3a, 0x31,
-	0x10, 0xc6, 0xb3, 0xc0, 0x7f, 0xc3, 0x0e, 0x7f, 0x39, 0x34, 0x1b, 0xb3, 0x12, 0x63, 0xc8, 0x1e,
-	0x0c, 0x27, 0x12, 0xf1, 0x6e, 0x62, 0xbc, 0xc8, 0xb5, 0x84, 0x07, 0xa8, 0xdb, 0x09, 0x34, 0xb0,
-	0xed, 0xda, 0x96, 0x44, 0x9f, 0xc3, 0xc4, 0xe7, 0x35, 0x3b, 0x2d, 0x82, 0x78, 0xe4, 0xe2, 0x6d,
-	0xbf, 0x99, 0xaf, 0xb3, 0xbf, 0x7e, 0x53, 0xb8, 0x10, 0x55, 0x65, 0x76, 0xda, 0x4f, 0x1b, 0x6b,
-	0xbc, 0x61, 0xa0, 0x8d, 0xc7, 0x0d, 0x62, 0x83, 0x76, 0xf4, 0xbf, 0x32, 0x75, 0x6d, 0x74, 0xe8,
-	0x94, 0x9f, 0x09, 0xe4, 0x8f, 0xc1, 0xbb, 0xf0, 0xc2, 0x23, 0x47, 0xd7, 0x18, 0xed, 0x90, 0xcd,
-	0x20, 0x5d, 0xa3, 0x90, 0x68, 0x8b, 0x64, 0x9c, 0x4c, 0x06, 0xb3, 0xd1, 0xf4, 0x30, 0x63, 0xba,
-	0x77, 0x3d, 0x93, 0x83, 0x47, 0x27, 0x1b, 0x41, 0xdf, 0xa9, 0x95, 0x46, 0x39, 0xd7, 0x45, 0x67,
-	0x9c, 0x4c, 0xfa, 0xfc, 0x5b, 0xb3, 0x4b, 0x48, 0xb7, 0xa6, 0xda, 0xa0, 0x2c, 0xba, 0xd4, 0x89,
-	0xaa, 0xad, 0xe3, 0x9b, 0x72, 0xde, 0x15, 0xbd, 0x50, 0x0f, 0xaa, 0xfc, 0x48, 0x20, 0x7f, 0xb2,
-	0x28, 0x3c, 0x46, 0x3c, 0x8e, 0xaf, 0x3b, 0x74, 0x9e, 0xdd, 0x9d, 0x80, 0x5d, 0xfd, 0x04, 0x23,
-	0xd3, 0x09, 0x17, 0x83, 0x9e, 0x16, 0x35, 0x12, 0x53, 0xc6, 0xe9, 0x9b, 0xe5, 0xf0, 0x0f, 0x6b,
-	0xa1, 0xb6, 0x84, 0x93, 0xf1, 0x20, 0xd8, 0x0d, 0x40, 0x23, 0x9c, 0x6b, 0xd6, 0x56, 0x38, 0x24,
-	0xa2, 0x8c, 0x1f, 0x55, 0xca, 0x07, 0x48, 0x97, 0x0e, 0xed, 0x5c, 0xb2, 0x6b, 0xc8, 0x62, 0xc6,
-	0x73, 0x49, 0x24, 0x19, 0x3f, 0x14, 0xda, 0x5b, 0xed, 0xc8, 0x17, 0xff, 0x19, 0x55, 0xb9, 0x85,
-	0x61, 0x38, 0x7f, 0x56, 0xce, 0xb7, 0xd0, 0x6b, 0xe7, 0xd1, 0xec, 0xc1, 0x8c, 0x1d, 0x9f, 0x88,
-	0xd3, 0xa9, 0x5f, 0xbe, 0x43, 0xbe, 0xd4, 0x6d, 0xce, 0xe7, 0x47, 0x38, 0x84, 0x8e, 0xda, 0x5f,
-	0xa6, 0xa3, 0xe4, 0x49, 0x50, 0xdd, 0x5f, 0x41, 0xb5, 0xeb, 0x5b, 0xa8, 0x95, 0x56, 0xfa, 0x0f,
-	0xad, 0xef, 0x25, 0xa5, 0x47, 0x7f, 0xff, 0x15, 0x00, 0x00, 0xff, 0xff, 0xe7, 0xa5, 0xae, 0xe3,
-	0x1f, 0x03, 0x00, 0x00,
+	// 352 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x93, 0xcf, 0x6a, 0xe3, 0x30,
+	0x10, 0xc6, 0xb1, 0x93, 0x35, 0xf1, 0x64, 0x93, 0x83, 0x30, 0xbb, 0xde, 0xb0, 0x94, 0x60, 0x4a,
+	0xc9, 0x29, 0xd0, 0xf4, 0x5e, 0x28, 0xb9, 0x34, 0xb7, 0xa0, 0x90, 0x07, 0x50, 0xed, 0x21, 0x11,
+	0x89, 0x25, 0xd7, 0x52, 0x68, 0xfb, 0x1a, 0x2d, 0xf4, 0x79, 0x8b, 0xc7, 0xca, 0x3f, 0xf7, 0x98,
+	0x4b, 0x6f, 0x9e, 0x6f, 0x3e, 0xcd, 0xfc, 0xf4, 0x21, 0x43, 0x4f, 0xa4, 0xa9, 0xde, 0x29, 0x3b,
+	0x2e, 0x4a, 0x6d, 0x35, 0x03, 0xa5, 0x2d, 0x6e, 0x10, 0x0b, 0x2c, 0x07, 0xbf, 0x53, 0x9d, 0xe7,
+	0x5a, 0xd5, 0x9d, 0xe4, 0xd3, 0x83, 0xe8, 0xa1, 0xf6, 0x2e, 0xac, 0xb0, 0xc8, 0xd1, 0x14, 0x5a,
+	0x19, 0x64, 0x13, 0x08, 0xd6, 0x28, 0x32, 0x2c, 0x63, 0x6f, 0xe8, 0x8d, 0xba, 0x93, 0xc1, 0xf8,
+	0x38, 0x63, 0xbc, 0x77, 0x3d, 0x92, 0x83, 0x3b, 0x27, 0x1b, 0x40, 0xc7, 0xc8, 0x95, 0xc2, 0x6c,
+	0xa6, 0x62, 0x7f, 0xe8, 0x8d, 0x3a, 0xfc, 0x50, 0xb3, 0x3f, 0x10, 0x6c, 0x75, 0xba, 0xc1, 0x2c,
+	0x6e, 0x51, 0xc7, 0x55, 0x95, 0x8e, 0xaf, 0xd2, 0x58, 0x13, 0xb7, 0x6b, 0xbd, 0xae, 0x92, 0x0f,
+	0x0f, 0xa2, 0x69, 0x89, 0xc2, 0xa2, 0xc3, 0xe3, 0xf8, 0xbc, 0x43, 0x63, 0xd9, 0x6d, 0x03, 0xec,
+	0xdf, 0x39, 0x18, 0x99, 0x1a, 0x5c, 0x0c, 0xda, 0x4a, 0xe4, 0x48, 0x4c, 0x21, 0xa7, 0x6f, 0x16,
+	0xc1, 0x2f, 0xcc, 0x85, 0xdc, 0x12, 0x4e, 0xc8, 0xeb, 0x82, 0x5d, 0x01, 0x14, 0xc2, 0x98, 0x62,
+	0x5d, 0x0a, 0x83, 0x44, 0x14, 0xf2, 0x13, 0x25, 0xb9, 0x87, 0x60, 0x69, 0xb0, 0x9c, 0x65, 0xec,
+	0x3f, 0x84, 0x2e, 0xe3, 0x59, 0x46, 0x24, 0x21, 0x3f, 0x0a, 0xd5, 0xad, 0x76, 0xe4, 0x73, 0x3b,
+	0x5d, 0x95, 0x6c, 0xa1, 0x5f, 0x9f, 0xbf, 0x28, 0xe7, 0x1b, 0x68, 0x57, 0xf3, 0x68, 0x76, 0x77,
+	0xc2, 0x4e, 0x4f, 0xb8, 0xe9, 0xd4, 0x4f, 0xde, 0x20, 0x5a, 0xaa, 0x2a, 0xe7, 0xcb, 0x23, 0xec,
+	0x83, 0x2f, 0xf7, 0x97, 0xf1, 0x65, 0xd6, 0x08, 0xaa, 0xf5, 0x2d, 0xa8, 0x77, 0x0f, 0xfe, 0x4e,
+	0xd7, 0x42, 0xad, 0x70, 0x7e, 0x10, 0x2f, 0x58, 0x7f, 0xbe, 0xce, 0x6f, 0xae, 0x63, 0xd7, 0xd0,
+	0x53, 0xf8, 0x32, 0x6f, 0x12, 0x9d, 0x8b, 0xf4, 0xa6, 0x16, 0x72, 0xa5, 0xa4, 0xfa, 0x41, 0x6f,
+	0xea, 0x29, 0xa0, 0x3f, 0xf1, 0xee, 0x6b, 0x00, 0x19, 0xdc, 0x92, 0x7a, 0xb4, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: client.proto
+
+package notekeeper
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// A client that has completed a key exchange
+type Client struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created              string   `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	LastUsed             string   `protobuf:"bytes,3,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	Expires              string   `protobuf:"bytes,4,opt,name=expires,proto3" json:"expires,omitempty"`
+	Current              bool     `protobuf:"varint,5,opt,name=current,proto3" json:"current,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Client) Reset()         { *m = Client{} }
+func (m *Client) String() string { return proto.CompactTextString(m) }
+func (*Client) ProtoMessage()    {}
+func (*Client) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{0}
+}
+
+func (m *Client) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Client.Unmarshal(m, b)
+}
+func (m *Client) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Client.Marshal(b, m, deterministic)
+}
+func (m *Client) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Client.Merge(m, src)
+}
+func (m *Client) XXX_Size() int {
+	return xxx_messageInfo_Client.Size(m)
+}
+func (m *Client) XXX_DiscardUnknown() {
+	xxx_messageInfo_Client.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Client proto.InternalMessageInfo
+
+func (m *Client) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Client) GetCreated() string {
+	if m != nil {
+		return m.Created
+	}
+	return ""
+}
+
+func (m *Client) GetLastUsed() string {
+	if m != nil {
+		return m.LastUsed
+	}
+	return ""
+}
+
+func (m *Client) GetExpires() string {
+	if m != nil {
+		return m.Expires
+	}
+	return ""
+}
+
+func (m *Client) GetCurrent() bool {
+	if m != nil {
+		return m.Current
+	}
+	return false
+}
+
+type GetClientsResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Clients              []*Client       `protobuf:"bytes,2,rep,name=clients,proto3" json:"clients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetClientsResponse) Reset()         { *m = GetClientsResponse{} }
+func (m *GetClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientsResponse) ProtoMessage()    {}
+func (*GetClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{1}
+}
+
+func (m *GetClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientsResponse.Unmarshal(m, b)
+}
+func (m *GetClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientsResponse.Merge(m, src)
+}
+func (m *GetClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientsResponse.Size(m)
+}
+func (m *GetClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientsResponse proto.InternalMessageInfo
+
+func (m *GetClientsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetClientsResponse) GetClients() []*Client {
+	if m != nil {
+		return m.Clients
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Client)(nil), "notekeeper.Client")
+	proto.RegisterType((*GetClientsResponse)(nil), "notekeeper.GetClientsResponse")
+}
+
+func init() { proto.RegisterFile("client.proto", fileDescriptor_014de31d7ac8c57c) }
+
+var fileDescriptor_014de31d7ac8c57c = []byte{
+	// 210 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xb1, 0x4e, 0x04, 0x21,
+	0x14, 0x45, 0x03, 0xab, 0xb3, 0xeb, 0xdb, 0x8d, 0xc5, 0xab, 0xc8, 0x54, 0x93, 0xad, 0xa6, 0x30,
+	0x53, 0x8c, 0x9f, 0x60, 0xa1, 0x35, 0x89, 0x1f, 0x30, 0x0e, 0x37, 0x91, 0xb8, 0x0b, 0x04, 0xd0,
+	0x58, 0xfb, 0xe5, 0x46, 0x58, 0xd4, 0xf2, 0xf0, 0xce, 0x85, 0x77, 0xa1, 0xc3, 0x7a, 0xb2, 0x70,
+	0x79, 0x0a, 0xd1, 0x67, 0xcf, 0xe4, 0x7c, 0xc6, 0x1b, 0x10, 0x10, 0xfb, 0xc3, 0xea, 0xcf, 0x67,
+	0xef, 0xea, 0xe4, 0xf8, 0x25, 0xa8, 0x7b, 0x28, 0x2a, 0xdf, 0x92, 0xb4, 0x46, 0x89, 0x41, 0x8c,
+	0x37, 0x5a, 0x5a, 0xc3, 0x8a, 0xb6, 0x6b, 0xc4, 0x92, 0x61, 0x94, 0x2c, 0x87, 0x0d, 0xb9, 0xa7,
+	0xdd, 0x69, 0x49, 0xf9, 0x39, 0xc1, 0xa8, 0x4d, 0x19, 0xfd, 0xf2, 0x4f, 0x0a, 0x9f, 0xc1, 0x46,
+	0x24, 0x75, 0x55, 0x53, 0x17, 0x2c, 0xf7, 0xbd, 0xc7, 0x08, 0x97, 0xd5, 0xf5, 0x20, 0xc6, 0x9d,
+	0x6e, 0x78, 0xfc, 0x20, 0x7e, 0x44, 0xae, 0x6b, 0x24, 0x8d, 0x14, 0xbc, 0x4b, 0xe0, 0x99, 0xba,
+	0x57, 0x2c, 0x06, 0xb1, 0xec, 0xb4, 0x9f, 0xfb, 0xe9, 0xaf, 0xc5, 0xd4, 0xac, 0xa7, 0x62, 0xe8,
+	0x8b, 0xc9, 0x77, 0xb4, 0xad, 0xc5, 0x93, 0x92, 0xc3, 0x66, 0xdc, 0xcf, 0xfc, 0x3f, 0x54, 0x5f,
+	0xd0, 0x4d, 0x79, 0xe9, 0xca, 0x1f, 0xdc, 0x7f, 0x0f, 0x00, 0x9a, 0x07, 0xb3, 0x5e, 0x2d, 0x01,
+	0x00, 0x00,
+}
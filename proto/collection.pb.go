@@ -27,6 +27,9 @@ type Collection struct {
 	ShelfId              string   `protobuf:"bytes,4,opt,name=shelfId,proto3" json:"shelfId,omitempty"`
 	Created              string   `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
 	Updated              string   `protobuf:"bytes,6,opt,name=updated,proto3" json:"updated,omitempty"`
+	NoteCount            int32    `protobuf:"varint,7,opt,name=noteCount,proto3" json:"noteCount,omitempty"`
+	ContentBytes         int64    `protobuf:"varint,8,opt,name=contentBytes,proto3" json:"contentBytes,omitempty"`
+	LastModified         string   `protobuf:"bytes,9,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -99,6 +102,27 @@ func (m *Collection) GetUpdated() string {
 	return ""
 }
 
+func (m *Collection) GetNoteCount() int32 {
+	if m != nil {
+		return m.NoteCount
+	}
+	return 0
+}
+
+func (m *Collection) GetContentBytes() int64 {
+	if m != nil {
+		return m.ContentBytes
+	}
+	return 0
+}
+
+func (m *Collection) GetLastModified() string {
+	if m != nil {
+		return m.LastModified
+	}
+	return ""
+}
+
 type GetCollectionsRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	ShelfId              string         `protobuf:"bytes,2,opt,name=shelfId,proto3" json:"shelfId,omitempty"`
@@ -418,28 +442,31 @@ func init() {
 func init() { proto.RegisterFile("collection.proto", fileDescriptor_9eceb2b1ad103104) }
 
 var fileDescriptor_9eceb2b1ad103104 = []byte{
-	// 356 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x53, 0xc1, 0x4a, 0xeb, 0x40,
-	0x14, 0x7d, 0x93, 0xb6, 0x79, 0xf6, 0x46, 0x44, 0x07, 0xdb, 0x8e, 0x5d, 0x85, 0x80, 0x90, 0x55,
-	0xc1, 0xb8, 0x71, 0x5f, 0x41, 0xdd, 0x95, 0xd1, 0x1f, 0x88, 0x99, 0x2b, 0x0d, 0x4d, 0x33, 0x31,
-	0x33, 0x75, 0xe1, 0x07, 0xb8, 0xf2, 0x2f, 0x5c, 0xf9, 0x29, 0xfe, 0x95, 0x74, 0x92, 0x36, 0xd3,
-	0x82, 0x50, 0xc5, 0x85, 0xcb, 0xc3, 0x39, 0xb9, 0xe7, 0xdc, 0x33, 0x37, 0x70, 0x98, 0xc8, 0x2c,
-	0xc3, 0x44, 0xa7, 0x32, 0x1f, 0x15, 0xa5, 0xd4, 0x92, 0x42, 0x2e, 0x35, 0xce, 0x10, 0x0b, 0x2c,
-	0x87, 0xfb, 0x89, 0x9c, 0xcf, 0x57, 0xcc, 0xd0, 0xd3, 0xa9, 0xce, 0xb0, 0x02, 0xc1, 0x3b, 0x01,
-	0x18, 0xaf, 0xbf, 0xa5, 0x07, 0xe0, 0xa4, 0x82, 0x11, 0x9f, 0x84, 0x5d, 0xee, 0xa4, 0x82, 0x9e,
-	0x42, 0x3b, 0x8f, 0xe7, 0xc8, 0x1c, 0x9f, 0x84, 0x5e, 0x74, 0x34, 0x6a, 0x86, 0x8e, 0xee, 0x96,
-	0x53, 0xb8, 0xa1, 0x69, 0x1f, 0xdc, 0x4c, 0x26, 0x33, 0x14, 0xac, 0xe5, 0x93, 0x70, 0x8f, 0xd7,
-	0x88, 0x32, 0xf8, 0xaf, 0xa6, 0x98, 0x3d, 0xdc, 0x08, 0xd6, 0x36, 0x33, 0x57, 0x70, 0xc9, 0x24,
-	0x25, 0xc6, 0x1a, 0x05, 0xeb, 0x54, 0x4c, 0x0d, 0x97, 0xcc, 0xa2, 0x10, 0x86, 0x71, 0x2b, 0xa6,
-	0x86, 0xc1, 0x33, 0xf4, 0xae, 0x50, 0x37, 0x69, 0x15, 0xc7, 0xc7, 0x05, 0x2a, 0x4d, 0xcf, 0xc0,
-	0x9d, 0x62, 0x2c, 0xb0, 0x34, 0xc9, 0xbd, 0xe8, 0xc4, 0xce, 0x59, 0x8b, 0xae, 0x8d, 0x80, 0xd7,
-	0x42, 0x3b, 0x99, 0xb3, 0x99, 0xec, 0x18, 0x3a, 0x2a, 0x91, 0x05, 0x9a, 0x55, 0xba, 0xbc, 0x02,
-	0xc1, 0x0b, 0x81, 0xfe, 0xb6, 0xb9, 0x2a, 0x64, 0xae, 0x90, 0x46, 0x5b, 0xee, 0xc3, 0x4d, 0xf7,
-	0x4a, 0xb5, 0x65, 0x7f, 0x01, 0x5e, 0xf3, 0x62, 0x8a, 0x39, 0x7e, 0x2b, 0xf4, 0xa2, 0xbe, 0xfd,
-	0x61, 0xe3, 0xc4, 0x6d, 0x69, 0xf0, 0x46, 0x60, 0x30, 0x36, 0x55, 0x59, 0x8a, 0x9f, 0xf7, 0xb0,
-	0xe3, 0x03, 0x5b, 0x75, 0xb5, 0xbe, 0xa8, 0xab, 0x6d, 0xd7, 0xf5, 0x41, 0xa0, 0x77, 0x1b, 0x3f,
-	0xfd, 0x4e, 0xc6, 0xea, 0x28, 0x9d, 0xf5, 0x51, 0x7e, 0x33, 0xcc, 0x7a, 0xc7, 0xce, 0xae, 0x47,
-	0xec, 0xda, 0x47, 0x1c, 0xbc, 0x12, 0x18, 0x5c, 0x62, 0x86, 0xfa, 0x4f, 0x6c, 0x33, 0xf9, 0x37,
-	0x21, 0xf7, 0xae, 0xf9, 0x79, 0xcf, 0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x34, 0x42, 0xa5, 0x3c,
-	0xf7, 0x03, 0x00, 0x00,
+	// 407 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xc1, 0xaa, 0xd3, 0x40,
+	0x14, 0x75, 0x92, 0x26, 0xef, 0xe5, 0xa6, 0x88, 0x0e, 0xb6, 0x1d, 0x8b, 0x8b, 0x10, 0x10, 0xb2,
+	0x2a, 0x18, 0x37, 0xae, 0xad, 0xa0, 0x2e, 0x84, 0x32, 0xfa, 0x03, 0x31, 0x73, 0x4b, 0x43, 0xd3,
+	0x4c, 0xcc, 0x4c, 0x05, 0xfd, 0x00, 0x57, 0x82, 0x1f, 0xe1, 0xd7, 0xf8, 0x57, 0x92, 0x49, 0xda,
+	0x4c, 0x0a, 0x42, 0x15, 0x17, 0x6f, 0x79, 0xee, 0x39, 0x73, 0xcf, 0xdc, 0x93, 0x3b, 0x81, 0x07,
+	0xb9, 0x2c, 0x4b, 0xcc, 0x75, 0x21, 0xab, 0x55, 0xdd, 0x48, 0x2d, 0x29, 0x54, 0x52, 0xe3, 0x1e,
+	0xb1, 0xc6, 0x66, 0x39, 0xcd, 0xe5, 0xe1, 0x70, 0x62, 0x96, 0xa1, 0x2e, 0x74, 0x89, 0x1d, 0x88,
+	0x7f, 0x38, 0x00, 0xeb, 0xf3, 0x59, 0x7a, 0x1f, 0x9c, 0x42, 0x30, 0x12, 0x91, 0x24, 0xe0, 0x4e,
+	0x21, 0xe8, 0x53, 0x98, 0x54, 0xd9, 0x01, 0x99, 0x13, 0x91, 0x24, 0x4c, 0x1f, 0xae, 0x86, 0xa6,
+	0xab, 0x0f, 0x6d, 0x17, 0x6e, 0x68, 0x3a, 0x07, 0xbf, 0x94, 0xf9, 0x1e, 0x05, 0x73, 0x23, 0x92,
+	0xdc, 0xf2, 0x1e, 0x51, 0x06, 0x37, 0x6a, 0x87, 0xe5, 0xf6, 0xad, 0x60, 0x13, 0xd3, 0xf3, 0x04,
+	0x5b, 0x26, 0x6f, 0x30, 0xd3, 0x28, 0x98, 0xd7, 0x31, 0x3d, 0x6c, 0x99, 0x63, 0x2d, 0x0c, 0xe3,
+	0x77, 0x4c, 0x0f, 0xe9, 0x13, 0x08, 0x5a, 0xff, 0xb5, 0x3c, 0x56, 0x9a, 0xdd, 0x44, 0x24, 0xf1,
+	0xf8, 0x50, 0xa0, 0x31, 0x4c, 0x73, 0x59, 0x69, 0xac, 0xf4, 0xcb, 0x2f, 0x1a, 0x15, 0xbb, 0x8d,
+	0x48, 0xe2, 0xf2, 0x51, 0xad, 0xd5, 0x94, 0x99, 0xd2, 0xef, 0xa4, 0x28, 0xb6, 0x05, 0x0a, 0x16,
+	0x18, 0x83, 0x51, 0x2d, 0xfe, 0x0a, 0xb3, 0xd7, 0xa8, 0x87, 0x4c, 0x14, 0xc7, 0x4f, 0x47, 0x54,
+	0x9a, 0x3e, 0x03, 0x7f, 0x87, 0x99, 0xc0, 0xc6, 0xe4, 0x13, 0xa6, 0x8f, 0xed, 0x34, 0x7a, 0xd1,
+	0x1b, 0x23, 0xe0, 0xbd, 0xd0, 0x9e, 0xdf, 0x19, 0xcf, 0xff, 0x08, 0x3c, 0x95, 0xcb, 0x1a, 0x4d,
+	0x60, 0x01, 0xef, 0x40, 0xfc, 0x8d, 0xc0, 0xfc, 0xd2, 0x5c, 0xd5, 0xb2, 0x52, 0x48, 0xd3, 0x0b,
+	0xf7, 0xe5, 0xd8, 0xbd, 0x53, 0x5d, 0xd8, 0xbf, 0x80, 0x70, 0xd8, 0x0b, 0xc5, 0x9c, 0xc8, 0x4d,
+	0xc2, 0x74, 0x6e, 0x1f, 0x1c, 0x9c, 0xb8, 0x2d, 0x8d, 0x7f, 0x12, 0x58, 0xac, 0xcd, 0x07, 0xb1,
+	0x14, 0xff, 0x9e, 0xc3, 0x95, 0x6b, 0x64, 0xc5, 0xe5, 0xfe, 0x21, 0xae, 0x89, 0x1d, 0xd7, 0x2f,
+	0x02, 0xb3, 0xf7, 0xd9, 0xe7, 0xff, 0x73, 0xc7, 0x6e, 0xf5, 0x9d, 0xf3, 0xea, 0xff, 0xe5, 0x65,
+	0xce, 0x33, 0x7a, 0xd7, 0x3e, 0x15, 0xdf, 0x7e, 0x2a, 0xf1, 0x77, 0x02, 0x8b, 0x57, 0x58, 0xa2,
+	0xbe, 0x13, 0xd3, 0x6c, 0xee, 0x6d, 0xc8, 0x47, 0xdf, 0xfc, 0x22, 0x9e, 0xff, 0x1e, 0x00, 0xdc,
+	0x53, 0x3d, 0x0b, 0x5d, 0x04, 0x00, 0x00,
 }
@@ -76,21 +76,81 @@ func (m *RequestHeader) GetSequence() int32 {
 	return 0
 }
 
-// All responses will include this embedded message type
-type ResponseHeader struct {
-	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Code                 int32    `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
-	Scope                int32    `protobuf:"varint,3,opt,name=scope,proto3" json:"scope,omitempty"`
+// A request field that failed validation
+type FieldError struct {
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *FieldError) Reset()         { *m = FieldError{} }
+func (m *FieldError) String() string { return proto.CompactTextString(m) }
+func (*FieldError) ProtoMessage()    {}
+func (*FieldError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{1}
+}
+
+func (m *FieldError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldError.Unmarshal(m, b)
+}
+func (m *FieldError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldError.Marshal(b, m, deterministic)
+}
+func (m *FieldError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldError.Merge(m, src)
+}
+func (m *FieldError) XXX_Size() int {
+	return xxx_messageInfo_FieldError.Size(m)
+}
+func (m *FieldError) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldError proto.InternalMessageInfo
+
+func (m *FieldError) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *FieldError) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *FieldError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// All responses will include this embedded message type
+type ResponseHeader struct {
+	Status               string        `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Code                 int32         `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Scope                int32         `protobuf:"varint,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Type                 int32         `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`
+	Reason               string        `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Details              []*FieldError `protobuf:"bytes,6,rep,name=details,proto3" json:"details,omitempty"`
+	Retryable            bool          `protobuf:"varint,7,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
 func (m *ResponseHeader) Reset()         { *m = ResponseHeader{} }
 func (m *ResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*ResponseHeader) ProtoMessage()    {}
 func (*ResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{1}
+	return fileDescriptor_555bd8c177793206, []int{2}
 }
 
 func (m *ResponseHeader) XXX_Unmarshal(b []byte) error {
@@ -132,24 +192,171 @@ func (m *ResponseHeader) GetScope() int32 {
 	return 0
 }
 
+func (m *ResponseHeader) GetType() int32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *ResponseHeader) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ResponseHeader) GetDetails() []*FieldError {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *ResponseHeader) GetRetryable() bool {
+	if m != nil {
+		return m.Retryable
+	}
+	return false
+}
+
+// Sorting, filtering & paging options for list requests
+type ListOptions struct {
+	Sort                 string   `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending           bool     `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+	Cursor               string   `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit                int32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Locked               string   `protobuf:"bytes,5,opt,name=locked,proto3" json:"locked,omitempty"`
+	Type                 string   `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	TagId                string   `protobuf:"bytes,7,opt,name=tagId,proto3" json:"tagId,omitempty"`
+	From                 string   `protobuf:"bytes,8,opt,name=from,proto3" json:"from,omitempty"`
+	Until                string   `protobuf:"bytes,9,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOptions) Reset()         { *m = ListOptions{} }
+func (m *ListOptions) String() string { return proto.CompactTextString(m) }
+func (*ListOptions) ProtoMessage()    {}
+func (*ListOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{3}
+}
+
+func (m *ListOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOptions.Unmarshal(m, b)
+}
+func (m *ListOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOptions.Marshal(b, m, deterministic)
+}
+func (m *ListOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOptions.Merge(m, src)
+}
+func (m *ListOptions) XXX_Size() int {
+	return xxx_messageInfo_ListOptions.Size(m)
+}
+func (m *ListOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOptions proto.InternalMessageInfo
+
+func (m *ListOptions) GetSort() string {
+	if m != nil {
+		return m.Sort
+	}
+	return ""
+}
+
+func (m *ListOptions) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+func (m *ListOptions) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *ListOptions) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListOptions) GetLocked() string {
+	if m != nil {
+		return m.Locked
+	}
+	return ""
+}
+
+func (m *ListOptions) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ListOptions) GetTagId() string {
+	if m != nil {
+		return m.TagId
+	}
+	return ""
+}
+
+func (m *ListOptions) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ListOptions) GetUntil() string {
+	if m != nil {
+		return m.Until
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*RequestHeader)(nil), "notekeeper.RequestHeader")
+	proto.RegisterType((*FieldError)(nil), "notekeeper.FieldError")
 	proto.RegisterType((*ResponseHeader)(nil), "notekeeper.ResponseHeader")
+	proto.RegisterType((*ListOptions)(nil), "notekeeper.ListOptions")
 }
 
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 171 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x8f, 0x31, 0xce, 0x82, 0x40,
-	0x10, 0x46, 0xb3, 0xff, 0x2f, 0x44, 0x26, 0x68, 0xb1, 0x31, 0x86, 0x18, 0x0b, 0x42, 0x45, 0x65,
-	0xe3, 0x25, 0xac, 0xe7, 0x06, 0xeb, 0x32, 0x51, 0x63, 0xd8, 0x59, 0x77, 0x86, 0xfb, 0x1b, 0x41,
-	0xa4, 0x9b, 0x37, 0xc5, 0x7b, 0xf9, 0xa0, 0xf4, 0xdc, 0xf7, 0x1c, 0x4e, 0x31, 0xb1, 0xb2, 0x85,
-	0xc0, 0x4a, 0x4f, 0xa2, 0x48, 0xa9, 0x71, 0xb0, 0x41, 0x7a, 0x0d, 0x24, 0x7a, 0x21, 0xd7, 0x51,
-	0xb2, 0x7b, 0xc8, 0x7b, 0xd2, 0x3b, 0x77, 0x95, 0xa9, 0x4d, 0x5b, 0xe0, 0x97, 0xec, 0x11, 0x0a,
-	0x79, 0xdc, 0x82, 0xd3, 0x21, 0x51, 0xf5, 0x57, 0x9b, 0xb6, 0xc4, 0xe5, 0x61, 0x0f, 0xb0, 0x96,
-	0x8f, 0x26, 0x78, 0xaa, 0xfe, 0x6b, 0xd3, 0x66, 0xf8, 0xe3, 0x06, 0x61, 0x8b, 0x24, 0x91, 0x83,
-	0xd0, 0xd2, 0x10, 0x75, 0x3a, 0xc8, 0xdc, 0x98, 0xc8, 0x5a, 0x58, 0x79, 0xee, 0x26, 0x7d, 0x86,
-	0xe3, 0x6d, 0x77, 0x90, 0x89, 0xe7, 0x38, 0x6b, 0x27, 0xb8, 0xe6, 0xe3, 0x92, 0xf3, 0x3b, 0x00,
-	0x00, 0xff, 0xff, 0x71, 0xee, 0x87, 0x7b, 0xd9, 0x00, 0x00, 0x00,
+	// 370 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0xdf, 0x8a, 0xd5, 0x30,
+	0x10, 0xc6, 0xa9, 0xbb, 0xe7, 0x4f, 0x67, 0x57, 0x2f, 0x82, 0x2c, 0x41, 0x44, 0xca, 0xb9, 0xea,
+	0xd5, 0x41, 0xf4, 0x19, 0x14, 0x05, 0x41, 0x08, 0xbe, 0x40, 0xb6, 0x99, 0xad, 0x61, 0xdb, 0x4c,
+	0xcd, 0x4c, 0x2f, 0xf6, 0x25, 0x7d, 0x01, 0x5f, 0x46, 0x92, 0xb4, 0xdb, 0xb3, 0x77, 0xf3, 0x1b,
+	0x3e, 0xbe, 0x7c, 0xdf, 0x10, 0xb8, 0xed, 0x68, 0x1c, 0x29, 0x9c, 0xa7, 0x48, 0x42, 0x0a, 0x02,
+	0x09, 0x3e, 0x22, 0x4e, 0x18, 0x4f, 0x16, 0x5e, 0x1b, 0xfc, 0x33, 0x23, 0xcb, 0x37, 0xb4, 0x0e,
+	0xa3, 0xba, 0x83, 0xfd, 0x88, 0xf2, 0x9b, 0x9c, 0xae, 0x9a, 0xaa, 0xad, 0xcd, 0x42, 0xea, 0x3d,
+	0xd4, 0xec, 0xfb, 0x60, 0x65, 0x8e, 0xa8, 0x5f, 0x35, 0x55, 0x7b, 0x6b, 0xb6, 0x85, 0x7a, 0x07,
+	0x47, 0x4e, 0x36, 0xa1, 0x43, 0x7d, 0xd5, 0x54, 0xed, 0xce, 0x3c, 0xf3, 0xe9, 0x17, 0xc0, 0x57,
+	0x8f, 0x83, 0xfb, 0x12, 0x23, 0x45, 0xf5, 0x16, 0x76, 0x0f, 0x89, 0x16, 0xfb, 0x02, 0xe9, 0xd5,
+	0x88, 0x96, 0x29, 0x64, 0xeb, 0xda, 0x2c, 0xa4, 0x34, 0x1c, 0x46, 0x64, 0xb6, 0x7d, 0xb1, 0xad,
+	0xcd, 0x8a, 0xa7, 0xbf, 0x15, 0xbc, 0x31, 0xc8, 0x13, 0x05, 0xc6, 0x2d, 0x3a, 0x8b, 0x95, 0x99,
+	0xd7, 0xe8, 0x85, 0x94, 0x82, 0xeb, 0x8e, 0x5c, 0x49, 0xbd, 0x33, 0x79, 0x4e, 0x31, 0xb8, 0xa3,
+	0x69, 0x4d, 0x5b, 0x20, 0x29, 0xe5, 0x69, 0x42, 0x7d, 0x5d, 0x94, 0x69, 0xbe, 0x88, 0xb6, 0x7b,
+	0x11, 0xed, 0x23, 0x1c, 0x1c, 0x8a, 0xf5, 0x03, 0xeb, 0x7d, 0x73, 0xd5, 0xde, 0x7c, 0xba, 0x3b,
+	0x6f, 0x77, 0x3d, 0x6f, 0x8d, 0xcd, 0x2a, 0x4b, 0x27, 0x8c, 0x28, 0xf1, 0xc9, 0xde, 0x0f, 0xa8,
+	0x0f, 0x4d, 0xd5, 0x1e, 0xcd, 0xb6, 0x38, 0xfd, 0xab, 0xe0, 0xe6, 0x87, 0x67, 0xf9, 0x39, 0x89,
+	0xa7, 0x90, 0x53, 0x33, 0x45, 0x59, 0xba, 0xe4, 0x59, 0x7d, 0x00, 0x70, 0xc8, 0x1d, 0x06, 0xe7,
+	0x43, 0x9f, 0xfb, 0x1c, 0xcd, 0xc5, 0x26, 0x65, 0xed, 0xe6, 0xc8, 0x14, 0x97, 0x6b, 0x2d, 0x94,
+	0xda, 0x0e, 0x7e, 0xf4, 0xb2, 0x14, 0x2b, 0x90, 0xd4, 0x03, 0x75, 0x8f, 0xe8, 0xd6, 0x66, 0x85,
+	0x9e, 0xaf, 0xb0, 0x2f, 0x2f, 0xa7, 0x39, 0x39, 0x88, 0xed, 0xbf, 0xbb, 0x9c, 0xbb, 0x36, 0x05,
+	0x92, 0xf2, 0x21, 0xd2, 0xa8, 0x8f, 0x45, 0x99, 0xe6, 0xa4, 0x9c, 0x83, 0xf8, 0x41, 0xd7, 0x45,
+	0x99, 0xe1, 0x7e, 0x9f, 0xbf, 0xde, 0xe7, 0xff, 0x03, 0x00, 0xb3, 0xca, 0x2e, 0x5e, 0x8a, 0x02,
+	0x00, 0x00,
 }
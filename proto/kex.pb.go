@@ -24,6 +24,7 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type KeyExchangeRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	PublicKey            []byte         `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Proof                []byte         `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -68,6 +69,13 @@ func (m *KeyExchangeRequest) GetPublicKey() []byte {
 	return nil
 }
 
+func (m *KeyExchangeRequest) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 // A key exchange server response
 type KeyExchangeResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -132,17 +140,17 @@ func init() {
 func init() { proto.RegisterFile("kex.proto", fileDescriptor_d370dc43d05af896) }
 
 var fileDescriptor_d370dc43d05af896 = []byte{
-	// 178 bytes of a gzipped FileDescriptorProto
+	// 183 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcc, 0x4e, 0xad, 0xd0,
 	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0xca, 0xcb, 0x2f, 0x49, 0xcd, 0x4e, 0x4d, 0x2d, 0x48,
-	0x2d, 0x92, 0xe2, 0x49, 0xce, 0xcf, 0xcd, 0xcd, 0xcf, 0x83, 0xc8, 0x28, 0xa5, 0x72, 0x09, 0x79,
+	0x2d, 0x92, 0xe2, 0x49, 0xce, 0xcf, 0xcd, 0xcd, 0xcf, 0x83, 0xc8, 0x28, 0x55, 0x73, 0x09, 0x79,
 	0xa7, 0x56, 0xba, 0x56, 0x24, 0x67, 0x24, 0xe6, 0xa5, 0xa7, 0x06, 0xa5, 0x16, 0x96, 0xa6, 0x16,
 	0x97, 0x08, 0x19, 0x72, 0xb1, 0x65, 0xa4, 0x26, 0xa6, 0xa4, 0x16, 0x49, 0x30, 0x2a, 0x30, 0x6a,
 	0x70, 0x1b, 0x49, 0xea, 0x21, 0x0c, 0xd0, 0x83, 0x2a, 0xf2, 0x00, 0x2b, 0x08, 0x82, 0x2a, 0x14,
 	0x92, 0xe1, 0xe2, 0x2c, 0x28, 0x4d, 0xca, 0xc9, 0x4c, 0xf6, 0x4e, 0xad, 0x94, 0x60, 0x52, 0x60,
-	0xd4, 0xe0, 0x09, 0x42, 0x08, 0x28, 0xd5, 0x72, 0x09, 0xa3, 0x58, 0x53, 0x5c, 0x90, 0x9f, 0x57,
-	0x9c, 0x2a, 0x64, 0x84, 0x66, 0x8f, 0x14, 0xaa, 0x3d, 0x10, 0x55, 0xa4, 0x58, 0x24, 0x24, 0xc2,
-	0xc5, 0x5a, 0x92, 0x9f, 0x9d, 0x9a, 0x27, 0xc1, 0xac, 0xc0, 0xa8, 0xc1, 0x19, 0x04, 0xe1, 0x24,
-	0xb1, 0x81, 0x3d, 0x6b, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xe3, 0xa2, 0x05, 0xee, 0x13, 0x01,
-	0x00, 0x00,
+	0xd4, 0xe0, 0x09, 0x42, 0x08, 0x08, 0x89, 0x70, 0xb1, 0x16, 0x14, 0xe5, 0xe7, 0xa7, 0x49, 0x30,
+	0x83, 0x65, 0x20, 0x1c, 0xa5, 0x5a, 0x2e, 0x61, 0x14, 0xcb, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a, 0x53,
+	0x85, 0x8c, 0xd0, 0x6c, 0x97, 0x42, 0xb5, 0x1d, 0xa2, 0x8a, 0x54, 0xeb, 0x4b, 0xf2, 0xb3, 0x53,
+	0xf3, 0xc0, 0xd6, 0x73, 0x06, 0x41, 0x38, 0x49, 0x6c, 0xe0, 0x20, 0x30, 0x06, 0x0c, 0x00, 0x44,
+	0xa4, 0x33, 0xee, 0x29, 0x01, 0x00, 0x00,
 }
@@ -418,6 +418,86 @@ func (m *DeleteNoteRequest) GetStore() string {
 	return ""
 }
 
+// LockNoteRequest is used to lock & unlock a note
+type LockNoteRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	StoreId              string         `protobuf:"bytes,3,opt,name=storeId,proto3" json:"storeId,omitempty"`
+	OwnerId              string         `protobuf:"bytes,4,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Scope                string         `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
+	Store                string         `protobuf:"bytes,6,opt,name=store,proto3" json:"store,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *LockNoteRequest) Reset()         { *m = LockNoteRequest{} }
+func (m *LockNoteRequest) String() string { return proto.CompactTextString(m) }
+func (*LockNoteRequest) ProtoMessage()    {}
+func (*LockNoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_640dafe07df50d4e, []int{4}
+}
+
+func (m *LockNoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockNoteRequest.Unmarshal(m, b)
+}
+func (m *LockNoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockNoteRequest.Marshal(b, m, deterministic)
+}
+func (m *LockNoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockNoteRequest.Merge(m, src)
+}
+func (m *LockNoteRequest) XXX_Size() int {
+	return xxx_messageInfo_LockNoteRequest.Size(m)
+}
+func (m *LockNoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockNoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockNoteRequest proto.InternalMessageInfo
+
+func (m *LockNoteRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *LockNoteRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LockNoteRequest) GetStoreId() string {
+	if m != nil {
+		return m.StoreId
+	}
+	return ""
+}
+
+func (m *LockNoteRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *LockNoteRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *LockNoteRequest) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
 type LoadNoteRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
@@ -434,7 +514,7 @@ func (m *LoadNoteRequest) Reset()         { *m = LoadNoteRequest{} }
 func (m *LoadNoteRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadNoteRequest) ProtoMessage()    {}
 func (*LoadNoteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_640dafe07df50d4e, []int{4}
+	return fileDescriptor_640dafe07df50d4e, []int{5}
 }
 
 func (m *LoadNoteRequest) XXX_Unmarshal(b []byte) error {
@@ -509,7 +589,7 @@ func (m *LoadNoteResponse) Reset()         { *m = LoadNoteResponse{} }
 func (m *LoadNoteResponse) String() string { return proto.CompactTextString(m) }
 func (*LoadNoteResponse) ProtoMessage()    {}
 func (*LoadNoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_640dafe07df50d4e, []int{5}
+	return fileDescriptor_640dafe07df50d4e, []int{6}
 }
 
 func (m *LoadNoteResponse) XXX_Unmarshal(b []byte) error {
@@ -551,6 +631,7 @@ type GetNotesRequest struct {
 	OwnerId              string         `protobuf:"bytes,4,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
 	Scope                string         `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
 	Store                string         `protobuf:"bytes,6,opt,name=store,proto3" json:"store,omitempty"`
+	Options              *ListOptions   `protobuf:"bytes,7,opt,name=options,proto3" json:"options,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -560,7 +641,7 @@ func (m *GetNotesRequest) Reset()         { *m = GetNotesRequest{} }
 func (m *GetNotesRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNotesRequest) ProtoMessage()    {}
 func (*GetNotesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_640dafe07df50d4e, []int{6}
+	return fileDescriptor_640dafe07df50d4e, []int{7}
 }
 
 func (m *GetNotesRequest) XXX_Unmarshal(b []byte) error {
@@ -623,9 +704,17 @@ func (m *GetNotesRequest) GetStore() string {
 	return ""
 }
 
+func (m *GetNotesRequest) GetOptions() *ListOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 type GetNotesResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Notes                []*Note         `protobuf:"bytes,2,rep,name=notes,proto3" json:"notes,omitempty"`
+	NextCursor           string          `protobuf:"bytes,3,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -635,7 +724,7 @@ func (m *GetNotesResponse) Reset()         { *m = GetNotesResponse{} }
 func (m *GetNotesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNotesResponse) ProtoMessage()    {}
 func (*GetNotesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_640dafe07df50d4e, []int{7}
+	return fileDescriptor_640dafe07df50d4e, []int{8}
 }
 
 func (m *GetNotesResponse) XXX_Unmarshal(b []byte) error {
@@ -670,48 +759,303 @@ func (m *GetNotesResponse) GetNotes() []*Note {
 	return nil
 }
 
+func (m *GetNotesResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type MoveNoteRequest struct {
+	Header                *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                    string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	StoreId               string         `protobuf:"bytes,3,opt,name=storeId,proto3" json:"storeId,omitempty"`
+	OwnerId               string         `protobuf:"bytes,4,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Scope                 string         `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
+	Store                 string         `protobuf:"bytes,6,opt,name=store,proto3" json:"store,omitempty"`
+	DestinationNotebookId string         `protobuf:"bytes,7,opt,name=destinationNotebookId,proto3" json:"destinationNotebookId,omitempty"`
+	DestinationStoreId    string         `protobuf:"bytes,8,opt,name=destinationStoreId,proto3" json:"destinationStoreId,omitempty"`
+	DestinationOwnerId    string         `protobuf:"bytes,9,opt,name=destinationOwnerId,proto3" json:"destinationOwnerId,omitempty"`
+	DestinationScope      string         `protobuf:"bytes,10,opt,name=destinationScope,proto3" json:"destinationScope,omitempty"`
+	DestinationStore      string         `protobuf:"bytes,11,opt,name=destinationStore,proto3" json:"destinationStore,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}       `json:"-"`
+	XXX_unrecognized      []byte         `json:"-"`
+	XXX_sizecache         int32          `json:"-"`
+}
+
+func (m *MoveNoteRequest) Reset()         { *m = MoveNoteRequest{} }
+func (m *MoveNoteRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveNoteRequest) ProtoMessage()    {}
+func (*MoveNoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_640dafe07df50d4e, []int{9}
+}
+
+func (m *MoveNoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveNoteRequest.Unmarshal(m, b)
+}
+func (m *MoveNoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveNoteRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveNoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveNoteRequest.Merge(m, src)
+}
+func (m *MoveNoteRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveNoteRequest.Size(m)
+}
+func (m *MoveNoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveNoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveNoteRequest proto.InternalMessageInfo
+
+func (m *MoveNoteRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *MoveNoteRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetStoreId() string {
+	if m != nil {
+		return m.StoreId
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetDestinationNotebookId() string {
+	if m != nil {
+		return m.DestinationNotebookId
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetDestinationStoreId() string {
+	if m != nil {
+		return m.DestinationStoreId
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetDestinationOwnerId() string {
+	if m != nil {
+		return m.DestinationOwnerId
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetDestinationScope() string {
+	if m != nil {
+		return m.DestinationScope
+	}
+	return ""
+}
+
+func (m *MoveNoteRequest) GetDestinationStore() string {
+	if m != nil {
+		return m.DestinationStore
+	}
+	return ""
+}
+
+type CopyNoteRequest struct {
+	Header                *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                    string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	StoreId               string         `protobuf:"bytes,3,opt,name=storeId,proto3" json:"storeId,omitempty"`
+	OwnerId               string         `protobuf:"bytes,4,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Scope                 string         `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
+	Store                 string         `protobuf:"bytes,6,opt,name=store,proto3" json:"store,omitempty"`
+	DestinationNotebookId string         `protobuf:"bytes,7,opt,name=destinationNotebookId,proto3" json:"destinationNotebookId,omitempty"`
+	DestinationStoreId    string         `protobuf:"bytes,8,opt,name=destinationStoreId,proto3" json:"destinationStoreId,omitempty"`
+	DestinationOwnerId    string         `protobuf:"bytes,9,opt,name=destinationOwnerId,proto3" json:"destinationOwnerId,omitempty"`
+	DestinationScope      string         `protobuf:"bytes,10,opt,name=destinationScope,proto3" json:"destinationScope,omitempty"`
+	DestinationStore      string         `protobuf:"bytes,11,opt,name=destinationStore,proto3" json:"destinationStore,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}       `json:"-"`
+	XXX_unrecognized      []byte         `json:"-"`
+	XXX_sizecache         int32          `json:"-"`
+}
+
+func (m *CopyNoteRequest) Reset()         { *m = CopyNoteRequest{} }
+func (m *CopyNoteRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyNoteRequest) ProtoMessage()    {}
+func (*CopyNoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_640dafe07df50d4e, []int{10}
+}
+
+func (m *CopyNoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyNoteRequest.Unmarshal(m, b)
+}
+func (m *CopyNoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyNoteRequest.Marshal(b, m, deterministic)
+}
+func (m *CopyNoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyNoteRequest.Merge(m, src)
+}
+func (m *CopyNoteRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyNoteRequest.Size(m)
+}
+func (m *CopyNoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyNoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyNoteRequest proto.InternalMessageInfo
+
+func (m *CopyNoteRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CopyNoteRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetStoreId() string {
+	if m != nil {
+		return m.StoreId
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetDestinationNotebookId() string {
+	if m != nil {
+		return m.DestinationNotebookId
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetDestinationStoreId() string {
+	if m != nil {
+		return m.DestinationStoreId
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetDestinationOwnerId() string {
+	if m != nil {
+		return m.DestinationOwnerId
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetDestinationScope() string {
+	if m != nil {
+		return m.DestinationScope
+	}
+	return ""
+}
+
+func (m *CopyNoteRequest) GetDestinationStore() string {
+	if m != nil {
+		return m.DestinationStore
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Note)(nil), "notekeeper.Note")
 	proto.RegisterType((*CreateNoteRequest)(nil), "notekeeper.CreateNoteRequest")
 	proto.RegisterType((*SaveNoteRequest)(nil), "notekeeper.SaveNoteRequest")
 	proto.RegisterType((*DeleteNoteRequest)(nil), "notekeeper.DeleteNoteRequest")
+	proto.RegisterType((*LockNoteRequest)(nil), "notekeeper.LockNoteRequest")
 	proto.RegisterType((*LoadNoteRequest)(nil), "notekeeper.LoadNoteRequest")
 	proto.RegisterType((*LoadNoteResponse)(nil), "notekeeper.LoadNoteResponse")
 	proto.RegisterType((*GetNotesRequest)(nil), "notekeeper.GetNotesRequest")
 	proto.RegisterType((*GetNotesResponse)(nil), "notekeeper.GetNotesResponse")
+	proto.RegisterType((*MoveNoteRequest)(nil), "notekeeper.MoveNoteRequest")
+	proto.RegisterType((*CopyNoteRequest)(nil), "notekeeper.CopyNoteRequest")
 }
 
 func init() { proto.RegisterFile("note.proto", fileDescriptor_640dafe07df50d4e) }
 
 var fileDescriptor_640dafe07df50d4e = []byte{
-	// 451 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x55, 0xd1, 0x8a, 0xd3, 0x40,
-	0x14, 0x75, 0xd2, 0x24, 0x6d, 0x6f, 0x16, 0xdb, 0x0e, 0x22, 0x63, 0x11, 0x09, 0x41, 0xa5, 0x4f,
-	0x05, 0xeb, 0x27, 0x28, 0x68, 0x41, 0x64, 0x89, 0xfe, 0x40, 0xb6, 0x73, 0xc1, 0xd0, 0x34, 0x13,
-	0x33, 0xb3, 0x2b, 0xfe, 0x97, 0x6f, 0xbe, 0xfa, 0x0d, 0xfe, 0x85, 0x5f, 0xe0, 0x8b, 0xcc, 0x9d,
-	0x59, 0x92, 0x2e, 0xed, 0x22, 0xec, 0xfa, 0xe0, 0xbe, 0xcd, 0x3d, 0xe7, 0xcc, 0x70, 0xce, 0x99,
-	0x09, 0x01, 0xa8, 0x95, 0xc1, 0x65, 0xd3, 0x2a, 0xa3, 0x38, 0xad, 0xb7, 0x88, 0x0d, 0xb6, 0xf3,
-	0x93, 0x8d, 0xda, 0xed, 0x54, 0xed, 0x98, 0x79, 0x62, 0x4a, 0x53, 0x79, 0x59, 0xf6, 0x3d, 0x80,
-	0xf0, 0xbd, 0x32, 0xc8, 0xef, 0x43, 0x50, 0x4a, 0xc1, 0x52, 0xb6, 0x18, 0xe7, 0x41, 0x29, 0xf9,
-	0x13, 0x77, 0xda, 0x99, 0x52, 0xdb, 0xb5, 0x14, 0x01, 0xe1, 0x3d, 0x84, 0x0b, 0x18, 0xaa, 0x2f,
-	0x35, 0xb6, 0x6b, 0x29, 0x06, 0x44, 0x5e, 0x8e, 0x96, 0xd1, 0x46, 0xb5, 0xb8, 0x96, 0x22, 0x74,
-	0x8c, 0x1f, 0xf9, 0x03, 0x88, 0xf4, 0x46, 0x35, 0x28, 0x22, 0xc2, 0xdd, 0x40, 0xa8, 0x15, 0x88,
-	0xd8, 0xa3, 0x76, 0xe0, 0xcf, 0x20, 0xac, 0x8b, 0x1d, 0x8a, 0x61, 0xca, 0x16, 0xc9, 0x6a, 0xb6,
-	0xec, 0xe2, 0x2c, 0x3f, 0x5a, 0xff, 0x39, 0xd1, 0x9c, 0x43, 0x68, 0xbe, 0x36, 0x28, 0x46, 0xb4,
-	0x97, 0xd6, 0xfc, 0x31, 0x8c, 0x5b, 0xbc, 0x28, 0x75, 0xa9, 0x6a, 0x2d, 0xc6, 0x29, 0x5b, 0x44,
-	0x79, 0x07, 0xf0, 0x87, 0x10, 0x57, 0x6a, 0xb3, 0x45, 0x29, 0x20, 0x65, 0x8b, 0x51, 0xee, 0x27,
-	0x6b, 0x7b, 0xd3, 0x62, 0x61, 0x50, 0x8a, 0xc4, 0xd9, 0xf6, 0xa3, 0x65, 0xce, 0x1b, 0x49, 0xcc,
-	0x89, 0x63, 0xfc, 0x98, 0xfd, 0x62, 0x30, 0x7b, 0x45, 0x2a, 0xdb, 0x61, 0x8e, 0x9f, 0xcf, 0x51,
-	0x1b, 0xfe, 0x02, 0xe2, 0x4f, 0x58, 0x48, 0x6c, 0xa9, 0xce, 0x64, 0xf5, 0xa8, 0x6f, 0xde, 0x8b,
-	0xde, 0x92, 0x20, 0xf7, 0xc2, 0xbf, 0x69, 0xfb, 0xb2, 0xd3, 0xc1, 0x7e, 0xa7, 0xbd, 0x7b, 0x08,
-	0xf7, 0xef, 0xe1, 0xf6, 0xdb, 0xce, 0x7e, 0x33, 0x98, 0x7c, 0x28, 0x2e, 0x6e, 0x9a, 0xd6, 0xbd,
-	0xb5, 0xe0, 0xc8, 0x5b, 0x1b, 0x5c, 0x97, 0x3e, 0x3c, 0x9a, 0x3e, 0x3a, 0x92, 0x3e, 0x3e, 0x98,
-	0x7e, 0x78, 0x28, 0xfd, 0xe8, 0xfa, 0xf4, 0x3f, 0x19, 0xcc, 0x5e, 0x63, 0x85, 0xe6, 0x8e, 0xe5,
-	0xcf, 0xbe, 0x31, 0x98, 0xbc, 0x53, 0x85, 0xbc, 0xe5, 0x58, 0xff, 0xf8, 0xd1, 0x66, 0x15, 0x4c,
-	0x3b, 0xd7, 0xba, 0x51, 0xb5, 0x46, 0xbe, 0xba, 0x62, 0x7b, 0xbe, 0x6f, 0xdb, 0xa9, 0xae, 0xf8,
-	0x7e, 0x0a, 0xa1, 0x15, 0x91, 0xf3, 0x64, 0x35, 0xed, 0xef, 0xa0, 0xb3, 0x89, 0xcd, 0x7e, 0x30,
-	0x98, 0xbc, 0x41, 0x63, 0x11, 0xfd, 0xff, 0x7e, 0xe9, 0x59, 0x0d, 0xd3, 0x2e, 0xc5, 0x0d, 0x4a,
-	0x7b, 0x0e, 0x91, 0x15, 0x69, 0x11, 0xa4, 0x83, 0x83, 0xad, 0x39, 0xfa, 0xf4, 0xde, 0x29, 0x3b,
-	0x8b, 0xe9, 0x5f, 0xf3, 0xf2, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5f, 0x9b, 0x11, 0x9b, 0xa0,
-	0x06, 0x00, 0x00,
+	// 575 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0x5b, 0x6e, 0xd3, 0x40,
+	0x14, 0x65, 0x1c, 0xe7, 0x75, 0x5d, 0x91, 0x64, 0xc4, 0x63, 0x88, 0x10, 0x8a, 0x2c, 0x40, 0x11,
+	0x1f, 0x91, 0x1a, 0xd8, 0x41, 0x90, 0x20, 0x52, 0x69, 0x2b, 0x97, 0x0d, 0xb8, 0x99, 0x2b, 0x61,
+	0x25, 0xf1, 0x18, 0xcf, 0xa4, 0xd0, 0x4d, 0xb0, 0x08, 0xd6, 0xc0, 0x1f, 0xfb, 0x60, 0x17, 0x7c,
+	0xf3, 0xc1, 0x0f, 0x9a, 0x47, 0x1a, 0xe7, 0x55, 0x21, 0x35, 0x95, 0x28, 0xea, 0x9f, 0xef, 0x3d,
+	0xc7, 0xa3, 0x73, 0xce, 0x1d, 0xdf, 0x04, 0x20, 0x15, 0x0a, 0x7b, 0x59, 0x2e, 0x94, 0xa0, 0xe6,
+	0x79, 0x8c, 0x98, 0x61, 0xde, 0xde, 0x1b, 0x89, 0xe9, 0x54, 0xa4, 0x16, 0x69, 0x07, 0x2a, 0x51,
+	0x13, 0x47, 0x0b, 0xbf, 0x7b, 0xe0, 0x1f, 0x0a, 0x85, 0xf4, 0x2e, 0x78, 0x09, 0x67, 0xa4, 0x43,
+	0xba, 0xf5, 0xc8, 0x4b, 0x38, 0x7d, 0x62, 0x4f, 0x3b, 0x15, 0x62, 0x3c, 0xe4, 0xcc, 0x33, 0xfd,
+	0x42, 0x87, 0x32, 0xa8, 0x8a, 0x4f, 0x29, 0xe6, 0x43, 0xce, 0x4a, 0x06, 0x9c, 0x97, 0x1a, 0x91,
+	0x4a, 0xe4, 0x38, 0xe4, 0xcc, 0xb7, 0x88, 0x2b, 0xe9, 0x3d, 0x28, 0xcb, 0x91, 0xc8, 0x90, 0x95,
+	0x4d, 0xdf, 0x16, 0xa6, 0xab, 0x09, 0xac, 0xe2, 0xba, 0xba, 0xa0, 0xcf, 0xc0, 0x4f, 0xe3, 0x29,
+	0xb2, 0x6a, 0x87, 0x74, 0x83, 0x7e, 0xab, 0xb7, 0xb0, 0xd3, 0x7b, 0xaf, 0xf5, 0x47, 0x06, 0xa6,
+	0x14, 0x7c, 0x75, 0x9e, 0x21, 0xab, 0x99, 0x77, 0xcd, 0x33, 0x7d, 0x0c, 0xf5, 0x1c, 0xcf, 0x12,
+	0x99, 0x88, 0x54, 0xb2, 0x7a, 0x87, 0x74, 0xcb, 0xd1, 0xa2, 0x41, 0x1f, 0x40, 0x65, 0x22, 0x46,
+	0x63, 0xe4, 0x0c, 0x3a, 0xa4, 0x5b, 0x8b, 0x5c, 0xa5, 0x65, 0x8f, 0x72, 0x8c, 0x15, 0x72, 0x16,
+	0x58, 0xd9, 0xae, 0xd4, 0xc8, 0x2c, 0xe3, 0x06, 0xd9, 0xb3, 0x88, 0x2b, 0xc3, 0x9f, 0x04, 0x5a,
+	0x03, 0xc3, 0xd2, 0x19, 0x46, 0xf8, 0x71, 0x86, 0x52, 0xd1, 0x7d, 0xa8, 0x7c, 0xc0, 0x98, 0x63,
+	0x6e, 0xe2, 0x0c, 0xfa, 0x8f, 0x8a, 0xe2, 0x1d, 0xe9, 0xad, 0x21, 0x44, 0x8e, 0xf8, 0x37, 0x69,
+	0xcf, 0x33, 0x2d, 0x2d, 0x67, 0x5a, 0x98, 0x83, 0xbf, 0x3c, 0x87, 0xdd, 0xa7, 0x1d, 0xfe, 0x26,
+	0xd0, 0x38, 0x89, 0xcf, 0xae, 0xea, 0xd6, 0xde, 0x35, 0x6f, 0xcb, 0x5d, 0x2b, 0x5d, 0xe6, 0xde,
+	0xdf, 0xea, 0xbe, 0xbc, 0xc5, 0x7d, 0x65, 0xa3, 0xfb, 0xea, 0x26, 0xf7, 0xb5, 0xcb, 0xdd, 0xff,
+	0x20, 0xd0, 0x7a, 0x8d, 0x13, 0x54, 0xff, 0x99, 0xff, 0xf0, 0x1b, 0x81, 0xc6, 0x81, 0x18, 0x8d,
+	0x77, 0x6c, 0xeb, 0x9a, 0x2f, 0xad, 0x93, 0x1d, 0xf3, 0x1b, 0x26, 0x7b, 0x02, 0xcd, 0x85, 0x6a,
+	0x99, 0x89, 0x54, 0x22, 0xed, 0xaf, 0xc8, 0x6e, 0x2f, 0xcb, 0xb6, 0xac, 0x15, 0xdd, 0x4f, 0xc1,
+	0xd7, 0x24, 0xa3, 0x3c, 0xe8, 0x37, 0x8b, 0x6f, 0x98, 0xb3, 0x0d, 0x1a, 0xfe, 0x22, 0xd0, 0x78,
+	0x83, 0x4a, 0x77, 0xe4, 0x0d, 0x5e, 0x50, 0xfb, 0x50, 0x15, 0x99, 0x32, 0x1b, 0xdd, 0xee, 0xa8,
+	0x87, 0x45, 0xcd, 0x07, 0x89, 0x54, 0x47, 0x16, 0x8e, 0xe6, 0xbc, 0xf0, 0x0b, 0x81, 0xe6, 0xc2,
+	0xf9, 0x15, 0x82, 0x7e, 0x0e, 0x65, 0x4d, 0x92, 0xcc, 0xeb, 0x94, 0x36, 0x26, 0x6d, 0x61, 0x93,
+	0x11, 0x7e, 0x56, 0x83, 0x59, 0x2e, 0x45, 0x7e, 0xf1, 0x19, 0x5f, 0x74, 0xc2, 0xaf, 0x25, 0x68,
+	0xbc, 0x13, 0x3b, 0xdf, 0x9e, 0xd7, 0x1d, 0xfd, 0x2b, 0xb8, 0xcf, 0x51, 0xaa, 0x24, 0x8d, 0x75,
+	0xae, 0x87, 0x8b, 0x5b, 0x60, 0x77, 0xc8, 0x66, 0x90, 0xf6, 0x80, 0x16, 0x80, 0x13, 0x27, 0xd0,
+	0xfe, 0x4c, 0x6f, 0x40, 0x56, 0xf8, 0x47, 0x4e, 0x76, 0x7d, 0x8d, 0xef, 0x10, 0xfa, 0x02, 0x9a,
+	0xc5, 0x53, 0x8c, 0x19, 0x30, 0xec, 0xb5, 0xfe, 0x2a, 0xd7, 0x58, 0x0c, 0xd6, 0xb9, 0xe6, 0xeb,
+	0xd4, 0x43, 0x1a, 0x88, 0xec, 0xfc, 0x76, 0x48, 0xff, 0xee, 0x90, 0x8e, 0xef, 0x1c, 0x93, 0xd3,
+	0x8a, 0xf9, 0x03, 0xfb, 0xf2, 0xcf, 0x00, 0x13, 0xe4, 0x53, 0x66, 0xf5, 0x0a, 0x00, 0x00,
 }
@@ -21,20 +21,24 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Notebook struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 *Title   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Scope                string   `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
-	Container            string   `protobuf:"bytes,4,opt,name=container,proto3" json:"container,omitempty"`
-	OwnerId              string   `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
-	ContainerId          string   `protobuf:"bytes,6,opt,name=containerId,proto3" json:"containerId,omitempty"`
-	Default              bool     `protobuf:"varint,7,opt,name=default,proto3" json:"default,omitempty"`
-	Locked               bool     `protobuf:"varint,8,opt,name=locked,proto3" json:"locked,omitempty"`
-	NoteCount            int32    `protobuf:"varint,9,opt,name=noteCount,proto3" json:"noteCount,omitempty"`
-	Created              string   `protobuf:"bytes,10,opt,name=created,proto3" json:"created,omitempty"`
-	Updated              string   `protobuf:"bytes,11,opt,name=updated,proto3" json:"updated,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 *Title      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scope                string      `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Container            string      `protobuf:"bytes,4,opt,name=container,proto3" json:"container,omitempty"`
+	OwnerId              string      `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	ContainerId          string      `protobuf:"bytes,6,opt,name=containerId,proto3" json:"containerId,omitempty"`
+	Default              bool        `protobuf:"varint,7,opt,name=default,proto3" json:"default,omitempty"`
+	Locked               bool        `protobuf:"varint,8,opt,name=locked,proto3" json:"locked,omitempty"`
+	NoteCount            int32       `protobuf:"varint,9,opt,name=noteCount,proto3" json:"noteCount,omitempty"`
+	Created              string      `protobuf:"bytes,10,opt,name=created,proto3" json:"created,omitempty"`
+	Updated              string      `protobuf:"bytes,11,opt,name=updated,proto3" json:"updated,omitempty"`
+	ParentId             string      `protobuf:"bytes,12,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	Children             []*Notebook `protobuf:"bytes,13,rep,name=children,proto3" json:"children,omitempty"`
+	ContentBytes         int64       `protobuf:"varint,14,opt,name=contentBytes,proto3" json:"contentBytes,omitempty"`
+	LastModified         string      `protobuf:"bytes,15,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *Notebook) Reset()         { *m = Notebook{} }
@@ -139,6 +143,34 @@ func (m *Notebook) GetUpdated() string {
 	return ""
 }
 
+func (m *Notebook) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *Notebook) GetChildren() []*Notebook {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func (m *Notebook) GetContentBytes() int64 {
+	if m != nil {
+		return m.ContentBytes
+	}
+	return 0
+}
+
+func (m *Notebook) GetLastModified() string {
+	if m != nil {
+		return m.LastModified
+	}
+	return ""
+}
+
 type CreateNotebookRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Name                 *Title         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -146,6 +178,7 @@ type CreateNotebookRequest struct {
 	Container            string         `protobuf:"bytes,4,opt,name=container,proto3" json:"container,omitempty"`
 	OwnerId              string         `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
 	ContainerId          string         `protobuf:"bytes,6,opt,name=containerId,proto3" json:"containerId,omitempty"`
+	ParentId             string         `protobuf:"bytes,7,opt,name=parentId,proto3" json:"parentId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -218,6 +251,13 @@ func (m *CreateNotebookRequest) GetContainerId() string {
 	return ""
 }
 
+func (m *CreateNotebookRequest) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
 type SaveNotebookRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
@@ -327,6 +367,7 @@ type GetNotebooksRequest struct {
 	Container            string         `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
 	OwnerId              string         `protobuf:"bytes,4,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
 	ContainerId          string         `protobuf:"bytes,5,opt,name=containerId,proto3" json:"containerId,omitempty"`
+	Options              *ListOptions   `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -392,9 +433,17 @@ func (m *GetNotebooksRequest) GetContainerId() string {
 	return ""
 }
 
+func (m *GetNotebooksRequest) GetOptions() *ListOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 type GetNotebooksResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Notebooks            []*Notebook     `protobuf:"bytes,2,rep,name=notebooks,proto3" json:"notebooks,omitempty"`
+	NextCursor           string          `protobuf:"bytes,3,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -439,6 +488,13 @@ func (m *GetNotebooksResponse) GetNotebooks() []*Notebook {
 	return nil
 }
 
+func (m *GetNotebooksResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 type DeleteNotebookRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
@@ -518,6 +574,244 @@ func (m *DeleteNotebookRequest) GetContainerId() string {
 	return ""
 }
 
+type MoveNotebookRequest struct {
+	Header                 *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                     string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Scope                  string         `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Container              string         `protobuf:"bytes,4,opt,name=container,proto3" json:"container,omitempty"`
+	OwnerId                string         `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	ContainerId            string         `protobuf:"bytes,6,opt,name=containerId,proto3" json:"containerId,omitempty"`
+	ParentId               string         `protobuf:"bytes,7,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	DestinationContainer   string         `protobuf:"bytes,8,opt,name=destinationContainer,proto3" json:"destinationContainer,omitempty"`
+	DestinationContainerId string         `protobuf:"bytes,9,opt,name=destinationContainerId,proto3" json:"destinationContainerId,omitempty"`
+	DestinationScope       string         `protobuf:"bytes,10,opt,name=destinationScope,proto3" json:"destinationScope,omitempty"`
+	DestinationOwnerId     string         `protobuf:"bytes,11,opt,name=destinationOwnerId,proto3" json:"destinationOwnerId,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}       `json:"-"`
+	XXX_unrecognized       []byte         `json:"-"`
+	XXX_sizecache          int32          `json:"-"`
+}
+
+func (m *MoveNotebookRequest) Reset()         { *m = MoveNotebookRequest{} }
+func (m *MoveNotebookRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveNotebookRequest) ProtoMessage()    {}
+func (*MoveNotebookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4288154b4c2ba34, []int{6}
+}
+
+func (m *MoveNotebookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveNotebookRequest.Unmarshal(m, b)
+}
+func (m *MoveNotebookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveNotebookRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveNotebookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveNotebookRequest.Merge(m, src)
+}
+func (m *MoveNotebookRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveNotebookRequest.Size(m)
+}
+func (m *MoveNotebookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveNotebookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveNotebookRequest proto.InternalMessageInfo
+
+func (m *MoveNotebookRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *MoveNotebookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetDestinationContainer() string {
+	if m != nil {
+		return m.DestinationContainer
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetDestinationContainerId() string {
+	if m != nil {
+		return m.DestinationContainerId
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetDestinationScope() string {
+	if m != nil {
+		return m.DestinationScope
+	}
+	return ""
+}
+
+func (m *MoveNotebookRequest) GetDestinationOwnerId() string {
+	if m != nil {
+		return m.DestinationOwnerId
+	}
+	return ""
+}
+
+type CopyNotebookRequest struct {
+	Header                 *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                     string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Scope                  string         `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Container              string         `protobuf:"bytes,4,opt,name=container,proto3" json:"container,omitempty"`
+	OwnerId                string         `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	ContainerId            string         `protobuf:"bytes,6,opt,name=containerId,proto3" json:"containerId,omitempty"`
+	ParentId               string         `protobuf:"bytes,7,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	DestinationContainer   string         `protobuf:"bytes,8,opt,name=destinationContainer,proto3" json:"destinationContainer,omitempty"`
+	DestinationContainerId string         `protobuf:"bytes,9,opt,name=destinationContainerId,proto3" json:"destinationContainerId,omitempty"`
+	DestinationScope       string         `protobuf:"bytes,10,opt,name=destinationScope,proto3" json:"destinationScope,omitempty"`
+	DestinationOwnerId     string         `protobuf:"bytes,11,opt,name=destinationOwnerId,proto3" json:"destinationOwnerId,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}       `json:"-"`
+	XXX_unrecognized       []byte         `json:"-"`
+	XXX_sizecache          int32          `json:"-"`
+}
+
+func (m *CopyNotebookRequest) Reset()         { *m = CopyNotebookRequest{} }
+func (m *CopyNotebookRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyNotebookRequest) ProtoMessage()    {}
+func (*CopyNotebookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4288154b4c2ba34, []int{7}
+}
+
+func (m *CopyNotebookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyNotebookRequest.Unmarshal(m, b)
+}
+func (m *CopyNotebookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyNotebookRequest.Marshal(b, m, deterministic)
+}
+func (m *CopyNotebookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyNotebookRequest.Merge(m, src)
+}
+func (m *CopyNotebookRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyNotebookRequest.Size(m)
+}
+func (m *CopyNotebookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyNotebookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyNotebookRequest proto.InternalMessageInfo
+
+func (m *CopyNotebookRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CopyNotebookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetDestinationContainer() string {
+	if m != nil {
+		return m.DestinationContainer
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetDestinationContainerId() string {
+	if m != nil {
+		return m.DestinationContainerId
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetDestinationScope() string {
+	if m != nil {
+		return m.DestinationScope
+	}
+	return ""
+}
+
+func (m *CopyNotebookRequest) GetDestinationOwnerId() string {
+	if m != nil {
+		return m.DestinationOwnerId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Notebook)(nil), "notekeeper.Notebook")
 	proto.RegisterType((*CreateNotebookRequest)(nil), "notekeeper.CreateNotebookRequest")
@@ -525,37 +819,51 @@ func init() {
 	proto.RegisterType((*GetNotebooksRequest)(nil), "notekeeper.GetNotebooksRequest")
 	proto.RegisterType((*GetNotebooksResponse)(nil), "notekeeper.GetNotebooksResponse")
 	proto.RegisterType((*DeleteNotebookRequest)(nil), "notekeeper.DeleteNotebookRequest")
+	proto.RegisterType((*MoveNotebookRequest)(nil), "notekeeper.MoveNotebookRequest")
+	proto.RegisterType((*CopyNotebookRequest)(nil), "notekeeper.CopyNotebookRequest")
 }
 
 func init() { proto.RegisterFile("notebook.proto", fileDescriptor_e4288154b4c2ba34) }
 
 var fileDescriptor_e4288154b4c2ba34 = []byte{
-	// 428 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xdd, 0x8a, 0xd3, 0x40,
-	0x18, 0x75, 0xa6, 0x4d, 0x9a, 0x7c, 0x91, 0x05, 0x67, 0xbb, 0x32, 0x16, 0x2f, 0x42, 0x40, 0xc8,
-	0x55, 0xc1, 0xf8, 0x08, 0x2b, 0xe8, 0xde, 0xc8, 0x12, 0x7d, 0x81, 0x6c, 0xe6, 0x13, 0x43, 0xd3,
-	0x99, 0x98, 0x4c, 0xf4, 0xce, 0x77, 0xf1, 0x21, 0x04, 0x9f, 0xc1, 0xb7, 0xf0, 0x4d, 0x24, 0x33,
-	0x49, 0x93, 0xdd, 0xd2, 0x20, 0xf8, 0xc3, 0x5e, 0x9e, 0x39, 0xe7, 0xfb, 0x38, 0xe7, 0xeb, 0x49,
-	0xe1, 0x4c, 0x2a, 0x8d, 0x37, 0x4a, 0xed, 0xb6, 0x55, 0xad, 0xb4, 0x62, 0xd0, 0xe1, 0x1d, 0x62,
-	0x85, 0xf5, 0xe6, 0x61, 0xae, 0xf6, 0x7b, 0x25, 0x2d, 0xb3, 0x09, 0x74, 0xa1, 0x4b, 0xb4, 0x20,
-	0xfa, 0x46, 0xc1, 0x7b, 0xd3, 0x4f, 0xb2, 0x33, 0xa0, 0x85, 0xe0, 0x24, 0x24, 0xb1, 0x9f, 0xd2,
-	0x42, 0xb0, 0x67, 0xb0, 0x94, 0xd9, 0x1e, 0x39, 0x0d, 0x49, 0x1c, 0x24, 0x8f, 0xb6, 0xe3, 0xca,
-	0xed, 0xbb, 0x6e, 0x47, 0x6a, 0x68, 0xb6, 0x06, 0xa7, 0xc9, 0x55, 0x85, 0x7c, 0x61, 0x26, 0x2d,
-	0x60, 0x4f, 0xc1, 0xcf, 0x95, 0xd4, 0x59, 0x21, 0xb1, 0xe6, 0x4b, 0xc3, 0x8c, 0x0f, 0x8c, 0xc3,
-	0x4a, 0x7d, 0x96, 0x58, 0x5f, 0x09, 0xee, 0x18, 0x6e, 0x80, 0x2c, 0x84, 0xe0, 0x20, 0xbb, 0x12,
-	0xdc, 0x35, 0xec, 0xf4, 0xa9, 0x9b, 0x15, 0xf8, 0x3e, 0x6b, 0x4b, 0xcd, 0x57, 0x21, 0x89, 0xbd,
-	0x74, 0x80, 0xec, 0x31, 0xb8, 0xa5, 0xca, 0x77, 0x28, 0xb8, 0x67, 0x88, 0x1e, 0x75, 0x5e, 0x3a,
-	0xef, 0x97, 0xaa, 0x95, 0x9a, 0xfb, 0x21, 0x89, 0x9d, 0x74, 0x7c, 0xe8, 0xf6, 0xe5, 0x35, 0x66,
-	0x1a, 0x05, 0x07, 0xeb, 0xa5, 0x87, 0x1d, 0xd3, 0x56, 0xc2, 0x30, 0x81, 0x65, 0x7a, 0x18, 0xfd,
-	0x24, 0x70, 0x71, 0x69, 0x54, 0xc3, 0xf5, 0x52, 0xfc, 0xd8, 0x62, 0xa3, 0xd9, 0x73, 0x70, 0x3f,
-	0x60, 0x26, 0xb0, 0x36, 0x87, 0x0c, 0x92, 0x27, 0xd3, 0xb3, 0xf5, 0xa2, 0xd7, 0x46, 0x90, 0xf6,
-	0xc2, 0x7b, 0x7a, 0xe7, 0xe8, 0x2b, 0x85, 0xf3, 0xb7, 0xd9, 0xa7, 0xbf, 0x91, 0xd0, 0x36, 0x8b,
-	0x1e, 0x35, 0x6b, 0xf1, 0x9b, 0x89, 0x97, 0x27, 0x13, 0x3b, 0x33, 0x89, 0xdd, 0xd9, 0xc4, 0xab,
-	0xd9, 0x66, 0x79, 0xa7, 0x9a, 0xe5, 0x4f, 0x9b, 0x15, 0x7d, 0x27, 0x70, 0xfe, 0x0a, 0xf5, 0x70,
-	0xa2, 0xe6, 0x0f, 0x6e, 0x74, 0x08, 0x4b, 0x4f, 0x86, 0x5d, 0xcc, 0x84, 0x5d, 0xce, 0x86, 0x75,
-	0x8e, 0x7f, 0xde, 0x2f, 0xb0, 0xbe, 0xed, 0xbc, 0xa9, 0x94, 0x6c, 0x90, 0x25, 0x77, 0xac, 0x6f,
-	0x6e, 0x5b, 0xb7, 0xaa, 0x3b, 0xde, 0x13, 0xfb, 0x81, 0x99, 0x45, 0x9c, 0x86, 0x8b, 0x38, 0x48,
-	0xd6, 0xd3, 0xb1, 0x43, 0x85, 0x46, 0x59, 0xf4, 0x83, 0xc0, 0xc5, 0x4b, 0x2c, 0x51, 0xff, 0x8b,
-	0x82, 0xfd, 0xe7, 0x6f, 0xe5, 0xfa, 0xc1, 0x35, 0xb9, 0x71, 0xcd, 0x5f, 0xea, 0x8b, 0x5f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x24, 0xc5, 0x0b, 0xbc, 0x8b, 0x05, 0x00, 0x00,
+	// 615 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0xef, 0x6a, 0xd4, 0x4e,
+	0x14, 0xfd, 0x25, 0xd9, 0x3f, 0xd9, 0x9b, 0xfe, 0xaa, 0x4e, 0xb7, 0x75, 0x5c, 0x44, 0x42, 0x40,
+	0x08, 0x7e, 0x58, 0x6c, 0x04, 0x1f, 0xc0, 0x15, 0x74, 0xc1, 0xda, 0x92, 0xfa, 0x02, 0x69, 0xe6,
+	0x96, 0x86, 0xa6, 0x33, 0x31, 0x99, 0xad, 0xf6, 0x65, 0x04, 0xbf, 0xfb, 0x24, 0xfa, 0x28, 0x3e,
+	0x83, 0x20, 0x99, 0x49, 0xb2, 0xd9, 0xb6, 0x1b, 0x04, 0xff, 0xa0, 0xe2, 0xc7, 0x7b, 0xce, 0x99,
+	0xd9, 0x7b, 0xce, 0xcd, 0x4d, 0x16, 0x36, 0xb9, 0x90, 0x78, 0x24, 0xc4, 0xe9, 0x34, 0xcb, 0x85,
+	0x14, 0x04, 0xca, 0xfa, 0x14, 0x31, 0xc3, 0x7c, 0xb2, 0x11, 0x8b, 0xb3, 0x33, 0xc1, 0x35, 0x33,
+	0x71, 0x64, 0x22, 0x53, 0xd4, 0x85, 0xf7, 0xc9, 0x02, 0xfb, 0x65, 0x75, 0x92, 0x6c, 0x82, 0x99,
+	0x30, 0x6a, 0xb8, 0x86, 0x3f, 0x0a, 0xcd, 0x84, 0x91, 0xfb, 0xd0, 0xe3, 0xd1, 0x19, 0x52, 0xd3,
+	0x35, 0x7c, 0x27, 0xb8, 0x35, 0x5d, 0x5e, 0x39, 0x7d, 0x55, 0xde, 0x11, 0x2a, 0x9a, 0x8c, 0xa1,
+	0x5f, 0xc4, 0x22, 0x43, 0x6a, 0xa9, 0x93, 0xba, 0x20, 0x77, 0x61, 0x14, 0x0b, 0x2e, 0xa3, 0x84,
+	0x63, 0x4e, 0x7b, 0x8a, 0x59, 0x02, 0x84, 0xc2, 0x50, 0xbc, 0xe1, 0x98, 0xcf, 0x19, 0xed, 0x2b,
+	0xae, 0x2e, 0x89, 0x0b, 0x4e, 0x23, 0x9b, 0x33, 0x3a, 0x50, 0x6c, 0x1b, 0x2a, 0xcf, 0x32, 0x3c,
+	0x8e, 0x16, 0xa9, 0xa4, 0x43, 0xd7, 0xf0, 0xed, 0xb0, 0x2e, 0xc9, 0x0e, 0x0c, 0x52, 0x11, 0x9f,
+	0x22, 0xa3, 0xb6, 0x22, 0xaa, 0xaa, 0xec, 0xa5, 0xec, 0x7d, 0x26, 0x16, 0x5c, 0xd2, 0x91, 0x6b,
+	0xf8, 0xfd, 0x70, 0x09, 0x94, 0xf7, 0xc5, 0x39, 0x46, 0x12, 0x19, 0x05, 0xdd, 0x4b, 0x55, 0x96,
+	0xcc, 0x22, 0x63, 0x8a, 0x71, 0x34, 0x53, 0x95, 0x64, 0x02, 0x76, 0x16, 0xe5, 0xc8, 0xe5, 0x9c,
+	0xd1, 0x0d, 0x45, 0x35, 0x35, 0x79, 0x08, 0x76, 0x7c, 0x92, 0xa4, 0x2c, 0x47, 0x4e, 0xff, 0x77,
+	0x2d, 0xdf, 0x09, 0xc6, 0xed, 0xe8, 0xea, 0xb8, 0xc3, 0x46, 0x45, 0x3c, 0xd8, 0x28, 0x0d, 0x22,
+	0x97, 0x4f, 0x2e, 0x24, 0x16, 0x74, 0xd3, 0x35, 0x7c, 0x2b, 0x5c, 0xc1, 0x4a, 0x4d, 0x1a, 0x15,
+	0x72, 0x4f, 0xb0, 0xe4, 0x38, 0x41, 0x46, 0x6f, 0xa8, 0x5f, 0x5d, 0xc1, 0xbc, 0x2f, 0x06, 0x6c,
+	0xcf, 0x54, 0xef, 0xcd, 0x8f, 0xe0, 0xeb, 0x05, 0x16, 0x92, 0xec, 0xc2, 0xe0, 0x04, 0x23, 0x86,
+	0xb9, 0x1a, 0xaf, 0x13, 0xdc, 0x69, 0x77, 0x54, 0x89, 0x9e, 0x2b, 0x41, 0x58, 0x09, 0x7f, 0xd7,
+	0xe9, 0xb7, 0x93, 0x1f, 0xae, 0x26, 0xef, 0xbd, 0x37, 0x61, 0xeb, 0x30, 0x3a, 0xff, 0x11, 0xee,
+	0xf5, 0x2e, 0x98, 0x57, 0x76, 0xc1, 0xfa, 0xc6, 0x34, 0x7a, 0x6b, 0xd3, 0xe8, 0x77, 0xa4, 0x31,
+	0xe8, 0x4c, 0x63, 0xd8, 0xb9, 0x0b, 0xf6, 0xba, 0x5d, 0x18, 0xb5, 0x77, 0xc1, 0xfb, 0x6c, 0xc0,
+	0xd6, 0x33, 0x94, 0x75, 0x44, 0xc5, 0x77, 0x64, 0xd4, 0x98, 0x35, 0xd7, 0x9a, 0xb5, 0x3a, 0xcc,
+	0xf6, 0x3a, 0xcd, 0xf6, 0xaf, 0x9a, 0xdd, 0x85, 0xa1, 0xc8, 0x64, 0x22, 0x78, 0xa1, 0x82, 0x72,
+	0x82, 0xdb, 0xed, 0x1e, 0x5f, 0x24, 0x85, 0xdc, 0xd7, 0x74, 0x58, 0xeb, 0xbc, 0x77, 0x06, 0x8c,
+	0x57, 0xdd, 0x16, 0x99, 0xe0, 0x05, 0x92, 0xe0, 0x92, 0xdd, 0xc9, 0xaa, 0x5d, 0xad, 0xba, 0xe4,
+	0x37, 0xd0, 0xaf, 0x11, 0x75, 0x11, 0x35, 0x3b, 0x36, 0x7b, 0x29, 0x23, 0xf7, 0x00, 0x38, 0xbe,
+	0x95, 0xb3, 0x45, 0x5e, 0x88, 0x3a, 0x8e, 0x16, 0xe2, 0x7d, 0x34, 0x60, 0xfb, 0x29, 0xa6, 0x28,
+	0x7f, 0xc6, 0x43, 0xfb, 0x8b, 0x77, 0xd3, 0xfb, 0x60, 0xc1, 0xd6, 0x9e, 0x38, 0xff, 0x0b, 0xac,
+	0x74, 0xbd, 0x66, 0x48, 0x00, 0x63, 0x86, 0x85, 0x4c, 0x78, 0x54, 0x3e, 0x64, 0xb3, 0xa6, 0x01,
+	0x5b, 0xe9, 0xae, 0xe5, 0xc8, 0x63, 0xd8, 0xb9, 0x0e, 0x9f, 0xeb, 0xf5, 0x1c, 0x85, 0x6b, 0x58,
+	0xf2, 0x00, 0x6e, 0xb6, 0x98, 0x43, 0x15, 0x81, 0xfe, 0x4a, 0x5d, 0xc1, 0xc9, 0x14, 0x48, 0x0b,
+	0xdb, 0xaf, 0xac, 0xeb, 0x2f, 0xd7, 0x35, 0x8c, 0x1a, 0xd7, 0x4c, 0x64, 0x17, 0xff, 0xc6, 0xf5,
+	0x27, 0x8c, 0xeb, 0xe0, 0xbf, 0x03, 0xe3, 0x68, 0xa0, 0xfe, 0xb6, 0x3d, 0xfa, 0x3a, 0x00, 0x35,
+	0xf7, 0x5e, 0xf6, 0xef, 0x09, 0x00, 0x00,
 }
@@ -0,0 +1,2986 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: service.proto
+
+package notekeeper
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
+
+var fileDescriptor_a0b84a42fa06f626 = []byte{
+	// 1059 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x99, 0x5b, 0x73, 0xe2, 0x36,
+	0x14, 0xc7, 0xdf, 0x3a, 0x1b, 0x01, 0x49, 0xea, 0x36, 0xbd, 0x40, 0x92, 0x2d, 0xcd, 0x7b, 0xa6,
+	0xd3, 0x7e, 0x81, 0x6e, 0x49, 0x20, 0x04, 0x42, 0xba, 0x5c, 0x76, 0xba, 0xd3, 0x87, 0x8e, 0x62,
+	0xce, 0x1a, 0x06, 0x6a, 0xb9, 0x96, 0x48, 0x37, 0xcf, 0xfd, 0xe2, 0x1d, 0xdb, 0xba, 0xdb, 0x6b,
+	0x0c, 0x62, 0xdf, 0xec, 0x73, 0xe4, 0x1f, 0x7f, 0xfd, 0x75, 0x74, 0x64, 0x27, 0xa8, 0x41, 0x21,
+	0x7e, 0x5e, 0xfa, 0x70, 0x1d, 0xc5, 0x84, 0x11, 0x0f, 0x85, 0x84, 0xc1, 0x0a, 0x20, 0x82, 0xb8,
+	0xd9, 0xc0, 0xbe, 0x4f, 0x36, 0x21, 0xcb, 0x52, 0xcd, 0xba, 0xbf, 0x5e, 0x82, 0xbc, 0x3b, 0xf5,
+	0xc9, 0x7a, 0x0d, 0x3e, 0x5b, 0x92, 0x90, 0x47, 0x5e, 0xcd, 0x9f, 0xf8, 0xd5, 0xd1, 0x0a, 0x3e,
+	0xf2, 0xcb, 0x94, 0xc7, 0xaf, 0x8f, 0x93, 0xeb, 0x27, 0x42, 0x56, 0x62, 0x58, 0x1c, 0xf9, 0xfc,
+	0xb2, 0x46, 0x17, 0xb0, 0xfe, 0x20, 0x6f, 0x18, 0x66, 0x54, 0xfc, 0x6a, 0x72, 0xb3, 0x11, 0x77,
+	0x47, 0x0c, 0x07, 0x82, 0xb6, 0x59, 0xfe, 0x95, 0xe4, 0x38, 0xfd, 0xe7, 0xff, 0x7e, 0x42, 0x68,
+	0x44, 0x18, 0x0c, 0x52, 0xf1, 0xde, 0x08, 0xd5, 0x06, 0xf0, 0x72, 0xfb, 0xd1, 0x5f, 0xe0, 0x30,
+	0x00, 0xef, 0xf2, 0x5a, 0x4d, 0xec, 0x5a, 0x4b, 0x8c, 0xe1, 0x9f, 0x0d, 0x50, 0xd6, 0x7c, 0xfd,
+	0xc9, 0x3c, 0x8d, 0x48, 0x48, 0xc1, 0xeb, 0xa2, 0x93, 0x49, 0xe6, 0xd4, 0x64, 0xb1, 0x61, 0x73,
+	0xf2, 0x6f, 0xe8, 0x7d, 0xa7, 0x3f, 0x73, 0xfb, 0x77, 0xc4, 0x5e, 0x04, 0xed, 0xfb, 0x82, 0x0c,
+	0xe7, 0x0c, 0x51, 0x43, 0x70, 0xd2, 0x89, 0x95, 0x50, 0xda, 0x7a, 0xc6, 0x78, 0x48, 0xd2, 0x46,
+	0xa8, 0xc6, 0x13, 0x43, 0x12, 0x50, 0x73, 0x96, 0x5a, 0xa2, 0x70, 0x96, 0x46, 0x5e, 0xce, 0x12,
+	0x75, 0xd2, 0x55, 0x1e, 0x2e, 0x29, 0x2b, 0x91, 0x66, 0xfc, 0x50, 0x0f, 0x58, 0xf6, 0x90, 0xe2,
+	0xfc, 0x8a, 0xea, 0x59, 0x68, 0x0c, 0xcf, 0x64, 0x05, 0xde, 0x99, 0x3e, 0xbe, 0x3f, 0xaf, 0xe0,
+	0xd3, 0x3d, 0xaa, 0x3f, 0x60, 0xca, 0x20, 0xbe, 0x79, 0x7a, 0x8c, 0x20, 0xf4, 0x0c, 0xe9, 0x49,
+	0x44, 0x64, 0x2b, 0xb0, 0x1e, 0x50, 0xe3, 0x4d, 0x56, 0xca, 0x9d, 0x18, 0x30, 0x03, 0xef, 0x07,
+	0x7d, 0x6c, 0x16, 0xe3, 0x03, 0x04, 0xad, 0xa9, 0x8f, 0x98, 0x51, 0x88, 0xfb, 0x73, 0x89, 0x1b,
+	0x4a, 0xdc, 0x2c, 0x5c, 0x13, 0x7f, 0x65, 0xe2, 0xb2, 0x98, 0x85, 0xab, 0x24, 0x6e, 0xb2, 0x0c,
+	0xc2, 0x65, 0x68, 0xd2, 0xb2, 0xd8, 0x0e, 0xe2, 0x6e, 0xd1, 0xb1, 0x86, 0x23, 0x1b, 0xb6, 0x5f,
+	0x99, 0xfe, 0x86, 0x6a, 0x1c, 0x33, 0x4c, 0x66, 0xb8, 0x17, 0xe3, 0x3d, 0xfa, 0x56, 0xd8, 0x9e,
+	0xee, 0xa5, 0xdf, 0x31, 0xa5, 0xd1, 0x22, 0xc6, 0x14, 0xbc, 0x2b, 0x63, 0x01, 0xac, 0x6c, 0x25,
+	0xd3, 0x4e, 0xc4, 0x2c, 0x19, 0x66, 0xd0, 0x83, 0xb2, 0x69, 0x1a, 0x86, 0xea, 0x8f, 0x49, 0xdc,
+	0x5b, 0x54, 0x4f, 0x02, 0x94, 0x27, 0xcd, 0x62, 0xd3, 0x9e, 0xa0, 0xdb, 0x90, 0x6a, 0x07, 0xdc,
+	0xa1, 0xda, 0xac, 0x9f, 0x84, 0x60, 0x48, 0xf0, 0xbc, 0x44, 0x9d, 0xf1, 0x5b, 0xc9, 0x58, 0xfe,
+	0x58, 0x01, 0x69, 0x82, 0x9f, 0xad, 0x4e, 0x96, 0x44, 0xe4, 0xf8, 0x0a, 0xbd, 0xa7, 0x96, 0x54,
+	0xcb, 0x64, 0x01, 0xeb, 0x67, 0xa0, 0xde, 0x85, 0xb5, 0x89, 0x79, 0xfc, 0x53, 0x7b, 0x5c, 0xa6,
+	0x39, 0xed, 0x51, 0x55, 0xda, 0x61, 0x80, 0x7d, 0x74, 0x22, 0xe4, 0x7d, 0xe0, 0x1b, 0xf5, 0x32,
+	0xbf, 0x51, 0xd3, 0xb4, 0x40, 0x7e, 0x63, 0xf7, 0x15, 0x39, 0x53, 0x4f, 0xd3, 0xe6, 0x4a, 0xbb,
+	0x43, 0x0d, 0x29, 0x2c, 0x5d, 0x83, 0x73, 0x7b, 0x0d, 0x0c, 0x4c, 0xc9, 0x0a, 0x0c, 0xd0, 0xa9,
+	0xae, 0xcb, 0x0d, 0x36, 0xd4, 0xfc, 0xba, 0x81, 0x35, 0xd8, 0x33, 0xcc, 0x62, 0x55, 0x69, 0x8f,
+	0xa6, 0x65, 0xee, 0xc0, 0x3f, 0x32, 0x79, 0x1d, 0xf9, 0x9e, 0x40, 0xbd, 0xb6, 0x7d, 0x6c, 0xa8,
+	0x9c, 0x00, 0xfe, 0x58, 0x36, 0x84, 0x93, 0xff, 0x94, 0x52, 0x3f, 0x03, 0x7c, 0x82, 0xbe, 0x36,
+	0x65, 0xf3, 0xe2, 0xb9, 0xca, 0x17, 0x8f, 0x1a, 0xb3, 0xad, 0x82, 0xde, 0xa9, 0x56, 0x78, 0x50,
+	0xee, 0x18, 0x79, 0xa6, 0xd8, 0xb4, 0xa2, 0xda, 0x76, 0x45, 0xe5, 0x81, 0x25, 0xeb, 0x36, 0x43,
+	0x67, 0x39, 0xad, 0x07, 0xc0, 0xbe, 0xb3, 0x7d, 0xe5, 0x15, 0x76, 0x95, 0xaf, 0xb0, 0x9d, 0xb8,
+	0xef, 0x0b, 0xac, 0x3d, 0x10, 0xba, 0x83, 0x5e, 0x25, 0x92, 0xa7, 0x38, 0xa0, 0x5e, 0xd3, 0x2a,
+	0x9d, 0x24, 0x28, 0x10, 0xad, 0xc2, 0x9c, 0x7c, 0xa5, 0x12, 0x27, 0xa9, 0x1b, 0xe7, 0x36, 0x6b,
+	0x42, 0x53, 0x1c, 0xf0, 0xc2, 0x39, 0xcf, 0x17, 0xce, 0x14, 0x07, 0xdb, 0x7b, 0xd9, 0xa9, 0x92,
+	0xe3, 0x44, 0xba, 0xc9, 0x4e, 0x93, 0x29, 0x0e, 0xd2, 0xea, 0x68, 0xda, 0xd5, 0xa1, 0x21, 0x4a,
+	0x3c, 0xee, 0xc9, 0x53, 0xc4, 0x11, 0x74, 0x27, 0xfd, 0xe1, 0xab, 0x7f, 0x9e, 0x5f, 0xfd, 0x6a,
+	0xa4, 0x81, 0x6e, 0x91, 0x2b, 0x4c, 0xb9, 0xf4, 0x40, 0xec, 0xc9, 0x25, 0x91, 0x3d, 0x5c, 0x72,
+	0x01, 0x75, 0x51, 0x5d, 0xc8, 0x81, 0x38, 0x00, 0xcf, 0x28, 0xb9, 0x34, 0x54, 0x8d, 0xd3, 0x97,
+	0x2f, 0x60, 0xce, 0x28, 0xb5, 0x70, 0x6f, 0x28, 0x5d, 0x06, 0xa1, 0xe9, 0x75, 0x16, 0xdb, 0x63,
+	0xe1, 0x5c, 0x61, 0xfc, 0x74, 0x9d, 0xe2, 0x60, 0x16, 0xe2, 0x8c, 0x75, 0x69, 0xbe, 0xe7, 0xe3,
+	0x1d, 0x68, 0xea, 0x74, 0x3d, 0x10, 0x70, 0x9c, 0xb9, 0x36, 0xe2, 0x9f, 0xd4, 0xd4, 0x7c, 0x67,
+	0xed, 0x01, 0x93, 0x99, 0xc2, 0x77, 0x56, 0x73, 0x80, 0xec, 0xfc, 0xc2, 0xbf, 0x83, 0x62, 0x1f,
+	0xb3, 0x43, 0x4a, 0x24, 0x78, 0xd3, 0x69, 0xe7, 0x9b, 0x8e, 0x18, 0xb1, 0xfd, 0xd4, 0x3b, 0xb3,
+	0x74, 0xba, 0x33, 0x47, 0xe8, 0x54, 0x17, 0x99, 0x76, 0xa2, 0xd7, 0x76, 0x27, 0xb2, 0x61, 0x25,
+	0xeb, 0xf3, 0x16, 0x7d, 0x65, 0x69, 0x74, 0x46, 0x8e, 0x4d, 0x1f, 0x79, 0x67, 0x6a, 0xe7, 0x3b,
+	0xd3, 0x0e, 0xcc, 0x59, 0xce, 0xca, 0x83, 0x60, 0x2d, 0x37, 0x1f, 0x88, 0x3d, 0xf5, 0x24, 0xe2,
+	0xe4, 0xa6, 0x33, 0x72, 0x60, 0x4a, 0xec, 0x90, 0xe8, 0xc5, 0xe4, 0x25, 0x91, 0xea, 0xd5, 0x63,
+	0xeb, 0x73, 0xe3, 0x75, 0xd1, 0x91, 0x10, 0x47, 0xbd, 0x56, 0xc1, 0x0e, 0x93, 0xdb, 0xef, 0xbc,
+	0x38, 0x29, 0xdb, 0x74, 0x5d, 0xd3, 0xe5, 0x8a, 0x12, 0x92, 0xd2, 0x2f, 0xda, 0x96, 0xfd, 0xdd,
+	0x9a, 0x64, 0x0a, 0x51, 0x2a, 0xa9, 0x5a, 0xab, 0xa6, 0xca, 0x95, 0xd6, 0x43, 0xc7, 0x42, 0x18,
+	0x6f, 0x03, 0x17, 0xc5, 0x6d, 0x60, 0x9b, 0xe9, 0xf7, 0xe8, 0x4b, 0x4d, 0x96, 0x1b, 0xab, 0xab,
+	0xdc, 0x4a, 0xf7, 0x7d, 0xab, 0x68, 0xdf, 0xef, 0x74, 0xce, 0x3a, 0xa3, 0xee, 0x95, 0x4f, 0x7c,
+	0x8f, 0x5f, 0x14, 0xef, 0xf1, 0x4a, 0x7f, 0x7f, 0xd1, 0xad, 0x72, 0xc6, 0x75, 0xf5, 0xda, 0xf2,
+	0x57, 0x76, 0x35, 0xf8, 0xab, 0xbd, 0xdc, 0x72, 0x42, 0xdd, 0x29, 0xb7, 0xf8, 0x5f, 0xf9, 0xf6,
+	0x25, 0x0d, 0x0c, 0xaf, 0x1c, 0x61, 0x9a, 0x53, 0x69, 0x07, 0x6c, 0x15, 0x75, 0xc0, 0x9d, 0x9d,
+	0x72, 0x42, 0x75, 0x94, 0xa4, 0xb4, 0xe9, 0xb5, 0x8a, 0x9a, 0xde, 0xf6, 0xfd, 0xa2, 0xeb, 0xd9,
+	0x9b, 0xf3, 0xf4, 0x45, 0xfa, 0xcf, 0x80, 0x5f, 0xfe, 0x1f, 0x00, 0xea, 0x09, 0x5f, 0xd6, 0xd7,
+	0x18, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NoteKeeperClient is the client API for NoteKeeper service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NoteKeeperClient interface {
+	KeyExchange(ctx context.Context, in *KeyExchangeRequest, opts ...grpc.CallOption) (*KeyExchangeResponse, error)
+	ServiceShutdown(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ServiceStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ServiceStatusResponse, error)
+	ServiceLogs(ctx context.Context, in *ServiceLogsRequest, opts ...grpc.CallOption) (*ServiceLogsResponse, error)
+	ClientList(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetClientsResponse, error)
+	ClientRevoke(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	MasterDbOpen(ctx context.Context, in *OpenMasterDbRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountCreate(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*UserIdResponse, error)
+	AccountUnlock(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountSignin(ctx context.Context, in *SigninAccountRequest, opts ...grpc.CallOption) (*UserIdResponse, error)
+	AccountSignout(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountLock(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountStateGet(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*AccountStateResponse, error)
+	StatsAccount(ctx context.Context, in *AccountStatsRequest, opts ...grpc.CallOption) (*AccountStatsResponse, error)
+	UIStateLoad(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*LoadUIStateResponse, error)
+	UIStateSave(ctx context.Context, in *SaveUIStateRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserShelves(ctx context.Context, in *GetShelvesRequest, opts ...grpc.CallOption) (*GetShelvesResponse, error)
+	AccountShelves(ctx context.Context, in *GetShelvesRequest, opts ...grpc.CallOption) (*GetShelvesResponse, error)
+	UserShelfCreate(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	AccountShelfCreate(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	UserShelfSave(ctx context.Context, in *SaveShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountShelfSave(ctx context.Context, in *SaveShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserShelfDelete(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountShelfDelete(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error)
+	AccountCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error)
+	UserCollectionCreate(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	AccountCollectionCreate(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	UserCollectionSave(ctx context.Context, in *SaveCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountCollectionSave(ctx context.Context, in *SaveCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserCollectionDelete(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountCollectionDelete(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
+	AccountTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
+	UserTagCreate(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	AccountTagCreate(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	UserTagSave(ctx context.Context, in *SaveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountTagSave(ctx context.Context, in *SaveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserTagDelete(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountTagDelete(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserTagMove(ctx context.Context, in *MoveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountTagMove(ctx context.Context, in *MoveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserTagMerge(ctx context.Context, in *MergeTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountTagMerge(ctx context.Context, in *MergeTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserTagAssign(ctx context.Context, in *AssignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountTagAssign(ctx context.Context, in *AssignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserTagUnassign(ctx context.Context, in *UnassignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountTagUnassign(ctx context.Context, in *UnassignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNotebooks(ctx context.Context, in *GetNotebooksRequest, opts ...grpc.CallOption) (*GetNotebooksResponse, error)
+	AccountNotebooks(ctx context.Context, in *GetNotebooksRequest, opts ...grpc.CallOption) (*GetNotebooksResponse, error)
+	UserNotebookCreate(ctx context.Context, in *CreateNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	AccountNotebookCreate(ctx context.Context, in *CreateNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	UserNotebookSave(ctx context.Context, in *SaveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNotebookSave(ctx context.Context, in *SaveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNotebookDelete(ctx context.Context, in *DeleteNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNotebookDelete(ctx context.Context, in *DeleteNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNotebookMove(ctx context.Context, in *MoveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNotebookMove(ctx context.Context, in *MoveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNotebookCopy(ctx context.Context, in *CopyNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	AccountNotebookCopy(ctx context.Context, in *CopyNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	UserNotes(ctx context.Context, in *GetNotesRequest, opts ...grpc.CallOption) (*GetNotesResponse, error)
+	AccountNotes(ctx context.Context, in *GetNotesRequest, opts ...grpc.CallOption) (*GetNotesResponse, error)
+	UserNoteLoad(ctx context.Context, in *LoadNoteRequest, opts ...grpc.CallOption) (*LoadNoteResponse, error)
+	AccountNoteLoad(ctx context.Context, in *LoadNoteRequest, opts ...grpc.CallOption) (*LoadNoteResponse, error)
+	UserNoteCreate(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	AccountNoteCreate(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	UserNoteSave(ctx context.Context, in *SaveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNoteSave(ctx context.Context, in *SaveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNoteDelete(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNoteDelete(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNoteLock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNoteLock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNoteUnlock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNoteUnlock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNoteMove(ctx context.Context, in *MoveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AccountNoteMove(ctx context.Context, in *MoveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UserNoteCopy(ctx context.Context, in *CopyNoteRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	AccountNoteCopy(ctx context.Context, in *CopyNoteRequest, opts ...grpc.CallOption) (*IdResponse, error)
+}
+
+type noteKeeperClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNoteKeeperClient(cc *grpc.ClientConn) NoteKeeperClient {
+	return &noteKeeperClient{cc}
+}
+
+func (c *noteKeeperClient) KeyExchange(ctx context.Context, in *KeyExchangeRequest, opts ...grpc.CallOption) (*KeyExchangeResponse, error) {
+	out := new(KeyExchangeResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/KeyExchange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) ServiceShutdown(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/ServiceShutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) ServiceStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ServiceStatusResponse, error) {
+	out := new(ServiceStatusResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/ServiceStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) ServiceLogs(ctx context.Context, in *ServiceLogsRequest, opts ...grpc.CallOption) (*ServiceLogsResponse, error) {
+	out := new(ServiceLogsResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/ServiceLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) ClientList(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetClientsResponse, error) {
+	out := new(GetClientsResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/ClientList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) ClientRevoke(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/ClientRevoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) MasterDbOpen(ctx context.Context, in *OpenMasterDbRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/MasterDbOpen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountCreate(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*UserIdResponse, error) {
+	out := new(UserIdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountUnlock(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountUnlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountSignin(ctx context.Context, in *SigninAccountRequest, opts ...grpc.CallOption) (*UserIdResponse, error) {
+	out := new(UserIdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountSignin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountSignout(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountSignout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountLock(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountChangePassphrase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountStateGet(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*AccountStateResponse, error) {
+	out := new(AccountStateResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountStateGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) StatsAccount(ctx context.Context, in *AccountStatsRequest, opts ...grpc.CallOption) (*AccountStatsResponse, error) {
+	out := new(AccountStatsResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/StatsAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UIStateLoad(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*LoadUIStateResponse, error) {
+	out := new(LoadUIStateResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UIStateLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UIStateSave(ctx context.Context, in *SaveUIStateRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UIStateSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserShelves(ctx context.Context, in *GetShelvesRequest, opts ...grpc.CallOption) (*GetShelvesResponse, error) {
+	out := new(GetShelvesResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserShelves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountShelves(ctx context.Context, in *GetShelvesRequest, opts ...grpc.CallOption) (*GetShelvesResponse, error) {
+	out := new(GetShelvesResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountShelves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserShelfCreate(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserShelfCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountShelfCreate(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountShelfCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserShelfSave(ctx context.Context, in *SaveShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserShelfSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountShelfSave(ctx context.Context, in *SaveShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountShelfSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserShelfDelete(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserShelfDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountShelfDelete(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountShelfDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error) {
+	out := new(GetCollectionsResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserCollections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error) {
+	out := new(GetCollectionsResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountCollections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserCollectionCreate(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserCollectionCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountCollectionCreate(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountCollectionCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserCollectionSave(ctx context.Context, in *SaveCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserCollectionSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountCollectionSave(ctx context.Context, in *SaveCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountCollectionSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserCollectionDelete(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserCollectionDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountCollectionDelete(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountCollectionDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error) {
+	out := new(GetTagsResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error) {
+	out := new(GetTagsResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTagCreate(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTagCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTagCreate(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTagCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTagSave(ctx context.Context, in *SaveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTagSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTagSave(ctx context.Context, in *SaveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTagSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTagDelete(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTagDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTagDelete(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTagDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTagMove(ctx context.Context, in *MoveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTagMove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTagMove(ctx context.Context, in *MoveTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTagMove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTagMerge(ctx context.Context, in *MergeTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTagMerge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTagMerge(ctx context.Context, in *MergeTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTagMerge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTagAssign(ctx context.Context, in *AssignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTagAssign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTagAssign(ctx context.Context, in *AssignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTagAssign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserTagUnassign(ctx context.Context, in *UnassignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserTagUnassign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountTagUnassign(ctx context.Context, in *UnassignTagRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountTagUnassign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNotebooks(ctx context.Context, in *GetNotebooksRequest, opts ...grpc.CallOption) (*GetNotebooksResponse, error) {
+	out := new(GetNotebooksResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNotebooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNotebooks(ctx context.Context, in *GetNotebooksRequest, opts ...grpc.CallOption) (*GetNotebooksResponse, error) {
+	out := new(GetNotebooksResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNotebooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNotebookCreate(ctx context.Context, in *CreateNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNotebookCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNotebookCreate(ctx context.Context, in *CreateNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNotebookCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNotebookSave(ctx context.Context, in *SaveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNotebookSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNotebookSave(ctx context.Context, in *SaveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNotebookSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNotebookDelete(ctx context.Context, in *DeleteNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNotebookDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNotebookDelete(ctx context.Context, in *DeleteNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNotebookDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNotebookMove(ctx context.Context, in *MoveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNotebookMove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNotebookMove(ctx context.Context, in *MoveNotebookRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNotebookMove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNotebookCopy(ctx context.Context, in *CopyNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNotebookCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNotebookCopy(ctx context.Context, in *CopyNotebookRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNotebookCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNotes(ctx context.Context, in *GetNotesRequest, opts ...grpc.CallOption) (*GetNotesResponse, error) {
+	out := new(GetNotesResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNotes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNotes(ctx context.Context, in *GetNotesRequest, opts ...grpc.CallOption) (*GetNotesResponse, error) {
+	out := new(GetNotesResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNotes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteLoad(ctx context.Context, in *LoadNoteRequest, opts ...grpc.CallOption) (*LoadNoteResponse, error) {
+	out := new(LoadNoteResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteLoad(ctx context.Context, in *LoadNoteRequest, opts ...grpc.CallOption) (*LoadNoteResponse, error) {
+	out := new(LoadNoteResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteCreate(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteCreate(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteSave(ctx context.Context, in *SaveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteSave(ctx context.Context, in *SaveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteSave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteDelete(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteDelete(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteLock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteLock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteUnlock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteUnlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteUnlock(ctx context.Context, in *LockNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteUnlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteMove(ctx context.Context, in *MoveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteMove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteMove(ctx context.Context, in *MoveNoteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteMove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) UserNoteCopy(ctx context.Context, in *CopyNoteRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/UserNoteCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteKeeperClient) AccountNoteCopy(ctx context.Context, in *CopyNoteRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/notekeeper.NoteKeeper/AccountNoteCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NoteKeeperServer is the server API for NoteKeeper service.
+type NoteKeeperServer interface {
+	KeyExchange(context.Context, *KeyExchangeRequest) (*KeyExchangeResponse, error)
+	ServiceShutdown(context.Context, *EmptyRequest) (*EmptyResponse, error)
+	ServiceStatus(context.Context, *EmptyRequest) (*ServiceStatusResponse, error)
+	ServiceLogs(context.Context, *ServiceLogsRequest) (*ServiceLogsResponse, error)
+	ClientList(context.Context, *EmptyRequest) (*GetClientsResponse, error)
+	ClientRevoke(context.Context, *IdRequest) (*EmptyResponse, error)
+	MasterDbOpen(context.Context, *OpenMasterDbRequest) (*EmptyResponse, error)
+	AccountCreate(context.Context, *CreateAccountRequest) (*UserIdResponse, error)
+	AccountUnlock(context.Context, *UnlockAccountRequest) (*EmptyResponse, error)
+	AccountSignin(context.Context, *SigninAccountRequest) (*UserIdResponse, error)
+	AccountSignout(context.Context, *EmptyRequest) (*EmptyResponse, error)
+	AccountLock(context.Context, *EmptyRequest) (*EmptyResponse, error)
+	AccountChangePassphrase(context.Context, *ChangePassphraseRequest) (*EmptyResponse, error)
+	AccountStateGet(context.Context, *EmptyRequest) (*AccountStateResponse, error)
+	StatsAccount(context.Context, *AccountStatsRequest) (*AccountStatsResponse, error)
+	UIStateLoad(context.Context, *EmptyRequest) (*LoadUIStateResponse, error)
+	UIStateSave(context.Context, *SaveUIStateRequest) (*EmptyResponse, error)
+	UserShelves(context.Context, *GetShelvesRequest) (*GetShelvesResponse, error)
+	AccountShelves(context.Context, *GetShelvesRequest) (*GetShelvesResponse, error)
+	UserShelfCreate(context.Context, *CreateShelfRequest) (*IdResponse, error)
+	AccountShelfCreate(context.Context, *CreateShelfRequest) (*IdResponse, error)
+	UserShelfSave(context.Context, *SaveShelfRequest) (*EmptyResponse, error)
+	AccountShelfSave(context.Context, *SaveShelfRequest) (*EmptyResponse, error)
+	UserShelfDelete(context.Context, *DeleteShelfRequest) (*EmptyResponse, error)
+	AccountShelfDelete(context.Context, *DeleteShelfRequest) (*EmptyResponse, error)
+	UserCollections(context.Context, *GetCollectionsRequest) (*GetCollectionsResponse, error)
+	AccountCollections(context.Context, *GetCollectionsRequest) (*GetCollectionsResponse, error)
+	UserCollectionCreate(context.Context, *CreateCollectionRequest) (*IdResponse, error)
+	AccountCollectionCreate(context.Context, *CreateCollectionRequest) (*IdResponse, error)
+	UserCollectionSave(context.Context, *SaveCollectionRequest) (*EmptyResponse, error)
+	AccountCollectionSave(context.Context, *SaveCollectionRequest) (*EmptyResponse, error)
+	UserCollectionDelete(context.Context, *DeleteCollectionRequest) (*EmptyResponse, error)
+	AccountCollectionDelete(context.Context, *DeleteCollectionRequest) (*EmptyResponse, error)
+	UserTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error)
+	AccountTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error)
+	UserTagCreate(context.Context, *CreateTagRequest) (*IdResponse, error)
+	AccountTagCreate(context.Context, *CreateTagRequest) (*IdResponse, error)
+	UserTagSave(context.Context, *SaveTagRequest) (*EmptyResponse, error)
+	AccountTagSave(context.Context, *SaveTagRequest) (*EmptyResponse, error)
+	UserTagDelete(context.Context, *DeleteTagRequest) (*EmptyResponse, error)
+	AccountTagDelete(context.Context, *DeleteTagRequest) (*EmptyResponse, error)
+	UserTagMove(context.Context, *MoveTagRequest) (*EmptyResponse, error)
+	AccountTagMove(context.Context, *MoveTagRequest) (*EmptyResponse, error)
+	UserTagMerge(context.Context, *MergeTagRequest) (*EmptyResponse, error)
+	AccountTagMerge(context.Context, *MergeTagRequest) (*EmptyResponse, error)
+	UserTagAssign(context.Context, *AssignTagRequest) (*EmptyResponse, error)
+	AccountTagAssign(context.Context, *AssignTagRequest) (*EmptyResponse, error)
+	UserTagUnassign(context.Context, *UnassignTagRequest) (*EmptyResponse, error)
+	AccountTagUnassign(context.Context, *UnassignTagRequest) (*EmptyResponse, error)
+	UserNotebooks(context.Context, *GetNotebooksRequest) (*GetNotebooksResponse, error)
+	AccountNotebooks(context.Context, *GetNotebooksRequest) (*GetNotebooksResponse, error)
+	UserNotebookCreate(context.Context, *CreateNotebookRequest) (*IdResponse, error)
+	AccountNotebookCreate(context.Context, *CreateNotebookRequest) (*IdResponse, error)
+	UserNotebookSave(context.Context, *SaveNotebookRequest) (*EmptyResponse, error)
+	AccountNotebookSave(context.Context, *SaveNotebookRequest) (*EmptyResponse, error)
+	UserNotebookDelete(context.Context, *DeleteNotebookRequest) (*EmptyResponse, error)
+	AccountNotebookDelete(context.Context, *DeleteNotebookRequest) (*EmptyResponse, error)
+	UserNotebookMove(context.Context, *MoveNotebookRequest) (*EmptyResponse, error)
+	AccountNotebookMove(context.Context, *MoveNotebookRequest) (*EmptyResponse, error)
+	UserNotebookCopy(context.Context, *CopyNotebookRequest) (*IdResponse, error)
+	AccountNotebookCopy(context.Context, *CopyNotebookRequest) (*IdResponse, error)
+	UserNotes(context.Context, *GetNotesRequest) (*GetNotesResponse, error)
+	AccountNotes(context.Context, *GetNotesRequest) (*GetNotesResponse, error)
+	UserNoteLoad(context.Context, *LoadNoteRequest) (*LoadNoteResponse, error)
+	AccountNoteLoad(context.Context, *LoadNoteRequest) (*LoadNoteResponse, error)
+	UserNoteCreate(context.Context, *CreateNoteRequest) (*IdResponse, error)
+	AccountNoteCreate(context.Context, *CreateNoteRequest) (*IdResponse, error)
+	UserNoteSave(context.Context, *SaveNoteRequest) (*EmptyResponse, error)
+	AccountNoteSave(context.Context, *SaveNoteRequest) (*EmptyResponse, error)
+	UserNoteDelete(context.Context, *DeleteNoteRequest) (*EmptyResponse, error)
+	AccountNoteDelete(context.Context, *DeleteNoteRequest) (*EmptyResponse, error)
+	UserNoteLock(context.Context, *LockNoteRequest) (*EmptyResponse, error)
+	AccountNoteLock(context.Context, *LockNoteRequest) (*EmptyResponse, error)
+	UserNoteUnlock(context.Context, *LockNoteRequest) (*EmptyResponse, error)
+	AccountNoteUnlock(context.Context, *LockNoteRequest) (*EmptyResponse, error)
+	UserNoteMove(context.Context, *MoveNoteRequest) (*EmptyResponse, error)
+	AccountNoteMove(context.Context, *MoveNoteRequest) (*EmptyResponse, error)
+	UserNoteCopy(context.Context, *CopyNoteRequest) (*IdResponse, error)
+	AccountNoteCopy(context.Context, *CopyNoteRequest) (*IdResponse, error)
+}
+
+// UnimplementedNoteKeeperServer can be embedded to have forward compatible implementations.
+type UnimplementedNoteKeeperServer struct {
+}
+
+func (*UnimplementedNoteKeeperServer) KeyExchange(ctx context.Context, req *KeyExchangeRequest) (*KeyExchangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeyExchange not implemented")
+}
+func (*UnimplementedNoteKeeperServer) ServiceShutdown(ctx context.Context, req *EmptyRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceShutdown not implemented")
+}
+func (*UnimplementedNoteKeeperServer) ServiceStatus(ctx context.Context, req *EmptyRequest) (*ServiceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceStatus not implemented")
+}
+func (*UnimplementedNoteKeeperServer) ServiceLogs(ctx context.Context, req *ServiceLogsRequest) (*ServiceLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceLogs not implemented")
+}
+func (*UnimplementedNoteKeeperServer) ClientList(ctx context.Context, req *EmptyRequest) (*GetClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientList not implemented")
+}
+func (*UnimplementedNoteKeeperServer) ClientRevoke(ctx context.Context, req *IdRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientRevoke not implemented")
+}
+func (*UnimplementedNoteKeeperServer) MasterDbOpen(ctx context.Context, req *OpenMasterDbRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MasterDbOpen not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountCreate(ctx context.Context, req *CreateAccountRequest) (*UserIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountUnlock(ctx context.Context, req *UnlockAccountRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountUnlock not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountSignin(ctx context.Context, req *SigninAccountRequest) (*UserIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountSignin not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountSignout(ctx context.Context, req *EmptyRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountSignout not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountLock(ctx context.Context, req *EmptyRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountLock not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountChangePassphrase(ctx context.Context, req *ChangePassphraseRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountChangePassphrase not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountStateGet(ctx context.Context, req *EmptyRequest) (*AccountStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountStateGet not implemented")
+}
+func (*UnimplementedNoteKeeperServer) StatsAccount(ctx context.Context, req *AccountStatsRequest) (*AccountStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatsAccount not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UIStateLoad(ctx context.Context, req *EmptyRequest) (*LoadUIStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UIStateLoad not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UIStateSave(ctx context.Context, req *SaveUIStateRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UIStateSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserShelves(ctx context.Context, req *GetShelvesRequest) (*GetShelvesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserShelves not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountShelves(ctx context.Context, req *GetShelvesRequest) (*GetShelvesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountShelves not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserShelfCreate(ctx context.Context, req *CreateShelfRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserShelfCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountShelfCreate(ctx context.Context, req *CreateShelfRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountShelfCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserShelfSave(ctx context.Context, req *SaveShelfRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserShelfSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountShelfSave(ctx context.Context, req *SaveShelfRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountShelfSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserShelfDelete(ctx context.Context, req *DeleteShelfRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserShelfDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountShelfDelete(ctx context.Context, req *DeleteShelfRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountShelfDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserCollections(ctx context.Context, req *GetCollectionsRequest) (*GetCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserCollections not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountCollections(ctx context.Context, req *GetCollectionsRequest) (*GetCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountCollections not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserCollectionCreate(ctx context.Context, req *CreateCollectionRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserCollectionCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountCollectionCreate(ctx context.Context, req *CreateCollectionRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountCollectionCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserCollectionSave(ctx context.Context, req *SaveCollectionRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserCollectionSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountCollectionSave(ctx context.Context, req *SaveCollectionRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountCollectionSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserCollectionDelete(ctx context.Context, req *DeleteCollectionRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserCollectionDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountCollectionDelete(ctx context.Context, req *DeleteCollectionRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountCollectionDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTags(ctx context.Context, req *GetTagsRequest) (*GetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTags not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTags(ctx context.Context, req *GetTagsRequest) (*GetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTags not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTagCreate(ctx context.Context, req *CreateTagRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTagCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTagCreate(ctx context.Context, req *CreateTagRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTagCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTagSave(ctx context.Context, req *SaveTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTagSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTagSave(ctx context.Context, req *SaveTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTagSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTagDelete(ctx context.Context, req *DeleteTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTagDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTagDelete(ctx context.Context, req *DeleteTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTagDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTagMove(ctx context.Context, req *MoveTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTagMove not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTagMove(ctx context.Context, req *MoveTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTagMove not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTagMerge(ctx context.Context, req *MergeTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTagMerge not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTagMerge(ctx context.Context, req *MergeTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTagMerge not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTagAssign(ctx context.Context, req *AssignTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTagAssign not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTagAssign(ctx context.Context, req *AssignTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTagAssign not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserTagUnassign(ctx context.Context, req *UnassignTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTagUnassign not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountTagUnassign(ctx context.Context, req *UnassignTagRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountTagUnassign not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNotebooks(ctx context.Context, req *GetNotebooksRequest) (*GetNotebooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNotebooks not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNotebooks(ctx context.Context, req *GetNotebooksRequest) (*GetNotebooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNotebooks not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNotebookCreate(ctx context.Context, req *CreateNotebookRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNotebookCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNotebookCreate(ctx context.Context, req *CreateNotebookRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNotebookCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNotebookSave(ctx context.Context, req *SaveNotebookRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNotebookSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNotebookSave(ctx context.Context, req *SaveNotebookRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNotebookSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNotebookDelete(ctx context.Context, req *DeleteNotebookRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNotebookDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNotebookDelete(ctx context.Context, req *DeleteNotebookRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNotebookDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNotebookMove(ctx context.Context, req *MoveNotebookRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNotebookMove not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNotebookMove(ctx context.Context, req *MoveNotebookRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNotebookMove not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNotebookCopy(ctx context.Context, req *CopyNotebookRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNotebookCopy not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNotebookCopy(ctx context.Context, req *CopyNotebookRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNotebookCopy not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNotes(ctx context.Context, req *GetNotesRequest) (*GetNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNotes not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNotes(ctx context.Context, req *GetNotesRequest) (*GetNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNotes not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteLoad(ctx context.Context, req *LoadNoteRequest) (*LoadNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteLoad not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteLoad(ctx context.Context, req *LoadNoteRequest) (*LoadNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteLoad not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteCreate(ctx context.Context, req *CreateNoteRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteCreate(ctx context.Context, req *CreateNoteRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteCreate not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteSave(ctx context.Context, req *SaveNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteSave(ctx context.Context, req *SaveNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteSave not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteDelete(ctx context.Context, req *DeleteNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteDelete(ctx context.Context, req *DeleteNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteDelete not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteLock(ctx context.Context, req *LockNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteLock not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteLock(ctx context.Context, req *LockNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteLock not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteUnlock(ctx context.Context, req *LockNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteUnlock not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteUnlock(ctx context.Context, req *LockNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteUnlock not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteMove(ctx context.Context, req *MoveNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteMove not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteMove(ctx context.Context, req *MoveNoteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteMove not implemented")
+}
+func (*UnimplementedNoteKeeperServer) UserNoteCopy(ctx context.Context, req *CopyNoteRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNoteCopy not implemented")
+}
+func (*UnimplementedNoteKeeperServer) AccountNoteCopy(ctx context.Context, req *CopyNoteRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNoteCopy not implemented")
+}
+
+func RegisterNoteKeeperServer(s *grpc.Server, srv NoteKeeperServer) {
+	s.RegisterService(&_NoteKeeper_serviceDesc, srv)
+}
+
+func _NoteKeeper_KeyExchange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyExchangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).KeyExchange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/KeyExchange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).KeyExchange(ctx, req.(*KeyExchangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_ServiceShutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).ServiceShutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/ServiceShutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).ServiceShutdown(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_ServiceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).ServiceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/ServiceStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).ServiceStatus(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_ServiceLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).ServiceLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/ServiceLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).ServiceLogs(ctx, req.(*ServiceLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_ClientList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).ClientList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/ClientList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).ClientList(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_ClientRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).ClientRevoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/ClientRevoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).ClientRevoke(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_MasterDbOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenMasterDbRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).MasterDbOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/MasterDbOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).MasterDbOpen(ctx, req.(*OpenMasterDbRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountCreate(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountUnlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountUnlock(ctx, req.(*UnlockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountSignin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SigninAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountSignin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountSignin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountSignin(ctx, req.(*SigninAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountSignout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountSignout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountSignout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountSignout(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountLock(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountChangePassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePassphraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountChangePassphrase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountChangePassphrase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountChangePassphrase(ctx, req.(*ChangePassphraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountStateGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountStateGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountStateGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountStateGet(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_StatsAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).StatsAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/StatsAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).StatsAccount(ctx, req.(*AccountStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UIStateLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UIStateLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UIStateLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UIStateLoad(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UIStateSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveUIStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UIStateSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UIStateSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UIStateSave(ctx, req.(*SaveUIStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserShelves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShelvesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserShelves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserShelves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserShelves(ctx, req.(*GetShelvesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountShelves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShelvesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountShelves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountShelves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountShelves(ctx, req.(*GetShelvesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserShelfCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserShelfCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserShelfCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserShelfCreate(ctx, req.(*CreateShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountShelfCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountShelfCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountShelfCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountShelfCreate(ctx, req.(*CreateShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserShelfSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserShelfSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserShelfSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserShelfSave(ctx, req.(*SaveShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountShelfSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountShelfSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountShelfSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountShelfSave(ctx, req.(*SaveShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserShelfDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserShelfDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserShelfDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserShelfDelete(ctx, req.(*DeleteShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountShelfDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountShelfDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountShelfDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountShelfDelete(ctx, req.(*DeleteShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserCollections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserCollections(ctx, req.(*GetCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountCollections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountCollections(ctx, req.(*GetCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserCollectionCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserCollectionCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserCollectionCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserCollectionCreate(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountCollectionCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountCollectionCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountCollectionCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountCollectionCreate(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserCollectionSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserCollectionSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserCollectionSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserCollectionSave(ctx, req.(*SaveCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountCollectionSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountCollectionSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountCollectionSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountCollectionSave(ctx, req.(*SaveCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserCollectionDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserCollectionDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserCollectionDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserCollectionDelete(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountCollectionDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountCollectionDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountCollectionDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountCollectionDelete(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTags(ctx, req.(*GetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTags(ctx, req.(*GetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTagCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTagCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTagCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTagCreate(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTagCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTagCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTagCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTagCreate(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTagSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTagSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTagSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTagSave(ctx, req.(*SaveTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTagSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTagSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTagSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTagSave(ctx, req.(*SaveTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTagDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTagDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTagDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTagDelete(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTagDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTagDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTagDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTagDelete(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTagMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTagMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTagMove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTagMove(ctx, req.(*MoveTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTagMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTagMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTagMove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTagMove(ctx, req.(*MoveTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTagMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTagMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTagMerge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTagMerge(ctx, req.(*MergeTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTagMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTagMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTagMerge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTagMerge(ctx, req.(*MergeTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTagAssign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTagAssign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTagAssign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTagAssign(ctx, req.(*AssignTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTagAssign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTagAssign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTagAssign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTagAssign(ctx, req.(*AssignTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserTagUnassign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserTagUnassign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserTagUnassign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserTagUnassign(ctx, req.(*UnassignTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountTagUnassign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountTagUnassign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountTagUnassign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountTagUnassign(ctx, req.(*UnassignTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNotebooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotebooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNotebooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNotebooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNotebooks(ctx, req.(*GetNotebooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNotebooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotebooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNotebooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNotebooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNotebooks(ctx, req.(*GetNotebooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNotebookCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNotebookCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNotebookCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNotebookCreate(ctx, req.(*CreateNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNotebookCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNotebookCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNotebookCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNotebookCreate(ctx, req.(*CreateNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNotebookSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNotebookSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNotebookSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNotebookSave(ctx, req.(*SaveNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNotebookSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNotebookSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNotebookSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNotebookSave(ctx, req.(*SaveNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNotebookDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNotebookDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNotebookDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNotebookDelete(ctx, req.(*DeleteNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNotebookDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNotebookDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNotebookDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNotebookDelete(ctx, req.(*DeleteNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNotebookMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNotebookMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNotebookMove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNotebookMove(ctx, req.(*MoveNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNotebookMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNotebookMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNotebookMove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNotebookMove(ctx, req.(*MoveNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNotebookCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNotebookCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNotebookCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNotebookCopy(ctx, req.(*CopyNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNotebookCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyNotebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNotebookCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNotebookCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNotebookCopy(ctx, req.(*CopyNotebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNotes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNotes(ctx, req.(*GetNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNotes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNotes(ctx, req.(*GetNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteLoad(ctx, req.(*LoadNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteLoad(ctx, req.(*LoadNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteCreate(ctx, req.(*CreateNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteCreate(ctx, req.(*CreateNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteSave(ctx, req.(*SaveNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteSave(ctx, req.(*SaveNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteDelete(ctx, req.(*DeleteNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteDelete(ctx, req.(*DeleteNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteLock(ctx, req.(*LockNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteLock(ctx, req.(*LockNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteUnlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteUnlock(ctx, req.(*LockNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteUnlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteUnlock(ctx, req.(*LockNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteMove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteMove(ctx, req.(*MoveNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteMove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteMove(ctx, req.(*MoveNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_UserNoteCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).UserNoteCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/UserNoteCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).UserNoteCopy(ctx, req.(*CopyNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteKeeper_AccountNoteCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteKeeperServer).AccountNoteCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notekeeper.NoteKeeper/AccountNoteCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteKeeperServer).AccountNoteCopy(ctx, req.(*CopyNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NoteKeeper_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "notekeeper.NoteKeeper",
+	HandlerType: (*NoteKeeperServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "KeyExchange",
+			Handler:    _NoteKeeper_KeyExchange_Handler,
+		},
+		{
+			MethodName: "ServiceShutdown",
+			Handler:    _NoteKeeper_ServiceShutdown_Handler,
+		},
+		{
+			MethodName: "ServiceStatus",
+			Handler:    _NoteKeeper_ServiceStatus_Handler,
+		},
+		{
+			MethodName: "ServiceLogs",
+			Handler:    _NoteKeeper_ServiceLogs_Handler,
+		},
+		{
+			MethodName: "ClientList",
+			Handler:    _NoteKeeper_ClientList_Handler,
+		},
+		{
+			MethodName: "ClientRevoke",
+			Handler:    _NoteKeeper_ClientRevoke_Handler,
+		},
+		{
+			MethodName: "MasterDbOpen",
+			Handler:    _NoteKeeper_MasterDbOpen_Handler,
+		},
+		{
+			MethodName: "AccountCreate",
+			Handler:    _NoteKeeper_AccountCreate_Handler,
+		},
+		{
+			MethodName: "AccountUnlock",
+			Handler:    _NoteKeeper_AccountUnlock_Handler,
+		},
+		{
+			MethodName: "AccountSignin",
+			Handler:    _NoteKeeper_AccountSignin_Handler,
+		},
+		{
+			MethodName: "AccountSignout",
+			Handler:    _NoteKeeper_AccountSignout_Handler,
+		},
+		{
+			MethodName: "AccountLock",
+			Handler:    _NoteKeeper_AccountLock_Handler,
+		},
+		{
+			MethodName: "AccountChangePassphrase",
+			Handler:    _NoteKeeper_AccountChangePassphrase_Handler,
+		},
+		{
+			MethodName: "AccountStateGet",
+			Handler:    _NoteKeeper_AccountStateGet_Handler,
+		},
+		{
+			MethodName: "StatsAccount",
+			Handler:    _NoteKeeper_StatsAccount_Handler,
+		},
+		{
+			MethodName: "UIStateLoad",
+			Handler:    _NoteKeeper_UIStateLoad_Handler,
+		},
+		{
+			MethodName: "UIStateSave",
+			Handler:    _NoteKeeper_UIStateSave_Handler,
+		},
+		{
+			MethodName: "UserShelves",
+			Handler:    _NoteKeeper_UserShelves_Handler,
+		},
+		{
+			MethodName: "AccountShelves",
+			Handler:    _NoteKeeper_AccountShelves_Handler,
+		},
+		{
+			MethodName: "UserShelfCreate",
+			Handler:    _NoteKeeper_UserShelfCreate_Handler,
+		},
+		{
+			MethodName: "AccountShelfCreate",
+			Handler:    _NoteKeeper_AccountShelfCreate_Handler,
+		},
+		{
+			MethodName: "UserShelfSave",
+			Handler:    _NoteKeeper_UserShelfSave_Handler,
+		},
+		{
+			MethodName: "AccountShelfSave",
+			Handler:    _NoteKeeper_AccountShelfSave_Handler,
+		},
+		{
+			MethodName: "UserShelfDelete",
+			Handler:    _NoteKeeper_UserShelfDelete_Handler,
+		},
+		{
+			MethodName: "AccountShelfDelete",
+			Handler:    _NoteKeeper_AccountShelfDelete_Handler,
+		},
+		{
+			MethodName: "UserCollections",
+			Handler:    _NoteKeeper_UserCollections_Handler,
+		},
+		{
+			MethodName: "AccountCollections",
+			Handler:    _NoteKeeper_AccountCollections_Handler,
+		},
+		{
+			MethodName: "UserCollectionCreate",
+			Handler:    _NoteKeeper_UserCollectionCreate_Handler,
+		},
+		{
+			MethodName: "AccountCollectionCreate",
+			Handler:    _NoteKeeper_AccountCollectionCreate_Handler,
+		},
+		{
+			MethodName: "UserCollectionSave",
+			Handler:    _NoteKeeper_UserCollectionSave_Handler,
+		},
+		{
+			MethodName: "AccountCollectionSave",
+			Handler:    _NoteKeeper_AccountCollectionSave_Handler,
+		},
+		{
+			MethodName: "UserCollectionDelete",
+			Handler:    _NoteKeeper_UserCollectionDelete_Handler,
+		},
+		{
+			MethodName: "AccountCollectionDelete",
+			Handler:    _NoteKeeper_AccountCollectionDelete_Handler,
+		},
+		{
+			MethodName: "UserTags",
+			Handler:    _NoteKeeper_UserTags_Handler,
+		},
+		{
+			MethodName: "AccountTags",
+			Handler:    _NoteKeeper_AccountTags_Handler,
+		},
+		{
+			MethodName: "UserTagCreate",
+			Handler:    _NoteKeeper_UserTagCreate_Handler,
+		},
+		{
+			MethodName: "AccountTagCreate",
+			Handler:    _NoteKeeper_AccountTagCreate_Handler,
+		},
+		{
+			MethodName: "UserTagSave",
+			Handler:    _NoteKeeper_UserTagSave_Handler,
+		},
+		{
+			MethodName: "AccountTagSave",
+			Handler:    _NoteKeeper_AccountTagSave_Handler,
+		},
+		{
+			MethodName: "UserTagDelete",
+			Handler:    _NoteKeeper_UserTagDelete_Handler,
+		},
+		{
+			MethodName: "AccountTagDelete",
+			Handler:    _NoteKeeper_AccountTagDelete_Handler,
+		},
+		{
+			MethodName: "UserTagMove",
+			Handler:    _NoteKeeper_UserTagMove_Handler,
+		},
+		{
+			MethodName: "AccountTagMove",
+			Handler:    _NoteKeeper_AccountTagMove_Handler,
+		},
+		{
+			MethodName: "UserTagMerge",
+			Handler:    _NoteKeeper_UserTagMerge_Handler,
+		},
+		{
+			MethodName: "AccountTagMerge",
+			Handler:    _NoteKeeper_AccountTagMerge_Handler,
+		},
+		{
+			MethodName: "UserTagAssign",
+			Handler:    _NoteKeeper_UserTagAssign_Handler,
+		},
+		{
+			MethodName: "AccountTagAssign",
+			Handler:    _NoteKeeper_AccountTagAssign_Handler,
+		},
+		{
+			MethodName: "UserTagUnassign",
+			Handler:    _NoteKeeper_UserTagUnassign_Handler,
+		},
+		{
+			MethodName: "AccountTagUnassign",
+			Handler:    _NoteKeeper_AccountTagUnassign_Handler,
+		},
+		{
+			MethodName: "UserNotebooks",
+			Handler:    _NoteKeeper_UserNotebooks_Handler,
+		},
+		{
+			MethodName: "AccountNotebooks",
+			Handler:    _NoteKeeper_AccountNotebooks_Handler,
+		},
+		{
+			MethodName: "UserNotebookCreate",
+			Handler:    _NoteKeeper_UserNotebookCreate_Handler,
+		},
+		{
+			MethodName: "AccountNotebookCreate",
+			Handler:    _NoteKeeper_AccountNotebookCreate_Handler,
+		},
+		{
+			MethodName: "UserNotebookSave",
+			Handler:    _NoteKeeper_UserNotebookSave_Handler,
+		},
+		{
+			MethodName: "AccountNotebookSave",
+			Handler:    _NoteKeeper_AccountNotebookSave_Handler,
+		},
+		{
+			MethodName: "UserNotebookDelete",
+			Handler:    _NoteKeeper_UserNotebookDelete_Handler,
+		},
+		{
+			MethodName: "AccountNotebookDelete",
+			Handler:    _NoteKeeper_AccountNotebookDelete_Handler,
+		},
+		{
+			MethodName: "UserNotebookMove",
+			Handler:    _NoteKeeper_UserNotebookMove_Handler,
+		},
+		{
+			MethodName: "AccountNotebookMove",
+			Handler:    _NoteKeeper_AccountNotebookMove_Handler,
+		},
+		{
+			MethodName: "UserNotebookCopy",
+			Handler:    _NoteKeeper_UserNotebookCopy_Handler,
+		},
+		{
+			MethodName: "AccountNotebookCopy",
+			Handler:    _NoteKeeper_AccountNotebookCopy_Handler,
+		},
+		{
+			MethodName: "UserNotes",
+			Handler:    _NoteKeeper_UserNotes_Handler,
+		},
+		{
+			MethodName: "AccountNotes",
+			Handler:    _NoteKeeper_AccountNotes_Handler,
+		},
+		{
+			MethodName: "UserNoteLoad",
+			Handler:    _NoteKeeper_UserNoteLoad_Handler,
+		},
+		{
+			MethodName: "AccountNoteLoad",
+			Handler:    _NoteKeeper_AccountNoteLoad_Handler,
+		},
+		{
+			MethodName: "UserNoteCreate",
+			Handler:    _NoteKeeper_UserNoteCreate_Handler,
+		},
+		{
+			MethodName: "AccountNoteCreate",
+			Handler:    _NoteKeeper_AccountNoteCreate_Handler,
+		},
+		{
+			MethodName: "UserNoteSave",
+			Handler:    _NoteKeeper_UserNoteSave_Handler,
+		},
+		{
+			MethodName: "AccountNoteSave",
+			Handler:    _NoteKeeper_AccountNoteSave_Handler,
+		},
+		{
+			MethodName: "UserNoteDelete",
+			Handler:    _NoteKeeper_UserNoteDelete_Handler,
+		},
+		{
+			MethodName: "AccountNoteDelete",
+			Handler:    _NoteKeeper_AccountNoteDelete_Handler,
+		},
+		{
+			MethodName: "UserNoteLock",
+			Handler:    _NoteKeeper_UserNoteLock_Handler,
+		},
+		{
+			MethodName: "AccountNoteLock",
+			Handler:    _NoteKeeper_AccountNoteLock_Handler,
+		},
+		{
+			MethodName: "UserNoteUnlock",
+			Handler:    _NoteKeeper_UserNoteUnlock_Handler,
+		},
+		{
+			MethodName: "AccountNoteUnlock",
+			Handler:    _NoteKeeper_AccountNoteUnlock_Handler,
+		},
+		{
+			MethodName: "UserNoteMove",
+			Handler:    _NoteKeeper_UserNoteMove_Handler,
+		},
+		{
+			MethodName: "AccountNoteMove",
+			Handler:    _NoteKeeper_AccountNoteMove_Handler,
+		},
+		{
+			MethodName: "UserNoteCopy",
+			Handler:    _NoteKeeper_UserNoteCopy_Handler,
+		},
+		{
+			MethodName: "AccountNoteCopy",
+			Handler:    _NoteKeeper_AccountNoteCopy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
@@ -29,6 +29,9 @@ type Shelf struct {
 	Locked               bool     `protobuf:"varint,6,opt,name=locked,proto3" json:"locked,omitempty"`
 	Created              string   `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
 	Updated              string   `protobuf:"bytes,8,opt,name=updated,proto3" json:"updated,omitempty"`
+	NoteCount            int32    `protobuf:"varint,9,opt,name=noteCount,proto3" json:"noteCount,omitempty"`
+	ContentBytes         int64    `protobuf:"varint,10,opt,name=contentBytes,proto3" json:"contentBytes,omitempty"`
+	LastModified         string   `protobuf:"bytes,11,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -115,10 +118,32 @@ func (m *Shelf) GetUpdated() string {
 	return ""
 }
 
+func (m *Shelf) GetNoteCount() int32 {
+	if m != nil {
+		return m.NoteCount
+	}
+	return 0
+}
+
+func (m *Shelf) GetContentBytes() int64 {
+	if m != nil {
+		return m.ContentBytes
+	}
+	return 0
+}
+
+func (m *Shelf) GetLastModified() string {
+	if m != nil {
+		return m.LastModified
+	}
+	return ""
+}
+
 type GetShelvesRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	Scope                string         `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Options              *ListOptions   `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -170,9 +195,17 @@ func (m *GetShelvesRequest) GetScope() string {
 	return ""
 }
 
+func (m *GetShelvesRequest) GetOptions() *ListOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 type GetShelvesResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Shelves              []*Shelf        `protobuf:"bytes,2,rep,name=shelves,proto3" json:"shelves,omitempty"`
+	NextCursor           string          `protobuf:"bytes,3,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -217,6 +250,13 @@ func (m *GetShelvesResponse) GetShelves() []*Shelf {
 	return nil
 }
 
+func (m *GetShelvesResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 type CreateShelfRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Name                 *Title         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -434,28 +474,34 @@ func init() {
 func init() { proto.RegisterFile("shelf.proto", fileDescriptor_997c08397bcb74ab) }
 
 var fileDescriptor_997c08397bcb74ab = []byte{
-	// 365 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x53, 0xd1, 0x4a, 0xf3, 0x30,
-	0x18, 0xfd, 0xd3, 0xad, 0xed, 0xfe, 0xaf, 0x22, 0x2e, 0x88, 0xc4, 0x5d, 0x95, 0x82, 0x30, 0x10,
-	0x06, 0xce, 0x47, 0x50, 0x50, 0xef, 0x46, 0xe7, 0x0b, 0xd4, 0xe6, 0x1b, 0x1b, 0xeb, 0x9a, 0xda,
-	0xa4, 0xf3, 0x11, 0x7c, 0x01, 0x1f, 0xc9, 0x07, 0xf0, 0x91, 0x24, 0x49, 0x3b, 0x67, 0x9d, 0x22,
-	0x7a, 0xe1, 0xe5, 0xc9, 0x39, 0x39, 0xdf, 0x77, 0x4e, 0x5a, 0x08, 0xe4, 0x1c, 0xb3, 0xd9, 0xa8,
-	0x28, 0x85, 0x12, 0x14, 0x72, 0xa1, 0x70, 0x89, 0x58, 0x60, 0x39, 0xd8, 0x4b, 0xc5, 0x6a, 0x25,
-	0x72, 0xcb, 0x0c, 0x02, 0xb5, 0x50, 0x19, 0x5a, 0x10, 0xbd, 0x10, 0x70, 0xa7, 0xfa, 0x1a, 0xdd,
-	0x07, 0x67, 0xc1, 0x19, 0x09, 0xc9, 0xf0, 0x7f, 0xec, 0x2c, 0x38, 0x3d, 0x81, 0x6e, 0x9e, 0xac,
-	0x90, 0x39, 0x21, 0x19, 0x06, 0xe3, 0xfe, 0xe8, 0xcd, 0x6f, 0x74, 0xab, 0x0d, 0x62, 0x43, 0xd3,
-	0x43, 0x70, 0x65, 0x2a, 0x0a, 0x64, 0x1d, 0x73, 0xd3, 0x02, 0xca, 0xc0, 0xe7, 0x38, 0x4b, 0xaa,
-	0x4c, 0xb1, 0x6e, 0x48, 0x86, 0xbd, 0xb8, 0x81, 0x5a, 0xaf, 0xca, 0x44, 0xce, 0x99, 0x6b, 0xce,
-	0x2d, 0xa0, 0x47, 0xe0, 0x65, 0x22, 0x5d, 0x22, 0x67, 0x9e, 0x39, 0xae, 0x91, 0xf6, 0x49, 0x4b,
-	0x4c, 0x14, 0x72, 0xe6, 0x1b, 0xff, 0x06, 0x6a, 0xa6, 0x2a, 0xb8, 0x61, 0x7a, 0x96, 0xa9, 0x61,
-	0x94, 0x41, 0xff, 0x0a, 0x95, 0x0e, 0xb5, 0x46, 0x19, 0xe3, 0x7d, 0x85, 0x52, 0xd1, 0x33, 0xf0,
-	0xe6, 0x98, 0x70, 0x2c, 0x4d, 0xc2, 0x60, 0x7c, 0xbc, 0x9d, 0xa7, 0x16, 0x5d, 0x1b, 0x41, 0x5c,
-	0x0b, 0xeb, 0x42, 0x9c, 0x4d, 0x21, 0x3b, 0x93, 0x46, 0x15, 0xd0, 0xed, 0x69, 0xb2, 0x10, 0xb9,
-	0x44, 0x3a, 0x6e, 0x8d, 0x1b, 0xbc, 0x1f, 0x67, 0x55, 0xad, 0x79, 0xa7, 0xe0, 0x4b, 0x6b, 0xc3,
-	0x9c, 0xb0, 0xd3, 0xee, 0xdc, 0x3c, 0x52, 0xdc, 0x28, 0xa2, 0x27, 0x02, 0xf4, 0xc2, 0x54, 0x61,
-	0x89, 0x9f, 0xc7, 0xfc, 0xe6, 0x3b, 0xdb, 0x36, 0x3a, 0x1f, 0xdb, 0xe8, 0x6e, 0xb7, 0xf1, 0x4c,
-	0xe0, 0x60, 0x9a, 0xac, 0x7f, 0xbd, 0x54, 0xbb, 0x7b, 0x06, 0xbe, 0x78, 0xc8, 0xb1, 0xbc, 0x69,
-	0x56, 0x68, 0xe0, 0xee, 0x3d, 0x36, 0xa1, 0xdc, 0xaf, 0x43, 0x7d, 0xf2, 0xd9, 0x45, 0x8f, 0x04,
-	0xe8, 0x25, 0x66, 0xa8, 0xfe, 0x3a, 0xc8, 0xe4, 0xdf, 0x84, 0xdc, 0x79, 0xe6, 0x57, 0x3d, 0x7f,
-	0x0d, 0x00, 0x00, 0xff, 0xff, 0x3a, 0x11, 0x69, 0xa0, 0xe0, 0x03, 0x00, 0x00,
+	// 459 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x54, 0xd1, 0x8a, 0xd3, 0x40,
+	0x14, 0x75, 0xd2, 0x4d, 0xb3, 0xbd, 0x59, 0xc4, 0x1d, 0x44, 0xc7, 0x22, 0x12, 0x02, 0x42, 0x40,
+	0x28, 0x6c, 0xfd, 0x03, 0x2b, 0xa8, 0xa0, 0xb8, 0xcc, 0xfa, 0x03, 0x31, 0x73, 0x4b, 0xc3, 0xa6,
+	0x33, 0x31, 0x73, 0xb3, 0xea, 0x17, 0xf8, 0x03, 0xfa, 0xec, 0x5f, 0xf8, 0x05, 0x7e, 0x98, 0x64,
+	0x26, 0xd9, 0x4d, 0xeb, 0x2a, 0xa2, 0x0f, 0x3e, 0x9e, 0x7b, 0xce, 0x9c, 0x9e, 0x7b, 0x66, 0x1a,
+	0x88, 0xed, 0x06, 0xab, 0xf5, 0xa2, 0x6e, 0x0c, 0x19, 0x0e, 0xda, 0x10, 0x9e, 0x23, 0xd6, 0xd8,
+	0xcc, 0x8f, 0x0a, 0xb3, 0xdd, 0x1a, 0xed, 0x99, 0x79, 0x4c, 0x25, 0x55, 0xe8, 0x41, 0xfa, 0x2d,
+	0x80, 0xf0, 0xac, 0x3b, 0xc6, 0x6f, 0x42, 0x50, 0x2a, 0xc1, 0x12, 0x96, 0xcd, 0x64, 0x50, 0x2a,
+	0xfe, 0x10, 0x0e, 0x74, 0xbe, 0x45, 0x11, 0x24, 0x2c, 0x8b, 0x97, 0xc7, 0x8b, 0x2b, 0xbf, 0xc5,
+	0x9b, 0xce, 0x40, 0x3a, 0x9a, 0xdf, 0x86, 0xd0, 0x16, 0xa6, 0x46, 0x31, 0x71, 0x27, 0x3d, 0xe0,
+	0x02, 0x22, 0x85, 0xeb, 0xbc, 0xad, 0x48, 0x1c, 0x24, 0x2c, 0x3b, 0x94, 0x03, 0xec, 0xf4, 0xd4,
+	0xe4, 0x76, 0x23, 0x42, 0x37, 0xf7, 0x80, 0xdf, 0x81, 0x69, 0x65, 0x8a, 0x73, 0x54, 0x62, 0xea,
+	0xc6, 0x3d, 0xea, 0x7c, 0x8a, 0x06, 0x73, 0x42, 0x25, 0x22, 0xe7, 0x3f, 0xc0, 0x8e, 0x69, 0x6b,
+	0xe5, 0x98, 0x43, 0xcf, 0xf4, 0x90, 0xdf, 0x87, 0x59, 0x97, 0x75, 0x65, 0x5a, 0x4d, 0x62, 0x96,
+	0xb0, 0x2c, 0x94, 0x57, 0x03, 0x9e, 0xc2, 0x51, 0x61, 0x34, 0xa1, 0xa6, 0x27, 0x1f, 0x09, 0xad,
+	0x80, 0x84, 0x65, 0x13, 0xb9, 0x33, 0xeb, 0x34, 0x55, 0x6e, 0xe9, 0x95, 0x51, 0xe5, 0xba, 0x44,
+	0x25, 0x62, 0xf7, 0x03, 0x3b, 0xb3, 0xf4, 0x2b, 0x83, 0xe3, 0x67, 0x48, 0x5d, 0x77, 0x17, 0x68,
+	0x25, 0xbe, 0x6b, 0xd1, 0x12, 0x3f, 0x81, 0xe9, 0x06, 0x73, 0x85, 0x8d, 0x2b, 0x32, 0x5e, 0xde,
+	0x1b, 0xd7, 0xd6, 0x8b, 0x9e, 0x3b, 0x81, 0xec, 0x85, 0x7d, 0xef, 0xc1, 0x65, 0xef, 0xd7, 0x17,
+	0x7a, 0x02, 0x91, 0xa9, 0xa9, 0x34, 0xda, 0xba, 0x42, 0xe3, 0xe5, 0xdd, 0xb1, 0xf3, 0xcb, 0xd2,
+	0xd2, 0x6b, 0x4f, 0xcb, 0x41, 0x97, 0x7e, 0x61, 0xc0, 0xc7, 0x09, 0x6d, 0x6d, 0xb4, 0x45, 0xbe,
+	0xdc, 0x8b, 0x38, 0xdf, 0x8d, 0xe8, 0x55, 0x7b, 0x19, 0x1f, 0x41, 0x64, 0xbd, 0x8d, 0x08, 0x92,
+	0xc9, 0xfe, 0x73, 0x70, 0xef, 0x47, 0x0e, 0x0a, 0xfe, 0x00, 0x40, 0xe3, 0x07, 0x5a, 0xb5, 0x8d,
+	0x35, 0x4d, 0xbf, 0xc5, 0x68, 0x92, 0x7e, 0x66, 0xc0, 0x57, 0xee, 0x16, 0xfd, 0xc1, 0xbf, 0xaf,
+	0xee, 0x0f, 0x9f, 0xa8, 0x6f, 0x78, 0xf2, 0x73, 0xc3, 0x07, 0xa3, 0x86, 0xd3, 0xef, 0x0c, 0x6e,
+	0x9d, 0xe5, 0x17, 0xff, 0x1c, 0x6a, 0xff, 0x3e, 0x05, 0x44, 0xe6, 0xbd, 0xc6, 0xe6, 0xc5, 0x10,
+	0x61, 0x80, 0xd7, 0xe7, 0xb8, 0x5c, 0x2a, 0xfc, 0xfd, 0x52, 0xbf, 0xf8, 0xc7, 0xa4, 0x9f, 0x18,
+	0xf0, 0xa7, 0x58, 0x21, 0xfd, 0xef, 0x45, 0x4e, 0x6f, 0x9c, 0xb2, 0xb7, 0x53, 0xf7, 0x95, 0x79,
+	0xfc, 0x63, 0x00, 0x32, 0x5a, 0x3d, 0xe6, 0x9b, 0x04, 0x00, 0x00,
 }
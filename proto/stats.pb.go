@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: stats.proto
+
+package notekeeper
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ContainerStats struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 *Title   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scope                string   `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Type                 string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	ShelfId              string   `protobuf:"bytes,5,opt,name=shelfId,proto3" json:"shelfId,omitempty"`
+	NoteCount            int32    `protobuf:"varint,6,opt,name=noteCount,proto3" json:"noteCount,omitempty"`
+	ContentBytes         int64    `protobuf:"varint,7,opt,name=contentBytes,proto3" json:"contentBytes,omitempty"`
+	LastModified         string   `protobuf:"bytes,8,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerStats) Reset()         { *m = ContainerStats{} }
+func (m *ContainerStats) String() string { return proto.CompactTextString(m) }
+func (*ContainerStats) ProtoMessage()    {}
+func (*ContainerStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4756a0aec8b9d44, []int{0}
+}
+
+func (m *ContainerStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerStats.Unmarshal(m, b)
+}
+func (m *ContainerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerStats.Marshal(b, m, deterministic)
+}
+func (m *ContainerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerStats.Merge(m, src)
+}
+func (m *ContainerStats) XXX_Size() int {
+	return xxx_messageInfo_ContainerStats.Size(m)
+}
+func (m *ContainerStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerStats proto.InternalMessageInfo
+
+func (m *ContainerStats) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ContainerStats) GetName() *Title {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+func (m *ContainerStats) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *ContainerStats) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ContainerStats) GetShelfId() string {
+	if m != nil {
+		return m.ShelfId
+	}
+	return ""
+}
+
+func (m *ContainerStats) GetNoteCount() int32 {
+	if m != nil {
+		return m.NoteCount
+	}
+	return 0
+}
+
+func (m *ContainerStats) GetContentBytes() int64 {
+	if m != nil {
+		return m.ContentBytes
+	}
+	return 0
+}
+
+func (m *ContainerStats) GetLastModified() string {
+	if m != nil {
+		return m.LastModified
+	}
+	return ""
+}
+
+type AccountStatsRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AccountStatsRequest) Reset()         { *m = AccountStatsRequest{} }
+func (m *AccountStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountStatsRequest) ProtoMessage()    {}
+func (*AccountStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4756a0aec8b9d44, []int{1}
+}
+
+func (m *AccountStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountStatsRequest.Unmarshal(m, b)
+}
+func (m *AccountStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountStatsRequest.Merge(m, src)
+}
+func (m *AccountStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountStatsRequest.Size(m)
+}
+func (m *AccountStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountStatsRequest proto.InternalMessageInfo
+
+func (m *AccountStatsRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type AccountStatsResponse struct {
+	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	NoteCount            int32             `protobuf:"varint,2,opt,name=noteCount,proto3" json:"noteCount,omitempty"`
+	ContentBytes         int64             `protobuf:"varint,3,opt,name=contentBytes,proto3" json:"contentBytes,omitempty"`
+	LastModified         string            `protobuf:"bytes,4,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
+	Containers           []*ContainerStats `protobuf:"bytes,5,rep,name=containers,proto3" json:"containers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *AccountStatsResponse) Reset()         { *m = AccountStatsResponse{} }
+func (m *AccountStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountStatsResponse) ProtoMessage()    {}
+func (*AccountStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4756a0aec8b9d44, []int{2}
+}
+
+func (m *AccountStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountStatsResponse.Unmarshal(m, b)
+}
+func (m *AccountStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountStatsResponse.Merge(m, src)
+}
+func (m *AccountStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountStatsResponse.Size(m)
+}
+func (m *AccountStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountStatsResponse proto.InternalMessageInfo
+
+func (m *AccountStatsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *AccountStatsResponse) GetNoteCount() int32 {
+	if m != nil {
+		return m.NoteCount
+	}
+	return 0
+}
+
+func (m *AccountStatsResponse) GetContentBytes() int64 {
+	if m != nil {
+		return m.ContentBytes
+	}
+	return 0
+}
+
+func (m *AccountStatsResponse) GetLastModified() string {
+	if m != nil {
+		return m.LastModified
+	}
+	return ""
+}
+
+func (m *AccountStatsResponse) GetContainers() []*ContainerStats {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ContainerStats)(nil), "notekeeper.ContainerStats")
+	proto.RegisterType((*AccountStatsRequest)(nil), "notekeeper.AccountStatsRequest")
+	proto.RegisterType((*AccountStatsResponse)(nil), "notekeeper.AccountStatsResponse")
+}
+
+func init() { proto.RegisterFile("stats.proto", fileDescriptor_b4756a0aec8b9d44) }
+
+var fileDescriptor_b4756a0aec8b9d44 = []byte{
+	// 323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x3f, 0x4f, 0xf3, 0x30,
+	0x10, 0xc6, 0x5f, 0xe7, 0x4f, 0xfb, 0xf6, 0x52, 0x55, 0xc2, 0x74, 0x30, 0x15, 0x43, 0x14, 0x09,
+	0x29, 0x53, 0x25, 0xca, 0xc6, 0x06, 0x5d, 0xca, 0x80, 0x54, 0x19, 0xbe, 0x40, 0x48, 0xae, 0x6a,
+	0x44, 0x63, 0x87, 0xf8, 0x3a, 0xf4, 0x3b, 0xb3, 0xf0, 0x0d, 0x50, 0x9c, 0x56, 0xad, 0xcb, 0x00,
+	0x9b, 0xef, 0xb9, 0xe7, 0x7e, 0xf6, 0x23, 0x1f, 0x44, 0x86, 0x32, 0x32, 0xd3, 0xba, 0xd1, 0xa4,
+	0x39, 0x28, 0x4d, 0xf8, 0x8e, 0x58, 0x63, 0x33, 0x19, 0xe6, 0xba, 0xaa, 0xb4, 0xea, 0x3a, 0x93,
+	0x88, 0x4a, 0xda, 0x60, 0x57, 0x24, 0x5f, 0x0c, 0x46, 0x73, 0xad, 0x28, 0x2b, 0x15, 0x36, 0x2f,
+	0xed, 0x3c, 0x1f, 0x81, 0x57, 0x16, 0x82, 0xc5, 0x2c, 0x1d, 0x48, 0xaf, 0x2c, 0xf8, 0x0d, 0x04,
+	0x2a, 0xab, 0x50, 0x78, 0x31, 0x4b, 0xa3, 0xd9, 0xc5, 0xf4, 0x08, 0x9e, 0xbe, 0xb6, 0x24, 0x69,
+	0xdb, 0x7c, 0x0c, 0xa1, 0xc9, 0x75, 0x8d, 0xc2, 0xb7, 0x93, 0x5d, 0xc1, 0x39, 0x04, 0xb4, 0xab,
+	0x51, 0x04, 0x56, 0xb4, 0x67, 0x2e, 0xa0, 0x6f, 0xd6, 0xb8, 0x59, 0x3d, 0x15, 0x22, 0xb4, 0xf2,
+	0xa1, 0xe4, 0xd7, 0x30, 0x68, 0xe9, 0x73, 0xbd, 0x55, 0x24, 0x7a, 0x31, 0x4b, 0x43, 0x79, 0x14,
+	0x78, 0x02, 0xc3, 0x5c, 0x2b, 0x42, 0x45, 0x8f, 0x3b, 0x42, 0x23, 0xfa, 0x31, 0x4b, 0x7d, 0xe9,
+	0x68, 0xad, 0x67, 0x93, 0x19, 0x7a, 0xd6, 0x45, 0xb9, 0x2a, 0xb1, 0x10, 0xff, 0xed, 0x05, 0x8e,
+	0x96, 0x2c, 0xe0, 0xf2, 0x21, 0xcf, 0x5b, 0xa4, 0x0d, 0x2c, 0xf1, 0x63, 0x8b, 0x86, 0xf8, 0x2d,
+	0xf4, 0xd6, 0x98, 0x15, 0xd8, 0xd8, 0xec, 0xd1, 0xec, 0xea, 0x34, 0xe9, 0xde, 0xb4, 0xb0, 0x06,
+	0xb9, 0x37, 0x26, 0x9f, 0x0c, 0xc6, 0x2e, 0xca, 0xd4, 0x5a, 0x19, 0xe4, 0xb3, 0x33, 0xd6, 0xc4,
+	0x65, 0x75, 0x2e, 0x17, 0xe6, 0x86, 0xf7, 0x7e, 0x0b, 0xef, 0xff, 0x21, 0x7c, 0xf0, 0x33, 0x3c,
+	0xbf, 0x07, 0xc8, 0x0f, 0xff, 0x6d, 0x44, 0x18, 0xfb, 0xe7, 0xaf, 0x73, 0xb7, 0x41, 0x9e, 0xb8,
+	0x97, 0xff, 0x96, 0xec, 0xad, 0x67, 0xf7, 0xe6, 0xee, 0x7b, 0x00, 0xa5, 0x33, 0xb8, 0x90, 0x6d,
+	0x02, 0x00, 0x00,
+}
@@ -0,0 +1,489 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: status.proto
+
+package notekeeper
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// An open db handle
+type DbHandle struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DbHandle) Reset()         { *m = DbHandle{} }
+func (m *DbHandle) String() string { return proto.CompactTextString(m) }
+func (*DbHandle) ProtoMessage()    {}
+func (*DbHandle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{0}
+}
+
+func (m *DbHandle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DbHandle.Unmarshal(m, b)
+}
+func (m *DbHandle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DbHandle.Marshal(b, m, deterministic)
+}
+func (m *DbHandle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DbHandle.Merge(m, src)
+}
+func (m *DbHandle) XXX_Size() int {
+	return xxx_messageInfo_DbHandle.Size(m)
+}
+func (m *DbHandle) XXX_DiscardUnknown() {
+	xxx_messageInfo_DbHandle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DbHandle proto.InternalMessageInfo
+
+func (m *DbHandle) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DbHandle) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DbHandle) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+// Requests made to a method since the backend started
+type MethodStatus struct {
+	Method               string   `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Errors               int64    `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	AverageMs            float64  `protobuf:"fixed64,4,opt,name=averageMs,proto3" json:"averageMs,omitempty"`
+	MaxMs                float64  `protobuf:"fixed64,5,opt,name=maxMs,proto3" json:"maxMs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MethodStatus) Reset()         { *m = MethodStatus{} }
+func (m *MethodStatus) String() string { return proto.CompactTextString(m) }
+func (*MethodStatus) ProtoMessage()    {}
+func (*MethodStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{1}
+}
+
+func (m *MethodStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MethodStatus.Unmarshal(m, b)
+}
+func (m *MethodStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MethodStatus.Marshal(b, m, deterministic)
+}
+func (m *MethodStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MethodStatus.Merge(m, src)
+}
+func (m *MethodStatus) XXX_Size() int {
+	return xxx_messageInfo_MethodStatus.Size(m)
+}
+func (m *MethodStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_MethodStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MethodStatus proto.InternalMessageInfo
+
+func (m *MethodStatus) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *MethodStatus) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *MethodStatus) GetErrors() int64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *MethodStatus) GetAverageMs() float64 {
+	if m != nil {
+		return m.AverageMs
+	}
+	return 0
+}
+
+func (m *MethodStatus) GetMaxMs() float64 {
+	if m != nil {
+		return m.MaxMs
+	}
+	return 0
+}
+
+// The last error reported in a scope
+type ScopeError struct {
+	Scope                int32    `protobuf:"varint,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Code                 int32    `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Method               string   `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	Time                 string   `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScopeError) Reset()         { *m = ScopeError{} }
+func (m *ScopeError) String() string { return proto.CompactTextString(m) }
+func (*ScopeError) ProtoMessage()    {}
+func (*ScopeError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{2}
+}
+
+func (m *ScopeError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScopeError.Unmarshal(m, b)
+}
+func (m *ScopeError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScopeError.Marshal(b, m, deterministic)
+}
+func (m *ScopeError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScopeError.Merge(m, src)
+}
+func (m *ScopeError) XXX_Size() int {
+	return xxx_messageInfo_ScopeError.Size(m)
+}
+func (m *ScopeError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScopeError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScopeError proto.InternalMessageInfo
+
+func (m *ScopeError) GetScope() int32 {
+	if m != nil {
+		return m.Scope
+	}
+	return 0
+}
+
+func (m *ScopeError) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ScopeError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ScopeError) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *ScopeError) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+type ServiceStatusResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Version              string          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	BuildType            string          `protobuf:"bytes,3,opt,name=buildType,proto3" json:"buildType,omitempty"`
+	ProtocolVersion      int32           `protobuf:"varint,4,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	SchemaVersion        int32           `protobuf:"varint,5,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
+	Started              string          `protobuf:"bytes,6,opt,name=started,proto3" json:"started,omitempty"`
+	UptimeSeconds        int64           `protobuf:"varint,7,opt,name=uptimeSeconds,proto3" json:"uptimeSeconds,omitempty"`
+	UserState            string          `protobuf:"bytes,8,opt,name=userState,proto3" json:"userState,omitempty"`
+	Dbs                  []*DbHandle     `protobuf:"bytes,9,rep,name=dbs,proto3" json:"dbs,omitempty"`
+	Clients              int32           `protobuf:"varint,10,opt,name=clients,proto3" json:"clients,omitempty"`
+	Methods              []*MethodStatus `protobuf:"bytes,11,rep,name=methods,proto3" json:"methods,omitempty"`
+	Errors               []*ScopeError   `protobuf:"bytes,12,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ServiceStatusResponse) Reset()         { *m = ServiceStatusResponse{} }
+func (m *ServiceStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ServiceStatusResponse) ProtoMessage()    {}
+func (*ServiceStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{3}
+}
+
+func (m *ServiceStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceStatusResponse.Unmarshal(m, b)
+}
+func (m *ServiceStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ServiceStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceStatusResponse.Merge(m, src)
+}
+func (m *ServiceStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ServiceStatusResponse.Size(m)
+}
+func (m *ServiceStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceStatusResponse proto.InternalMessageInfo
+
+func (m *ServiceStatusResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ServiceStatusResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ServiceStatusResponse) GetBuildType() string {
+	if m != nil {
+		return m.BuildType
+	}
+	return ""
+}
+
+func (m *ServiceStatusResponse) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *ServiceStatusResponse) GetSchemaVersion() int32 {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return 0
+}
+
+func (m *ServiceStatusResponse) GetStarted() string {
+	if m != nil {
+		return m.Started
+	}
+	return ""
+}
+
+func (m *ServiceStatusResponse) GetUptimeSeconds() int64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
+func (m *ServiceStatusResponse) GetUserState() string {
+	if m != nil {
+		return m.UserState
+	}
+	return ""
+}
+
+func (m *ServiceStatusResponse) GetDbs() []*DbHandle {
+	if m != nil {
+		return m.Dbs
+	}
+	return nil
+}
+
+func (m *ServiceStatusResponse) GetClients() int32 {
+	if m != nil {
+		return m.Clients
+	}
+	return 0
+}
+
+func (m *ServiceStatusResponse) GetMethods() []*MethodStatus {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *ServiceStatusResponse) GetErrors() []*ScopeError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type ServiceLogsRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Lines                int32          `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ServiceLogsRequest) Reset()         { *m = ServiceLogsRequest{} }
+func (m *ServiceLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ServiceLogsRequest) ProtoMessage()    {}
+func (*ServiceLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{4}
+}
+
+func (m *ServiceLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceLogsRequest.Unmarshal(m, b)
+}
+func (m *ServiceLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ServiceLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceLogsRequest.Merge(m, src)
+}
+func (m *ServiceLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ServiceLogsRequest.Size(m)
+}
+func (m *ServiceLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceLogsRequest proto.InternalMessageInfo
+
+func (m *ServiceLogsRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ServiceLogsRequest) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+type ServiceLogsResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Lines                []string        `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ServiceLogsResponse) Reset()         { *m = ServiceLogsResponse{} }
+func (m *ServiceLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ServiceLogsResponse) ProtoMessage()    {}
+func (*ServiceLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{5}
+}
+
+func (m *ServiceLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceLogsResponse.Unmarshal(m, b)
+}
+func (m *ServiceLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ServiceLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceLogsResponse.Merge(m, src)
+}
+func (m *ServiceLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ServiceLogsResponse.Size(m)
+}
+func (m *ServiceLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceLogsResponse proto.InternalMessageInfo
+
+func (m *ServiceLogsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ServiceLogsResponse) GetLines() []string {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DbHandle)(nil), "notekeeper.DbHandle")
+	proto.RegisterType((*MethodStatus)(nil), "notekeeper.MethodStatus")
+	proto.RegisterType((*ScopeError)(nil), "notekeeper.ScopeError")
+	proto.RegisterType((*ServiceStatusResponse)(nil), "notekeeper.ServiceStatusResponse")
+	proto.RegisterType((*ServiceLogsRequest)(nil), "notekeeper.ServiceLogsRequest")
+	proto.RegisterType((*ServiceLogsResponse)(nil), "notekeeper.ServiceLogsResponse")
+}
+
+func init() { proto.RegisterFile("status.proto", fileDescriptor_dfe4fce6682daf5b) }
+
+var fileDescriptor_dfe4fce6682daf5b = []byte{
+	// 501 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0x4d, 0x8f, 0xd3, 0x30,
+	0x10, 0x55, 0x9a, 0xa6, 0xdd, 0x4e, 0x0b, 0x48, 0xa6, 0xac, 0xcc, 0x8a, 0x43, 0x15, 0x21, 0x94,
+	0x53, 0x25, 0xca, 0x3f, 0x40, 0x20, 0xed, 0x81, 0x5e, 0x5c, 0xc4, 0x0d, 0xa1, 0x34, 0x19, 0xb5,
+	0x16, 0x4d, 0x1c, 0x3c, 0x4e, 0xc5, 0x22, 0x7e, 0x00, 0xff, 0x90, 0xbf, 0x83, 0xfc, 0x91, 0x6d,
+	0xd2, 0x2b, 0x37, 0xbf, 0xf1, 0xf3, 0xf3, 0x9b, 0x79, 0x03, 0x0b, 0x32, 0xb9, 0x69, 0x69, 0xdd,
+	0x68, 0x65, 0x14, 0x83, 0x5a, 0x19, 0xfc, 0x8e, 0xd8, 0xa0, 0xbe, 0x5b, 0x14, 0xaa, 0xaa, 0x54,
+	0xed, 0x6f, 0xd2, 0xf7, 0x70, 0xf3, 0x61, 0x7f, 0x9f, 0xd7, 0xe5, 0x09, 0xd9, 0x53, 0x18, 0xc9,
+	0x92, 0x47, 0xab, 0x28, 0x9b, 0x89, 0x91, 0x2c, 0x19, 0x83, 0xb1, 0x79, 0x68, 0x90, 0x8f, 0x5c,
+	0xc5, 0x9d, 0x6d, 0x8d, 0xe4, 0x2f, 0xe4, 0xf1, 0x2a, 0xca, 0x62, 0xe1, 0xce, 0xe9, 0x9f, 0x08,
+	0x16, 0x5b, 0x34, 0x47, 0x55, 0xee, 0xdc, 0xa7, 0xec, 0x16, 0x26, 0x95, 0xc3, 0x41, 0x2c, 0x20,
+	0xb6, 0x84, 0xa4, 0x50, 0x6d, 0x6d, 0x9c, 0x62, 0x2c, 0x3c, 0xb0, 0x6c, 0xd4, 0x5a, 0x69, 0x0a,
+	0xa2, 0x01, 0xb1, 0x57, 0x30, 0xcb, 0xcf, 0xa8, 0xf3, 0x03, 0x6e, 0x89, 0x8f, 0x57, 0x51, 0x16,
+	0x89, 0x4b, 0xc1, 0x6a, 0x55, 0xf9, 0xcf, 0x2d, 0xf1, 0xc4, 0xdd, 0x78, 0x90, 0xfe, 0x06, 0xd8,
+	0x15, 0xaa, 0xc1, 0x8f, 0x56, 0xc2, 0x72, 0xc8, 0x22, 0x67, 0x23, 0x11, 0x1e, 0xd8, 0x16, 0x0a,
+	0x55, 0xfa, 0xb6, 0x12, 0xe1, 0xce, 0x8c, 0xc3, 0xb4, 0x42, 0xa2, 0xfc, 0xe0, 0x3b, 0x9b, 0x89,
+	0x0e, 0xf6, 0x7a, 0x19, 0x0f, 0x7a, 0xb1, 0xc3, 0x91, 0x15, 0xf2, 0x24, 0x0c, 0x47, 0x56, 0x98,
+	0xfe, 0x8d, 0xe1, 0xc5, 0x0e, 0xf5, 0x59, 0x16, 0xe8, 0x27, 0x21, 0x90, 0x1a, 0x55, 0x13, 0xb2,
+	0x0d, 0x4c, 0x8e, 0x98, 0x97, 0xa8, 0x9d, 0x95, 0xf9, 0xe6, 0x6e, 0x7d, 0x49, 0x64, 0xdd, 0xb1,
+	0xee, 0x1d, 0x43, 0x04, 0xa6, 0xf5, 0x74, 0x46, 0x4d, 0x52, 0xd5, 0x21, 0x81, 0x0e, 0xda, 0xc9,
+	0xec, 0x5b, 0x79, 0x2a, 0x3f, 0x3f, 0x34, 0x9d, 0xdf, 0x4b, 0x81, 0x65, 0xf0, 0xcc, 0x65, 0x5b,
+	0xa8, 0xd3, 0x97, 0xf0, 0x7e, 0xec, 0x5a, 0xbd, 0x2e, 0xb3, 0xd7, 0xf0, 0x84, 0x8a, 0x23, 0x56,
+	0x79, 0xc7, 0x4b, 0x1c, 0x6f, 0x58, 0xb4, 0x3e, 0xc8, 0xe4, 0xda, 0x60, 0xc9, 0x27, 0xde, 0x47,
+	0x80, 0xf6, 0x7d, 0xdb, 0xd8, 0xce, 0x77, 0x58, 0xa8, 0xba, 0x24, 0x3e, 0x75, 0x01, 0x0e, 0x8b,
+	0xd6, 0x6d, 0x4b, 0xa8, 0xed, 0x44, 0x90, 0xdf, 0x78, 0xb7, 0x8f, 0x05, 0xf6, 0x06, 0xe2, 0x72,
+	0x4f, 0x7c, 0xb6, 0x8a, 0xb3, 0xf9, 0x66, 0xd9, 0x1f, 0x4b, 0xb7, 0x97, 0xc2, 0x12, 0xac, 0x8b,
+	0xe2, 0x24, 0xb1, 0x36, 0xc4, 0xc1, 0xb9, 0xec, 0x20, 0xdb, 0xc0, 0xd4, 0x67, 0x42, 0x7c, 0xee,
+	0x54, 0x78, 0x5f, 0xa5, 0xbf, 0x98, 0xa2, 0x23, 0xb2, 0xf5, 0xe3, 0xce, 0x2d, 0xdc, 0x93, 0xdb,
+	0xfe, 0x93, 0xcb, 0x06, 0x75, 0xbb, 0x98, 0x7e, 0x05, 0x16, 0x82, 0xfd, 0xa4, 0x0e, 0x24, 0xf0,
+	0x47, 0x8b, 0x64, 0xd8, 0xdb, 0xab, 0x54, 0x5f, 0x0e, 0x53, 0x75, 0xa4, 0xab, 0x50, 0x97, 0x90,
+	0x9c, 0x64, 0x8d, 0x14, 0xb6, 0xcf, 0x83, 0xf4, 0x1b, 0x3c, 0x1f, 0xc8, 0xff, 0xc7, 0xd6, 0xf4,
+	0x3e, 0x88, 0xb3, 0x59, 0xf8, 0x60, 0x3f, 0x71, 0xd1, 0xbf, 0xfb, 0x37, 0x00, 0x26, 0xbc, 0x3e,
+	0x37, 0x17, 0x04, 0x00, 0x00,
+}
@@ -26,6 +26,8 @@ type Tag struct {
 	Scope                string   `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
 	Created              string   `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
 	Updated              string   `protobuf:"bytes,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	ParentId             string   `protobuf:"bytes,6,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	Path                 string   `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -91,10 +93,26 @@ func (m *Tag) GetUpdated() string {
 	return ""
 }
 
+func (m *Tag) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *Tag) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
 type GetTagsRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	Scope                string         `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	ParentId             string         `protobuf:"bytes,4,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	Options              *ListOptions   `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -146,9 +164,24 @@ func (m *GetTagsRequest) GetScope() string {
 	return ""
 }
 
+func (m *GetTagsRequest) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *GetTagsRequest) GetOptions() *ListOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 type GetTagsResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Tags                 []*Tag          `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	NextCursor           string          `protobuf:"bytes,3,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -193,11 +226,19 @@ func (m *GetTagsResponse) GetTags() []*Tag {
 	return nil
 }
 
+func (m *GetTagsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 type CreateTagRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Name                 *Title         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Id                   string         `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	Scope                string         `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	ParentId             string         `protobuf:"bytes,5,opt,name=parentId,proto3" json:"parentId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -256,6 +297,13 @@ func (m *CreateTagRequest) GetScope() string {
 	return ""
 }
 
+func (m *CreateTagRequest) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
 type SaveTagRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
@@ -390,6 +438,314 @@ func (m *DeleteTagRequest) GetScope() string {
 	return ""
 }
 
+type MoveTagRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string         `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Scope                string         `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	ParentId             string         `protobuf:"bytes,5,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MoveTagRequest) Reset()         { *m = MoveTagRequest{} }
+func (m *MoveTagRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveTagRequest) ProtoMessage()    {}
+func (*MoveTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_27f545bcde37ecb5, []int{6}
+}
+
+func (m *MoveTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveTagRequest.Unmarshal(m, b)
+}
+func (m *MoveTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveTagRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveTagRequest.Merge(m, src)
+}
+func (m *MoveTagRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveTagRequest.Size(m)
+}
+func (m *MoveTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveTagRequest proto.InternalMessageInfo
+
+func (m *MoveTagRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *MoveTagRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MoveTagRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *MoveTagRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *MoveTagRequest) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+type MergeTagRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string         `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Scope                string         `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	TargetId             string         `protobuf:"bytes,5,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MergeTagRequest) Reset()         { *m = MergeTagRequest{} }
+func (m *MergeTagRequest) String() string { return proto.CompactTextString(m) }
+func (*MergeTagRequest) ProtoMessage()    {}
+func (*MergeTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_27f545bcde37ecb5, []int{7}
+}
+
+func (m *MergeTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MergeTagRequest.Unmarshal(m, b)
+}
+func (m *MergeTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MergeTagRequest.Marshal(b, m, deterministic)
+}
+func (m *MergeTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeTagRequest.Merge(m, src)
+}
+func (m *MergeTagRequest) XXX_Size() int {
+	return xxx_messageInfo_MergeTagRequest.Size(m)
+}
+func (m *MergeTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeTagRequest proto.InternalMessageInfo
+
+func (m *MergeTagRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *MergeTagRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MergeTagRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *MergeTagRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *MergeTagRequest) GetTargetId() string {
+	if m != nil {
+		return m.TargetId
+	}
+	return ""
+}
+
+type AssignTagRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string         `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Scope                string         `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	ObjectId             string         `protobuf:"bytes,5,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	ObjectType           string         `protobuf:"bytes,6,opt,name=objectType,proto3" json:"objectType,omitempty"`
+	Store                string         `protobuf:"bytes,7,opt,name=store,proto3" json:"store,omitempty"`
+	StoreId              string         `protobuf:"bytes,8,opt,name=storeId,proto3" json:"storeId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AssignTagRequest) Reset()         { *m = AssignTagRequest{} }
+func (m *AssignTagRequest) String() string { return proto.CompactTextString(m) }
+func (*AssignTagRequest) ProtoMessage()    {}
+func (*AssignTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_27f545bcde37ecb5, []int{8}
+}
+
+func (m *AssignTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AssignTagRequest.Unmarshal(m, b)
+}
+func (m *AssignTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AssignTagRequest.Marshal(b, m, deterministic)
+}
+func (m *AssignTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssignTagRequest.Merge(m, src)
+}
+func (m *AssignTagRequest) XXX_Size() int {
+	return xxx_messageInfo_AssignTagRequest.Size(m)
+}
+func (m *AssignTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssignTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssignTagRequest proto.InternalMessageInfo
+
+func (m *AssignTagRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *AssignTagRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AssignTagRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *AssignTagRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *AssignTagRequest) GetObjectId() string {
+	if m != nil {
+		return m.ObjectId
+	}
+	return ""
+}
+
+func (m *AssignTagRequest) GetObjectType() string {
+	if m != nil {
+		return m.ObjectType
+	}
+	return ""
+}
+
+func (m *AssignTagRequest) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *AssignTagRequest) GetStoreId() string {
+	if m != nil {
+		return m.StoreId
+	}
+	return ""
+}
+
+type UnassignTagRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Id                   string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId              string         `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Scope                string         `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	ObjectId             string         `protobuf:"bytes,5,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *UnassignTagRequest) Reset()         { *m = UnassignTagRequest{} }
+func (m *UnassignTagRequest) String() string { return proto.CompactTextString(m) }
+func (*UnassignTagRequest) ProtoMessage()    {}
+func (*UnassignTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_27f545bcde37ecb5, []int{9}
+}
+
+func (m *UnassignTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnassignTagRequest.Unmarshal(m, b)
+}
+func (m *UnassignTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnassignTagRequest.Marshal(b, m, deterministic)
+}
+func (m *UnassignTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnassignTagRequest.Merge(m, src)
+}
+func (m *UnassignTagRequest) XXX_Size() int {
+	return xxx_messageInfo_UnassignTagRequest.Size(m)
+}
+func (m *UnassignTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnassignTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnassignTagRequest proto.InternalMessageInfo
+
+func (m *UnassignTagRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *UnassignTagRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UnassignTagRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *UnassignTagRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *UnassignTagRequest) GetObjectId() string {
+	if m != nil {
+		return m.ObjectId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Tag)(nil), "notekeeper.Tag")
 	proto.RegisterType((*GetTagsRequest)(nil), "notekeeper.GetTagsRequest")
@@ -397,30 +753,45 @@ func init() {
 	proto.RegisterType((*CreateTagRequest)(nil), "notekeeper.CreateTagRequest")
 	proto.RegisterType((*SaveTagRequest)(nil), "notekeeper.SaveTagRequest")
 	proto.RegisterType((*DeleteTagRequest)(nil), "notekeeper.DeleteTagRequest")
+	proto.RegisterType((*MoveTagRequest)(nil), "notekeeper.MoveTagRequest")
+	proto.RegisterType((*MergeTagRequest)(nil), "notekeeper.MergeTagRequest")
+	proto.RegisterType((*AssignTagRequest)(nil), "notekeeper.AssignTagRequest")
+	proto.RegisterType((*UnassignTagRequest)(nil), "notekeeper.UnassignTagRequest")
 }
 
 func init() { proto.RegisterFile("tag.proto", fileDescriptor_27f545bcde37ecb5) }
 
 var fileDescriptor_27f545bcde37ecb5 = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x53, 0x4d, 0x4e, 0x83, 0x40,
-	0x18, 0x75, 0xf8, 0x69, 0xd3, 0x8f, 0x84, 0xd6, 0x89, 0x8b, 0xb1, 0x2b, 0x82, 0x31, 0x61, 0x45,
-	0x22, 0x1e, 0x41, 0x13, 0x75, 0x67, 0x90, 0x0b, 0x8c, 0xe5, 0x0b, 0xa2, 0x95, 0x41, 0x66, 0xaa,
-	0x27, 0xd0, 0x03, 0x78, 0x0c, 0x4f, 0x69, 0x18, 0x7e, 0x6c, 0xb1, 0x31, 0x46, 0x17, 0x5d, 0xbe,
-	0x79, 0xef, 0xcb, 0xfb, 0x21, 0xc0, 0x44, 0xf1, 0x2c, 0x2c, 0x2b, 0xa1, 0x04, 0x85, 0x42, 0x28,
-	0x7c, 0x40, 0x2c, 0xb1, 0x9a, 0x3b, 0x2a, 0x57, 0x4b, 0x6c, 0x08, 0xff, 0x8d, 0x80, 0x99, 0xf0,
-	0x8c, 0xba, 0x60, 0xe4, 0x29, 0x23, 0x1e, 0x09, 0x26, 0xb1, 0x91, 0xa7, 0xf4, 0x18, 0xac, 0x82,
-	0x3f, 0x22, 0x33, 0x3c, 0x12, 0x38, 0xd1, 0x7e, 0xf8, 0x75, 0x1f, 0x26, 0xf5, 0x79, 0xac, 0x69,
-	0x7a, 0x00, 0xb6, 0x5c, 0x88, 0x12, 0x99, 0xa9, 0x2f, 0x1b, 0x40, 0x19, 0x8c, 0x17, 0x15, 0x72,
-	0x85, 0x29, 0xb3, 0xf4, 0x7b, 0x07, 0x6b, 0x66, 0x55, 0xa6, 0x9a, 0xb1, 0x1b, 0xa6, 0x85, 0x7e,
-	0x0e, 0xee, 0x05, 0xaa, 0x84, 0x67, 0x32, 0xc6, 0xa7, 0x15, 0x4a, 0x45, 0x4f, 0x60, 0x74, 0x87,
-	0x3c, 0xc5, 0x4a, 0xc7, 0x72, 0xa2, 0xc3, 0xf5, 0x10, 0xad, 0xe8, 0x52, 0x0b, 0xe2, 0x56, 0xd8,
-	0xb6, 0x30, 0xfa, 0x16, 0x5b, 0xe3, 0xf9, 0xf7, 0x30, 0xed, 0xad, 0x64, 0x29, 0x0a, 0x89, 0x34,
-	0x1a, 0x78, 0xcd, 0x37, 0xbd, 0x1a, 0xd5, 0xc0, 0xec, 0x08, 0x2c, 0xc5, 0x33, 0xc9, 0x0c, 0xcf,
-	0x0c, 0x9c, 0x68, 0xba, 0x31, 0x11, 0xcf, 0x62, 0x4d, 0xfa, 0xef, 0x04, 0x66, 0x67, 0xba, 0x7c,
-	0xfd, 0xf6, 0xf7, 0x66, 0xbf, 0xfc, 0x1e, 0xcd, 0x00, 0xe6, 0xf7, 0x01, 0xac, 0xf5, 0x01, 0x3e,
-	0x08, 0xb8, 0x37, 0xfc, 0xf9, 0x9f, 0x91, 0x86, 0x63, 0x33, 0x18, 0x8b, 0x97, 0x02, 0xab, 0xab,
-	0x2e, 0x40, 0x07, 0xb7, 0xa7, 0xe8, 0x2b, 0xd9, 0x3f, 0x56, 0xf2, 0x5f, 0x09, 0xcc, 0xce, 0x71,
-	0x89, 0x6a, 0xb7, 0x71, 0xaf, 0xf7, 0x6e, 0x47, 0xfa, 0x97, 0x39, 0xfd, 0x0c, 0x00, 0x00, 0xff,
-	0xff, 0x4f, 0x18, 0x1a, 0x21, 0x58, 0x03, 0x00, 0x00,
+	// 494 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x95, 0xcf, 0x8e, 0xd3, 0x3c,
+	0x14, 0xc5, 0x3f, 0xa7, 0x69, 0x3b, 0x73, 0xf3, 0xa9, 0x2d, 0x16, 0x12, 0xa6, 0x0b, 0x54, 0x19,
+	0x21, 0x75, 0x55, 0x69, 0xc2, 0x13, 0xa0, 0x41, 0x82, 0x4a, 0x8c, 0x18, 0x85, 0xf2, 0x00, 0x9e,
+	0xe6, 0x2a, 0x13, 0x98, 0xda, 0xc6, 0x76, 0xf9, 0xf3, 0x00, 0x6c, 0x78, 0x09, 0x90, 0xd8, 0xb1,
+	0x60, 0x89, 0x78, 0x2d, 0xde, 0x00, 0xc5, 0x4e, 0x4a, 0x5a, 0x98, 0x11, 0x82, 0x45, 0xd9, 0xf9,
+	0xdc, 0x73, 0x9d, 0xfe, 0xce, 0xbd, 0x6d, 0x0a, 0x87, 0x4e, 0x14, 0x33, 0x6d, 0x94, 0x53, 0x14,
+	0xa4, 0x72, 0xf8, 0x1c, 0x51, 0xa3, 0x19, 0xff, 0xbf, 0x54, 0xab, 0x95, 0x92, 0xc1, 0x19, 0x27,
+	0xae, 0x74, 0x17, 0x18, 0x04, 0xff, 0x42, 0xa0, 0xb3, 0x10, 0x05, 0x1d, 0x40, 0x54, 0xe6, 0x8c,
+	0x4c, 0xc8, 0xf4, 0x30, 0x8b, 0xca, 0x9c, 0xde, 0x81, 0x58, 0x8a, 0x15, 0xb2, 0x68, 0x42, 0xa6,
+	0x49, 0x7a, 0x6d, 0xf6, 0xe3, 0x69, 0xb3, 0x45, 0x75, 0x3d, 0xf3, 0x36, 0xbd, 0x0e, 0x5d, 0xbb,
+	0x54, 0x1a, 0x59, 0xc7, 0xdf, 0x0c, 0x82, 0x32, 0xe8, 0x2f, 0x0d, 0x0a, 0x87, 0x39, 0x8b, 0x7d,
+	0xbd, 0x91, 0x95, 0xb3, 0xd6, 0xb9, 0x77, 0xba, 0xc1, 0xa9, 0x25, 0x1d, 0xc3, 0x81, 0x16, 0x06,
+	0xa5, 0x9b, 0xe7, 0xac, 0xe7, 0xad, 0x8d, 0xa6, 0x14, 0x62, 0x2d, 0xdc, 0x39, 0xeb, 0xfb, 0xba,
+	0x3f, 0xf3, 0xaf, 0x04, 0x06, 0x0f, 0xd0, 0x2d, 0x44, 0x61, 0x33, 0x7c, 0xb1, 0x46, 0xeb, 0xe8,
+	0x11, 0xf4, 0xce, 0x51, 0xe4, 0x68, 0x7c, 0x8e, 0x24, 0xbd, 0xd9, 0xa6, 0xae, 0x9b, 0x1e, 0xfa,
+	0x86, 0xac, 0x6e, 0xac, 0x63, 0x47, 0x9b, 0xd8, 0xbf, 0xce, 0xd3, 0x66, 0x8b, 0x77, 0xd8, 0x8e,
+	0xa0, 0xaf, 0xb4, 0x2b, 0x95, 0xb4, 0x3e, 0x51, 0x92, 0xde, 0x68, 0x7f, 0xea, 0xa3, 0xd2, 0xba,
+	0xc7, 0xc1, 0xce, 0x9a, 0x3e, 0xfe, 0x8e, 0xc0, 0x70, 0x83, 0x6e, 0xb5, 0x92, 0x16, 0x69, 0xba,
+	0xc3, 0x3e, 0xde, 0x66, 0x0f, 0x5d, 0x3b, 0xf0, 0xb7, 0x21, 0x76, 0xa2, 0xb0, 0x2c, 0x9a, 0x74,
+	0xa6, 0x49, 0x3a, 0xdc, 0xda, 0x91, 0x28, 0x32, 0x6f, 0xd2, 0x5b, 0x00, 0x12, 0x5f, 0xbb, 0xe3,
+	0xb5, 0xb1, 0xca, 0xd4, 0xb1, 0x5a, 0x15, 0xfe, 0x99, 0xc0, 0xe8, 0xd8, 0x6f, 0xa7, 0xba, 0xf3,
+	0xe7, 0x93, 0xfc, 0xcd, 0x2f, 0x4c, 0x18, 0x78, 0xe7, 0xe7, 0x81, 0xc7, 0x97, 0x0d, 0xbc, 0xbb,
+	0x3d, 0x70, 0xfe, 0x89, 0xc0, 0xe0, 0x89, 0x78, 0xf9, 0x97, 0xb8, 0xbb, 0x8b, 0x67, 0xd0, 0x57,
+	0xaf, 0x24, 0x9a, 0x79, 0x03, 0xd7, 0xc8, 0x4b, 0x08, 0x9b, 0xb8, 0xdd, 0x2b, 0xe3, 0xf2, 0xb7,
+	0x04, 0x46, 0xf7, 0xf1, 0x02, 0xdd, 0x7e, 0x71, 0xf9, 0x7b, 0x02, 0x83, 0x13, 0xb5, 0xef, 0xa1,
+	0x5d, 0xb5, 0xd6, 0x0f, 0x04, 0x86, 0x27, 0x68, 0x8a, 0xfd, 0x23, 0x3a, 0x61, 0x0a, 0x6c, 0x21,
+	0x36, 0x9a, 0x7f, 0x23, 0x30, 0xba, 0x67, 0x6d, 0x59, 0xc8, 0xbd, 0x33, 0xaa, 0xb3, 0x67, 0xb8,
+	0x6c, 0x31, 0x36, 0xba, 0xfa, 0xb9, 0x87, 0xf3, 0xe2, 0x8d, 0xc6, 0xfa, 0x45, 0xda, 0xaa, 0xf8,
+	0x27, 0x3a, 0x65, 0xb0, 0x7e, 0x97, 0x06, 0x51, 0x11, 0xf8, 0xc3, 0x3c, 0x67, 0x07, 0x81, 0xa0,
+	0x96, 0xfc, 0x23, 0x01, 0xfa, 0x54, 0x8a, 0x7f, 0x3b, 0xf5, 0xe9, 0x7f, 0xa7, 0xe4, 0xac, 0xe7,
+	0xff, 0xd0, 0xee, 0x7e, 0x1f, 0x00, 0xaf, 0x4a, 0x3a, 0x50, 0x04, 0x07, 0x00, 0x00,
 }
@@ -0,0 +1,238 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// SortKey is the field that a list is sorted by
+type SortKey int
+
+const (
+	// SortTitle sorts a list alphabetically by title
+	SortTitle SortKey = iota
+	// SortCreated sorts a list by creation time
+	SortCreated
+	// SortUpdated sorts a list by the time of the last update
+	SortUpdated
+)
+
+// Direction is the order that a list is sorted in
+type Direction int
+
+const (
+	// Ascending sorts from A to Z or oldest to newest
+	Ascending Direction = iota
+	// Descending sorts from Z to A or newest to oldest
+	Descending
+)
+
+// Locked filters a list by whether records are locked
+type Locked int
+
+const (
+	// LockedAny includes records whether they are locked or not
+	LockedAny Locked = iota
+	// LockedOnly includes only locked records
+	LockedOnly
+	// UnlockedOnly includes only unlocked records
+	UnlockedOnly
+)
+
+// ErrInvalidCursor is returned when a page cursor can't be decoded
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// Item is a record that can be sorted, filtered & paged
+type Item interface {
+	ItemID() uuid.UUID
+	ItemTitle() string
+	ItemCreated() time.Time
+	ItemUpdated() time.Time
+}
+
+// Lockable is an item that can be filtered by its locked state
+type Lockable interface {
+	ItemLocked() bool
+}
+
+// Typed is an item that can be filtered by type
+type Typed interface {
+	ItemType() int
+}
+
+// Tagged is an item that can be filtered by the tags it carries
+type Tagged interface {
+	ItemTags() []uuid.UUID
+}
+
+// Query describes how a list of records is sorted, filtered & paged
+// The zero value returns everything sorted by title.
+type Query struct {
+	Sort      SortKey
+	Direction Direction
+	Cursor    string // Cursor is the value of Next from the previous page
+	Limit     int    // Limit is the page size (0 for no limit)
+
+	Locked Locked
+	Type   *int                 // Type only includes records of a single type
+	TagID  uuid.UUID            // TagID only includes records carrying the tag
+	Tagged map[uuid.UUID]bool   // Tagged is the set of record ids known to be assigned TagID
+	From   time.Time            // From only includes records updated at or after this time
+	Until  time.Time            // Until only includes records updated at or before this time
+	Extra  func(item Item) bool // Extra is an optional additional filter
+}
+
+// Page is a single page of results
+type Page struct {
+	Items []Item
+	Next  string // Next is the cursor for the following page (empty on the last page)
+}
+
+// cursor marks the position of the last item on a page
+type cursor struct {
+	Key string    `json:"k"`
+	ID  uuid.UUID `json:"id"`
+}
+
+// Filtered tests whether the query filters records in addition to paging them
+func (query *Query) Filtered() bool {
+	return query.Locked != LockedAny || query.Type != nil || query.TagID != uuid.Nil ||
+		!query.From.IsZero() || !query.Until.IsZero() || query.Extra != nil
+}
+
+// Match tests whether an item passes the query filters
+func (query *Query) Match(item Item) bool {
+	if query.Locked != LockedAny {
+		locked := false
+		if l, ok := item.(Lockable); ok {
+			locked = l.ItemLocked()
+		}
+		if (query.Locked == LockedOnly) != locked {
+			return false
+		}
+	}
+	if query.Type != nil {
+		t, ok := item.(Typed)
+		if !ok || t.ItemType() != *query.Type {
+			return false
+		}
+	}
+	if query.TagID != uuid.Nil && !query.hasTag(item) {
+		return false
+	}
+	updated := item.ItemUpdated()
+	if !query.From.IsZero() && updated.Before(query.From) {
+		return false
+	}
+	if !query.Until.IsZero() && updated.After(query.Until) {
+		return false
+	}
+	if query.Extra != nil && !query.Extra(item) {
+		return false
+	}
+	return true
+}
+
+func (query *Query) hasTag(item Item) bool {
+	if query.Tagged[item.ItemID()] {
+		return true
+	}
+	if t, ok := item.(Tagged); ok {
+		for _, id := range t.ItemTags() {
+			if id == query.TagID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// key returns the sortable value of an item for the query's sort key
+// Times are formatted so that they sort correctly as strings.
+func (query *Query) key(item Item) string {
+	switch query.Sort {
+	case SortCreated:
+		return item.ItemCreated().UTC().Format(time.RFC3339Nano)
+	case SortUpdated:
+		return item.ItemUpdated().UTC().Format(time.RFC3339Nano)
+	default:
+		return strings.ToLower(item.ItemTitle())
+	}
+}
+
+// less orders two positions, falling back on the id so the order is always total
+func (query *Query) less(a cursor, b cursor) bool {
+	if a.Key != b.Key {
+		if query.Direction == Descending {
+			return a.Key > b.Key
+		}
+		return a.Key < b.Key
+	}
+	return strings.Compare(a.ID.String(), b.ID.String()) < 0
+}
+
+// Order sorts items in place
+func (query *Query) Order(items []Item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return query.less(cursor{query.key(items[i]), items[i].ItemID()}, cursor{query.key(items[j]), items[j].ItemID()})
+	})
+}
+
+// Apply filters, sorts & pages a list of items
+// Pages are anchored on the last item of the previous page rather than an
+// offset, so records being added or removed between requests don't cause
+// items to be skipped or repeated.
+func (query *Query) Apply(items []Item) (*Page, error) {
+	var after *cursor
+	if query.Cursor != "" {
+		decoded, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	var matched []Item
+	for _, item := range items {
+		if !query.Match(item) {
+			continue
+		}
+		if after != nil && !query.less(*after, cursor{query.key(item), item.ItemID()}) {
+			continue
+		}
+		matched = append(matched, item)
+	}
+	query.Order(matched)
+
+	page := &Page{Items: matched}
+	if query.Limit > 0 && len(matched) > query.Limit {
+		page.Items = matched[:query.Limit]
+		last := page.Items[len(page.Items)-1]
+		page.Next = encodeCursor(cursor{query.key(last), last.ItemID()})
+	}
+	return page, nil
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := &cursor{}
+	err = json.Unmarshal(data, c)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
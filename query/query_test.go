@@ -0,0 +1,175 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type item struct {
+	id      uuid.UUID
+	title   string
+	created time.Time
+	locked  bool
+	kind    int
+	tags    []uuid.UUID
+}
+
+func (i *item) ItemID() uuid.UUID {
+	return i.id
+}
+
+func (i *item) ItemTitle() string {
+	return i.title
+}
+
+func (i *item) ItemCreated() time.Time {
+	return i.created
+}
+
+func (i *item) ItemUpdated() time.Time {
+	return i.created
+}
+
+func (i *item) ItemLocked() bool {
+	return i.locked
+}
+
+func (i *item) ItemType() int {
+	return i.kind
+}
+
+func (i *item) ItemTags() []uuid.UUID {
+	return i.tags
+}
+
+func newItems() []Item {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	titles := []string{"delta", "Alpha", "charlie", "echo", "bravo"}
+	var items []Item
+	for n, t := range titles {
+		items = append(items, &item{
+			id:      uuid.NewV4(),
+			title:   t,
+			created: start.Add(time.Duration(n) * time.Hour),
+			locked:  n%2 == 0,
+			kind:    n % 3,
+		})
+	}
+	return items
+}
+
+func titles(items []Item) []string {
+	var result []string
+	for _, i := range items {
+		result = append(result, i.ItemTitle())
+	}
+	return result
+}
+
+func equal(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for n := range a {
+		if a[n] != b[n] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSort(t *testing.T) {
+	items := newItems()
+
+	q := &Query{}
+	page, err := q.Apply(items)
+	if err != nil {
+		t.Fatal("Expected to apply query - ", err)
+	}
+	expected := []string{"Alpha", "bravo", "charlie", "delta", "echo"}
+	if !equal(titles(page.Items), expected) {
+		t.Error("Expected case insensitive title order but got ", titles(page.Items))
+	}
+	if page.Next != "" {
+		t.Error("Expected no next page without a limit")
+	}
+
+	q = &Query{Sort: SortCreated, Direction: Descending}
+	page, err = q.Apply(items)
+	if err != nil {
+		t.Fatal("Expected to apply query - ", err)
+	}
+	expected = []string{"bravo", "echo", "charlie", "Alpha", "delta"}
+	if !equal(titles(page.Items), expected) {
+		t.Error("Expected newest first but got ", titles(page.Items))
+	}
+}
+
+func TestPaging(t *testing.T) {
+	items := newItems()
+	// duplicate titles still page without skipping or repeating items
+	items = append(items, &item{id: uuid.NewV4(), title: "charlie"})
+
+	q := &Query{Limit: 2}
+	var seen []string
+	for pages := 0; pages < 10; pages++ {
+		page, err := q.Apply(items)
+		if err != nil {
+			t.Fatal("Expected to apply query - ", err)
+		}
+		if len(page.Items) > 2 {
+			t.Error("Expected at most 2 items per page but got ", len(page.Items))
+		}
+		seen = append(seen, titles(page.Items)...)
+		if page.Next == "" {
+			break
+		}
+		q.Cursor = page.Next
+	}
+	expected := []string{"Alpha", "bravo", "charlie", "charlie", "delta", "echo"}
+	if !equal(seen, expected) {
+		t.Error("Expected every item once but got ", seen)
+	}
+
+	q = &Query{Cursor: "not a cursor"}
+	_, err := q.Apply(items)
+	if err != ErrInvalidCursor {
+		t.Error("Expected an invalid cursor error - ", err)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	items := newItems()
+	tagID := uuid.NewV4()
+	items[1].(*item).tags = []uuid.UUID{tagID}
+
+	q := &Query{Locked: LockedOnly}
+	page, _ := q.Apply(items)
+	if !equal(titles(page.Items), []string{"bravo", "charlie", "delta"}) {
+		t.Error("Expected only locked items but got ", titles(page.Items))
+	}
+
+	kind := 1
+	q = &Query{Type: &kind}
+	page, _ = q.Apply(items)
+	if !equal(titles(page.Items), []string{"Alpha", "bravo"}) {
+		t.Error("Expected only items of type 1 but got ", titles(page.Items))
+	}
+
+	q = &Query{TagID: tagID, Tagged: map[uuid.UUID]bool{items[3].ItemID(): true}}
+	page, _ = q.Apply(items)
+	if !equal(titles(page.Items), []string{"Alpha", "echo"}) {
+		t.Error("Expected items carrying or assigned the tag but got ", titles(page.Items))
+	}
+
+	q = &Query{From: items[1].ItemUpdated(), Until: items[3].ItemUpdated()}
+	page, _ = q.Apply(items)
+	if !equal(titles(page.Items), []string{"Alpha", "charlie", "echo"}) {
+		t.Error("Expected items within the date range but got ", titles(page.Items))
+	}
+	if !q.Filtered() || (&Query{Limit: 1}).Filtered() {
+		t.Error("Expected Filtered to only report filters")
+	}
+}
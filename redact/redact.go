@@ -0,0 +1,161 @@
+// Package redact keeps secrets out of the log
+// The log is written in plain text next to the encrypted dbs, so passphrases,
+// keys, note content & email addresses must never reach it.  Rather than
+// trusting every log call, the logger's formatter is wrapped so that secrets are
+// replaced just before each entry is written.
+package redact
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Redacted replaces secrets in the log
+	Redacted = "[redacted]"
+	// MinSecretLength is the shortest secret scrubbed from log messages
+	// Shorter values (e.g. a one letter note) would mangle every message they
+	// appear in, so they are only redacted as fields.
+	MinSecretLength = 4
+)
+
+// sensitiveNames are the parts of field names that hold secrets
+var sensitiveNames = []string{"passphrase", "password", "secret", "key", "content", "email", "token", "proof"}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)+`)
+
+// Formatter redacts log entries before passing them on to the formatter it wraps
+type Formatter struct {
+	Formatter logrus.Formatter
+	lock      sync.RWMutex
+	secrets   map[string]int
+}
+
+// Install wraps the formatter of a logger, returning the existing redacting formatter if there is one
+func Install(logger *logrus.Logger) *Formatter {
+	if formatter, ok := logger.Formatter.(*Formatter); ok {
+		return formatter
+	}
+	formatter := &Formatter{
+		Formatter: logger.Formatter,
+		secrets:   make(map[string]int),
+	}
+	logger.Formatter = formatter
+	return formatter
+}
+
+// Add registers secrets to scrub from log messages until the returned function is called
+// Secrets are counted, so concurrent requests carrying the same secret can each
+// remove it when they are done.
+func (formatter *Formatter) Add(secrets ...string) func() {
+	var added []string
+	formatter.lock.Lock()
+	for _, secret := range secrets {
+		if len(secret) < MinSecretLength {
+			continue
+		}
+		formatter.secrets[secret]++
+		added = append(added, secret)
+	}
+	formatter.lock.Unlock()
+
+	return func() {
+		formatter.lock.Lock()
+		defer formatter.lock.Unlock()
+		for _, secret := range added {
+			formatter.secrets[secret]--
+			if formatter.secrets[secret] <= 0 {
+				delete(formatter.secrets, secret)
+			}
+		}
+	}
+}
+
+// String replaces email addresses & registered secrets in a string
+func (formatter *Formatter) String(s string) string {
+	formatter.lock.RLock()
+	for secret := range formatter.secrets {
+		s = strings.Replace(s, secret, Redacted, -1)
+	}
+	formatter.lock.RUnlock()
+	return emailPattern.ReplaceAllString(s, Redacted)
+}
+
+// Format redacts the message & fields of an entry before formatting it
+func (formatter *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	redacted := *entry
+	redacted.Message = formatter.String(entry.Message)
+	redacted.Data = make(logrus.Fields, len(entry.Data))
+	for name, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			value = formatter.String(v)
+		case error:
+			value = formatter.String(v.Error())
+		case fmt.Stringer:
+			value = formatter.String(v.String())
+		}
+		if Sensitive(name) {
+			value = Redacted
+		}
+		redacted.Data[name] = value
+	}
+	return formatter.Formatter.Format(&redacted)
+}
+
+// Sensitive reports whether a field name suggests that it holds a secret
+func Sensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Secrets collects the values of the sensitive fields of a message & the messages it contains
+// Byte fields are collected base64 encoded, the way they would be printed in JSON.
+func Secrets(message interface{}) []string {
+	var secrets []string
+	collect(reflect.ValueOf(message), false, &secrets)
+	return secrets
+}
+
+func collect(value reflect.Value, sensitive bool, secrets *[]string) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			collect(value.Elem(), sensitive, secrets)
+		}
+	case reflect.Struct:
+		valueType := value.Type()
+		for i := 0; i < value.NumField(); i++ {
+			field := valueType.Field(i)
+			if field.PkgPath != "" || strings.HasPrefix(field.Name, "XXX_") {
+				continue
+			}
+			collect(value.Field(i), sensitive || Sensitive(field.Name), secrets)
+		}
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			if sensitive && value.Len() > 0 {
+				*secrets = append(*secrets, base64.StdEncoding.EncodeToString(value.Bytes()))
+			}
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			collect(value.Index(i), sensitive, secrets)
+		}
+	case reflect.String:
+		if sensitive && value.Len() > 0 {
+			*secrets = append(*secrets, value.String())
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestLogger() (*logrus.Logger, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.Out = out
+	logger.Formatter = &logrus.JSONFormatter{}
+	return logger, out
+}
+
+func TestInstall(t *testing.T) {
+	logger, _ := newTestLogger()
+	formatter := Install(logger)
+	if Install(logger) != formatter {
+		t.Error("Expected installing twice to keep the first redacting formatter")
+	}
+	if _, ok := formatter.Formatter.(*logrus.JSONFormatter); !ok {
+		t.Error("Expected the redacting formatter to wrap the original formatter")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	logger, out := newTestLogger()
+	formatter := Install(logger)
+
+	logger.WithFields(logrus.Fields{
+		"passphrase":  "correct horse",
+		"PublicKey":   []byte{1, 2, 3},
+		"noteContent": "dear diary",
+		"error":       errors.New("no account for user@example.com"),
+		"method":      "Account::signin",
+	}).Warn("Signin failed for user@example.com")
+	logged := out.String()
+	for _, secret := range []string{"correct horse", "AQID", "dear diary", "user@example.com"} {
+		if strings.Contains(logged, secret) {
+			t.Error("Expected [", secret, "] to be redacted from - ", logged)
+		}
+	}
+	if !strings.Contains(logged, "Account::signin") || !strings.Contains(logged, "Signin failed for "+Redacted) {
+		t.Error("Expected the rest of the entry to be logged - ", logged)
+	}
+
+	out.Reset()
+	remove := formatter.Add("correct horse", "abc")
+	logger.Info("Error deriving key from correct horse & abc")
+	if strings.Contains(out.String(), "correct horse") || !strings.Contains(out.String(), "abc") {
+		t.Error("Expected registered secrets of at least ", MinSecretLength, " characters to be redacted - ", out.String())
+	}
+
+	out.Reset()
+	again := formatter.Add("correct horse")
+	remove()
+	logger.Info("still correct horse")
+	if strings.Contains(out.String(), "correct horse") {
+		t.Error("Expected a secret added twice to be redacted until both are removed")
+	}
+	again()
+	out.Reset()
+	logger.Info("correct horse")
+	if !strings.Contains(out.String(), "correct horse") {
+		t.Error("Expected removed secrets to be logged")
+	}
+}
+
+func TestSecrets(t *testing.T) {
+	request := &messages.CreateAccountRequest{
+		Name:       "account",
+		Email:      "user@example.com",
+		Passphrase: "passphrase",
+	}
+	secrets := Secrets(request)
+	expected := []string{"user@example.com", "passphrase"}
+	if !reflect.DeepEqual(secrets, expected) {
+		t.Error("Expected secrets ", expected, " but got ", secrets)
+	}
+	if len(Secrets(nil)) != 0 || len(Secrets(&messages.EmptyRequest{})) != 0 {
+		t.Error("Expected no secrets in empty messages")
+	}
+}
@@ -0,0 +1,188 @@
+package rpc
+
+import (
+	"notekeeper-electron-backend/account"
+	"notekeeper-electron-backend/api"
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/shelf"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Target is a container that a request acts on
+type Target struct {
+	Scope       string    // Scope is either user or account
+	OwnerID     uuid.UUID // OwnerID is the user or account id given by the client (uuid.Nil when the request doesn't name one)
+	Container   string    // Container is shelf, collection or empty when the request only acts on the owner
+	ContainerID uuid.UUID // ContainerID is the id of the shelf or collection
+	ShelfID     uuid.UUID // ShelfID is the shelf holding a collection, when the request names it
+}
+
+// Resolver extracts the targets of a decoded request
+type Resolver func(call *Call) ([]*Target, error)
+
+// Containers looks up the containers that exist for an owner
+type Containers interface {
+	Shelves(scope string, ownerID uuid.UUID) ([]uuid.UUID, error)
+	Collections(scope string, shelfID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// Authorize checks that the active user has the right to act on the targets of a request
+func (rpc *Server) Authorize(call *Call) error {
+	route, ok := rpc.Methods[call.Method]
+	if !ok || route.method.Resolver == nil {
+		return nil
+	}
+
+	if !rpc.IsSignedIn() {
+		call.Context.Logger.Warn("Unauthorized request for [", call.Method, "] while signed out")
+		code := codes.New(codes.ScopeRPC, codes.ErrorUnauthorized)
+		return code
+	}
+
+	targets, err := route.method.Resolver(call)
+	if err != nil {
+		call.Context.Logger.Warn("Error resolving request targets for [", call.Method, "] - ", err)
+		code := codes.New(codes.ScopeRPC, codes.ErrorDecode)
+		return code
+	}
+
+	containers := &registryContainers{server: rpc}
+	return rpc.authorize(targets, containers)
+}
+
+// authorize checks each target against the active user's account
+// A user may act on their own user-scoped containers & any of their account's
+// containers, but only when the container belongs to that owner.
+func (rpc *Server) authorize(targets []*Target, containers Containers) error {
+	for _, target := range targets {
+		ownerID, ok := ownerForScope(rpc.Account, target.Scope)
+		if !ok || (target.OwnerID != uuid.Nil && target.OwnerID != ownerID) {
+			rpc.Logger.Warn("Unauthorized request for owner [", target.OwnerID, "] in scope [", target.Scope, "]")
+			code := codes.New(codes.ScopeRPC, codes.ErrorUnauthorized)
+			return code
+		}
+
+		ok, err := owns(containers, target, ownerID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			rpc.Logger.Warn("Unauthorized request for ", target.Container, " [", target.ContainerID, "]")
+			code := codes.New(codes.ScopeRPC, codes.ErrorUnauthorized)
+			return code
+		}
+	}
+	return nil
+}
+
+func ownerForScope(a *account.Account, scope string) (uuid.UUID, bool) {
+	if a == nil || a.ActiveUser == nil {
+		return uuid.Nil, false
+	}
+	if scope == "account" {
+		return a.ID, true
+	} else if scope == "user" {
+		return a.ActiveUser.ID, true
+	}
+	return uuid.Nil, false
+}
+
+// owns tests whether a target's container belongs to ownerID
+func owns(containers Containers, target *Target, ownerID uuid.UUID) (bool, error) {
+	if target.Container == "" {
+		return true, nil
+	}
+	if target.Container != "shelf" && target.Container != "collection" {
+		return false, nil
+	}
+
+	shelves, err := containers.Shelves(target.Scope, ownerID)
+	if err != nil {
+		return false, err
+	}
+	if target.Container == "shelf" {
+		return contains(shelves, target.ContainerID), nil
+	}
+
+	// collection requests don't always name the shelf, so check every shelf the owner has
+	if target.ShelfID != uuid.Nil {
+		if !contains(shelves, target.ShelfID) {
+			return false, nil
+		}
+		shelves = []uuid.UUID{target.ShelfID}
+	}
+	for _, shelfID := range shelves {
+		collections, err := containers.Collections(target.Scope, shelfID)
+		if err != nil {
+			return false, err
+		}
+		if contains(collections, target.ContainerID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func contains(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// registryContainers looks up containers in the open databases of the active user
+type registryContainers struct {
+	server *Server
+}
+
+func (containers *registryContainers) Shelves(scope string, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	shelfScope := shelf.ScopeUser
+	if scope == "account" {
+		shelfScope = shelf.ScopeAccount
+	}
+	index := shelf.NewIndex(shelfScope, ownerID, containers.server.DBRegistry, containers.server.Logger)
+	err := index.LoadAll(containers.server.Account.ActiveUser.PassphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for _, s := range index.Shelves {
+		ids = append(ids, s.ID)
+	}
+	return ids, nil
+}
+
+// Collections lists the collections of a shelf
+// The collection index is kept in the shelf db, so it's opened with the account
+// or user key that the shelf db is sealed with rather than the passphrase key.
+func (containers *registryContainers) Collections(scope string, shelfID uuid.UUID) ([]uuid.UUID, error) {
+	shelfScope := shelf.ScopeUser
+	collectionScope := collection.ScopeUser
+	if scope == "account" {
+		shelfScope = shelf.ScopeAccount
+		collectionScope = collection.ScopeAccount
+	}
+	server := containers.server
+	key, err := api.New(server.DBRegistry, server.Logger).ShelfKey(server.Account, shelfScope)
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.Zero(key)
+
+	index := collection.NewIndex(collectionScope, server.DBRegistry, server.Logger)
+	index.ShelfID = shelfID
+	err = index.LoadAll(key)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for _, c := range index.Collections {
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"notekeeper-electron-backend/account"
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/user"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// containers is a fixed set of shelves & collections for testing
+type containers struct {
+	shelves     map[uuid.UUID][]uuid.UUID // shelves keyed by owner
+	collections map[uuid.UUID][]uuid.UUID // collections keyed by shelf
+}
+
+func (c *containers) Shelves(scope string, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	return c.shelves[ownerID], nil
+}
+
+func (c *containers) Collections(scope string, shelfID uuid.UUID) ([]uuid.UUID, error) {
+	return c.collections[shelfID], nil
+}
+
+func newTestServer() *Server {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	return NewServer(logger, nil, nil)
+}
+
+func expectUnauthorized(t *testing.T, err error) {
+	if err == nil {
+		t.Error("Expected request to be unauthorized")
+		return
+	}
+	if codes.ToInternalError(err).Code != codes.ErrorUnauthorized {
+		t.Error("Expected ErrorUnauthorized but got - ", err)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	accountID := uuid.NewV4()
+	userID := uuid.NewV4()
+	otherUserID := uuid.NewV4()
+
+	userShelf := uuid.NewV4()
+	accountShelf := uuid.NewV4()
+	otherShelf := uuid.NewV4()
+	userCollection := uuid.NewV4()
+	accountCollection := uuid.NewV4()
+	otherCollection := uuid.NewV4()
+
+	server := newTestServer()
+	server.UserState = UserStateSignedIn
+	server.Account = &account.Account{
+		ID:         accountID,
+		ActiveUser: &user.User{ID: userID, AccountID: accountID},
+	}
+	c := &containers{
+		shelves: map[uuid.UUID][]uuid.UUID{
+			userID:      {userShelf},
+			accountID:   {accountShelf},
+			otherUserID: {otherShelf},
+		},
+		collections: map[uuid.UUID][]uuid.UUID{
+			userShelf:    {userCollection},
+			accountShelf: {accountCollection},
+			otherShelf:   {otherCollection},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		targets    []*Target
+		authorized bool
+	}{
+		{"own user", []*Target{{Scope: "user", OwnerID: userID}}, true},
+		{"own account", []*Target{{Scope: "account", OwnerID: accountID}}, true},
+		{"implied owner", []*Target{{Scope: "user", Container: "shelf", ContainerID: userShelf}}, true},
+		{"other user", []*Target{{Scope: "user", OwnerID: otherUserID}}, false},
+		{"account id as user", []*Target{{Scope: "user", OwnerID: accountID}}, false},
+		{"user id as account", []*Target{{Scope: "account", OwnerID: userID}}, false},
+		{"invalid scope", []*Target{{Scope: "global", OwnerID: userID}}, false},
+		{"user shelf", []*Target{{Scope: "user", OwnerID: userID, Container: "shelf", ContainerID: userShelf}}, true},
+		{"account shelf", []*Target{{Scope: "account", OwnerID: accountID, Container: "shelf", ContainerID: accountShelf}}, true},
+		{"other user's shelf", []*Target{{Scope: "user", OwnerID: userID, Container: "shelf", ContainerID: otherShelf}}, false},
+		{"account shelf as user", []*Target{{Scope: "user", OwnerID: userID, Container: "shelf", ContainerID: accountShelf}}, false},
+		{"user shelf as account", []*Target{{Scope: "account", OwnerID: accountID, Container: "shelf", ContainerID: userShelf}}, false},
+		{"unknown shelf", []*Target{{Scope: "user", OwnerID: userID, Container: "shelf", ContainerID: uuid.NewV4()}}, false},
+		{"user collection", []*Target{{Scope: "user", OwnerID: userID, Container: "collection", ContainerID: userCollection}}, true},
+		{"user collection in shelf", []*Target{{Scope: "user", Container: "collection", ContainerID: userCollection, ShelfID: userShelf}}, true},
+		{"account collection", []*Target{{Scope: "account", OwnerID: accountID, Container: "collection", ContainerID: accountCollection}}, true},
+		{"other user's collection", []*Target{{Scope: "user", OwnerID: userID, Container: "collection", ContainerID: otherCollection}}, false},
+		{"collection in other user's shelf", []*Target{{Scope: "user", Container: "collection", ContainerID: otherCollection, ShelfID: otherShelf}}, false},
+		{"collection in the wrong shelf", []*Target{{Scope: "account", Container: "collection", ContainerID: userCollection, ShelfID: accountShelf}}, false},
+		{"invalid container", []*Target{{Scope: "user", OwnerID: userID, Container: "notebook", ContainerID: userShelf}}, false},
+		{"move to own account", []*Target{
+			{Scope: "user", OwnerID: userID, Container: "shelf", ContainerID: userShelf},
+			{Scope: "account", OwnerID: accountID, Container: "collection", ContainerID: accountCollection},
+		}, true},
+		{"move to other user", []*Target{
+			{Scope: "user", OwnerID: userID, Container: "shelf", ContainerID: userShelf},
+			{Scope: "user", OwnerID: otherUserID, Container: "shelf", ContainerID: otherShelf},
+		}, false},
+		{"move from other user", []*Target{
+			{Scope: "user", OwnerID: otherUserID, Container: "shelf", ContainerID: otherShelf},
+			{Scope: "user", OwnerID: userID, Container: "shelf", ContainerID: userShelf},
+		}, false},
+	}
+
+	for _, test := range tests {
+		err := server.authorize(test.targets, c)
+		if test.authorized && err != nil {
+			t.Error("Expected [", test.name, "] to be authorized - ", err)
+		}
+		if !test.authorized {
+			if err == nil {
+				t.Error("Expected [", test.name, "] to be unauthorized")
+			} else {
+				expectUnauthorized(t, err)
+			}
+		}
+	}
+}
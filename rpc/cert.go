@@ -3,35 +3,292 @@ package rpc
 import (
 	"bytes"
 	"encoding/pem"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
 
 	"crypto/tls"
 
-	"notekeeper-electron-backend/appdir"
+	"notekeeper-electron-backend/crypto"
 )
 
-func (rpc *Server) createCertificate() bool {
-	now := time.Now()
+// CertificatePolicy controls how long the TLS certificate is reused
+type CertificatePolicy struct {
+	Lifetime time.Duration // Lifetime is how long a new certificate is valid
+	Overlap  time.Duration // Overlap is how long before expiry the next certificate is created & announced
+}
+
+// DefaultCertificatePolicy returns the policy used unless the backend is configured otherwise
+func DefaultCertificatePolicy() CertificatePolicy {
+	policy := CertificatePolicy{
+		Lifetime: 365 * 24 * time.Hour,
+		Overlap:  30 * 24 * time.Hour,
+	}
+	return policy
+}
+
+// Certificate file names
+// The current certificate keeps the name it has always had, so the frontend
+// can still read it from the app data directory.
+const (
+	CertificateFile        = "certificate"
+	CertificateKeyFile     = "certificate.key"
+	NextCertificateFile    = "certificate.next"
+	NextCertificateKeyFile = "certificate.next.key"
+	CertificateSecretFile  = "certificate.secret"
+)
+
+// CertificateFingerprint returns the SHA-256 fingerprint of a certificate
+// It uses the same format as node's X509Certificate.fingerprint256 so the
+// frontend can compare it directly.
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// Fingerprints returns the fingerprints of the current & next certificates
+// The next fingerprint is empty unless a rotation is in progress.
+func (rpc *Server) Fingerprints() (string, string) {
+	rpc.certLock.Lock()
+	defer rpc.certLock.Unlock()
+	current := ""
+	if rpc.Certificate.Leaf != nil {
+		current = CertificateFingerprint(rpc.Certificate.Leaf)
+	}
+	next := ""
+	if rpc.NextCertificate != nil {
+		next = CertificateFingerprint(rpc.NextCertificate.Leaf)
+	}
+	return current, next
+}
+
+// loadCertificates loads the certificates saved by a previous start, creating or rotating them as needed
+// The current certificate is replaced by the next one once it has expired,
+// and the next certificate is created once the current one is within the
+// overlap period of its expiry.  Clients that pin both fingerprints during the
+// overlap keep working across the rotation.
+func (rpc *Server) loadCertificates(now time.Time) bool {
+	err := os.MkdirAll(rpc.CertificatePath, 0700)
+	if err != nil {
+		rpc.Logger.Warn("Error creating certificate directory - ", err)
+		return false
+	}
+	key, ok := rpc.certificateSecret()
+	if !ok {
+		return false
+	}
+
+	current := rpc.readCertificate(CertificateFile, CertificateKeyFile, key)
+	next := rpc.readCertificate(NextCertificateFile, NextCertificateKeyFile, key)
+	if next != nil && !now.Before(next.Leaf.NotAfter) {
+		next = nil
+	}
+
+	if current == nil || !now.Before(current.Leaf.NotAfter) {
+		if next != nil {
+			rpc.Logger.Info("Rotating to the next certificate")
+			current = next
+			next = nil
+		} else {
+			rpc.Logger.Info("Creating a new certificate")
+			current = rpc.createCertificate(now)
+			if current == nil {
+				return false
+			}
+		}
+		if !rpc.writeCertificate(CertificateFile, CertificateKeyFile, current, key) {
+			return false
+		}
+		rpc.removeCertificate(NextCertificateFile, NextCertificateKeyFile)
+	}
+
+	if next == nil && !now.Before(current.Leaf.NotAfter.Add(-rpc.CertificatePolicy.Overlap)) {
+		rpc.Logger.Info("Creating the next certificate")
+		next = rpc.createCertificate(now)
+		if next == nil {
+			return false
+		}
+		if !rpc.writeCertificate(NextCertificateFile, NextCertificateKeyFile, next, key) {
+			return false
+		}
+	}
+
+	rpc.certLock.Lock()
+	defer rpc.certLock.Unlock()
+	rpc.Certificate = *current
+	rpc.NextCertificate = next
+	return true
+}
+
+// serverCertificate returns the certificate to present for a TLS handshake
+// A certificate that expires while the server is running is swapped for the
+// next certificate, which clients were told about at startup.
+func (rpc *Server) serverCertificate(now time.Time) *tls.Certificate {
+	rpc.certLock.Lock()
+	defer rpc.certLock.Unlock()
+	if rpc.NextCertificate != nil && !now.Before(rpc.Certificate.Leaf.NotAfter) {
+		rpc.Logger.Info("Certificate has expired, switching to the next certificate")
+		rpc.Certificate = *rpc.NextCertificate
+		rpc.NextCertificate = nil
+	}
+	return &rpc.Certificate
+}
+
+// tlsConfig creates the TLS configuration shared by the RPC & gRPC listeners
+func (rpc *Server) tlsConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return rpc.serverCertificate(time.Now()), nil
+		},
+	}
+}
+
+// certificateSecret loads the key that encrypts certificate private keys, creating it on first use
+func (rpc *Server) certificateSecret() (*[crypto.KeySize]byte, bool) {
+	path := filepath.Join(rpc.CertificatePath, CertificateSecretFile)
+	key := new([crypto.KeySize]byte)
+	data, err := ioutil.ReadFile(path)
+	if err == nil && len(data) == crypto.KeySize {
+		copy(key[:], data)
+		return key, true
+	}
+	if err == nil {
+		rpc.Logger.Warn("Certificate secret is the wrong size, creating a new one")
+	} else if !os.IsNotExist(err) {
+		rpc.Logger.Warn("Error reading certificate secret - ", err)
+		return nil, false
+	}
+
+	key, err = crypto.New(rpc.Logger).GenerateKey()
+	if err != nil {
+		return nil, false
+	}
+	err = writeFile(path, key[:])
+	if err != nil {
+		rpc.Logger.Warn("Error writing certificate secret - ", err)
+		return nil, false
+	}
+	// certificates saved with another secret can't be opened any more
+	rpc.removeCertificate(CertificateFile, CertificateKeyFile)
+	rpc.removeCertificate(NextCertificateFile, NextCertificateKeyFile)
+	return key, true
+}
+
+// readCertificate loads a saved certificate & decrypts its key, returning nil if it can't be used
+func (rpc *Server) readCertificate(certFile string, keyFile string, key *[crypto.KeySize]byte) *tls.Certificate {
+	certPEM, err := ioutil.ReadFile(filepath.Join(rpc.CertificatePath, certFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			rpc.Logger.Warn("Error reading certificate [", certFile, "] - ", err)
+		}
+		return nil
+	}
+	encryptedKey, err := ioutil.ReadFile(filepath.Join(rpc.CertificatePath, keyFile))
+	if err != nil {
+		rpc.Logger.Warn("Error reading certificate key [", keyFile, "] - ", err)
+		return nil
+	}
+	keyPEM, err := crypto.New(rpc.Logger).Decrypt(key, encryptedKey)
+	if err != nil {
+		rpc.Logger.Warn("Error decrypting certificate key [", keyFile, "] - ", err)
+		return nil
+	}
+	defer crypto.Zero(keyPEM)
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		rpc.Logger.Warn("Error loading certificate [", certFile, "] - ", err)
+		return nil
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		rpc.Logger.Warn("Error parsing certificate [", certFile, "] - ", err)
+		return nil
+	}
+	return &cert
+}
+
+// writeCertificate saves a certificate along with its key, encrypted with the certificate secret
+func (rpc *Server) writeCertificate(certFile string, keyFile string, cert *tls.Certificate, key *[crypto.KeySize]byte) bool {
+	certBuf := &bytes.Buffer{}
+	err := pem.Encode(certBuf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	if err != nil {
+		rpc.Logger.Warn("Error encoding certificate - ", err)
+		return false
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		rpc.Logger.Warn("Error marshaling key bytes - ", err)
+		return false
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	defer crypto.Zero(keyPEM)
+	encryptedKey, err := crypto.New(rpc.Logger).Encrypt(key, keyPEM)
+	if err != nil {
+		rpc.Logger.Warn("Error encrypting certificate key - ", err)
+		return false
+	}
+
+	// the key is written first so a certificate is never saved without one
+	err = writeFile(filepath.Join(rpc.CertificatePath, keyFile), encryptedKey)
+	if err != nil {
+		rpc.Logger.Warn("Error writing certificate key - ", err)
+		return false
+	}
+	err = writeFile(filepath.Join(rpc.CertificatePath, certFile), certBuf.Bytes())
+	if err != nil {
+		rpc.Logger.Warn("Error writing certificate - ", err)
+		return false
+	}
+	return true
+}
+
+// removeCertificate deletes a saved certificate & its key
+func (rpc *Server) removeCertificate(certFile string, keyFile string) {
+	for _, name := range []string{certFile, keyFile} {
+		err := os.Remove(filepath.Join(rpc.CertificatePath, name))
+		if err != nil && !os.IsNotExist(err) {
+			rpc.Logger.Warn("Error removing [", name, "] - ", err)
+		}
+	}
+}
+
+// writeFile replaces a file with data, so a partly written file is never left behind
+func writeFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	err := ioutil.WriteFile(tmpPath, data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// createCertificate creates a self-signed certificate valid from now for the policy lifetime
+func (rpc *Server) createCertificate(now time.Time) *tls.Certificate {
 	notBefore := now.Add(-time.Hour * 24)
-	// end of ASN.1 time
-	endOfTime := time.Date(2049, 12, 31, 23, 59, 59, 0, time.UTC)
-	notAfter := endOfTime // notBefore.Add(365 * 24 * time.Hour)
+	notAfter := now.Add(rpc.CertificatePolicy.Lifetime)
 
 	host, err := os.Hostname()
 	if err != nil {
 		rpc.Logger.Warn("Error getting hostname - ", err)
-		return false
+		return nil
 	}
 
 	ipAddresses := []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")}
@@ -52,7 +309,7 @@ func (rpc *Server) createCertificate() bool {
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
 		rpc.Logger.Warn("error getting interface addresses - ", err)
-		return false
+		return nil
 	}
 	for _, a := range addrs {
 		ipAddr, _, err := net.ParseCIDR(a.String())
@@ -65,7 +322,7 @@ func (rpc *Server) createCertificate() bool {
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
 	if err != nil {
 		rpc.Logger.Warn("Error creating serial number - ", err)
-		return false
+		return nil
 	}
 
 	// There's an old boringssl bug - https://github.com/grpc/grpc/issues/6722
@@ -76,7 +333,7 @@ func (rpc *Server) createCertificate() bool {
 	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		rpc.Logger.Warn("Error generating private key - ", err)
-		return false
+		return nil
 	}
 
 	template := x509.Certificate{
@@ -97,65 +354,18 @@ func (rpc *Server) createCertificate() bool {
 	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &privKey.PublicKey, privKey)
 	if err != nil {
 		rpc.Logger.Warn("Error creating certificate - ", err)
-		return false
-	}
-
-	certBuf := &bytes.Buffer{}
-	err = pem.Encode(certBuf, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
-	if err != nil {
-		rpc.Logger.Warn("Error encoding certificate - ", err)
-		return false
-	}
-
-	keyBytes, err := x509.MarshalECPrivateKey(privKey)
-	if err != nil {
-		rpc.Logger.Warn("Error marshaling key bytes - ", err)
-		return false
-	}
-
-	/*
-		keyPemBlock := pem.Block{
-			Type:  "EC PRIVATE KEY",
-			Bytes: keyBytes, // x509.MarshalPKCS1PrivateKey(privKey),
-		}
-	*/
-	keyBuf := &bytes.Buffer{}
-	err = pem.Encode(keyBuf, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
-	if err != nil {
-		rpc.Logger.Warn("Error encoding key - ", err)
-		return false
+		return nil
 	}
 
-	/*
-		certPemBlock := pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: derBytes,
-		}
-	*/
-	/*
-		certPath := filepath.Join(appdir.AppDataPath(), "certificate")
-		certOut, err := os.Create(certPath)
-		if err != nil {
-			rpc.Logger.Warn("Error creating certificate file - ", err)
-			return false
-		}
-
-		pem.Encode(certOut, &certPemBlock)
-		certOut.Close()
-	*/
-	//rpc.Certificate, err = tls.X509KeyPair(pem.EncodeToMemory(&certPemBlock), pem.EncodeToMemory(&keyPemBlock))
-	rpc.Certificate, err = tls.X509KeyPair(certBuf.Bytes(), keyBuf.Bytes())
+	leaf, err := x509.ParseCertificate(derBytes)
 	if err != nil {
-		rpc.Logger.Warn("Error converting certificate - ", err)
-		return false
+		rpc.Logger.Warn("Error parsing certificate - ", err)
+		return nil
 	}
 
-	certPath := filepath.Join(appdir.AppDataPath(), "certificate")
-	err = ioutil.WriteFile(certPath, certBuf.Bytes(), 0600)
-	if err != nil {
-		rpc.Logger.Warn("Error writing certificate - ", err)
-		return false
+	return &tls.Certificate{
+		Certificate: [][]byte{derBytes},
+		PrivateKey:  privKey,
+		Leaf:        leaf,
 	}
-
-	return true
 }
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newCertificateServer(t *testing.T) (*Server, func()) {
+	dir, err := ioutil.TempDir("", "notekeeper-cert")
+	if err != nil {
+		t.Fatal("Error creating certificate directory - ", err)
+	}
+	server := newTestServer()
+	server.CertificatePath = dir
+	server.CertificatePolicy = CertificatePolicy{Lifetime: 10 * 24 * time.Hour, Overlap: 2 * 24 * time.Hour}
+	return server, func() { os.RemoveAll(dir) }
+}
+
+func TestCertificatePersistence(t *testing.T) {
+	server, cleanup := newCertificateServer(t)
+	defer cleanup()
+	now := time.Now()
+
+	if !server.loadCertificates(now) {
+		t.Fatal("Expected a certificate to be created")
+	}
+	first, next := server.Fingerprints()
+	if next != "" {
+		t.Error("Expected no next certificate for a new certificate")
+	}
+
+	keyData, err := ioutil.ReadFile(filepath.Join(server.CertificatePath, CertificateKeyFile))
+	if err != nil {
+		t.Fatal("Expected the certificate key to be saved - ", err)
+	}
+	if bytes.Contains(keyData, []byte("PRIVATE KEY")) {
+		t.Error("Expected the certificate key to be encrypted")
+	}
+
+	restarted := newTestServer()
+	restarted.CertificatePath = server.CertificatePath
+	restarted.CertificatePolicy = server.CertificatePolicy
+	if !restarted.loadCertificates(now.Add(time.Hour)) {
+		t.Fatal("Expected the saved certificate to be loaded")
+	}
+	if fingerprint, _ := restarted.Fingerprints(); fingerprint != first {
+		t.Error("Expected the certificate to be reused after a restart")
+	}
+
+	// a certificate that can't be decrypted is replaced
+	err = os.Remove(filepath.Join(server.CertificatePath, CertificateSecretFile))
+	if err != nil {
+		t.Fatal("Error removing certificate secret - ", err)
+	}
+	if !restarted.loadCertificates(now.Add(time.Hour)) {
+		t.Fatal("Expected a certificate to be created")
+	}
+	if fingerprint, _ := restarted.Fingerprints(); fingerprint == first {
+		t.Error("Expected a new certificate once the secret was lost")
+	}
+}
+
+func TestCertificateRotation(t *testing.T) {
+	server, cleanup := newCertificateServer(t)
+	defer cleanup()
+	start := time.Now()
+
+	if !server.loadCertificates(start) {
+		t.Fatal("Expected a certificate to be created")
+	}
+	first, _ := server.Fingerprints()
+
+	// within the overlap the next certificate is announced alongside the current one
+	overlap := start.Add(9 * 24 * time.Hour)
+	if !server.loadCertificates(overlap) {
+		t.Fatal("Expected the saved certificate to be loaded")
+	}
+	current, next := server.Fingerprints()
+	if current != first || next == "" {
+		t.Fatal("Expected the current certificate & a next certificate during the overlap")
+	}
+	if !server.loadCertificates(overlap.Add(time.Hour)) {
+		t.Fatal("Expected the saved certificates to be loaded")
+	}
+	if _, again := server.Fingerprints(); again != next {
+		t.Error("Expected the next certificate to be reused")
+	}
+
+	// the next certificate takes over once the current one expires
+	if !server.loadCertificates(start.Add(11 * 24 * time.Hour)) {
+		t.Fatal("Expected the certificates to be rotated")
+	}
+	rotated, pending := server.Fingerprints()
+	if rotated != next || pending != "" {
+		t.Error("Expected the next certificate to become the current certificate")
+	}
+	if _, err := os.Stat(filepath.Join(server.CertificatePath, NextCertificateFile)); !os.IsNotExist(err) {
+		t.Error("Expected the next certificate file to be removed after rotation")
+	}
+}
+
+func TestServerCertificateExpiry(t *testing.T) {
+	server, cleanup := newCertificateServer(t)
+	defer cleanup()
+	start := time.Now()
+
+	if !server.loadCertificates(start.Add(-9 * 24 * time.Hour)) {
+		t.Fatal("Expected a certificate to be created")
+	}
+	if !server.loadCertificates(start) {
+		t.Fatal("Expected a next certificate to be created")
+	}
+	current, next := server.Fingerprints()
+
+	cert := server.serverCertificate(start)
+	if CertificateFingerprint(cert.Leaf) != current {
+		t.Error("Expected the current certificate before it expires")
+	}
+	cert = server.serverCertificate(start.Add(2 * 24 * time.Hour))
+	if CertificateFingerprint(cert.Leaf) != next {
+		t.Error("Expected the next certificate once the current one expires")
+	}
+}
+
+func TestCertificateFingerprint(t *testing.T) {
+	server, cleanup := newCertificateServer(t)
+	defer cleanup()
+	cert := server.createCertificate(time.Now())
+	if cert == nil {
+		t.Fatal("Expected a certificate to be created")
+	}
+	fingerprint := CertificateFingerprint(cert.Leaf)
+	// 32 bytes as colon separated hex pairs
+	if len(fingerprint) != 32*3-1 {
+		t.Error("Unexpected fingerprint format - ", fingerprint)
+	}
+}
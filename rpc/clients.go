@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTokenExpired is returned for a token that has passed its lifetime or idle timeout
+var ErrTokenExpired = errors.New("client token has expired")
+
+// errUnknownToken is returned for a token that was never issued or has been revoked
+var errUnknownToken = errors.New("invalid client token")
+
+// ClientPolicy controls how long client tokens stay valid
+type ClientPolicy struct {
+	Lifetime        time.Duration // Lifetime is how long a token is valid after key exchange (0 never expires)
+	IdleTimeout     time.Duration // IdleTimeout is how long a token is valid after its last request (0 never times out)
+	ReapInterval    time.Duration // ReapInterval is how often expired tokens are removed
+	RevokeOnSignout bool          // RevokeOnSignout revokes every token when the account is signed out
+	RevokeOnLock    bool          // RevokeOnLock revokes every token when the account is locked
+}
+
+// DefaultClientPolicy returns the policy used unless the backend is configured otherwise
+func DefaultClientPolicy() ClientPolicy {
+	policy := ClientPolicy{
+		Lifetime:        24 * time.Hour,
+		IdleTimeout:     time.Hour,
+		ReapInterval:    time.Minute,
+		RevokeOnSignout: true,
+	}
+	return policy
+}
+
+// AddClient registers a client token created by a key exchange
+func (rpc *Server) AddClient(client *ClientToken) {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	rpc.Clients[client.Token] = client
+}
+
+// removeClient removes a client token that failed to complete a key exchange
+func (rpc *Server) removeClient(token string) {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	delete(rpc.Clients, token)
+}
+
+// lookupClient finds the client for a request token
+// The token is only marked as used by countRequest, once the request has been
+// verified, so requests that merely name a token don't keep it alive.  Expired tokens are removed as they're found rather than waiting for the reaper,
+// & are reported with ErrTokenExpired so the client knows to exchange keys again.
+func (rpc *Server) lookupClient(token string, now time.Time) (*ClientToken, error) {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	client, ok := rpc.Clients[token]
+	if !ok {
+		return nil, errUnknownToken
+	}
+	if client.Expired(rpc.ClientPolicy, now) {
+		rpc.Logger.Info("Client token [", client.ID, "] has expired")
+		delete(rpc.Clients, token)
+		return nil, ErrTokenExpired
+	}
+	return client, nil
+}
+
+// checkSequence checks that a request from a client has the next expected sequence
+// The counter is only advanced by countRequest once the request's signature
+// has been verified, so a forged request can't push the client out of sequence.
+func (rpc *Server) checkSequence(client *ClientToken, sequence int32) bool {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	if sequence != client.RecvCounter+1 {
+		rpc.Logger.Warn("Invalid message sequence received. Expected [", client.RecvCounter+1, "] but got [", sequence, "]")
+		return false
+	}
+	return true
+}
+
+// countRequest counts a verified request from a client & marks its token as used at now
+// It fails if another request with the same sequence was counted since the
+// sequence was checked.
+func (rpc *Server) countRequest(client *ClientToken, sequence int32, now time.Time) bool {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	if sequence != client.RecvCounter+1 {
+		rpc.Logger.Warn("Message sequence [", sequence, "] was already received")
+		return false
+	}
+	client.RecvCounter = sequence
+	client.LastUsed = now
+	return true
+}
+
+// countResponse counts a response to a client, returning its sequence
+func (rpc *Server) countResponse(client *ClientToken) int32 {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	client.SendCounter++
+	return client.SendCounter
+}
+
+// ListClients returns copies of the registered client tokens
+func (rpc *Server) ListClients() []ClientToken {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	var clients []ClientToken
+	for _, client := range rpc.Clients {
+		clients = append(clients, *client)
+	}
+	return clients
+}
+
+// RevokeClient removes the client with the given id, returning false if it isn't registered
+func (rpc *Server) RevokeClient(id string) bool {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	for token, client := range rpc.Clients {
+		if client.ID == id {
+			delete(rpc.Clients, token)
+			rpc.Logger.Info("Revoked client token [", id, "]")
+			return true
+		}
+	}
+	return false
+}
+
+// RevokeClients removes every client, returning the number removed
+func (rpc *Server) RevokeClients() int {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	count := len(rpc.Clients)
+	rpc.Clients = make(map[string]*ClientToken)
+	rpc.Logger.Info("Revoked [", count, "] client tokens")
+	return count
+}
+
+// reapClients removes the clients that have expired by now, returning the number removed
+func (rpc *Server) reapClients(now time.Time) int {
+	rpc.clientsLock.Lock()
+	defer rpc.clientsLock.Unlock()
+	count := 0
+	for token, client := range rpc.Clients {
+		if client.Expired(rpc.ClientPolicy, now) {
+			delete(rpc.Clients, token)
+			count++
+		}
+	}
+	if count > 0 {
+		rpc.Logger.Info("Removed [", count, "] expired client tokens")
+	}
+	return count
+}
+
+// startReaper periodically removes expired clients until the server is stopped
+func (rpc *Server) startReaper() {
+	if rpc.ClientPolicy.ReapInterval <= 0 {
+		return
+	}
+	rpc.stopReaper = make(chan bool)
+	ticker := time.NewTicker(rpc.ClientPolicy.ReapInterval)
+	go func(stop chan bool) {
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				rpc.reapClients(now)
+			case <-stop:
+				return
+			}
+		}
+	}(rpc.stopReaper)
+}
@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientExpiry(t *testing.T) {
+	created := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	client := &ClientToken{Created: created, LastUsed: created.Add(time.Hour)}
+
+	tests := []struct {
+		name    string
+		policy  ClientPolicy
+		now     time.Time
+		expired bool
+	}{
+		{"never expires", ClientPolicy{}, created.Add(1000 * time.Hour), false},
+		{"within lifetime", ClientPolicy{Lifetime: 2 * time.Hour}, created.Add(time.Hour), false},
+		{"past lifetime", ClientPolicy{Lifetime: 2 * time.Hour}, created.Add(2 * time.Hour), true},
+		{"active", ClientPolicy{IdleTimeout: 30 * time.Minute}, created.Add(80 * time.Minute), false},
+		{"idle", ClientPolicy{IdleTimeout: 30 * time.Minute}, created.Add(90 * time.Minute), true},
+		{"active past lifetime", ClientPolicy{Lifetime: time.Hour, IdleTimeout: 30 * time.Minute}, created.Add(61 * time.Minute), true},
+	}
+	for _, test := range tests {
+		if client.Expired(test.policy, test.now) != test.expired {
+			t.Error("Expected [", test.name, "] token expiry to be ", test.expired)
+		}
+	}
+}
+
+func TestLookupClient(t *testing.T) {
+	server := newTestServer()
+	server.ClientPolicy = ClientPolicy{IdleTimeout: time.Minute}
+	client, _ := NewClientToken(server.Logger)
+	server.AddClient(client)
+
+	now := client.LastUsed.Add(30 * time.Second)
+	if _, err := server.lookupClient(client.Token, now); err != nil {
+		t.Fatal("Expected an active client to be found")
+	}
+	if client.LastUsed.Equal(now) {
+		t.Error("Expected looking up a client not to mark it as used before its request is verified")
+	}
+	if !server.countRequest(client, 1, now) || !client.LastUsed.Equal(now) {
+		t.Error("Expected counting a verified request to mark the client as used")
+	}
+	if _, err := server.lookupClient(client.Token, now.Add(time.Minute)); err != ErrTokenExpired {
+		t.Error("Expected an idle client to be rejected as expired but got [", err, "]")
+	}
+	if len(server.ListClients()) != 0 {
+		t.Error("Expected an idle client to be removed when it's looked up")
+	}
+}
+
+func TestReapClients(t *testing.T) {
+	server := newTestServer()
+	server.ClientPolicy = ClientPolicy{Lifetime: time.Hour}
+	old, _ := NewClientToken(server.Logger)
+	old.Created = old.Created.Add(-2 * time.Hour)
+	current, _ := NewClientToken(server.Logger)
+	server.AddClient(old)
+	server.AddClient(current)
+
+	if count := server.reapClients(time.Now()); count != 1 {
+		t.Error("Expected [1] expired client to be removed but got [", count, "]")
+	}
+	clients := server.ListClients()
+	if len(clients) != 1 || clients[0].ID != current.ID {
+		t.Error("Expected only the current client to remain")
+	}
+}
+
+func TestRevokeClient(t *testing.T) {
+	server := newTestServer()
+	first, _ := NewClientToken(server.Logger)
+	second, _ := NewClientToken(server.Logger)
+	server.AddClient(first)
+	server.AddClient(second)
+
+	if server.RevokeClient("unknown") {
+		t.Error("Expected revoking an unknown client to fail")
+	}
+	if !server.RevokeClient(first.ID) {
+		t.Fatal("Expected client to be revoked")
+	}
+	if _, err := server.lookupClient(first.Token, time.Now()); err != errUnknownToken {
+		t.Error("Expected a revoked client's token to be rejected")
+	}
+	if count := server.RevokeClients(); count != 1 {
+		t.Error("Expected [1] remaining client to be revoked but got [", count, "]")
+	}
+	if _, err := server.lookupClient(second.Token, time.Now()); err != errUnknownToken {
+		t.Error("Expected every client's token to be rejected after revoking all clients")
+	}
+}
+
+func TestConcurrentCounters(t *testing.T) {
+	server := newTestServer()
+	client, _ := NewClientToken(server.Logger)
+	server.AddClient(client)
+
+	// every request carries the same sequence, as a replay racing the original would
+	var wg sync.WaitGroup
+	var counted int32
+	requests := 50
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server.lookupClient(client.Token, time.Now())
+			if server.checkSequence(client, 1) && server.countRequest(client, 1, time.Now()) {
+				atomic.AddInt32(&counted, 1)
+			}
+			server.countResponse(client)
+			server.ListClients()
+		}()
+	}
+	wg.Wait()
+	if counted != 1 || client.RecvCounter != 1 {
+		t.Error("Expected exactly one request to be counted but got [", counted, "] with counter [", client.RecvCounter, "]")
+	}
+	if client.SendCounter != int32(requests) {
+		t.Error("Expected [", requests, "] responses to be counted but got [", client.SendCounter, "]")
+	}
+}
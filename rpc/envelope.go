@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// ProtocolVersion is the version of the signed request/response protocol
+// Version 1 signed only the message body.  Version 2 signs an envelope
+// covering the method, sequence & client token along with the body.
+const ProtocolVersion = 2
+
+// Envelope directions
+// The direction is part of the signed bytes, so a signature over a request
+// can never be passed off as a signature over a response.
+const (
+	EnvelopeRequest  = "request"
+	EnvelopeResponse = "response"
+)
+
+// Envelope is everything covered by a request or response signature
+type Envelope struct {
+	Direction string // Direction is either request or response
+	Method    string // Method is the full method name
+	Sequence  int32  // Sequence is the sender's message counter
+	Token     string // Token is the client token (empty for key exchange requests)
+	Body      []byte // Body is the marshaled message
+}
+
+// Bytes encodes the envelope into the canonical form that is signed
+// Every field is length-prefixed so that no two envelopes encode the same way.
+func (e *Envelope) Bytes() []byte {
+	var buf bytes.Buffer
+	writeField := func(field []byte) {
+		binary.Write(&buf, binary.BigEndian, uint32(len(field)))
+		buf.Write(field)
+	}
+	writeField([]byte("NoteKeeper-RPC"))
+	binary.Write(&buf, binary.BigEndian, uint32(ProtocolVersion))
+	writeField([]byte(e.Direction))
+	writeField([]byte(e.Method))
+	binary.Write(&buf, binary.BigEndian, e.Sequence)
+	writeField([]byte(e.Token))
+	writeField(e.Body)
+	return buf.Bytes()
+}
+
+// requestEnvelope builds the envelope of a request from its verified headers
+func requestEnvelope(body []byte, context *RequestContext) *Envelope {
+	return &Envelope{
+		Direction: EnvelopeRequest,
+		Method:    context.Header.Method,
+		Sequence:  context.Header.Sequence,
+		Token:     context.Header.Token,
+		Body:      body,
+	}
+}
+
+// responseEnvelope builds the envelope of a response to a request
+func responseEnvelope(body []byte, sequence int32, context *RequestContext) *Envelope {
+	return &Envelope{
+		Direction: EnvelopeResponse,
+		Method:    context.Header.Method,
+		Sequence:  sequence,
+		Token:     context.Token.Token,
+		Body:      body,
+	}
+}
+
+// verifyVersion checks that a client speaks the current protocol version
+// Clients from before versioning don't send a version at all.
+func (rpc *Server) verifyVersion(get func(string) string) error {
+	version := get(HeaderProtocolVersion)
+	if version == strconv.Itoa(ProtocolVersion) {
+		return nil
+	}
+	if version == "" {
+		version = "1"
+	}
+	rpc.Logger.Warn("Unsupported protocol version [", version, "]")
+	return fmt.Errorf("unsupported protocol version [%s], expected [%d]", version, ProtocolVersion)
+}
@@ -0,0 +1,253 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestEnvelopeBytes(t *testing.T) {
+	envelope := Envelope{Direction: EnvelopeRequest, Method: "Ping", Sequence: 1, Token: "token", Body: []byte("body")}
+	encoded := envelope.Bytes()
+
+	modify := func(f func(e *Envelope)) Envelope {
+		e := envelope
+		f(&e)
+		return e
+	}
+	tests := []struct {
+		name     string
+		envelope Envelope
+	}{
+		{"direction", modify(func(e *Envelope) { e.Direction = EnvelopeResponse })},
+		{"method", modify(func(e *Envelope) { e.Method = "Fail" })},
+		{"sequence", modify(func(e *Envelope) { e.Sequence = 2 })},
+		{"token", modify(func(e *Envelope) { e.Token = "other" })},
+		{"body", modify(func(e *Envelope) { e.Body = []byte("other") })},
+		{"shifted field boundary", modify(func(e *Envelope) { e.Method = "Pingtoken"; e.Token = "" })},
+	}
+	for _, test := range tests {
+		if bytes.Equal(encoded, test.envelope.Bytes()) {
+			t.Error("Expected a different [", test.name, "] to change the signed envelope")
+		}
+	}
+	if !bytes.Equal(encoded, envelope.Bytes()) {
+		t.Error("Expected envelope encoding to be stable")
+	}
+}
+
+// httpRequest is a signed POST /rpc request
+type httpRequest struct {
+	method    string
+	version   string
+	sequence  int32
+	signature string
+	body      []byte
+}
+
+func (client *testClient) request(method string, body []byte) *httpRequest {
+	sequence, signature := client.sign(method, body)
+	return &httpRequest{
+		method:    method,
+		version:   strconv.Itoa(ProtocolVersion),
+		sequence:  sequence,
+		signature: signature,
+		body:      body,
+	}
+}
+
+func (client *testClient) post(server *Server, request *httpRequest) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/rpc", bytes.NewReader(request.body))
+	req.Header.Set(HeaderRequestMethod, request.method)
+	req.Header.Set(HeaderClientToken, client.token.Token)
+	req.Header.Set(HeaderMessageSignature, request.signature)
+	req.Header.Set(HeaderMessageSequence, strconv.FormatInt(int64(request.sequence), 10))
+	if request.version != "" {
+		req.Header.Set(HeaderProtocolVersion, request.version)
+	}
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestReplay(t *testing.T) {
+	server := newTestServer()
+	server.UserState = UserStateSignedIn
+	scoped := idMethod("Thing::load", echo)
+	scoped.Scoped = true
+	server.Register(idMethod("Ping", echo), scoped)
+	client := newTestClient(t, server)
+	body, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+
+	answered := func(name string, request *httpRequest) bool {
+		resp := client.post(server, request)
+		if resp.Body.Len() == 0 {
+			return false
+		}
+		responseData, err := base64.StdEncoding.DecodeString(resp.Body.String())
+		if err != nil {
+			t.Fatal("Error decoding [", name, "] response - ", err)
+		}
+		if !client.verify(request.method, responseData, resp.Header().Get(HeaderMessageSequence), resp.Header().Get(HeaderMessageSignature)) {
+			t.Error("Expected [", name, "] response signature to verify")
+		}
+		return true
+	}
+
+	captured := client.request("Ping", body)
+	if !answered("valid", captured) {
+		t.Fatal("Expected a signed request to be answered")
+	}
+
+	// each case is made against the sequence the server expects next, so
+	// only the part of the envelope being tested is wrong
+	tests := []struct {
+		name    string
+		request func() *httpRequest
+	}{
+		{"replayed", func() *httpRequest {
+			client.sequence = server.Clients[client.token.Token].RecvCounter
+			return captured
+		}},
+		{"replayed with the next sequence", func() *httpRequest {
+			client.sequence = server.Clients[client.token.Token].RecvCounter
+			replay := *captured
+			replay.sequence = client.sequence + 1
+			return &replay
+		}},
+		{"replayed against another method", func() *httpRequest {
+			client.sequence = server.Clients[client.token.Token].RecvCounter
+			replay := *client.request("Ping", body)
+			replay.method = "User::Thing::load"
+			return &replay
+		}},
+		{"signed for another token", func() *httpRequest {
+			client.sequence = server.Clients[client.token.Token].RecvCounter
+			token := client.token.Token
+			client.token.Token = "other"
+			request := client.request("Ping", body)
+			client.token.Token = token
+			return request
+		}},
+	}
+	for _, test := range tests {
+		if answered(test.name, test.request()) {
+			t.Error("Expected [", test.name, "] request to be rejected")
+		}
+	}
+
+	client.sequence = server.Clients[client.token.Token].RecvCounter
+	if !answered("after rejected requests", client.request("User::Thing::load", body)) {
+		t.Error("Expected a signed request to be answered after rejected requests")
+	}
+}
+
+func TestForgedSequence(t *testing.T) {
+	server := newTestServer()
+	server.Register(idMethod("Ping", echo))
+	client := newTestClient(t, server)
+	body, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+
+	// a forged request carries the next sequence but can't sign it
+	forged := client.request("Ping", body)
+	forged.body, _ = proto.Marshal(&messages.IdRequest{Id: "forged"})
+	before := server.Clients[client.token.Token].RecvCounter
+	lastUsed := server.Clients[client.token.Token].LastUsed
+	if resp := client.post(server, forged); resp.Body.Len() != 0 {
+		t.Error("Expected a badly signed request to be rejected")
+	}
+	if !server.Clients[client.token.Token].LastUsed.Equal(lastUsed) {
+		t.Error("Expected a badly signed request not to keep the token from going idle")
+	}
+	if after := server.Clients[client.token.Token].RecvCounter; after != before {
+		t.Error("Expected a badly signed request to leave the sequence at [", before, "] but got [", after, "]")
+	}
+
+	// so the real client's request with the same sequence still gets through
+	client.sequence = before
+	if resp := client.post(server, client.request("Ping", body)); resp.Body.Len() == 0 {
+		t.Error("Expected the client's request to be answered after a forged request")
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	server := newTestServer()
+	server.ClientPolicy = ClientPolicy{IdleTimeout: time.Minute}
+	server.Register(idMethod("Ping", echo))
+	client := newTestClient(t, server)
+	body, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+
+	server.Clients[client.token.Token].LastUsed = time.Now().Add(-2 * time.Minute)
+	resp := client.post(server, client.request("Ping", body))
+	if resp.Code != http.StatusUnauthorized || resp.Header().Get(HeaderError) != ErrorTokenExpired {
+		t.Error("Expected an idle token to be refused as expired but got status ", resp.Code, " with error [", resp.Header().Get(HeaderError), "]")
+	}
+
+	// a token that's gone altogether is still refused without saying why
+	resp = client.post(server, client.request("Ping", body))
+	if resp.Code != http.StatusOK || resp.Body.Len() != 0 || resp.Header().Get(HeaderError) != "" {
+		t.Error("Expected an unknown token to get an empty response but got status ", resp.Code)
+	}
+}
+
+func TestProtocolVersion(t *testing.T) {
+	server := newTestServer()
+	server.Register(idMethod("Ping", echo))
+	client := newTestClient(t, server)
+	body, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+
+	for _, version := range []string{"", "1", "3"} {
+		// rejected requests don't move the sequence on
+		client.sequence = server.Clients[client.token.Token].RecvCounter
+		request := client.request("Ping", body)
+		request.version = version
+		resp := client.post(server, request)
+		if resp.Code != http.StatusUpgradeRequired {
+			t.Error("Expected protocol version [", version, "] to be rejected but got status ", resp.Code)
+		}
+		if resp.Header().Get(HeaderProtocolVersion) != strconv.Itoa(ProtocolVersion) {
+			t.Error("Expected the rejection to name the supported protocol version")
+		}
+	}
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	server := newTestServer()
+	server.Register(idMethod("Ping", echo))
+	body, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+
+	// each client's requests are sequenced, but clients race each other
+	// while new clients register & the client list is read
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		client := newTestClient(t, server)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				resp := client.post(server, client.request("Ping", body))
+				if resp.Body.Len() == 0 {
+					t.Error("Expected request [", i, "] to be answered")
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				server.ListClients()
+				newTestClient(t, server)
+			}
+		}()
+	}
+	wg.Wait()
+}
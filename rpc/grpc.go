@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCServiceName is the name of the gRPC service declared in proto/service.proto
+const GRPCServiceName = "notekeeper.NoteKeeper"
+
+// GRPCName converts a method name into the name of its gRPC rpc
+// The "::" separators are removed & each part is capitalized, so
+// "User::Shelf::create" becomes "UserShelfCreate".
+func GRPCName(name string) string {
+	parts := strings.Split(name, "::")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// rawCodec passes request & response bodies through unchanged
+// Signatures are made over the exact bytes that were sent, so requests are
+// decoded by the dispatcher rather than by gRPC.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	if b, ok := v.([]byte); ok {
+		return b, nil
+	}
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	if b, ok := v.(*[]byte); ok {
+		*b = append([]byte(nil), data...)
+		return nil
+	}
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+
+func (rawCodec) String() string {
+	return "proto"
+}
+
+// NewGRPCServer creates a gRPC server for all registered methods
+func (rpc *Server) NewGRPCServer(options ...grpc.ServerOption) *grpc.Server {
+	options = append(options, grpc.CustomCodec(rawCodec{}))
+	server := grpc.NewServer(options...)
+	server.RegisterService(rpc.grpcServiceDesc(), rpc)
+	return server
+}
+
+// grpcServiceDesc describes the registered methods as a gRPC service
+func (rpc *Server) grpcServiceDesc() *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{
+		ServiceName: GRPCServiceName,
+		HandlerType: (*interface{})(nil),
+		Metadata:    "service.proto",
+	}
+	for _, name := range rpc.MethodNames() {
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: GRPCName(name),
+			Handler:    rpc.grpcHandler(name),
+		})
+	}
+	return desc
+}
+
+// grpcHandler serves a gRPC request for a registered method
+// The client token, signature & sequence are taken from the request metadata
+// and checked the same way as the headers of a POST /rpc request.  The
+// response signature & sequence are returned in the response metadata.
+func (rpc *Server) grpcHandler(name string) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		var body []byte
+		err := dec(&body)
+		if err != nil {
+			rpc.Logger.Warn("Error reading [", name, "] request - ", err)
+			return nil, status.Error(grpccodes.InvalidArgument, "invalid request body")
+		}
+		handler := func(ctx context.Context, request interface{}) (interface{}, error) {
+			return rpc.serveGRPC(ctx, name, request.([]byte))
+		}
+		if interceptor == nil {
+			return handler(ctx, body)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/" + GRPCServiceName + "/" + GRPCName(name),
+		}
+		return interceptor(ctx, body, info, handler)
+	}
+}
+
+func (rpc *Server) serveGRPC(ctx context.Context, name string, body []byte) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	get := func(key string) string {
+		values := md.Get(key)
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+
+	err := rpc.verifyVersion(get)
+	if err != nil {
+		return nil, status.Error(grpccodes.FailedPrecondition, err.Error())
+	}
+
+	requestContext := &RequestContext{}
+	if !rpc.verifyHeaders(name, get, requestContext) {
+		if requestContext.TokenExpired {
+			return nil, status.Error(grpccodes.Unauthenticated, ErrorTokenExpired)
+		}
+		return nil, status.Error(grpccodes.Unauthenticated, "invalid request metadata")
+	}
+
+	route := rpc.findMethod(name)
+	if route == nil {
+		rpc.Logger.Warn("Could not find handler for method - ", name)
+		return nil, status.Error(grpccodes.Unimplemented, "unknown method")
+	}
+
+	responseData, ok := rpc.handle(route, body, requestContext)
+	if !ok {
+		return nil, status.Error(grpccodes.Unauthenticated, "message verification failed")
+	}
+
+	responseSignature, sequence := rpc.signResponse(responseData, requestContext)
+	header := metadata.Pairs(
+		HeaderMessageSignature, responseSignature,
+		HeaderMessageSequence, strconv.FormatInt(int64(sequence), 10),
+		HeaderProtocolVersion, strconv.Itoa(ProtocolVersion),
+	)
+	err = grpc.SetHeader(ctx, header)
+	if err != nil {
+		rpc.Logger.Warn("Error setting response metadata - ", err)
+	}
+	return responseData, nil
+}
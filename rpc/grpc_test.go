@@ -0,0 +1,205 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"testing"
+
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/agl/ed25519"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testClient signs requests for a client token registered with a test server
+type testClient struct {
+	token      *ClientToken
+	privateKey *[ed25519.PrivateKeySize]byte
+	sequence   int32
+}
+
+func newTestClient(t *testing.T, server *Server) *testClient {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal("Error generating client keys - ", err)
+	}
+	token, err := NewClientToken(server.Logger)
+	if err != nil {
+		t.Fatal("Error creating client token - ", err)
+	}
+	token.VerifyPublicKey = publicKey
+	server.AddClient(token)
+	return &testClient{token: token, privateKey: privateKey}
+}
+
+// sign counts a request & signs its envelope, returning the sequence & base64 signature to send with it
+func (client *testClient) sign(method string, body []byte) (int32, string) {
+	client.sequence++
+	envelope := &Envelope{
+		Direction: EnvelopeRequest,
+		Method:    method,
+		Sequence:  client.sequence,
+		Token:     client.token.Token,
+		Body:      body,
+	}
+	signature := ed25519.Sign(client.privateKey, envelope.Bytes())
+	return client.sequence, base64.StdEncoding.EncodeToString(signature[:])
+}
+
+// verify checks the signature of a response from the server
+func (client *testClient) verify(method string, body []byte, sequence string, signature string) bool {
+	parsedSeq, err := strconv.ParseInt(sequence, 10, 32)
+	if err != nil {
+		return false
+	}
+	envelope := &Envelope{
+		Direction: EnvelopeResponse,
+		Method:    method,
+		Sequence:  int32(parsedSeq),
+		Token:     client.token.Token,
+		Body:      body,
+	}
+	var sig [ed25519.SignatureSize]byte
+	decoded, _ := base64.StdEncoding.DecodeString(signature)
+	copy(sig[:], decoded)
+	return ed25519.Verify(client.token.SignPublicKey, envelope.Bytes(), &sig)
+}
+
+// metadata signs a request body & returns the metadata to send with it
+func (client *testClient) metadata(method string, body []byte) metadata.MD {
+	sequence, signature := client.sign(method, body)
+	return metadata.Pairs(
+		HeaderProtocolVersion, strconv.Itoa(ProtocolVersion),
+		HeaderClientToken, client.token.Token,
+		HeaderMessageSignature, signature,
+		HeaderMessageSequence, strconv.FormatInt(int64(sequence), 10),
+	)
+}
+
+func dialTestServer(t *testing.T, server *Server) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := server.NewGRPCServer()
+	go grpcServer.Serve(listener)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.Dial()
+	}
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal("Error connecting to gRPC server - ", err)
+	}
+	return conn
+}
+
+func TestGRPCName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"KeyExchange", "KeyExchange"},
+		{"MasterDb::open", "MasterDbOpen"},
+		{"UIState::save", "UIStateSave"},
+		{"User::shelves", "UserShelves"},
+		{"Account::Shelf::create", "AccountShelfCreate"},
+	}
+	for _, test := range tests {
+		if name := GRPCName(test.name); name != test.expected {
+			t.Error("Expected [", test.name, "] to be served as [", test.expected, "] but got [", name, "]")
+		}
+	}
+}
+
+func TestGRPC(t *testing.T) {
+	server := newTestServer()
+	server.UserState = UserStateSignedIn
+	scoped := idMethod("Thing::load", echo)
+	scoped.Scoped = true
+	server.Register(idMethod("Ping", echo), scoped)
+
+	conn := dialTestServer(t, server)
+	defer conn.Close()
+	client := newTestClient(t, server)
+
+	body, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+	invoke := func(method string, md metadata.MD) (*messages.IdResponse, metadata.MD, error) {
+		ctx := metadata.NewOutgoingContext(context.Background(), md)
+		var responseData []byte
+		var header metadata.MD
+		err := conn.Invoke(ctx, "/"+GRPCServiceName+"/"+GRPCName(method), body, &responseData, grpc.CallCustomCodec(rawCodec{}), grpc.Header(&header))
+		if err != nil {
+			return nil, header, err
+		}
+		if !client.verify(method, responseData, header.Get(HeaderMessageSequence)[0], header.Get(HeaderMessageSignature)[0]) {
+			t.Error("Expected [", method, "] response signature to verify")
+		}
+		response := &messages.IdResponse{}
+		err = proto.Unmarshal(responseData, response)
+		return response, header, err
+	}
+
+	response, header, err := invoke("User::Thing::load", client.metadata("User::Thing::load", body))
+	if err != nil {
+		t.Fatal("Expected signed request to succeed - ", err)
+	}
+	if response.Id != "user:abc" {
+		t.Error("Expected scoped method to be dispatched but got [", response.Id, "]")
+	}
+	if sequence := header.Get(HeaderMessageSequence); len(sequence) != 1 || sequence[0] != "1" {
+		t.Error("Expected response sequence [1] but got ", sequence)
+	}
+
+	response, _, err = invoke("Ping", client.metadata("Ping", body))
+	if err != nil || response.Id != ":abc" {
+		t.Error("Expected unscoped method to be dispatched - ", err)
+	}
+
+	md := client.metadata("Ping", body)
+	_, _, err = invoke("Ping", md)
+	if err != nil {
+		t.Fatal("Expected signed request to succeed - ", err)
+	}
+
+	// the server counts every request with a valid token, so later cases pick up its counter
+	tests := []struct {
+		name string
+		md   func() metadata.MD
+	}{
+		{"replayed", func() metadata.MD { return md }},
+		{"bad signature", func() metadata.MD {
+			client.sequence = client.token.RecvCounter
+			unsigned := client.metadata("Ping", body)
+			unsigned.Set(HeaderMessageSignature, base64.StdEncoding.EncodeToString(make([]byte, ed25519.SignatureSize)))
+			return unsigned
+		}},
+		{"unknown token", func() metadata.MD {
+			unknown := client.metadata("Ping", body)
+			unknown.Set(HeaderClientToken, "unknown")
+			return unknown
+		}},
+		{"signed for another method", func() metadata.MD {
+			client.sequence = client.token.RecvCounter
+			return client.metadata("User::Thing::load", body)
+		}},
+		{"no metadata", func() metadata.MD {
+			return metadata.Pairs(HeaderProtocolVersion, strconv.Itoa(ProtocolVersion))
+		}},
+	}
+	for _, test := range tests {
+		_, _, err = invoke("Ping", test.md())
+		if status.Code(err) != grpccodes.Unauthenticated {
+			t.Error("Expected [", test.name, "] request to be rejected but got - ", err)
+		}
+	}
+
+	_, _, err = invoke("Ping", metadata.MD{})
+	if status.Code(err) != grpccodes.FailedPrecondition {
+		t.Error("Expected a request without a protocol version to be rejected but got - ", err)
+	}
+}
@@ -1,6 +0,0 @@
-package rpc
-
-// RegisterHandlers registers all of the RPC handlers
-func (rpc *Server) RegisterHandlers(handlers map[string]Handler) {
-	rpc.Handlers = handlers
-}
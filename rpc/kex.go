@@ -1,32 +1,77 @@
 package rpc
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 
 	"github.com/agl/ed25519"
 )
 
+// BootstrapSecretSize is the length of the secret handed to the frontend at spawn
+const BootstrapSecretSize = 32
+
+// createBootstrapSecret creates the secret a client must hold to complete a key exchange
+// The secret is only ever written to stdout, which is read by the process that spawned us.
+func (rpc *Server) createBootstrapSecret() bool {
+	rpc.BootstrapSecret = make([]byte, BootstrapSecretSize)
+	_, err := rand.Read(rpc.BootstrapSecret)
+	if err != nil {
+		rpc.Logger.Warn("Error creating bootstrap secret - ", err)
+		return false
+	}
+	return true
+}
+
+// BootstrapProof creates the proof that a client holds the bootstrap secret
+func BootstrapProof(secret []byte, publicKey []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(publicKey)
+	return mac.Sum(nil)
+}
+
+// VerifyBootstrapProof checks that a key exchange was made by a client holding the bootstrap secret
+// The proof is made over the client's verify key, binding that key to the
+// process we were spawned by.
+func (rpc *Server) VerifyBootstrapProof(publicKey []byte, proof []byte) bool {
+	if len(rpc.BootstrapSecret) == 0 {
+		rpc.Logger.Warn("Key exchange attempted without a bootstrap secret")
+		return false
+	}
+	if !hmac.Equal(proof, BootstrapProof(rpc.BootstrapSecret, publicKey)) {
+		rpc.Logger.Warn("Key exchange bootstrap proof could not be verified")
+		return false
+	}
+	return true
+}
+
 // CreateSignature creates a signature for a response body
-func (rpc *Server) CreateSignature(response []byte, context *RequestContext) string {
-	signature := ed25519.Sign(context.Token.SignPrivateKey, response)
+// The response sequence is signed along with the body, so it has to be
+// counted before the response is signed.
+func (rpc *Server) CreateSignature(response []byte, sequence int32, context *RequestContext) string {
+	envelope := responseEnvelope(response, sequence, context)
+	signature := ed25519.Sign(context.Token.SignPrivateKey, envelope.Bytes())
 
 	sig := base64.StdEncoding.EncodeToString(signature[:])
 	return sig
+}
 
-	/*
-		rpc.SendCounter++
-		response.Sequence = rpc.SendCounter
-		return true
-	*/
+// signResponse counts a response & signs it, returning the signature & sequence to send with it
+func (rpc *Server) signResponse(response []byte, context *RequestContext) (string, int32) {
+	sequence := rpc.countResponse(context.Token)
+	return rpc.CreateSignature(response, sequence, context), sequence
 }
 
 // VerifyRequest uses the client's public key to verify the message signature
+// The signature covers the request's method, sequence & token as well as the body.
 func (rpc *Server) VerifyRequest(message []byte, sig []byte, context *RequestContext) bool {
 	var signature [ed25519.SignatureSize]byte
 	copy(signature[:], sig)
-	ok := ed25519.Verify(context.Token.VerifyPublicKey, message, &signature)
+	envelope := requestEnvelope(message, context)
+	ok := ed25519.Verify(context.Token.VerifyPublicKey, envelope.Bytes(), &signature)
 	if !ok {
-		rpc.Logger.Warn("Request payload signature could not be verified. key [", context.Token.VerifyPublicKey[:], "] message [", message, "] signature")
+		rpc.Logger.Warn("Request signature could not be verified for method [", context.Header.Method, "] sequence [", context.Header.Sequence, "]")
 		return false
 	}
 	return true
@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+
+	"github.com/agl/ed25519"
+)
+
+func TestVerifyBootstrapProof(t *testing.T) {
+	server := newTestServer()
+	if !server.createBootstrapSecret() {
+		t.Fatal("Expected bootstrap secret to be created")
+	}
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	otherSecret := make([]byte, BootstrapSecretSize)
+
+	tests := []struct {
+		name     string
+		key      []byte
+		proof    []byte
+		verified bool
+	}{
+		{"valid", publicKey[:], BootstrapProof(server.BootstrapSecret, publicKey[:]), true},
+		{"other key", otherKey[:], BootstrapProof(server.BootstrapSecret, publicKey[:]), false},
+		{"other secret", publicKey[:], BootstrapProof(otherSecret, publicKey[:]), false},
+		{"no proof", publicKey[:], nil, false},
+	}
+	for _, test := range tests {
+		if server.VerifyBootstrapProof(test.key, test.proof) != test.verified {
+			t.Error("Expected [", test.name, "] proof verification to be ", test.verified)
+		}
+	}
+
+	server.BootstrapSecret = nil
+	if server.VerifyBootstrapProof(publicKey[:], BootstrapProof(nil, publicKey[:])) {
+		t.Error("Expected key exchange to be rejected without a bootstrap secret")
+	}
+}
+
+func TestRejectedKeyExchange(t *testing.T) {
+	server := newTestServer()
+	server.Register(idMethod("KeyExchange", func(server *Server, call *Call) error {
+		return NewError(codes.ErrorUnauthorized)
+	}))
+
+	context := &RequestContext{}
+	get := func(key string) string {
+		if key == HeaderMessageSequence {
+			return "1"
+		}
+		return base64.StdEncoding.EncodeToString([]byte("signature"))
+	}
+	if !server.verifyHeaders("KeyExchange", get, context) {
+		t.Fatal("Expected key exchange headers to be verified without a client token")
+	}
+	_, ok := server.handle(server.findMethod("KeyExchange"), nil, context)
+	if ok {
+		t.Error("Expected a rejected key exchange not to be answered")
+	}
+	if len(server.Clients) != 0 {
+		t.Error("Expected a rejected key exchange not to register a client")
+	}
+}
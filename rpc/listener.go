@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Listener networks
+const (
+	NetworkTCP  = "tcp"
+	NetworkUnix = "unix"
+)
+
+// Listener names the network & address the server listens on
+// TCP listeners are served over TLS.  Unix socket listeners are created with
+// 0600 permissions, so only the user running the backend can connect to them,
+// and are served without TLS.
+type Listener struct {
+	Network string // Network is either tcp or unix
+	Address string // Address is a host:port for tcp or a socket path for unix
+}
+
+func (l Listener) String() string {
+	return l.Network + ":" + l.Address
+}
+
+// listen creates a listener, returning false if it can't be created
+func (rpc *Server) listen(listener Listener) (net.Listener, bool) {
+	switch listener.Network {
+	case NetworkTCP:
+		conn, err := net.Listen("tcp", listener.Address)
+		if err != nil {
+			rpc.Logger.Warn("Listen error - ", err)
+			return nil, false
+		}
+		return conn, true
+	case NetworkUnix:
+		return rpc.listenUnix(listener.Address)
+	}
+	rpc.Logger.Warn("Unknown listener network [", listener.Network, "]")
+	return nil, false
+}
+
+// listenUnix creates a unix socket that only the current user can connect to
+// A socket left behind by a backend that didn't shut down cleanly is replaced,
+// but a socket another backend is still serving on is not.
+func (rpc *Server) listenUnix(path string) (net.Listener, bool) {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		rpc.Logger.Warn("Error creating socket directory - ", err)
+		return nil, false
+	}
+
+	info, err := os.Lstat(path)
+	if err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			rpc.Logger.Warn("Socket path [", path, "] exists and is not a socket")
+			return nil, false
+		}
+		conn, err := net.DialTimeout("unix", path, time.Second)
+		if err == nil {
+			conn.Close()
+			rpc.Logger.Warn("Socket [", path, "] is in use by another backend")
+			return nil, false
+		}
+		err = os.Remove(path)
+		if err != nil {
+			rpc.Logger.Warn("Error removing stale socket - ", err)
+			return nil, false
+		}
+	}
+
+	conn, err := net.Listen("unix", path)
+	if err != nil {
+		rpc.Logger.Warn("Listen error - ", err)
+		return nil, false
+	}
+	err = os.Chmod(path, 0600)
+	if err != nil {
+		rpc.Logger.Warn("Error setting socket permissions - ", err)
+		conn.Close()
+		return nil, false
+	}
+
+	if !rpc.CheckPeerCredentials {
+		return conn, true
+	}
+	if !PeerCredentialsSupported {
+		rpc.Logger.Warn("Peer credential checks are not supported on this platform")
+		conn.Close()
+		return nil, false
+	}
+	return &peerListener{Listener: conn, uid: os.Getuid(), logger: rpc.Logger}, true
+}
+
+// peerListener only accepts unix socket connections from processes run by uid
+type peerListener struct {
+	net.Listener
+	uid    int
+	logger *logrus.Logger
+}
+
+// Accept waits for the next connection from an allowed peer
+// Connections from other users are closed before any data is read.
+func (l *peerListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		err = l.checkPeer(conn)
+		if err == nil {
+			return conn, nil
+		}
+		l.logger.Warn("Rejected socket connection - ", err)
+		conn.Close()
+	}
+}
+
+func (l *peerListener) checkPeer(conn net.Conn) error {
+	uid, err := peerUID(conn)
+	if err != nil {
+		return err
+	}
+	if uid != l.uid {
+		return fmt.Errorf("peer uid [%d] is not [%d]", uid, l.uid)
+	}
+	return nil
+}
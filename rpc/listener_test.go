@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func unixClient(path string) *http.Client {
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+}
+
+func serviceReady(client *http.Client) (string, error) {
+	req, _ := http.NewRequest("POST", "http://notekeeper/rpc", strings.NewReader(""))
+	req.Header.Set(HeaderRequestMethod, "SERVICE-READY")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func TestUnixListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-socket")
+	if err != nil {
+		t.Fatal("Error creating socket directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "notekeeper.sock")
+
+	server := newTestServer()
+	server.CheckPeerCredentials = PeerCredentialsSupported
+	conn, ok := server.listen(Listener{Network: NetworkUnix, Address: path})
+	if !ok {
+		t.Fatal("Expected the socket to be created")
+	}
+	defer conn.Close()
+	go http.Serve(conn, server)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal("Expected the socket to exist - ", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Error("Expected socket permissions 0600 but got ", info.Mode().Perm())
+	}
+
+	body, err := serviceReady(unixClient(path))
+	if err != nil || body != "OK" {
+		t.Error("Expected SERVICE-READY to be answered over the socket - ", err)
+	}
+
+	if _, ok := server.listen(Listener{Network: NetworkUnix, Address: path}); ok {
+		t.Error("Expected a socket in use to be left alone")
+	}
+}
+
+func TestStaleUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-socket")
+	if err != nil {
+		t.Fatal("Error creating socket directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "notekeeper.sock")
+
+	// leave a socket file behind without anything serving on it
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		t.Fatal("Error creating socket - ", err)
+	}
+	stale.SetUnlinkOnClose(false)
+	stale.Close()
+
+	server := newTestServer()
+	conn, ok := server.listen(Listener{Network: NetworkUnix, Address: path})
+	if !ok {
+		t.Fatal("Expected a stale socket to be replaced")
+	}
+	conn.Close()
+
+	err = ioutil.WriteFile(path, []byte("not a socket"), 0600)
+	if err != nil {
+		t.Fatal("Error writing file - ", err)
+	}
+	if _, ok := server.listen(Listener{Network: NetworkUnix, Address: path}); ok {
+		t.Error("Expected a file that isn't a socket to be left alone")
+	}
+}
+
+func TestPeerCredentials(t *testing.T) {
+	if !PeerCredentialsSupported {
+		t.Skip("Peer credentials are not supported on this platform")
+	}
+	dir, err := ioutil.TempDir("", "notekeeper-socket")
+	if err != nil {
+		t.Fatal("Error creating socket directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "notekeeper.sock")
+
+	server := newTestServer()
+	server.CheckPeerCredentials = true
+	conn, ok := server.listen(Listener{Network: NetworkUnix, Address: path})
+	if !ok {
+		t.Fatal("Expected the socket to be created")
+	}
+	defer conn.Close()
+	// only another user is allowed, so our own connections are rejected
+	listener := conn.(*peerListener)
+	listener.uid = os.Getuid() + 1
+	go http.Serve(listener, server)
+
+	if _, err := serviceReady(unixClient(path)); err == nil {
+		t.Error("Expected a connection from another user to be rejected")
+	}
+}
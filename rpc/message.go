@@ -29,7 +29,11 @@ func TimeToMessage(t time.Time) string {
 }
 
 // MessageToTitle converts a protobuf title message to a native title
+// A request that leaves out the title gets an empty one.
 func MessageToTitle(msg *messages.Title) *title.Title {
+	if msg == nil {
+		return title.New("")
+	}
 	t := title.New(msg.Text)
 	t.Formatting.Bold = msg.Bold
 	t.Formatting.Italics = msg.Italics
@@ -46,6 +50,7 @@ func SetInternalError(header *messages.ResponseHeader, err error) {
 	header.Code = int32(code.Code)
 	header.Scope = int32(code.Scope)
 	header.Status = code.Error()
+	setErrorModel(header, code)
 }
 
 // SetRPCError sets an rpc-specific error in a response header
@@ -53,6 +58,22 @@ func SetRPCError(header *messages.ResponseHeader, c codes.Code) {
 	header.Code = int32(c)
 	header.Scope = int32(codes.ScopeRPC)
 	header.Status = codes.StatusSystemError
+	setErrorModel(header, codes.New(codes.ScopeRPC, c))
+}
+
+// setErrorModel sets the type, reason, details & retryable flag of an error in a response header
+func setErrorModel(header *messages.ResponseHeader, code *codes.InternalError) {
+	header.Type = int32(code.Type)
+	header.Reason = code.Code.Reason()
+	header.Retryable = code.Retryable
+	header.Details = nil
+	for _, detail := range code.Details {
+		header.Details = append(header.Details, &messages.FieldError{
+			Field:   detail.Field,
+			Reason:  detail.Reason,
+			Message: detail.Message,
+		})
+	}
 }
 
 // NewResponseHeader creates a new response header
@@ -60,6 +81,8 @@ func NewResponseHeader() *messages.ResponseHeader {
 	header := &messages.ResponseHeader{
 		Code:   int32(codes.ErrorOK),
 		Status: codes.StatusOK,
+		Type:   int32(codes.TypeOK),
+		Reason: codes.ErrorOK.Reason(),
 	}
 	return header
 }
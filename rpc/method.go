@@ -0,0 +1,284 @@
+package rpc
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/redact"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Auth is the state a client has to be in before a method can be called
+type Auth int
+
+const (
+	// AuthNone methods can be called at any time (e.g. before an account exists)
+	AuthNone Auth = iota
+	// AuthSignedIn methods can only be called while a user is signed in
+	AuthSignedIn
+)
+
+// Scope variants of a method
+// Scoped methods are registered once for each scope, with the method name
+// prefixed by the scope's category (e.g. "User::Shelf::create" & "Account::Shelf::create").
+const (
+	ScopeUser    = "user"
+	ScopeAccount = "account"
+)
+
+var scopePrefixes = map[string]string{
+	ScopeUser:    "User::",
+	ScopeAccount: "Account::",
+}
+
+// Store is the kind of container a note or notebook request acts on
+// Note requests name it in their store field & notebook requests in their
+// container field.
+type Store int
+
+const (
+	// StoreNone is for requests that don't act on a shelf or collection
+	StoreNone Store = iota
+	// StoreShelf requests act on a shelf
+	StoreShelf
+	// StoreCollection requests act on a collection
+	StoreCollection
+)
+
+var storeNames = map[string]Store{
+	"shelf":      StoreShelf,
+	"collection": StoreCollection,
+}
+
+// storeRequest is a request that names the store holding a note
+type storeRequest interface {
+	GetStore() string
+}
+
+// containerRequest is a request that names the container holding a notebook
+type containerRequest interface {
+	GetContainer() string
+}
+
+// Handler handles a single decoded request
+// The request & response messages are created by the dispatcher from the
+// method declaration, so a handler only needs to fill in the response. A
+// returned error is set in the response header.
+type Handler func(server *Server, call *Call) error
+
+// Method declares an RPC method
+type Method struct {
+	Name     string               // Name is the method name, without the scope prefix for scoped methods
+	Scoped   bool                 // Scoped methods are registered for both the user & account scope
+	Auth     Auth                 // Auth is the state required to call the method
+	Request  func() proto.Message // Request creates an empty request message
+	Response func() proto.Message // Response creates the response message (the header is added by the dispatcher)
+	Handler  Handler              // Handler handles the request
+	Resolver Resolver             // Resolver is set for methods acting on an owner or container (optional)
+	Validate Validator            // Validate checks the fields of the request before it's authorized (optional)
+}
+
+// Validator checks the fields of a decoded request
+// A returned error is set in the response header & the handler isn't called.
+type Validator func(request proto.Message) error
+
+// Call is a single invocation of a method
+type Call struct {
+	Method   string                   // Method is the full method name
+	Scope    string                   // Scope is user or account for scoped methods (empty otherwise)
+	Store    Store                    // Store is the shelf or collection named by the request (StoreNone otherwise)
+	Request  proto.Message            // Request is the decoded request message
+	Response proto.Message            // Response is the response message being built
+	Header   *messages.ResponseHeader // Header is the header of the response
+	Context  *RequestContext          // Context is the context of the request
+}
+
+// route is a registered method along with the scope it was registered for
+type route struct {
+	method *Method
+	scope  string
+}
+
+// Register adds method declarations to the server
+// Nothing is registered if any of the methods has a response message without
+// a header, as the dispatcher couldn't report errors for it.
+func (rpc *Server) Register(methods ...*Method) error {
+	for _, method := range methods {
+		err := checkResponse(method)
+		if err != nil {
+			return err
+		}
+	}
+	for _, method := range methods {
+		if !method.Scoped {
+			rpc.Methods[method.Name] = &route{method: method}
+			continue
+		}
+		for _, scope := range []string{ScopeUser, ScopeAccount} {
+			rpc.Methods[scopePrefixes[scope]+method.Name] = &route{method: method, scope: scope}
+		}
+	}
+	return nil
+}
+
+// checkResponse checks that a method's response message has a response header field
+func checkResponse(method *Method) error {
+	if method.Response == nil {
+		return nil
+	}
+	response := reflect.ValueOf(method.Response())
+	if response.Kind() != reflect.Ptr || response.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("response for method [%s] isn't a message struct", method.Name)
+	}
+	header := response.Elem().FieldByName("Header")
+	if !header.IsValid() || header.Type() != reflect.TypeOf(&messages.ResponseHeader{}) {
+		return fmt.Errorf("response for method [%s] has no response header", method.Name)
+	}
+	return nil
+}
+
+// MethodNames lists the full names of all registered methods in alphabetical order
+func (rpc *Server) MethodNames() []string {
+	var names []string
+	for name := range rpc.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewError creates an rpc-specific error for a handler to return
+func NewError(code codes.Code) error {
+	err := codes.New(codes.ScopeRPC, code)
+	return err
+}
+
+// NewInvalid creates an rpc-specific error for request fields that failed validation
+func NewInvalid(details ...codes.FieldError) error {
+	err := codes.Invalid(codes.ScopeRPC, details...)
+	return err
+}
+
+// SetError sets the error returned by a handler in a response header
+func SetError(header *messages.ResponseHeader, err error) {
+	code := codes.ToInternalError(err)
+	if code.Scope == codes.ScopeRPC {
+		SetRPCError(header, code.Code)
+		if code.Type == codes.TypeApplication {
+			header.Status = codes.StatusAppError
+		}
+		setErrorModel(header, code)
+		return
+	}
+	SetInternalError(header, err)
+}
+
+// newResponse creates the response message for a method along with its header
+// Every registered response message has a Header field (see checkResponse),
+// which is set here so that handlers & the dispatcher can always report errors.
+func newResponse(method *Method) (proto.Message, *messages.ResponseHeader) {
+	var response proto.Message = &messages.EmptyResponse{}
+	if method.Response != nil {
+		response = method.Response()
+	}
+	header := NewResponseHeader()
+	reflect.ValueOf(response).Elem().FieldByName("Header").Set(reflect.ValueOf(header))
+	return response, header
+}
+
+// dispatch decodes a request, checks that it can be made & passes it on to the method's handler
+// Requests are verified & decoded concurrently, but handlers run one at a
+// time: they share the active account, user state & open dbs, and even
+// listing can write refreshed stats back to a db.
+func (rpc *Server) dispatch(name string, r *route, message []byte, context *RequestContext) proto.Message {
+	call := &Call{
+		Method:  name,
+		Scope:   r.scope,
+		Context: context,
+	}
+	call.Response, call.Header = newResponse(r.method)
+	rpc.startRequest(name, context)
+	defer rpc.finishRequest(call, time.Now())
+
+	call.Request = &messages.EmptyRequest{}
+	if r.method.Request != nil {
+		call.Request = r.method.Request()
+	}
+	err := proto.Unmarshal(message, call.Request)
+	if err != nil {
+		context.Logger.Warn("Error unmarshaling [", name, "] request - ", err)
+		SetRPCError(call.Header, codes.ErrorDecode)
+		return call.Response
+	}
+	defer rpc.Redactor.Add(redact.Secrets(call.Request)...)()
+
+	rpc.dispatchLock.Lock()
+	defer rpc.dispatchLock.Unlock()
+
+	if rpc.isStopping() {
+		context.Logger.Warn("Rejected request [", name, "] while shutting down")
+		SetRPCError(call.Header, codes.ErrorShutdown)
+		return call.Response
+	}
+
+	if r.method.Auth == AuthSignedIn && !rpc.IsSignedIn() {
+		context.Logger.Warn("Unauthorized request for [", name, "] while signed out")
+		SetRPCError(call.Header, codes.ErrorUnauthorized)
+		return call.Response
+	}
+
+	if r.method.Validate != nil {
+		err = r.method.Validate(call.Request)
+		if err != nil {
+			context.Logger.Warn("Invalid request for [", name, "] - ", err)
+			SetError(call.Header, err)
+			return call.Response
+		}
+	}
+
+	call.Store, err = resolveStore(call.Request)
+	if err != nil {
+		context.Logger.Warn("Invalid request for [", name, "] - ", err)
+		SetError(call.Header, err)
+		return call.Response
+	}
+
+	if r.method.Resolver != nil {
+		err = rpc.Authorize(call)
+		if err != nil {
+			SetError(call.Header, err)
+			return call.Response
+		}
+	}
+
+	err = r.method.Handler(rpc, call)
+	if err != nil {
+		context.Logger.Info("Request [", name, "] failed - ", err)
+		SetError(call.Header, err)
+	}
+	return call.Response
+}
+
+// resolveStore picks out the shelf or collection named by a note or notebook request
+// Requests that don't name one resolve to StoreNone.
+func resolveStore(request proto.Message) (Store, error) {
+	var field, name string
+	switch r := request.(type) {
+	case storeRequest:
+		field, name = "store", r.GetStore()
+	case containerRequest:
+		field, name = "container", r.GetContainer()
+	default:
+		return StoreNone, nil
+	}
+	store, ok := storeNames[name]
+	if !ok {
+		return StoreNone, NewInvalid(codes.FieldError{Field: field, Reason: codes.FieldUnknown, Message: field + " isn't shelf or collection"})
+	}
+	return store, nil
+}
@@ -0,0 +1,205 @@
+package rpc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func idMethod(name string, handler Handler) *Method {
+	return &Method{
+		Name:     name,
+		Request:  func() proto.Message { return &messages.IdRequest{} },
+		Response: func() proto.Message { return &messages.IdResponse{} },
+		Handler:  handler,
+	}
+}
+
+func echo(server *Server, call *Call) error {
+	request := call.Request.(*messages.IdRequest)
+	response := call.Response.(*messages.IdResponse)
+	response.Id = call.Scope + ":" + request.Id
+	return nil
+}
+
+func call(t *testing.T, server *Server, name string, message []byte) *messages.IdResponse {
+	route := server.findMethod(name)
+	if route == nil {
+		t.Fatal("Expected method [", name, "] to be registered")
+	}
+	response, ok := server.dispatch(name, route, message, &RequestContext{}).(*messages.IdResponse)
+	if !ok {
+		t.Fatal("Expected [", name, "] to respond with its declared response type")
+	}
+	if response.Header == nil {
+		t.Fatal("Expected [", name, "] response to have a header")
+	}
+	return response
+}
+
+func TestRegister(t *testing.T) {
+	server := newTestServer()
+	scoped := idMethod("Thing::load", echo)
+	scoped.Scoped = true
+	server.Register(idMethod("Ping", echo), scoped)
+
+	names := server.MethodNames()
+	expected := []string{"Account::Thing::load", "Ping", "User::Thing::load"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Error("Expected methods ", expected, " but got ", names)
+	}
+	if server.findMethod("Thing::load") != nil {
+		t.Error("Expected scoped method to only be registered with a scope prefix")
+	}
+
+	headerless := idMethod("Note::load", echo)
+	headerless.Response = func() proto.Message { return &messages.Note{} }
+	if err := server.Register(idMethod("Pong", echo), headerless); err == nil {
+		t.Error("Expected a method whose response has no header to fail registration")
+	}
+	if server.findMethod("Pong") != nil || server.findMethod("Note::load") != nil {
+		t.Error("Expected nothing to be registered when registration fails")
+	}
+}
+
+func TestResolveStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		request proto.Message
+		store   Store
+		invalid bool
+	}{
+		{"note in a collection", &messages.GetNotesRequest{Store: "collection"}, StoreCollection, false},
+		{"notebook in a shelf", &messages.GetNotebooksRequest{Container: "shelf"}, StoreShelf, false},
+		{"no store", &messages.IdRequest{Id: "abc"}, StoreNone, false},
+		{"unknown store", &messages.LoadNoteRequest{Store: "drawer"}, StoreNone, true},
+		{"missing container", &messages.CreateNotebookRequest{}, StoreNone, true},
+	}
+	for _, test := range tests {
+		store, err := resolveStore(test.request)
+		if store != test.store || (err != nil) != test.invalid {
+			t.Error("Expected [", test.name, "] to resolve to [", test.store, "] but got [", store, "] - ", err)
+		}
+		if err != nil && codes.ToInternalError(err).Code != codes.ErrorInvalid {
+			t.Error("Expected [", test.name, "] to be an invalid request but got ", err)
+		}
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	server := newTestServer()
+	scoped := idMethod("Thing::load", echo)
+	scoped.Scoped = true
+	scoped.Auth = AuthSignedIn
+	failing := idMethod("Fail", func(server *Server, call *Call) error {
+		return errors.New("escaped")
+	})
+	rejecting := idMethod("Reject", func(server *Server, call *Call) error {
+		return NewError(codes.ErrorUnauthorized)
+	})
+	server.Register(idMethod("Ping", echo), scoped, failing, rejecting)
+
+	message, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+
+	tests := []struct {
+		name     string
+		method   string
+		message  []byte
+		signedIn bool
+		code     codes.Code
+		scope    codes.Scope
+		id       string
+	}{
+		{"unscoped", "Ping", message, false, codes.ErrorOK, codes.ScopeGeneral, ":abc"},
+		{"user scope", "User::Thing::load", message, true, codes.ErrorOK, codes.ScopeGeneral, "user:abc"},
+		{"account scope", "Account::Thing::load", message, true, codes.ErrorOK, codes.ScopeGeneral, "account:abc"},
+		{"signed out", "User::Thing::load", message, false, codes.ErrorUnauthorized, codes.ScopeRPC, ""},
+		{"undecodable", "Ping", []byte{0xff, 0xff}, false, codes.ErrorDecode, codes.ScopeRPC, ""},
+		{"native error", "Fail", message, false, codes.ErrorInternalEscape, codes.ScopeGeneral, ""},
+		{"rpc error", "Reject", message, false, codes.ErrorUnauthorized, codes.ScopeRPC, ""},
+	}
+
+	for _, test := range tests {
+		server.UserState = UserStateSignedOut
+		if test.signedIn {
+			server.UserState = UserStateSignedIn
+		}
+		response := call(t, server, test.method, test.message)
+		if codes.Code(response.Header.Code) != test.code || codes.Scope(response.Header.Scope) != test.scope {
+			t.Error("Expected [", test.name, "] to respond with code [", test.code, "] in scope [", test.scope, "] but got ", response.Header)
+		}
+		if response.Id != test.id {
+			t.Error("Expected [", test.name, "] to respond with id [", test.id, "] but got [", response.Id, "]")
+		}
+	}
+}
+
+func TestAuthorizeDispatch(t *testing.T) {
+	server := newTestServer()
+	server.UserState = UserStateSignedIn
+	method := idMethod("Thing::load", echo)
+	method.Scoped = true
+	method.Auth = AuthSignedIn
+	method.Resolver = func(call *Call) ([]*Target, error) {
+		return nil, errors.New("invalid message")
+	}
+	server.Register(method)
+
+	message, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+	response := call(t, server, "User::Thing::load", message)
+	if codes.Code(response.Header.Code) != codes.ErrorDecode || response.Id != "" {
+		t.Error("Expected a request with unresolvable targets not to reach its handler - ", response.Header)
+	}
+}
+
+func TestErrorModel(t *testing.T) {
+	server := newTestServer()
+	invalid := idMethod("Invalid", func(server *Server, call *Call) error {
+		return NewInvalid(codes.FieldError{Field: "id", Reason: codes.FieldRequired, Message: "id is required"})
+	})
+	locked := idMethod("Locked", func(server *Server, call *Call) error {
+		return codes.NewApplication(codes.ScopeNote, codes.ErrorLocked)
+	})
+	busy := idMethod("Busy", func(server *Server, call *Call) error {
+		return codes.New(codes.ScopeDB, codes.ErrorDbOpen)
+	})
+	failing := idMethod("Fail", func(server *Server, call *Call) error {
+		return errors.New("escaped")
+	})
+	server.Register(idMethod("Ping", echo), invalid, locked, busy, failing)
+	message, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+
+	tests := []struct {
+		method    string
+		errorType codes.ErrorType
+		status    string
+		reason    string
+		retryable bool
+		details   int
+	}{
+		{"Ping", codes.TypeOK, codes.StatusOK, "ok", false, 0},
+		{"Invalid", codes.TypeApplication, codes.StatusAppError, "invalid", false, 1},
+		{"Locked", codes.TypeApplication, "note - error locked", "locked", false, 0},
+		{"Busy", codes.TypeSystem, "db - error opening db", "db_open", true, 0},
+		{"Fail", codes.TypeSystem, "general - internal error escape", "internal_escape", false, 0},
+	}
+	for _, test := range tests {
+		header := call(t, server, test.method, message).Header
+		if codes.ErrorType(header.Type) != test.errorType || header.Status != test.status || header.Reason != test.reason {
+			t.Error("Expected [", test.method, "] to respond with type [", test.errorType, "], status [", test.status, "] & reason [", test.reason, "] but got ", header)
+		}
+		if header.Retryable != test.retryable || len(header.Details) != test.details {
+			t.Error("Expected [", test.method, "] to be retryable [", test.retryable, "] with ", test.details, " details but got ", header)
+		}
+	}
+
+	header := call(t, server, "Invalid", message).Header
+	if header.Details[0].Field != "id" || header.Details[0].Reason != codes.FieldRequired || header.Details[0].Message != "id is required" {
+		t.Error("Expected the field error in the details but got ", header.Details[0])
+	}
+}
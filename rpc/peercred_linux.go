@@ -0,0 +1,36 @@
+// +build linux
+
+package rpc
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// PeerCredentialsSupported is true when unix socket peers can be identified
+const PeerCredentialsSupported = true
+
+// peerUID returns the uid of the process on the other end of a unix socket connection
+func peerUID(conn net.Conn) (int, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, errors.New("not a unix socket connection")
+	}
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+	var cred *syscall.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if credErr != nil {
+		return 0, credErr
+	}
+	return int(cred.Uid), nil
+}
@@ -0,0 +1,16 @@
+// +build !linux
+
+package rpc
+
+import (
+	"errors"
+	"net"
+)
+
+// PeerCredentialsSupported is true when unix socket peers can be identified
+const PeerCredentialsSupported = false
+
+// peerUID returns the uid of the process on the other end of a unix socket connection
+func peerUID(conn net.Conn) (int, error) {
+	return 0, errors.New("peer credentials are not supported on this platform")
+}
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// TokenHash identifies a client token in the log without revealing it
+func TokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:6])
+}
+
+// startRequest gives a request an id & a logger carrying it along with the method & client
+func (rpc *Server) startRequest(method string, context *RequestContext) {
+	if context.ID == "" {
+		context.ID = uuid.NewV4().String()
+	}
+	fields := logrus.Fields{"request": context.ID, "method": method}
+	if context.Token != nil {
+		fields["client"] = TokenHash(context.Token.Token)
+	}
+	context.Logger = rpc.Logger.WithFields(fields)
+}
+
+// finishRequest counts a dispatched request & logs one line for it
+// A key exchange only has a client once it has been handled, so the client is
+// added here when it wasn't known at the start.
+func (rpc *Server) finishRequest(call *Call, started time.Time) {
+	elapsed := time.Since(started)
+	rpc.recordRequest(call.Method, elapsed, call.Header)
+
+	fields := logrus.Fields{
+		"durationMs": float64(elapsed) / float64(time.Millisecond),
+		"code":       call.Header.Code,
+	}
+	if _, ok := call.Context.Logger.Data["client"]; !ok && call.Context.Token != nil {
+		fields["client"] = TokenHash(call.Context.Token.Token)
+	}
+	entry := call.Context.Logger.WithFields(fields)
+	if codes.Code(call.Header.Code) != codes.ErrorOK {
+		entry.WithField("scope", call.Header.Scope).Warn("Request failed")
+		return
+	}
+	entry.Info("Request handled")
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// logLines decodes the JSON log lines written to out
+func logLines(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		fields := make(map[string]interface{})
+		err := json.Unmarshal([]byte(line), &fields)
+		if err != nil {
+			t.Fatal("Expected a JSON log line but got - ", line)
+		}
+		lines = append(lines, fields)
+	}
+	return lines
+}
+
+func TestRequestLogging(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.Out = out
+	logger.Formatter = &logrus.JSONFormatter{}
+	server := NewServer(logger, nil, nil)
+	server.Register(&Method{
+		Name:     "Account::create",
+		Request:  func() proto.Message { return &messages.CreateAccountRequest{} },
+		Response: func() proto.Message { return &messages.EmptyResponse{} },
+		Handler: func(server *Server, call *Call) error {
+			request := call.Request.(*messages.CreateAccountRequest)
+			call.Context.Logger.Warn("Error creating account for [", request.Email, "] with [", request.Passphrase, "]")
+			return nil
+		},
+	})
+
+	message, _ := proto.Marshal(&messages.CreateAccountRequest{
+		Name:       "account",
+		Email:      "someone@example.com",
+		Passphrase: "correct horse battery",
+	})
+	context := &RequestContext{Token: &ClientToken{Token: "token"}}
+	route := server.findMethod("Account::create")
+	server.dispatch("Account::create", route, message, context)
+
+	if strings.Contains(out.String(), "correct horse battery") || strings.Contains(out.String(), "someone@example.com") {
+		t.Error("Expected the passphrase & email to be redacted - ", out.String())
+	}
+	lines := logLines(t, out)
+	if len(lines) != 2 {
+		t.Fatal("Expected the handler's line & one line for the request but got ", len(lines))
+	}
+	for _, line := range lines {
+		if line["request"] != context.ID || line["method"] != "Account::create" || line["client"] != TokenHash("token") {
+			t.Error("Expected each line to carry the request id, method & client but got ", line)
+		}
+	}
+	if lines[1]["msg"] != "Request handled" || lines[1]["durationMs"] == nil || lines[1]["code"] != float64(0) {
+		t.Error("Expected the request line to have the duration & code but got ", lines[1])
+	}
+
+	out.Reset()
+	server.dispatch("Account::create", route, []byte{0xff, 0xff}, &RequestContext{})
+	lines = logLines(t, out)
+	last := lines[len(lines)-1]
+	if last["msg"] != "Request failed" || last["scope"] == nil || last["request"] == context.ID {
+		t.Error("Expected a new request id & the failure to be logged but got ", last)
+	}
+}
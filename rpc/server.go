@@ -1,26 +1,32 @@
 package rpc
 
 import (
+	"context"
 	"encoding/base64"
 	"io/ioutil"
 	"log"
-	"net"
 	"net/http"
 
 	"crypto/tls"
 
 	"strconv"
+	"sync"
+	"time"
 
 	"notekeeper-electron-backend/account"
+	"notekeeper-electron-backend/api"
+	"notekeeper-electron-backend/appdir"
 	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/logfile"
+	"notekeeper-electron-backend/passphrase"
+	"notekeeper-electron-backend/redact"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-// Handler is an RPC message handler
-type Handler func(*Server, []byte, *RequestContext) (proto.Message, error)
-
 // RequestHeader contains the custom headers from a request
 type RequestHeader struct {
 	Signature []byte
@@ -33,42 +39,109 @@ type RequestHeader struct {
 type RequestContext struct {
 	Token  *ClientToken
 	Header *RequestHeader
+	ID     string        // ID correlates the log lines of a request
+	Logger *logrus.Entry // Logger logs with the request id, method & client
+
+	TokenExpired bool // TokenExpired is set when the request's token has expired
 }
 
 // Server is a RPC server instance
 type Server struct {
-	Logger      *logrus.Logger
-	DBRegistry  *db.Registry
-	UserState   UserState
-	Account     *account.Account
-	Certificate tls.Certificate
-	Status      chan string
-	Shutdown    chan bool
-	Handlers    map[string]Handler
-	Clients     map[string]*ClientToken
+	Logger            *logrus.Logger
+	DBRegistry        *db.Registry
+	UserState         UserState
+	Account           *account.Account
+	Certificate       tls.Certificate
+	NextCertificate   *tls.Certificate
+	CertificatePath   string
+	CertificatePolicy CertificatePolicy
+	DataPath          string
+	BootstrapSecret   []byte
+	Status            chan string
+	Shutdown          chan bool
+	Methods           map[string]*route
+	Clients           map[string]*ClientToken
+	ClientPolicy      ClientPolicy
+	// CheckPeerCredentials only accepts unix socket connections from the user running the backend
+	CheckPeerCredentials bool
+	Version              string // Version is the release version reported by Service::status
+	BuildType            string // BuildType is debug or release
+	Started              time.Time
+	Redactor             *redact.Formatter  // Redactor keeps request secrets out of the log
+	Log                  *logfile.File      // Log is the log file, if the backend logs to one
+	PassphrasePolicy     *passphrase.Policy // PassphrasePolicy is checked for new passphrases
+	clientsLock          sync.Mutex
+	certLock             sync.Mutex
+	dispatchLock         sync.Mutex
+	stopReaper           chan bool
+	serveLock            sync.Mutex
+	httpServer           *http.Server
+	grpcServer           *grpc.Server
+	stopping             bool
+	metrics              metrics
 }
 
+// ShutdownTimeout is how long Stop waits for requests in progress to finish
+const ShutdownTimeout = 10 * time.Second
+
 // NewServer creates a new RPCServer instance
 func NewServer(logger *logrus.Logger, Status chan string, Shutdown chan bool) *Server {
 	server := &Server{
-		Logger:     logger,
-		Handlers:   make(map[string]Handler, 0),
-		UserState:  UserStateSignedOut,
-		Status:     Status,
-		Shutdown:   Shutdown,
-		Clients:    make(map[string]*ClientToken),
-		DBRegistry: db.NewRegistry(logger),
+		Logger:       logger,
+		Methods:      make(map[string]*route, 0),
+		UserState:    UserStateSignedOut,
+		Status:       Status,
+		Shutdown:     Shutdown,
+		Clients:      make(map[string]*ClientToken),
+		ClientPolicy: DefaultClientPolicy(),
+		DBRegistry:   db.NewRegistry(logger),
+
+		CertificatePath:   appdir.AppDataPath(),
+		DataPath:          appdir.AppDataPath(),
+		CertificatePolicy: DefaultCertificatePolicy(),
+		Started:           time.Now(),
+		Redactor:          redact.Install(logger),
+		PassphrasePolicy:  passphrase.DefaultPolicy(),
 	}
 	return server
 }
 
+// Request header names
+// gRPC requests carry the same values in metadata, under the lower-case names.
+const (
+	HeaderRequestMethod    = "NoteKeeper-Request-Method"
+	HeaderClientToken      = "NoteKeeper-Client-Token"
+	HeaderMessageSignature = "NoteKeeper-Message-Signature"
+	HeaderMessageSequence  = "NoteKeeper-Message-Sequence"
+	HeaderProtocolVersion  = "NoteKeeper-Protocol-Version"
+)
+
+// HeaderError names the reason a request was refused before it was handled
+// It's sent with a 401 response when the client token has expired.
+const HeaderError = "NoteKeeper-Error"
+
+// ErrorTokenExpired is the HeaderError value for an expired client token
+const ErrorTokenExpired = "token_expired"
+
+// SERVICE-READY response header names
+// The next fingerprint is only sent while a certificate rotation is in progress.
+const (
+	HeaderCertificateFingerprint     = "NoteKeeper-Certificate-Fingerprint"
+	HeaderNextCertificateFingerprint = "NoteKeeper-Next-Certificate-Fingerprint"
+)
+
 // VerifyHeaders checks that a request contains the correct headers &
 // extracts their values into a working structure
 func (rpc *Server) VerifyHeaders(req *http.Request, context *RequestContext) bool {
+	return rpc.verifyHeaders(req.Header.Get(HeaderRequestMethod), req.Header.Get, context)
+}
+
+// verifyHeaders checks the headers of a request for method, looking up each header with get
+func (rpc *Server) verifyHeaders(method string, get func(string) string, context *RequestContext) bool {
 
 	context.Header = &RequestHeader{}
 
-	context.Header.Method = req.Header.Get("NoteKeeper-Request-Method")
+	context.Header.Method = method
 	if context.Header.Method == "" {
 		rpc.Logger.Warn("Missing request method")
 		return false
@@ -80,21 +153,22 @@ func (rpc *Server) VerifyHeaders(req *http.Request, context *RequestContext) boo
 
 	// Token creation is part of key exchange, so it doesn't exist here yet
 	if context.Header.Method != "KeyExchange" {
-		context.Header.Token = req.Header.Get("NoteKeeper-Client-Token")
+		context.Header.Token = get(HeaderClientToken)
 		if context.Header.Token == "" {
 			rpc.Logger.Warn("Missing request client token")
 			return false
 		}
-		var ok bool
-		context.Token, ok = rpc.Clients[context.Header.Token]
-		if !ok {
-			rpc.Logger.Warn("Invalid client token")
+		var err error
+		context.Token, err = rpc.lookupClient(context.Header.Token, time.Now())
+		if err != nil {
+			rpc.Logger.Warn(err)
+			context.TokenExpired = err == ErrTokenExpired
 			return false
 		}
 	}
 
 	// base64 encoded signature of the request body
-	signature := req.Header.Get("NoteKeeper-Message-Signature")
+	signature := get(HeaderMessageSignature)
 	if signature == "" {
 		rpc.Logger.Warn("Missing request signature")
 		return false
@@ -106,7 +180,7 @@ func (rpc *Server) VerifyHeaders(req *http.Request, context *RequestContext) boo
 		return false
 	}
 
-	seq := req.Header.Get("NoteKeeper-Message-Sequence")
+	seq := get(HeaderMessageSequence)
 	if seq == "" {
 		rpc.Logger.Warn("Missing request sequence")
 		return false
@@ -118,9 +192,8 @@ func (rpc *Server) VerifyHeaders(req *http.Request, context *RequestContext) boo
 	}
 	context.Header.Sequence = int32(parsedSeq)
 
-	context.Token.RecvCounter++
-	if context.Header.Method != "KeyExchange" && context.Header.Sequence != context.Token.RecvCounter {
-		rpc.Logger.Warn("Invalid message sequence received. Expected [", context.Token.RecvCounter, "] but got [", context.Header.Sequence, "]")
+	// the key exchange resets the counters of the token it creates
+	if context.Header.Method != "KeyExchange" && !rpc.checkSequence(context.Token, context.Header.Sequence) {
 		return false
 	}
 
@@ -146,9 +219,23 @@ func (rpc *Server) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	context := &RequestContext{}
 
 	if !rpc.VerifyHeaders(req, context) {
+		// an expired token is the one refusal the client can recover from, by exchanging keys again
+		if context.TokenExpired {
+			resp.Header().Set(HeaderError, ErrorTokenExpired)
+			http.Error(resp, ErrTokenExpired.Error(), http.StatusUnauthorized)
+		}
 		return
 	}
 
+	if context.Header.Method != "SERVICE-READY" {
+		err := rpc.verifyVersion(req.Header.Get)
+		if err != nil {
+			resp.Header().Set(HeaderProtocolVersion, strconv.Itoa(ProtocolVersion))
+			http.Error(resp, err.Error(), http.StatusUpgradeRequired)
+			return
+		}
+	}
+
 	body, err := ioutil.ReadAll(req.Body)
 	if err != nil {
 		rpc.Logger.Warn("Error reading request body - ", err)
@@ -157,6 +244,11 @@ func (rpc *Server) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 
 	rpc.Logger.Debug(context.Header.Method)
 	if context.Header.Method == "SERVICE-READY" {
+		current, next := rpc.Fingerprints()
+		resp.Header().Set(HeaderCertificateFingerprint, current)
+		if next != "" {
+			resp.Header().Set(HeaderNextCertificateFingerprint, next)
+		}
 		_, err = resp.Write([]byte("OK"))
 		rpc.Logger.Debug("ready!")
 		if err != nil {
@@ -165,102 +257,222 @@ func (rpc *Server) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	handler := rpc.FindHandler(context.Header.Method)
-	if handler == nil {
+	route := rpc.findMethod(context.Header.Method)
+	if route == nil {
 		rpc.Logger.Warn("Could not find handler for method - ", context.Header.Method)
 		return
 	}
 
+	responseData, ok := rpc.handle(route, body, context)
+	if !ok {
+		return
+	}
+	encodedData := base64.StdEncoding.EncodeToString(responseData)
+
+	// set response headers
+	responseSignature, sequence := rpc.signResponse(responseData, context)
+	resp.Header().Set(HeaderMessageSignature, responseSignature)
+	resp.Header().Set(HeaderMessageSequence, strconv.FormatInt(int64(sequence), 10))
+	resp.Header().Set(HeaderProtocolVersion, strconv.Itoa(ProtocolVersion))
+	// repackage request method header so client doesn't need to keep track of it
+	resp.Header().Set(HeaderRequestMethod, context.Header.Method)
+
+	// send response
+	_, err = resp.Write([]byte(encodedData))
+	if err != nil {
+		rpc.Logger.Warn("Error writing response - ", err)
+	}
+}
+
+// handle verifies the signature of a request body & dispatches it, returning the marshaled response
+func (rpc *Server) handle(route *route, body []byte, context *RequestContext) ([]byte, bool) {
 	// key exchange requests contain the key needed to do verification
 	// so we need to defer until after the request has been handled
 	if context.Header.Method != "KeyExchange" {
 		ok := rpc.VerifyRequest(body, context.Header.Signature, context)
 		if !ok {
 			rpc.Logger.Warn("Message Verification failed")
-			return
+			return nil, false
+		}
+		// only a verified request moves the sequence on
+		if !rpc.countRequest(context.Token, context.Header.Sequence, time.Now()) {
+			return nil, false
 		}
 	}
 
-	handlerResponse, err := handler(rpc, body, context)
-	if err != nil {
-		return
-	}
+	handlerResponse := rpc.dispatch(context.Header.Method, route, body, context)
 
 	if context.Header.Method == "KeyExchange" {
+		// rejected key exchanges don't create a token, so there's nothing to sign the response with
+		if context.Token == nil {
+			rpc.Logger.Warn("Key exchange rejected")
+			return nil, false
+		}
 		ok := rpc.VerifyRequest(body, context.Header.Signature, context)
 		if !ok {
 			rpc.Logger.Warn("Message Verification failed")
-			return
+			rpc.removeClient(context.Token.Token)
+			return nil, false
 		}
 	}
 
 	responseData, err := proto.Marshal(handlerResponse)
 	if err != nil {
 		rpc.Logger.Warn("Error marshaling response - ", err)
-		return
-	}
-	encodedData := base64.StdEncoding.EncodeToString(responseData)
-
-	// set response headers
-	responseSignature := rpc.CreateSignature(responseData, context)
-	resp.Header().Set("NoteKeeper-Message-Signature", responseSignature)
-
-	context.Token.SendCounter++
-	resp.Header().Set("NoteKeeper-Message-Sequence", strconv.FormatInt(int64(context.Token.SendCounter), 10))
-	// repackage request method header so client doesn't need to keep track of it
-	resp.Header().Set("NoteKeeper-Request-Method", context.Header.Method)
-
-	// send response
-	_, err = resp.Write([]byte(encodedData))
-	if err != nil {
-		rpc.Logger.Warn("Error writing response - ", err)
+		return nil, false
 	}
+	return responseData, true
 }
 
-// FindHandler matches a method name with a handler
-func (rpc *Server) FindHandler(requestMethod string) Handler {
-	for method, handler := range rpc.Handlers {
-		if method == requestMethod {
-			return handler
-		}
+// findMethod matches a method name with a registered method
+func (rpc *Server) findMethod(requestMethod string) *route {
+	route, ok := rpc.Methods[requestMethod]
+	if !ok {
+		return nil
 	}
-	return nil
+	return route
 }
 
 // Start an RPC Server
-func (rpc *Server) Start(port string) bool {
-	ok := rpc.createCertificate()
+// Signed POST /rpc requests are served on listener & gRPC requests on
+// grpcListener.  TCP listeners share the same certificate.
+func (rpc *Server) Start(listener Listener, grpcListener Listener) bool {
+	useTLS := listener.Network == NetworkTCP || grpcListener.Network == NetworkTCP
+	if useTLS && !rpc.loadCertificates(time.Now()) {
+		return false
+	}
+	ok := rpc.createBootstrapSecret()
 	if !ok {
 		return false
 	}
 
-	conn, err := net.Listen("tcp", port)
-	if err != nil {
-		rpc.Logger.Warn("Listen error - ", err)
+	conn, ok := rpc.listen(listener)
+	if !ok {
 		return false
 	}
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{rpc.Certificate},
+	if listener.Network == NetworkTCP {
+		conn = tls.NewListener(conn, rpc.tlsConfig())
 	}
-	tlsListener := tls.NewListener(conn, tlsConfig)
+
+	grpcConn, ok := rpc.listen(grpcListener)
+	if !ok {
+		conn.Close()
+		return false
+	}
+	var options []grpc.ServerOption
+	if grpcListener.Network == NetworkTCP {
+		options = append(options, grpc.Creds(credentials.NewTLS(rpc.tlsConfig())))
+	}
+	grpcServer := rpc.NewGRPCServer(options...)
+
 	writer := rpc.Logger.Writer()
 	defer writer.Close()
 	server := &http.Server{
-		Addr:     port,
 		Handler:  rpc,
 		ErrorLog: log.New(writer, "", 0),
 	}
-	rpc.Logger.Debug("RPC listening on port [", port, "]")
 
-	// send a token to stdout so the frontend knows the backend is done initializing
+	rpc.serveLock.Lock()
+	if rpc.stopping {
+		rpc.serveLock.Unlock()
+		conn.Close()
+		grpcConn.Close()
+		return true
+	}
+	rpc.httpServer = server
+	rpc.grpcServer = grpcServer
+	rpc.startReaper()
+	rpc.serveLock.Unlock()
+
+	go grpcServer.Serve(grpcConn)
+	rpc.Logger.Debug("gRPC listening on [", grpcListener, "]")
+	rpc.Logger.Debug("RPC listening on [", listener, "]")
+
+	// send the certificate fingerprints, the bootstrap secret & then a token to
+	// stdout so the frontend knows the backend is done initializing
+	if useTLS {
+		current, next := rpc.Fingerprints()
+		rpc.Status <- "NOTEKEEPER_CERTIFICATE_FINGERPRINT " + current
+		if next != "" {
+			rpc.Status <- "NOTEKEEPER_NEXT_CERTIFICATE_FINGERPRINT " + next
+		}
+	}
+	rpc.Status <- "NOTEKEEPER_BOOTSTRAP_SECRET " + base64.StdEncoding.EncodeToString(rpc.BootstrapSecret)
 	rpc.Status <- "NOTEKEEPER_SERVICE_READY"
 
-	server.Serve(tlsListener)
+	err := server.Serve(conn)
+	if err != http.ErrServerClosed {
+		rpc.Logger.Warn("Error serving RPC - ", err)
+		return false
+	}
 	return true
 }
 
+// RequestShutdown asks the backend to shut down
+// The request is sent asynchronously so that the request asking for shutdown
+// can still be answered; Stop waits for it to finish.
+func (rpc *Server) RequestShutdown() {
+	if rpc.Shutdown == nil {
+		return
+	}
+	go func() {
+		rpc.Shutdown <- true
+	}()
+}
+
+// isStopping reports whether Stop has been called
+func (rpc *Server) isStopping() bool {
+	rpc.serveLock.Lock()
+	defer rpc.serveLock.Unlock()
+	return rpc.stopping
+}
+
 // Stop performs shutdown routines before application termination
+// The listeners are closed first & requests in progress are given
+// ShutdownTimeout to finish.  Then the active account is signed out, which
+// zeroes its keys, & every db is closed.
 func (rpc *Server) Stop() {
+	rpc.serveLock.Lock()
+	rpc.stopping = true
+	httpServer, grpcServer := rpc.httpServer, rpc.grpcServer
+	rpc.httpServer, rpc.grpcServer = nil, nil
+	if rpc.stopReaper != nil {
+		close(rpc.stopReaper)
+		rpc.stopReaper = nil
+	}
+	rpc.serveLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if httpServer != nil {
+		err := httpServer.Shutdown(ctx)
+		if err != nil {
+			rpc.Logger.Warn("Timed out waiting for RPC requests to finish - ", err)
+			httpServer.Close()
+		}
+	}
+	if grpcServer != nil {
+		stopped := make(chan bool)
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			rpc.Logger.Warn("Timed out waiting for gRPC requests to finish")
+			grpcServer.Stop()
+		}
+	}
+
+	// wait for any request still in progress before closing the dbs
+	rpc.dispatchLock.Lock()
+	defer rpc.dispatchLock.Unlock()
+	if rpc.Account != nil {
+		api.New(rpc.DBRegistry, rpc.Logger).SignoutAccount(rpc.Account)
+		rpc.Account = nil
+		rpc.UserState = UserStateSignedOut
+	}
 	if rpc.DBRegistry == nil {
 		return
 	}
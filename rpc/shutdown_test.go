@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+)
+
+// send posts a signed request over a real connection
+func (client *testClient) send(httpClient *http.Client, request *httpRequest) (*http.Response, error) {
+	req, _ := http.NewRequest("POST", "http://notekeeper/rpc", bytes.NewReader(request.body))
+	req.Header.Set(HeaderRequestMethod, request.method)
+	req.Header.Set(HeaderClientToken, client.token.Token)
+	req.Header.Set(HeaderMessageSignature, request.signature)
+	req.Header.Set(HeaderMessageSequence, strconv.FormatInt(int64(request.sequence), 10))
+	req.Header.Set(HeaderProtocolVersion, request.version)
+	return httpClient.Do(req)
+}
+
+func TestStop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notekeeper-shutdown")
+	if err != nil {
+		t.Fatal("Error creating socket directory - ", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "notekeeper.sock")
+
+	started := make(chan bool)
+	release := make(chan bool)
+	server := newTestServer()
+	server.Status = make(chan string)
+	server.Register(idMethod("Ping", echo), idMethod("Wait", func(server *Server, call *Call) error {
+		close(started)
+		<-release
+		return echo(server, call)
+	}))
+
+	done := make(chan bool)
+	go func() {
+		done <- server.Start(Listener{NetworkUnix, path}, Listener{NetworkUnix, filepath.Join(dir, "grpc.sock")})
+	}()
+	for msg := range server.Status {
+		if msg == "NOTEKEEPER_SERVICE_READY" {
+			break
+		}
+	}
+
+	client := newTestClient(t, server)
+	httpClient := unixClient(path)
+	answered := make(chan *http.Response)
+	go func() {
+		resp, err := client.send(httpClient, client.request("Wait", nil))
+		if err != nil {
+			t.Error("Expected the request in progress to be answered - ", err)
+		}
+		answered <- resp
+	}()
+	<-started
+
+	stopped := make(chan bool)
+	go func() {
+		server.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("Expected Stop to wait for the request in progress")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+
+	resp := <-answered
+	if resp == nil || resp.StatusCode != http.StatusOK || resp.Header.Get(HeaderMessageSignature) == "" {
+		t.Error("Expected a signed response to the request in progress")
+	}
+	<-stopped
+	if !<-done {
+		t.Error("Expected Start to return cleanly after Stop")
+	}
+	if server.DBRegistry != nil {
+		t.Error("Expected the dbs to be closed")
+	}
+
+	if _, err := serviceReady(unixClient(path)); err == nil {
+		t.Error("Expected new connections to be refused after Stop")
+	}
+	response := call(t, server, "Ping", nil)
+	if codes.Code(response.Header.Code) != codes.ErrorShutdown {
+		t.Error("Expected requests after Stop to fail with ErrorShutdown but got ", response.Header.Code)
+	}
+}
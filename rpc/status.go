@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+)
+
+// MethodStats counts the requests made to a method
+type MethodStats struct {
+	Method string
+	Count  int64
+	Errors int64 // Errors is the number of requests answered with an error code
+	Total  time.Duration
+	Max    time.Duration
+}
+
+// Average returns the average time taken to answer a request
+func (stats *MethodStats) Average() time.Duration {
+	if stats.Count == 0 {
+		return 0
+	}
+	return stats.Total / time.Duration(stats.Count)
+}
+
+// ScopeError is the last error reported in a scope
+type ScopeError struct {
+	Scope  codes.Scope
+	Code   codes.Code
+	Method string
+	Time   time.Time
+}
+
+// metrics are kept for every request dispatched since the server was created
+// They have their own lock since requests that fail to decode are counted
+// outside of the dispatch lock.
+type metrics struct {
+	lock    sync.Mutex
+	methods map[string]*MethodStats
+	errors  map[codes.Scope]*ScopeError
+}
+
+// String returns the name of a user state
+func (state UserState) String() string {
+	switch state {
+	case UserStateSignedIn:
+		return "signedIn"
+	case UserStateLocked:
+		return "locked"
+	}
+	return "signedOut"
+}
+
+// Uptime returns how long the server has been running
+func (rpc *Server) Uptime(now time.Time) time.Duration {
+	return now.Sub(rpc.Started)
+}
+
+// recordRequest counts a dispatched request along with the error it was answered with
+func (rpc *Server) recordRequest(method string, elapsed time.Duration, header *messages.ResponseHeader) {
+	m := &rpc.metrics
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.methods == nil {
+		m.methods = make(map[string]*MethodStats)
+		m.errors = make(map[codes.Scope]*ScopeError)
+	}
+	stats, ok := m.methods[method]
+	if !ok {
+		stats = &MethodStats{Method: method}
+		m.methods[method] = stats
+	}
+	stats.Count++
+	stats.Total += elapsed
+	if elapsed > stats.Max {
+		stats.Max = elapsed
+	}
+
+	if codes.Code(header.Code) == codes.ErrorOK {
+		return
+	}
+	stats.Errors++
+	scope := codes.Scope(header.Scope)
+	m.errors[scope] = &ScopeError{
+		Scope:  scope,
+		Code:   codes.Code(header.Code),
+		Method: method,
+		Time:   time.Now(),
+	}
+}
+
+// MethodStats returns the request counts for every method that has been called, by name
+func (rpc *Server) MethodStats() []MethodStats {
+	m := &rpc.metrics
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	all := make([]MethodStats, 0, len(m.methods))
+	for _, stats := range m.methods {
+		all = append(all, *stats)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Method < all[j].Method })
+	return all
+}
+
+// LastErrors returns the last error reported in each scope, by scope
+func (rpc *Server) LastErrors() []ScopeError {
+	m := &rpc.metrics
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	all := make([]ScopeError, 0, len(m.errors))
+	for _, scopeError := range m.errors {
+		all = append(all, *scopeError)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Scope < all[j].Scope })
+	return all
+}
@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestRecordRequest(t *testing.T) {
+	server := newTestServer()
+	rejecting := idMethod("Reject", func(server *Server, call *Call) error {
+		return NewError(codes.ErrorUnauthorized)
+	})
+	server.Register(idMethod("Ping", echo), rejecting)
+
+	message, _ := proto.Marshal(&messages.IdRequest{Id: "abc"})
+	call(t, server, "Ping", message)
+	call(t, server, "Ping", []byte{0xff, 0xff})
+	call(t, server, "Reject", message)
+
+	stats := server.MethodStats()
+	if len(stats) != 2 || stats[0].Method != "Ping" || stats[1].Method != "Reject" {
+		t.Fatal("Expected stats for Ping & Reject but got ", stats)
+	}
+	if stats[0].Count != 2 || stats[0].Errors != 1 {
+		t.Error("Expected 2 Ping requests with 1 error but got ", stats[0].Count, " & ", stats[0].Errors)
+	}
+	if stats[1].Count != 1 || stats[1].Errors != 1 || stats[1].Max < stats[1].Average() {
+		t.Error("Expected 1 failed Reject request but got ", stats[1])
+	}
+
+	errors := server.LastErrors()
+	if len(errors) != 1 {
+		t.Fatal("Expected the last error in the rpc scope but got ", errors)
+	}
+	if errors[0].Scope != codes.ScopeRPC || errors[0].Code != codes.ErrorUnauthorized || errors[0].Method != "Reject" {
+		t.Error("Expected the Reject error to replace the decode error but got ", errors[0])
+	}
+}
+
+func TestUserStateString(t *testing.T) {
+	states := map[UserState]string{
+		UserStateSignedOut: "signedOut",
+		UserStateSignedIn:  "signedIn",
+		UserStateLocked:    "locked",
+	}
+	for state, name := range states {
+		if state.String() != name {
+			t.Error("Expected user state [", name, "] but got ", state.String())
+		}
+	}
+}
@@ -3,15 +3,20 @@ package rpc
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"time"
 
 	"github.com/agl/ed25519"
+	uuid "github.com/satori/go.uuid"
 	"github.com/sirupsen/logrus"
 )
 
 // ClientToken identifies a client that can communicate with the server
 // Each client has its own set of counters and signing keys
 type ClientToken struct {
+	ID              string // ID identifies the client in listings without revealing its token
 	Token           string
+	Created         time.Time
+	LastUsed        time.Time
 	RecvCounter     int32
 	SendCounter     int32
 	SignPublicKey   *[ed25519.PublicKeySize]byte
@@ -21,7 +26,11 @@ type ClientToken struct {
 
 // NewClientToken creates a new ClientToken
 func NewClientToken(logger *logrus.Logger) (*ClientToken, error) {
+	now := time.Now()
 	client := &ClientToken{
+		ID:          uuid.NewV4().String(),
+		Created:     now,
+		LastUsed:    now,
 		RecvCounter: 0,
 		SendCounter: 0,
 	}
@@ -44,3 +53,25 @@ func NewClientToken(logger *logrus.Logger) (*ClientToken, error) {
 
 	return client, nil
 }
+
+// Expires returns the time at which the token expires under a policy
+// The zero time is returned when the policy never expires tokens.
+func (client *ClientToken) Expires(policy ClientPolicy) time.Time {
+	var expires time.Time
+	if policy.Lifetime > 0 {
+		expires = client.Created.Add(policy.Lifetime)
+	}
+	if policy.IdleTimeout > 0 {
+		idle := client.LastUsed.Add(policy.IdleTimeout)
+		if expires.IsZero() || idle.Before(expires) {
+			expires = idle
+		}
+	}
+	return expires
+}
+
+// Expired tests whether the token has expired under a policy
+func (client *ClientToken) Expired(policy ClientPolicy, now time.Time) bool {
+	expires := client.Expires(policy)
+	return !expires.IsZero() && !now.Before(expires)
+}
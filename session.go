@@ -0,0 +1,287 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"notekeeper-electron-backend/account"
+	"notekeeper-electron-backend/api"
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/notebook"
+	"notekeeper-electron-backend/shelf"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// PassphraseEnv is the environment variable holding the passphrase for headless commands
+const PassphraseEnv = "NOTEKEEPER_PASSPHRASE"
+
+// stdin is read for passphrases & note content
+var stdin io.Reader = os.Stdin
+
+// signinFlags are the flags every headless command signs in with
+var signinFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "account",
+		EnvVar: "NOTEKEEPER_ACCOUNT",
+		Usage:  "`name` of the account to sign in to",
+	},
+	cli.StringFlag{
+		Name:   "email",
+		EnvVar: "NOTEKEEPER_EMAIL",
+		Usage:  "`email` of the user to sign in as",
+	},
+}
+
+// Session is an account signed in to from the command line, without the RPC server
+type Session struct {
+	Logger   *logrus.Logger
+	Registry *db.Registry
+	API      *api.API
+	Account  *account.Account
+	Shelves  []*shelf.Shelf
+}
+
+// openSession opens the master db in the data directory & signs in
+// The passphrase is taken from NOTEKEEPER_PASSPHRASE, or prompted for when
+// stdin is a terminal.
+func openSession(c *cli.Context) (*Session, error) {
+	config, err := LoadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.String("account") == "" || c.String("email") == "" {
+		return nil, fmt.Errorf("--account and --email are required to sign in")
+	}
+	passphrase, err := readPassphrase(c)
+	if err != nil {
+		return nil, err
+	}
+
+	logger, err := newLogger(config)
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{
+		Logger:   logger,
+		Registry: db.NewRegistry(logger),
+	}
+	session.API = api.New(session.Registry, logger)
+
+	err = session.Registry.OpenMaster(config.DataDir)
+	if db.IsInUse(err) {
+		return nil, inUseError(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the master db in [%s] - %v", config.DataDir, err)
+	}
+	session.Account, err = session.API.SigninAccount(c.String("account"), c.String("email"), passphrase)
+	if err != nil {
+		session.Close()
+		if db.IsInUse(err) {
+			return nil, inUseError(config)
+		}
+		return nil, fmt.Errorf("unable to sign in - %v", err)
+	}
+	session.Shelves, err = session.API.OpenShelves(session.Account)
+	if err != nil {
+		session.Close()
+		if db.IsInUse(err) {
+			return nil, inUseError(config)
+		}
+		return nil, fmt.Errorf("unable to open shelves - %v", err)
+	}
+	return session, nil
+}
+
+// inUseError explains a db that bolt couldn't lock because another process has it open
+func inUseError(config *Config) error {
+	return fmt.Errorf("the dbs in [%s] are in use - stop the backend or any other command using this data directory first", config.DataDir)
+}
+
+func readPassphrase(c *cli.Context) (string, error) {
+	if passphrase, ok := os.LookupEnv(PassphraseEnv); ok {
+		return passphrase, nil
+	}
+	if f, ok := stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		fmt.Fprint(c.App.ErrWriter, "Passphrase: ")
+		passphrase, err := terminal.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(c.App.ErrWriter)
+		return string(passphrase), err
+	}
+	return "", fmt.Errorf("set %s or run from a terminal to enter the passphrase", PassphraseEnv)
+}
+
+// Close signs out & closes every db
+func (session *Session) Close() {
+	if session.Account != nil {
+		session.API.SignoutAccount(session.Account)
+	}
+	session.Registry.CloseAll()
+}
+
+// findShelves looks up a shelf by id, returning every shelf when id is empty
+func (session *Session) findShelves(id string) ([]*shelf.Shelf, error) {
+	if id == "" {
+		return session.Shelves, nil
+	}
+	shelfID, err := uuid.FromString(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shelf id [%s]", id)
+	}
+	for _, s := range session.Shelves {
+		if s.ID == shelfID {
+			return []*shelf.Shelf{s}, nil
+		}
+	}
+	for _, s := range session.Shelves {
+		collections, _ := session.collections(s)
+		for _, c := range collections {
+			if c.ID == shelfID {
+				return nil, fmt.Errorf("[%s] is a collection - headless commands only work on shelves", id)
+			}
+		}
+	}
+	return nil, fmt.Errorf("shelf [%s] not found", id)
+}
+
+// collections loads the collections in a shelf
+// Headless commands don't open collection dbs, so these are only used to
+// report the collections that are skipped.
+func (session *Session) collections(s *shelf.Shelf) ([]*collection.Collection, error) {
+	key, err := session.API.ShelfKey(session.Account, s.Scope)
+	if err != nil {
+		return nil, err
+	}
+	scope := collection.ScopeUser
+	if s.Scope == shelf.ScopeAccount {
+		scope = collection.ScopeAccount
+	}
+	index := collection.NewIndex(scope, session.Registry, session.Logger)
+	index.ShelfID = s.ID
+	index.OwnerID = s.OwnerID
+	err = index.LoadAll(key)
+	if bucketMissing(err) {
+		return nil, nil
+	}
+	return index.Collections, err
+}
+
+// defaultShelf returns the active user's default shelf
+func (session *Session) defaultShelf() (*shelf.Shelf, error) {
+	for _, s := range session.Shelves {
+		if s.Default && s.Scope == shelf.ScopeUser {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("no default shelf found")
+}
+
+// notebooks loads the notebooks in a shelf
+func (session *Session) notebooks(s *shelf.Shelf) ([]*notebook.Notebook, error) {
+	key, err := session.API.ShelfKey(session.Account, s.Scope)
+	if err != nil {
+		return nil, err
+	}
+	scope := notebook.ScopeUser
+	if s.Scope == shelf.ScopeAccount {
+		scope = notebook.ScopeAccount
+	}
+	proxy, err := notebook.New(nil, scope, notebook.ContainerTypeShelf, session.Registry, session.Logger)
+	if err != nil {
+		return nil, err
+	}
+	proxy.OwnerID = s.OwnerID
+	proxy.ContainerID = s.ID
+	notebooks, err := proxy.LoadAll(key)
+	if bucketMissing(err) {
+		return nil, nil
+	}
+	return notebooks, err
+}
+
+// notes loads the notes stored in a shelf
+func (session *Session) notes(s *shelf.Shelf) ([]*note.Note, error) {
+	key, err := session.API.ShelfKey(session.Account, s.Scope)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := session.noteProxy(s)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := proxy.LoadAll(key)
+	if bucketMissing(err) {
+		return nil, nil
+	}
+	for _, n := range notes {
+		n.DBRegistry = session.Registry
+		n.Logger = session.Logger
+	}
+	return notes, err
+}
+
+// noteProxy creates a note for working with the notes stored in a shelf
+func (session *Session) noteProxy(s *shelf.Shelf) (*note.Note, error) {
+	scope := note.ScopeUser
+	if s.Scope == shelf.ScopeAccount {
+		scope = note.ScopeAccount
+	}
+	n, err := note.New(nil, scope, note.StoreTypeShelf, session.Registry, session.Logger)
+	if err != nil {
+		return nil, err
+	}
+	n.OwnerID = s.OwnerID
+	n.StoreID = s.ID
+	return n, nil
+}
+
+// findNote looks up a note by id in every shelf that can be opened
+// Notes in collections aren't searched.
+func (session *Session) findNote(id string) (*shelf.Shelf, *note.Note, error) {
+	noteID, err := uuid.FromString(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid note id [%s]", id)
+	}
+	for _, s := range session.Shelves {
+		notes, err := session.notes(s)
+		if err != nil {
+			session.Logger.Warn("Skipping shelf [", s.ID, "] while finding note - ", err)
+			continue
+		}
+		for _, n := range notes {
+			if n.ID == noteID {
+				return s, n, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("note [%s] not found in any shelf - notes in collections aren't supported", id)
+}
+
+// saveNote saves a note into the shelf holding it
+func (session *Session) saveNote(s *shelf.Shelf, n *note.Note) error {
+	key, err := session.API.ShelfKey(session.Account, s.Scope)
+	if err != nil {
+		return err
+	}
+	return n.Save(key)
+}
+
+// bucketMissing reports whether err is because nothing has been saved yet
+func bucketMissing(err error) bool {
+	return err != nil && codes.IsInternalError(err) && codes.ToInternalError(err).Code == codes.ErrorBucketMissing
+}
+
+// readContent reads note content from stdin
+func readContent() (string, error) {
+	data, err := ioutil.ReadAll(stdin)
+	return string(data), err
+}
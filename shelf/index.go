@@ -61,6 +61,11 @@ func (index *Index) Save(shelf *Shelf, encryptionKey []byte) error {
 			return code
 		}
 
+		err = index.checkUnlocked(bucket, encryptionKey, shelf.ID, shelf)
+		if err != nil {
+			return err
+		}
+
 		// serialize shelf data
 		data, err := json.Marshal(shelf)
 		if err != nil {
@@ -100,6 +105,56 @@ func (index *Index) Save(shelf *Shelf, encryptionKey []byte) error {
 	return nil
 }
 
+// Load a single shelf from the index
+func (index *Index) Load(id uuid.UUID, encryptionKey []byte) (*Shelf, error) {
+	handle, err := index.getDBHandle()
+	if err != nil {
+		return nil, err
+	}
+
+	shelf := &Shelf{
+		OwnerID:    index.OwnerID,
+		DBRegistry: index.DBRegistry,
+		Logger:     index.Logger,
+	}
+	err = handle.DB.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("shelf_index"))
+		if bucket == nil {
+			index.Logger.Warn("shelf bucket does not exist")
+			code := codes.New(codes.ScopeShelf, codes.ErrorBucketMissing)
+			return code
+		}
+
+		value := bucket.Get(id.Bytes())
+		if value == nil {
+			index.Logger.Debug("Shelf [", id, "] is not in the index")
+			code := codes.New(codes.ScopeShelf, codes.ErrorRecordMissing)
+			return code
+		}
+
+		c := crypto.New(index.Logger)
+		decryptedData, err := c.Open(encryptionKey, value)
+		if err != nil {
+			index.Logger.Warn("Error decrypting shelf data - ", err)
+			code := codes.New(codes.ScopeShelf, codes.ErrorDecrypt)
+			return code
+		}
+
+		err = json.Unmarshal(decryptedData, shelf)
+		if err != nil {
+			index.Logger.Warn("Error decoding shelf json - ", err)
+			code := codes.New(codes.ScopeShelf, codes.ErrorDecode)
+			return code
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shelf, nil
+}
+
 // LoadAll of the shelves from an account or user DB
 func (index *Index) LoadAll(passphraseKey []byte) error {
 	handle, err := index.getDBHandle()
@@ -158,7 +213,7 @@ func (index *Index) LoadAll(passphraseKey []byte) error {
 }
 
 // Delete a shelf from the index
-func (index *Index) Delete(shelf *Shelf) error {
+func (index *Index) Delete(shelf *Shelf, encryptionKey []byte) error {
 	handle, err := index.getDBHandle()
 	if err != nil {
 		return err
@@ -171,7 +226,12 @@ func (index *Index) Delete(shelf *Shelf) error {
 			return code
 		}
 
-		err := bucket.Delete(shelf.ID.Bytes())
+		err := index.checkUnlocked(bucket, encryptionKey, shelf.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		err = bucket.Delete(shelf.ID.Bytes())
 		if err != nil {
 			index.Logger.Warn("Error deleting shelf - ", err)
 			code := codes.New(codes.ScopeShelf, codes.ErrorDelete)
@@ -0,0 +1,101 @@
+package shelf
+
+import (
+	"encoding/json"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/lock"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// UnsealKey opens the encryption key of the db holding the shelf index
+func (index *Index) UnsealKey(passphraseKey []byte) ([]byte, error) {
+	handle, err := index.getDBHandle()
+	if err != nil {
+		return nil, err
+	}
+	c := crypto.New(index.Logger)
+	indexKey, err := c.Open(passphraseKey, handle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening shelf key - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorOpenKey)
+		return nil, code
+	}
+	return indexKey, nil
+}
+
+// checkUnlocked fails with ErrorLocked when a shelf can't be changed
+// A locked shelf can still be saved in order to unlock it. current is nil for
+// a delete.
+func (index *Index) checkUnlocked(bucket *bbolt.Bucket, indexKey []byte, id uuid.UUID, current *Shelf) error {
+	value := bucket.Get(id.Bytes())
+	if value == nil {
+		return nil
+	}
+	c := crypto.New(index.Logger)
+	decryptedData, err := c.Open(indexKey, value)
+	if err != nil {
+		index.Logger.Warn("Error decrypting shelf data - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorDecrypt)
+		return code
+	}
+	existing := &Shelf{}
+	err = json.Unmarshal(decryptedData, existing)
+	if err != nil {
+		index.Logger.Warn("Error decoding shelf json - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorDecode)
+		return code
+	}
+	if existing.Locked && (current == nil || current.Locked) {
+		index.Logger.Info("Shelf [", id, "] is locked")
+		code := codes.NewApplication(codes.ScopeShelf, codes.ErrorLocked)
+		return code
+	}
+	return nil
+}
+
+// SaveLock records the lock state of a shelf for everything stored inside of it
+// The shelf record lives in the index of the owner's db, so the state is copied
+// into the locks bucket of the shelf db & of each of the shelf's collections,
+// where the notebooks & notes they hold inherit it. This should be called
+// whenever the shelf is saved with a different lock state. The shelf &
+// collection dbs are opened with the owner key of the shelf scope.
+func (index *Index) SaveLock(shelf *Shelf, ownerKey []byte) error {
+	handle, err := index.DBRegistry.GetHandle(db.Key{ID: shelf.ID, Type: db.TypeShelf})
+	if err != nil {
+		return err
+	}
+	c := crypto.New(index.Logger)
+	shelfKey, err := c.Open(ownerKey, handle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening shelf key - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorOpenKey)
+		return code
+	}
+
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		return lock.Save(tx, shelfKey, shelf.ID, &lock.Lock{Locked: shelf.Locked}, index.Logger)
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		index.Logger.Warn("Error saving shelf lock - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorSave)
+		return code
+	}
+
+	scope := collection.ScopeUser
+	if shelf.Scope == ScopeAccount {
+		scope = collection.ScopeAccount
+	}
+	collections := collection.NewIndex(scope, index.DBRegistry, index.Logger)
+	collections.ShelfID = shelf.ID
+	collections.OwnerID = index.OwnerID
+	return collections.SaveLocks(shelf.Locked, ownerKey)
+}
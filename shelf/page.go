@@ -0,0 +1,74 @@
+package shelf
+
+import (
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/query"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ItemID returns the shelf id for sorting & paging
+func (shelf *Shelf) ItemID() uuid.UUID {
+	return shelf.ID
+}
+
+// ItemTitle returns the shelf title text for sorting
+func (shelf *Shelf) ItemTitle() string {
+	if shelf.Title == nil {
+		return ""
+	}
+	return shelf.Title.Title
+}
+
+// ItemCreated returns the creation time for sorting
+func (shelf *Shelf) ItemCreated() time.Time {
+	return shelf.Created
+}
+
+// ItemUpdated returns the update time for sorting & date filters
+func (shelf *Shelf) ItemUpdated() time.Time {
+	return shelf.Updated
+}
+
+// ItemLocked returns whether the shelf is locked for the locked filter
+func (shelf *Shelf) ItemLocked() bool {
+	return shelf.Locked
+}
+
+// ItemTags returns the ids of the tags stored with the shelf for the tag filter
+func (shelf *Shelf) ItemTags() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, t := range shelf.Tags {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// LoadPage loads a sorted & filtered page of shelves into the index
+// The shelf stats are refreshed as they are loaded. The cursor for the next
+// page is returned (empty on the last page).
+func (index *Index) LoadPage(q *query.Query, passphraseKey, ownerKey []byte) (string, error) {
+	err := index.RefreshStats(passphraseKey, ownerKey)
+	if err != nil {
+		return "", err
+	}
+
+	items := make([]query.Item, 0, len(index.Shelves))
+	for _, s := range index.Shelves {
+		items = append(items, s)
+	}
+	page, err := q.Apply(items)
+	if err != nil {
+		index.Logger.Warn("Error applying shelf query - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorDecode)
+		return "", code
+	}
+
+	index.Shelves = make([]*Shelf, 0, len(page.Items))
+	for _, item := range page.Items {
+		index.Shelves = append(index.Shelves, item.(*Shelf))
+	}
+	return page.Next, nil
+}
@@ -6,6 +6,7 @@ import (
 	"notekeeper-electron-backend/collection"
 	"notekeeper-electron-backend/db"
 	"notekeeper-electron-backend/notebook"
+	"notekeeper-electron-backend/stats"
 	"notekeeper-electron-backend/tag"
 	"notekeeper-electron-backend/title"
 
@@ -38,6 +39,7 @@ type Shelf struct {
 	Created      time.Time                `json:"created"`        // Created is the time when the shelf was created
 	Updated      time.Time                `json:"updated"`        // Updated is the time when the shelf was last updated
 	Locked       bool                     `json:"locked"`         // Locked indicates whether the shelf can be modified
+	Stats        *stats.Stats             `json:"stats"`          // Stats is the last known summary of the notes in the shelf & its collections
 	DBRegistry   *db.Registry             `json:"-"`              // DBRegistry provides access to the database
 	Logger       *logrus.Logger           `json:"-"`              // Logger is the logging facility
 }
@@ -1,9 +1,272 @@
 package shelf
 
 import (
+	"flag"
+	"os"
 	"testing"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/note"
+	"notekeeper-electron-backend/title"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 )
 
+var harness struct {
+	logger        *logrus.Logger
+	registry      *db.Registry
+	hook          *test.Hook
+	ownerID       uuid.UUID
+	passphraseKey []byte
+	ownerKey      []byte
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+func setup(t *testing.T) {
+	harness.logger, harness.hook = test.NewNullLogger()
+	harness.registry = db.NewRegistry(harness.logger)
+	harness.ownerID = uuid.NewV4()
+	harness.passphraseKey = []byte("passphrase key")
+	harness.ownerKey = []byte("owner key")
+
+	err := harness.registry.OpenMaster("./")
+	if err != nil {
+		t.Fatal("Failed to open master db - ", err)
+	}
+	createDB(t, db.Key{ID: harness.ownerID, Type: db.TypeUser}, harness.passphraseKey)
+}
+
+// createDB creates a db with its own key, sealed with sealKey
+// The user db is sealed with the passphrase key, while shelf & collection dbs
+// are sealed with the owner key.
+func createDB(t *testing.T, key db.Key, sealKey []byte) []byte {
+	handle, err := harness.registry.NewHandle(key)
+	if err != nil {
+		t.Fatal("Failed to create db - ", err)
+	}
+	c := crypto.New(harness.logger)
+	dbKey, err := c.GenerateKey()
+	if err != nil {
+		t.Fatal("Failed to generate db key - ", err)
+	}
+	handle.EncryptedKey, err = c.Seal(sealKey, dbKey[:])
+	if err != nil {
+		t.Fatal("Failed to seal db key - ", err)
+	}
+	return dbKey[:]
+}
+
+func teardown(t *testing.T) {
+	for _, handle := range harness.registry.Handles {
+		err := handle.Close()
+		if err != nil {
+			t.Error("Failed to close db - ", err)
+		}
+		err = os.Remove(handle.Info.Filename)
+		if err != nil {
+			t.Error("Failed to cleanup db - ", err)
+		}
+	}
+
+	harness.hook.Reset()
+}
+
+func createNote(t *testing.T, content string, store note.StoreType, storeID uuid.UUID, key []byte) *note.Note {
+	n, err := note.New(title.New(content), note.ScopeUser, store, harness.registry, harness.logger)
+	if err != nil {
+		t.Fatal("Expected to create note - ", err)
+	}
+	n.OwnerID = harness.ownerID
+	n.StoreID = storeID
+	n.NotebookID = uuid.NewV4()
+	n.Content = content
+	err = n.Save(key)
+	if err != nil {
+		t.Fatal("Expected to save note - ", err)
+	}
+	return n
+}
+
 func TestShelf(t *testing.T) {
+	setup(t)
+
+	testStats(t)
+	testLocks(t)
+
+	teardown(t)
+}
+
+func testStats(t *testing.T) {
+	userKey, err := harness.registry.GetHandle(db.Key{ID: harness.ownerID, Type: db.TypeUser})
+	if err != nil {
+		t.Fatal("Expected user db - ", err)
+	}
+	c := crypto.New(harness.logger)
+	indexKey, err := c.Open(harness.passphraseKey, userKey.EncryptedKey)
+	if err != nil {
+		t.Fatal("Expected to open user key - ", err)
+	}
+
+	s, _ := New(title.New("shelf"), ScopeUser, harness.registry, harness.logger)
+	createDB(t, db.Key{ID: s.ID, Type: db.TypeShelf}, harness.ownerKey)
+	index := NewIndex(ScopeUser, harness.ownerID, harness.registry, harness.logger)
+	err = index.Save(s, indexKey)
+	if err != nil {
+		t.Fatal("Expected to save shelf - ", err)
+	}
+
+	col, _ := collection.New(title.New("collection"), collection.ScopeUser, harness.registry, harness.logger)
+	col.ShelfID = s.ID
+	createDB(t, db.Key{ID: col.ID, Type: db.TypeCollection}, harness.ownerKey)
+	collections := collection.NewIndex(collection.ScopeUser, harness.registry, harness.logger)
+	collections.ShelfID = s.ID
+	err = collections.Save(col, harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to save collection - ", err)
+	}
+
+	createNote(t, "12345", note.StoreTypeShelf, s.ID, harness.ownerKey)
+	deleted := createNote(t, "1234567890", note.StoreTypeShelf, s.ID, harness.ownerKey)
+	edited := createNote(t, "123", note.StoreTypeCollection, col.ID, harness.ownerKey)
+	edited.Content = "1234"
+	err = edited.Save(harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to save note - ", err)
+	}
+	err = deleted.Delete(harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to delete note - ", err)
+	}
+
+	err = index.RefreshStats(harness.passphraseKey, harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to refresh shelf stats - ", err)
+	}
+	if len(index.Shelves) != 1 || index.Shelves[0].Stats == nil {
+		t.Fatal("Expected a shelf with stats")
+	}
+	total := index.Shelves[0].Stats
+	if total.NoteCount != 2 || total.ContentBytes != 9 {
+		t.Error("Expected shelf stats of 2 notes / 9 bytes but got ", total.NoteCount, " / ", total.ContentBytes)
+	}
+	if len(index.Shelves[0].Collections) != 1 {
+		t.Fatal("Expected the shelf collections to be loaded")
+	}
+	if cs := index.Shelves[0].Collections[0].Stats; cs == nil || cs.NoteCount != 1 || cs.ContentBytes != 4 {
+		t.Error("Expected collection stats of 1 note / 4 bytes")
+	}
+
+	// the refreshed stats are stored in the index record
+	reloaded := NewIndex(ScopeUser, harness.ownerID, harness.registry, harness.logger)
+	err = reloaded.LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load shelves - ", err)
+	}
+	if len(reloaded.Shelves) != 1 || reloaded.Shelves[0].Stats == nil || reloaded.Shelves[0].Stats.NoteCount != 2 {
+		t.Error("Expected the index record to keep the refreshed stats")
+	}
+}
+
+func expectLocked(t *testing.T, err error, what string) {
+	if err == nil || codes.ToInternalError(err).Code != codes.ErrorLocked {
+		t.Error("Expected ", what, " to fail with ErrorLocked - ", err)
+	}
+}
+
+func testLocks(t *testing.T) {
+	index := NewIndex(ScopeUser, harness.ownerID, harness.registry, harness.logger)
+	indexKey, err := index.UnsealKey(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to open user key - ", err)
+	}
+
+	s, _ := New(title.New("locked shelf"), ScopeUser, harness.registry, harness.logger)
+	createDB(t, db.Key{ID: s.ID, Type: db.TypeShelf}, harness.ownerKey)
+	err = index.Save(s, indexKey)
+	if err != nil {
+		t.Fatal("Expected to save shelf - ", err)
+	}
+	col, _ := collection.New(title.New("collection"), collection.ScopeUser, harness.registry, harness.logger)
+	col.ShelfID = s.ID
+	createDB(t, db.Key{ID: col.ID, Type: db.TypeCollection}, harness.ownerKey)
+	collections := collection.NewIndex(collection.ScopeUser, harness.registry, harness.logger)
+	collections.ShelfID = s.ID
+	err = collections.Save(col, harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to save collection - ", err)
+	}
+	shelfNote := createNote(t, "shelf note", note.StoreTypeShelf, s.ID, harness.ownerKey)
+	collectionNote := createNote(t, "collection note", note.StoreTypeCollection, col.ID, harness.ownerKey)
+
+	// locking a shelf locks the shelf record & everything inside of it
+	s.Locked = true
+	err = index.Save(s, indexKey)
+	if err != nil {
+		t.Fatal("Expected to lock shelf - ", err)
+	}
+	err = index.SaveLock(s, harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to pass the shelf lock on - ", err)
+	}
+	s.Title = title.New("renamed")
+	expectLocked(t, index.Save(s, indexKey), "saving a locked shelf")
+	expectLocked(t, index.Delete(s, indexKey), "deleting a locked shelf")
+	expectLocked(t, collections.Save(col, harness.ownerKey), "saving a collection in a locked shelf")
+	expectLocked(t, collections.Delete(col, harness.ownerKey), "deleting a collection in a locked shelf")
+	expectLocked(t, shelfNote.Save(harness.ownerKey), "saving a note in a locked shelf")
+	expectLocked(t, collectionNote.Save(harness.ownerKey), "saving a note in a collection of a locked shelf")
+	if !codes.IsApplicationError(shelfNote.Delete(harness.ownerKey)) {
+		t.Error("Expected deleting a note in a locked shelf to be an application error")
+	}
+
+	s.Locked = false
+	err = index.Save(s, indexKey)
+	if err != nil {
+		t.Fatal("Expected to unlock shelf - ", err)
+	}
+	err = index.SaveLock(s, harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to pass the shelf lock on - ", err)
+	}
+	err = collectionNote.Save(harness.ownerKey)
+	if err != nil {
+		t.Error("Expected to save a note once the shelf is unlocked - ", err)
+	}
+
+	// locking a collection locks the notes inside of it, but not the shelf
+	col.Locked = true
+	err = collections.Save(col, harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to lock collection - ", err)
+	}
+	col.Title = title.New("renamed")
+	expectLocked(t, collections.Save(col, harness.ownerKey), "saving a locked collection")
+	expectLocked(t, collections.Delete(col, harness.ownerKey), "deleting a locked collection")
+	expectLocked(t, collectionNote.Save(harness.ownerKey), "saving a note in a locked collection")
+	err = shelfNote.Save(harness.ownerKey)
+	if err != nil {
+		t.Error("Expected to save a note in the shelf - ", err)
+	}
 
+	col.Locked = false
+	err = collections.Save(col, harness.ownerKey)
+	if err != nil {
+		t.Fatal("Expected to unlock collection - ", err)
+	}
+	err = collectionNote.Delete(harness.ownerKey)
+	if err != nil {
+		t.Error("Expected to delete a note in an unlocked collection - ", err)
+	}
 }
@@ -0,0 +1,145 @@
+package shelf
+
+import (
+	"encoding/json"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/collection"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/stats"
+
+	"go.etcd.io/bbolt"
+)
+
+// loadStats totals the note stats of a shelf & the collections stored in it
+// A nil result means the shelf db isn't open, so its stats can't be read. The
+// shelf & collection dbs are opened with the owner key of the shelf scope.
+func (index *Index) loadStats(shelf *Shelf, ownerKey []byte) (*stats.Stats, error) {
+	handle, err := index.DBRegistry.GetHandle(db.Key{ID: shelf.ID, Type: db.TypeShelf})
+	if err != nil {
+		return nil, nil
+	}
+	c := crypto.New(index.Logger)
+	shelfKey, err := c.Open(ownerKey, handle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening shelf key - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorOpenKey)
+		return nil, code
+	}
+	total, err := stats.LoadContainer(handle.DB, shelfKey, shelf.ID, index.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := collection.ScopeUser
+	if shelf.Scope == ScopeAccount {
+		scope = collection.ScopeAccount
+	}
+	collections := collection.NewIndex(scope, index.DBRegistry, index.Logger)
+	collections.ShelfID = shelf.ID
+	collections.OwnerID = index.OwnerID
+	err = collections.RefreshStats(ownerKey)
+	if err != nil {
+		return nil, err
+	}
+	shelf.Collections = collections.Collections
+	for _, col := range collections.Collections {
+		if col.Stats != nil {
+			total.Add(col.Stats)
+		}
+	}
+	return total, nil
+}
+
+// RefreshStats loads the shelves in the index & updates their note stats
+// The stats kept in each shelf & collection db are authoritative - the copy in
+// the index record is brought up to date here so it can be listed without
+// opening every shelf. Shelves whose db isn't open keep their last known stats.
+// The index is opened with the passphrase key & the shelves with the owner key.
+func (index *Index) RefreshStats(passphraseKey, ownerKey []byte) error {
+	handle, err := index.getDBHandle()
+	if err != nil {
+		return err
+	}
+	c := crypto.New(index.Logger)
+	indexKey, err := c.Open(passphraseKey, handle.EncryptedKey)
+	if err != nil {
+		index.Logger.Warn("Error opening shelf key - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorOpenKey)
+		return code
+	}
+
+	index.Shelves = nil
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("shelf_index"))
+		if bucket == nil {
+			index.Logger.Warn("shelf bucket does not exist")
+			code := codes.New(codes.ScopeShelf, codes.ErrorBucketMissing)
+			return code
+		}
+
+		cursor := bucket.Cursor()
+		for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+			newShelf := &Shelf{
+				OwnerID:    index.OwnerID,
+				DBRegistry: index.DBRegistry,
+				Logger:     index.Logger,
+			}
+			decryptedData, err := c.Open(indexKey, value)
+			if err != nil {
+				index.Logger.Warn("Error decrypting shelf data - ", err)
+				code := codes.New(codes.ScopeShelf, codes.ErrorDecrypt)
+				return code
+			}
+			err = json.Unmarshal(decryptedData, newShelf)
+			if err != nil {
+				index.Logger.Warn("Error decoding shelf json - ", err)
+				code := codes.New(codes.ScopeShelf, codes.ErrorDecode)
+				return code
+			}
+			index.Shelves = append(index.Shelves, newShelf)
+		}
+
+		// records are rewritten after the cursor is done with the bucket
+		for _, shelf := range index.Shelves {
+			current, err := index.loadStats(shelf, ownerKey)
+			if err != nil {
+				return err
+			}
+			if current == nil {
+				continue
+			}
+			shelf.Stats = current
+
+			data, err := json.Marshal(shelf)
+			if err != nil {
+				index.Logger.Warn("Error marshaling shelf - ", err)
+				code := codes.New(codes.ScopeShelf, codes.ErrorMarshal)
+				return code
+			}
+			encryptedData, err := c.Seal(indexKey, data)
+			if err != nil {
+				index.Logger.Warn("Error encrypting shelf data - ", err)
+				code := codes.New(codes.ScopeShelf, codes.ErrorEncrypt)
+				return code
+			}
+			err = bucket.Put(shelf.ID.Bytes(), encryptedData)
+			if err != nil {
+				index.Logger.Warn("Error writing shelf - ", err)
+				code := codes.New(codes.ScopeShelf, codes.ErrorWriteBucket)
+				return code
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		index.Logger.Warn("Error refreshing shelf stats - ", err)
+		code := codes.New(codes.ScopeShelf, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package stats
+
+import (
+	"encoding/json"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+)
+
+// Bucket is the name of the bucket that stats are kept in
+// Every shelf & collection db has a stats bucket holding a record for the
+// container itself (keyed by the container id) and one for each notebook
+// stored in it (keyed by the notebook id). The records are updated in the same
+// transaction as the notes they describe, so they can't drift out of sync.
+const Bucket = "stats"
+
+// Stats is a summary of the notes held by a notebook or container
+type Stats struct {
+	NoteCount    int       `json:"note_count"`    // NoteCount is the number of notes
+	ContentBytes int64     `json:"content_bytes"` // ContentBytes is the total size of the note content
+	LastModified time.Time `json:"last_modified"` // LastModified is the last time that a note was changed
+}
+
+// Add folds another set of stats into this one
+func (stats *Stats) Add(other *Stats) {
+	stats.NoteCount += other.NoteCount
+	stats.ContentBytes += other.ContentBytes
+	if other.LastModified.After(stats.LastModified) {
+		stats.LastModified = other.LastModified
+	}
+}
+
+// Load reads the stats for id from an open transaction
+// A record that doesn't exist yet is returned as empty stats.
+func Load(tx *bbolt.Tx, encryptionKey []byte, id uuid.UUID, logger *logrus.Logger) (*Stats, error) {
+	stats := &Stats{}
+	bucket := tx.Bucket([]byte(Bucket))
+	if bucket == nil {
+		return stats, nil
+	}
+	value := bucket.Get(id.Bytes())
+	if value == nil {
+		return stats, nil
+	}
+
+	c := crypto.New(logger)
+	decryptedData, err := c.Open(encryptionKey, value)
+	if err != nil {
+		logger.Warn("Error decrypting stats - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorDecrypt)
+		return nil, code
+	}
+	err = json.Unmarshal(decryptedData, stats)
+	if err != nil {
+		logger.Warn("Error decoding stats json - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorDecode)
+		return nil, code
+	}
+	return stats, nil
+}
+
+// Save writes the stats for id in an open transaction
+func Save(tx *bbolt.Tx, encryptionKey []byte, id uuid.UUID, stats *Stats, logger *logrus.Logger) error {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(Bucket))
+	if err != nil {
+		logger.Warn("Error creating stats bucket - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorCreateBucket)
+		return code
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		logger.Warn("Error marshaling stats - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorMarshal)
+		return code
+	}
+	c := crypto.New(logger)
+	encryptedData, err := c.Seal(encryptionKey, data)
+	if err != nil {
+		logger.Warn("Error encrypting stats - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorEncrypt)
+		return code
+	}
+	err = bucket.Put(id.Bytes(), encryptedData)
+	if err != nil {
+		logger.Warn("Error writing stats - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorWriteBucket)
+		return code
+	}
+	return nil
+}
+
+// Adjust changes the stats for id by the given number of notes & bytes
+func Adjust(tx *bbolt.Tx, encryptionKey []byte, id uuid.UUID, notes int, bytes int64, logger *logrus.Logger) error {
+	if id == uuid.Nil {
+		return nil
+	}
+	stats, err := Load(tx, encryptionKey, id, logger)
+	if err != nil {
+		return err
+	}
+	stats.NoteCount += notes
+	if stats.NoteCount < 0 {
+		stats.NoteCount = 0
+	}
+	stats.ContentBytes += bytes
+	if stats.ContentBytes < 0 {
+		stats.ContentBytes = 0
+	}
+	stats.LastModified = time.Now()
+	return Save(tx, encryptionKey, id, stats, logger)
+}
+
+// Delete removes the stats for id in an open transaction
+func Delete(tx *bbolt.Tx, id uuid.UUID, logger *logrus.Logger) error {
+	bucket := tx.Bucket([]byte(Bucket))
+	if bucket == nil {
+		return nil
+	}
+	err := bucket.Delete(id.Bytes())
+	if err != nil {
+		logger.Warn("Error deleting stats - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorDelete)
+		return code
+	}
+	return nil
+}
+
+// LoadContainer reads the stats for a container from its own db
+func LoadContainer(handle *bbolt.DB, encryptionKey []byte, id uuid.UUID, logger *logrus.Logger) (*Stats, error) {
+	var stats *Stats
+	err := handle.View(func(tx *bbolt.Tx) error {
+		var err error
+		stats, err = Load(tx, encryptionKey, id, logger)
+		return err
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return nil, err
+		}
+		logger.Warn("Error loading stats - ", err)
+		code := codes.New(codes.ScopeStats, codes.ErrorLoad)
+		return nil, code
+	}
+	return stats, nil
+}
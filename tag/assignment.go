@@ -0,0 +1,251 @@
+package tag
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// ObjectType is the type of object a tag is assigned to
+type ObjectType int
+
+const (
+	// ObjectNote indicates that a tag is assigned to a note
+	ObjectNote ObjectType = iota
+	// ObjectNotebook indicates that a tag is assigned to a notebook
+	ObjectNotebook
+	// ObjectCollection indicates that a tag is assigned to a collection
+	ObjectCollection
+	// ObjectShelf indicates that a tag is assigned to a shelf
+	ObjectShelf
+)
+
+// Assignment links a tag to a tagged object
+// Assignments are stored in the same db as the tag, keyed by the tag id followed
+// by the object id so that all of the assignments for a tag can be found with a
+// single prefix scan.
+type Assignment struct {
+	TagID      uuid.UUID  `json:"tag_id"`      // TagID is the tag being assigned
+	ObjectID   uuid.UUID  `json:"object_id"`   // ObjectID is the id of the tagged object
+	ObjectType ObjectType `json:"object_type"` // ObjectType is the type of the tagged object
+	StoreID    uuid.UUID  `json:"store_id"`    // StoreID is the shelf or collection holding the tagged object
+}
+
+var objectTypeNames = map[string]ObjectType{
+	"note":       ObjectNote,
+	"notebook":   ObjectNotebook,
+	"collection": ObjectCollection,
+	"shelf":      ObjectShelf,
+}
+
+// ObjectTypeFromString converts the name of an object type (e.g. "note") to the object type
+func ObjectTypeFromString(s string) (ObjectType, bool) {
+	t, ok := objectTypeNames[s]
+	return t, ok
+}
+
+func assignmentKey(tagID uuid.UUID, objectID uuid.UUID) []byte {
+	key := make([]byte, 0, uuid.Size*2)
+	key = append(key, tagID.Bytes()...)
+	key = append(key, objectID.Bytes()...)
+	return key
+}
+
+// Assign the tag to an object
+func (tag *Tag) Assign(assignment *Assignment, passphraseKey []byte) error {
+	assignment.TagID = tag.ID
+	return tag.update(passphraseKey, func(tx *bbolt.Tx, bucket *bbolt.Bucket, tagKey []byte, tags []*Tag) error {
+		if findTag(tags, tag.ID) == nil {
+			tag.Logger.Warn("Error finding tag to assign [", tag.ID, "]")
+			code := codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+			return code
+		}
+		assignments, err := tx.CreateBucketIfNotExists([]byte("tag_assignments"))
+		if err != nil {
+			tag.Logger.Warn("Error creating tag assignment bucket - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorCreateBucket)
+			return code
+		}
+		return tag.writeAssignment(assignments, assignment, tagKey)
+	})
+}
+
+// Unassign the tag from an object
+func (tag *Tag) Unassign(objectID uuid.UUID, passphraseKey []byte) error {
+	return tag.update(passphraseKey, func(tx *bbolt.Tx, bucket *bbolt.Bucket, tagKey []byte, tags []*Tag) error {
+		assignments := tx.Bucket([]byte("tag_assignments"))
+		if assignments == nil {
+			return nil
+		}
+		err := assignments.Delete(assignmentKey(tag.ID, objectID))
+		if err != nil {
+			tag.Logger.Warn("Error deleting tag assignment - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDelete)
+			return code
+		}
+		return nil
+	})
+}
+
+// Assignments returns the objects that the tag is assigned to
+// When descendants is true, assignments of every tag below this one are
+// included as well, so asking for "project" also returns "project/alpha".
+func (tag *Tag) Assignments(descendants bool, passphraseKey []byte) ([]*Assignment, error) {
+	var result []*Assignment
+	handle, err := tag.getDBHandle()
+	if err != nil {
+		return result, err
+	}
+	c := crypto.New(tag.Logger)
+	tagKey, err := c.Open(passphraseKey, handle.EncryptedKey)
+	if err != nil {
+		tag.Logger.Warn("Error opening tag key - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorOpenKey)
+		return result, code
+	}
+
+	err = handle.DB.View(func(tx *bbolt.Tx) error {
+		ids := []uuid.UUID{tag.ID}
+		if descendants {
+			bucket := tx.Bucket([]byte("tags"))
+			if bucket == nil {
+				tag.Logger.Warn("tag bucket does not exist")
+				code := codes.New(codes.ScopeTag, codes.ErrorBucketMissing)
+				return code
+			}
+			tags, err := tag.readAll(bucket, tagKey)
+			if err != nil {
+				return err
+			}
+			for _, t := range Descendants(tags, tag.ID) {
+				ids = append(ids, t.ID)
+			}
+		}
+
+		assignments := tx.Bucket([]byte("tag_assignments"))
+		if assignments == nil {
+			// nothing has been tagged yet
+			return nil
+		}
+		for _, id := range ids {
+			found, err := tag.readAssignments(assignments, id, tagKey)
+			if err != nil {
+				return err
+			}
+			result = append(result, found...)
+		}
+		return nil
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return nil, err
+		}
+		tag.Logger.Warn("Error loading tag assignments - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorLoadAll)
+		return nil, code
+	}
+	return result, nil
+}
+
+func (tag *Tag) readAssignments(bucket *bbolt.Bucket, tagID uuid.UUID, tagKey []byte) ([]*Assignment, error) {
+	var assignments []*Assignment
+	c := crypto.New(tag.Logger)
+	prefix := tagID.Bytes()
+	cursor := bucket.Cursor()
+	for key, value := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, value = cursor.Next() {
+		decryptedData, err := c.Open(tagKey, value)
+		if err != nil {
+			tag.Logger.Warn("Error decrypting tag assignment - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDecrypt)
+			return nil, code
+		}
+		assignment := &Assignment{}
+		err = json.Unmarshal(decryptedData, assignment)
+		if err != nil {
+			tag.Logger.Warn("Error decoding tag assignment json - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDecode)
+			return nil, code
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+func (tag *Tag) writeAssignment(bucket *bbolt.Bucket, assignment *Assignment, tagKey []byte) error {
+	data, err := json.Marshal(assignment)
+	if err != nil {
+		tag.Logger.Warn("Error marshaling tag assignment - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorMarshal)
+		return code
+	}
+	c := crypto.New(tag.Logger)
+	encryptedData, err := c.Seal(tagKey, data)
+	if err != nil {
+		tag.Logger.Warn("Error encrypting tag assignment - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorEncrypt)
+		return code
+	}
+	err = bucket.Put(assignmentKey(assignment.TagID, assignment.ObjectID), encryptedData)
+	if err != nil {
+		tag.Logger.Warn("Error writing tag assignment - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorWriteBucket)
+		return code
+	}
+	return nil
+}
+
+// repointAssignments moves every assignment of one tag onto another tag
+func (tag *Tag) repointAssignments(tx *bbolt.Tx, fromID uuid.UUID, toID uuid.UUID, tagKey []byte) error {
+	bucket := tx.Bucket([]byte("tag_assignments"))
+	if bucket == nil {
+		return nil
+	}
+	assignments, err := tag.readAssignments(bucket, fromID, tagKey)
+	if err != nil {
+		return err
+	}
+	for _, assignment := range assignments {
+		err = bucket.Delete(assignmentKey(fromID, assignment.ObjectID))
+		if err != nil {
+			tag.Logger.Warn("Error deleting tag assignment - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDelete)
+			return code
+		}
+		// an object tagged with both tags just ends up with a single assignment
+		assignment.TagID = toID
+		err = tag.writeAssignment(bucket, assignment, tagKey)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAssignments removes every assignment of a tag
+func (tag *Tag) deleteAssignments(tx *bbolt.Tx, tagID uuid.UUID) error {
+	bucket := tx.Bucket([]byte("tag_assignments"))
+	if bucket == nil {
+		return nil
+	}
+	// collect the keys first as deleting while iterating skips entries
+	var keys [][]byte
+	prefix := tagID.Bytes()
+	cursor := bucket.Cursor()
+	for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+		keys = append(keys, append([]byte{}, key...))
+	}
+	for _, key := range keys {
+		err := bucket.Delete(key)
+		if err != nil {
+			tag.Logger.Warn("Error deleting tag assignment - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDelete)
+			return code
+		}
+	}
+	return nil
+}
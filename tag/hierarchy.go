@@ -0,0 +1,309 @@
+package tag
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+
+	uuid "github.com/satori/go.uuid"
+	"go.etcd.io/bbolt"
+)
+
+// PathSeparator separates tag titles when a tag is displayed with its ancestors
+const PathSeparator = "/"
+
+// Children returns the tags that are directly below parentID
+func Children(tags []*Tag, parentID uuid.UUID) []*Tag {
+	var children []*Tag
+	for _, t := range tags {
+		if t.ParentID == parentID && t.ID != parentID {
+			children = append(children, t)
+		}
+	}
+	return children
+}
+
+// Descendants returns every tag below id in the hierarchy (depth first)
+// The tag identified by id is not included in the result.
+func Descendants(tags []*Tag, id uuid.UUID) []*Tag {
+	var descendants []*Tag
+	visited := map[uuid.UUID]bool{id: true}
+	var walk func(parentID uuid.UUID)
+	walk = func(parentID uuid.UUID) {
+		for _, child := range Children(tags, parentID) {
+			// guard against corrupt records that already form a loop
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			descendants = append(descendants, child)
+			walk(child.ID)
+		}
+	}
+	walk(id)
+	return descendants
+}
+
+// Ancestors returns the chain of parents for a tag, starting with the nearest parent
+// A parent that can't be found in tags ends the chain.
+func Ancestors(tags []*Tag, t *Tag) []*Tag {
+	var ancestors []*Tag
+	byID := make(map[uuid.UUID]*Tag, len(tags))
+	for _, tag := range tags {
+		byID[tag.ID] = tag
+	}
+	visited := map[uuid.UUID]bool{t.ID: true}
+	parentID := t.ParentID
+	for parentID != uuid.Nil {
+		parent, ok := byID[parentID]
+		if !ok || visited[parent.ID] {
+			break
+		}
+		visited[parent.ID] = true
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentID
+	}
+	return ancestors
+}
+
+// Path returns the full title path of a tag, e.g. "project/alpha/design"
+func Path(tags []*Tag, t *Tag) string {
+	ancestors := Ancestors(tags, t)
+	parts := make([]string, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		parts = append(parts, titleText(ancestors[i]))
+	}
+	parts = append(parts, titleText(t))
+	return strings.Join(parts, PathSeparator)
+}
+
+func titleText(t *Tag) string {
+	if t.Title == nil {
+		return ""
+	}
+	return t.Title.Title
+}
+
+// isDescendant tests whether candidate is id or any tag below id
+func isDescendant(tags []*Tag, id uuid.UUID, candidate uuid.UUID) bool {
+	if id == candidate {
+		return true
+	}
+	for _, t := range Descendants(tags, id) {
+		if t.ID == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func findTag(tags []*Tag, id uuid.UUID) *Tag {
+	for _, t := range tags {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// LoadDescendants loads the tag along with all of the tags below it
+// The tag itself is the first entry in the result.
+func (tag *Tag) LoadDescendants(passphraseKey []byte) ([]*Tag, error) {
+	tags, err := tag.LoadAll(passphraseKey)
+	if err != nil {
+		return nil, err
+	}
+	root := findTag(tags, tag.ID)
+	if root == nil {
+		tag.Logger.Warn("Error finding tag [", tag.ID, "]")
+		code := codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+		return nil, code
+	}
+	result := []*Tag{root}
+	result = append(result, Descendants(tags, tag.ID)...)
+	return result, nil
+}
+
+// readAll decodes every tag in the tag bucket of an open transaction
+func (tag *Tag) readAll(bucket *bbolt.Bucket, tagKey []byte) ([]*Tag, error) {
+	var tags []*Tag
+	c := crypto.New(tag.Logger)
+	cursor := bucket.Cursor()
+	for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+		newTag := &Tag{
+			OwnerID:    tag.OwnerID,
+			DBRegistry: tag.DBRegistry,
+			Logger:     tag.Logger,
+		}
+
+		decryptedData, err := c.Open(tagKey, value)
+		if err != nil {
+			tag.Logger.Warn("Error decrypting tag data - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDecrypt)
+			return nil, code
+		}
+
+		err = json.Unmarshal(decryptedData, newTag)
+		if err != nil {
+			tag.Logger.Warn("Error decoding tag json - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDecode)
+			return nil, code
+		}
+		tags = append(tags, newTag)
+	}
+	return tags, nil
+}
+
+// write encodes a single tag into the tag bucket of an open transaction
+func (tag *Tag) write(bucket *bbolt.Bucket, t *Tag, tagKey []byte) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		tag.Logger.Warn("Error marshaling tag - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorMarshal)
+		return code
+	}
+
+	c := crypto.New(tag.Logger)
+	encryptedData, err := c.Seal(tagKey, data)
+	if err != nil {
+		tag.Logger.Warn("Error encrypting tag data - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorEncrypt)
+		return code
+	}
+
+	err = bucket.Put(t.ID.Bytes(), encryptedData)
+	if err != nil {
+		tag.Logger.Warn("Error writing tag - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorWriteBucket)
+		return code
+	}
+	return nil
+}
+
+// update runs fn inside of a single write transaction on the tag db
+// fn receives the tag bucket, the unsealed tag db key and the full set of tags
+func (tag *Tag) update(passphraseKey []byte, fn func(*bbolt.Tx, *bbolt.Bucket, []byte, []*Tag) error) error {
+	handle, err := tag.getDBHandle()
+	if err != nil {
+		return err
+	}
+	c := crypto.New(tag.Logger)
+	tagKey, err := c.Open(passphraseKey, handle.EncryptedKey)
+	if err != nil {
+		tag.Logger.Warn("Error opening tag key - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorOpenKey)
+		return code
+	}
+
+	err = handle.DB.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("tags"))
+		if bucket == nil {
+			tag.Logger.Warn("tag bucket does not exist")
+			code := codes.New(codes.ScopeTag, codes.ErrorBucketMissing)
+			return code
+		}
+		tags, err := tag.readAll(bucket, tagKey)
+		if err != nil {
+			return err
+		}
+		return fn(tx, bucket, tagKey, tags)
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		tag.Logger.Warn("Error updating tags - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorSave)
+		return code
+	}
+	return nil
+}
+
+// Move re-parents a tag
+// Passing uuid.Nil as the parentID moves the tag to the top level.
+// A tag can't be moved underneath itself or any of its own descendants.
+func (tag *Tag) Move(parentID uuid.UUID, passphraseKey []byte) error {
+	return tag.update(passphraseKey, func(tx *bbolt.Tx, bucket *bbolt.Bucket, tagKey []byte, tags []*Tag) error {
+		source := findTag(tags, tag.ID)
+		if source == nil {
+			tag.Logger.Warn("Error finding tag to move [", tag.ID, "]")
+			code := codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+			return code
+		}
+		if parentID != uuid.Nil {
+			if findTag(tags, parentID) == nil {
+				tag.Logger.Warn("Error finding new parent tag [", parentID, "]")
+				code := codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+				return code
+			}
+			if isDescendant(tags, tag.ID, parentID) {
+				tag.Logger.Warn("Moving tag [", tag.ID, "] below [", parentID, "] would create a cycle")
+				code := codes.New(codes.ScopeTag, codes.ErrorCycle)
+				return code
+			}
+		}
+
+		source.ParentID = parentID
+		source.Updated = time.Now()
+		err := tag.write(bucket, source, tagKey)
+		if err != nil {
+			return err
+		}
+		tag.ParentID = source.ParentID
+		tag.Updated = source.Updated
+		return nil
+	})
+}
+
+// Merge folds this tag into target
+// All of the assignments of this tag are re-pointed at target, any child tags
+// become children of target, and then this tag is deleted. Everything happens
+// in a single transaction so a failure leaves both tags untouched.
+func (tag *Tag) Merge(target *Tag, passphraseKey []byte) error {
+	if tag.ID == target.ID {
+		tag.Logger.Warn("Cannot merge tag [", tag.ID, "] into itself")
+		code := codes.New(codes.ScopeTag, codes.ErrorCycle)
+		return code
+	}
+	return tag.update(passphraseKey, func(tx *bbolt.Tx, bucket *bbolt.Bucket, tagKey []byte, tags []*Tag) error {
+		source := findTag(tags, tag.ID)
+		destination := findTag(tags, target.ID)
+		if source == nil || destination == nil {
+			tag.Logger.Warn("Error finding tags to merge [", tag.ID, "] into [", target.ID, "]")
+			code := codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+			return code
+		}
+		// re-parenting our children onto one of our own descendants would orphan a loop
+		if isDescendant(tags, source.ID, destination.ID) {
+			tag.Logger.Warn("Merging tag [", source.ID, "] into descendant [", destination.ID, "] would create a cycle")
+			code := codes.New(codes.ScopeTag, codes.ErrorCycle)
+			return code
+		}
+
+		now := time.Now()
+		for _, child := range Children(tags, source.ID) {
+			child.ParentID = destination.ID
+			child.Updated = now
+			err := tag.write(bucket, child, tagKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := tag.repointAssignments(tx, source.ID, destination.ID, tagKey)
+		if err != nil {
+			return err
+		}
+
+		err = bucket.Delete(source.ID.Bytes())
+		if err != nil {
+			tag.Logger.Warn("Error deleting merged tag - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDelete)
+			return code
+		}
+		return nil
+	})
+}
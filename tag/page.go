@@ -0,0 +1,53 @@
+package tag
+
+import (
+	"time"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/query"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ItemID returns the tag id for sorting & paging
+func (tag *Tag) ItemID() uuid.UUID {
+	return tag.ID
+}
+
+// ItemTitle returns the tag title text for sorting
+func (tag *Tag) ItemTitle() string {
+	return titleText(tag)
+}
+
+// ItemCreated returns the creation time for sorting
+func (tag *Tag) ItemCreated() time.Time {
+	return tag.Created
+}
+
+// ItemUpdated returns the update time for sorting & date filters
+func (tag *Tag) ItemUpdated() time.Time {
+	return tag.Updated
+}
+
+// Page sorts, filters & pages a set of tags loaded with LoadAll
+// Tags are paged separately from loading because the complete set is still
+// needed to build the path of each tag on the page. The cursor for the next
+// page is returned along with the tags (empty on the last page).
+func (tag *Tag) Page(tags []*Tag, q *query.Query) ([]*Tag, string, error) {
+	items := make([]query.Item, 0, len(tags))
+	for _, t := range tags {
+		items = append(items, t)
+	}
+	page, err := q.Apply(items)
+	if err != nil {
+		tag.Logger.Warn("Error applying tag query - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorDecode)
+		return nil, "", code
+	}
+
+	result := make([]*Tag, 0, len(page.Items))
+	for _, item := range page.Items {
+		result = append(result, item.(*Tag))
+	}
+	return result, page.Next, nil
+}
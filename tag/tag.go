@@ -28,8 +28,9 @@ const (
 type Tag struct {
 	ID         uuid.UUID      `json:"id"` // ID is the unique identifier of the tag
 	Scope      Scope          `json:"scope"`
-	OwnerID    uuid.UUID      `json:"-"`     // OwnerID is the ID of the account or user owning the shelf
-	Title      *title.Title   `json:"title"` // Title is the title of the tag
+	OwnerID    uuid.UUID      `json:"-"`         // OwnerID is the ID of the account or user owning the shelf
+	ParentID   uuid.UUID      `json:"parent_id"` // ParentID is the ID of the parent tag (uuid.Nil for top level tags)
+	Title      *title.Title   `json:"title"`     // Title is the title of the tag
 	Created    time.Time      `json:"created"`
 	Updated    time.Time      `json:"updated"`
 	DBRegistry *db.Registry   `json:"-"` // DBRegistry provides access to the database
@@ -158,6 +159,7 @@ func (tag *Tag) LoadAll(passphraseKey []byte) ([]*Tag, error) {
 
 		for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
 			newTag := &Tag{
+				OwnerID:    tag.OwnerID,
 				DBRegistry: tag.DBRegistry,
 				Logger:     tag.Logger,
 			}
@@ -186,13 +188,21 @@ func (tag *Tag) LoadAll(passphraseKey []byte) ([]*Tag, error) {
 	return tags, err
 }
 
-// Delete a tag
-func (tag *Tag) Delete(passphraseKey []byte) error {
+// Load a single tag from an account or user DB
+func (tag *Tag) Load(passphraseKey []byte) error {
 	tagDBHandle, err := tag.getDBHandle()
 	if err != nil {
 		return err
 	}
-	err = tagDBHandle.DB.Update(func(tx *bbolt.Tx) error {
+	c := crypto.New(tag.Logger)
+	tagKey, err := c.Open(passphraseKey, tagDBHandle.EncryptedKey)
+	if err != nil {
+		tag.Logger.Warn("Error opening tag key - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorOpenKey)
+		return code
+	}
+
+	err = tagDBHandle.DB.View(func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket([]byte("tags"))
 		if bucket == nil {
 			tag.Logger.Warn("tag bucket does not exist")
@@ -200,24 +210,74 @@ func (tag *Tag) Delete(passphraseKey []byte) error {
 			return code
 		}
 
-		err := bucket.Delete(tag.ID.Bytes())
+		value := bucket.Get(tag.ID.Bytes())
+		if value == nil {
+			tag.Logger.Warn("Error loading tag [", tag.ID, "]")
+			code := codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+			return code
+		}
+
+		decryptedData, err := c.Open(tagKey, value)
 		if err != nil {
-			tag.Logger.Warn("Error deleting tag - ", err)
-			code := codes.New(codes.ScopeTag, codes.ErrorDelete)
+			tag.Logger.Warn("Error decrypting tag data - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDecrypt)
 			return code
 		}
 
+		err = json.Unmarshal(decryptedData, tag)
+		if err != nil {
+			tag.Logger.Warn("Error decoding tag json - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDecode)
+			return code
+		}
 		return nil
 	})
-
 	if err != nil {
 		if codes.IsInternalError(err) {
 			return err
 		}
-		tag.Logger.Warn("Error deleting tag - ", err)
-		code := codes.New(codes.ScopeTag, codes.ErrorDelete)
+		tag.Logger.Warn("Error loading tag - ", err)
+		code := codes.New(codes.ScopeTag, codes.ErrorLoad)
 		return code
 	}
 
 	return nil
 }
+
+// Delete a tag
+// Child tags move up to the deleted tag's parent and the tag's assignments are
+// removed, all in the same transaction so no tag is left pointing at a parent
+// or an assignment that no longer exists.
+func (tag *Tag) Delete(passphraseKey []byte) error {
+	return tag.update(passphraseKey, func(tx *bbolt.Tx, bucket *bbolt.Bucket, tagKey []byte, tags []*Tag) error {
+		existing := findTag(tags, tag.ID)
+		if existing == nil {
+			tag.Logger.Warn("Error finding tag to delete [", tag.ID, "]")
+			code := codes.New(codes.ScopeTag, codes.ErrorRecordMissing)
+			return code
+		}
+
+		now := time.Now()
+		for _, child := range Children(tags, existing.ID) {
+			child.ParentID = existing.ParentID
+			child.Updated = now
+			err := tag.write(bucket, child, tagKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := tag.deleteAssignments(tx, existing.ID)
+		if err != nil {
+			return err
+		}
+
+		err = bucket.Delete(existing.ID.Bytes())
+		if err != nil {
+			tag.Logger.Warn("Error deleting tag - ", err)
+			code := codes.New(codes.ScopeTag, codes.ErrorDelete)
+			return code
+		}
+		return nil
+	})
+}
@@ -1,9 +1,302 @@
 package tag
 
 import (
+	"flag"
+	"os"
 	"testing"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/crypto"
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/title"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 )
 
+var harness struct {
+	logger        *logrus.Logger
+	registry      *db.Registry
+	hook          *test.Hook
+	ownerID       uuid.UUID
+	passphraseKey []byte
+	tags          map[string]*Tag
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+func setup(t *testing.T) {
+	harness.logger, harness.hook = test.NewNullLogger()
+	harness.registry = db.NewRegistry(harness.logger)
+	harness.tags = make(map[string]*Tag)
+
+	err := harness.registry.OpenMaster("./")
+	if err != nil {
+		t.Fatal("Failed to open master db - ", err)
+	}
+
+	// tags live in the user db, which is sealed with a key derived from the passphrase
+	harness.ownerID = uuid.NewV4()
+	handle, err := harness.registry.NewHandle(db.Key{ID: harness.ownerID, Type: db.TypeUser})
+	if err != nil {
+		t.Fatal("Failed to create user db - ", err)
+	}
+	c := crypto.New(harness.logger)
+	userKey, err := c.GenerateKey()
+	if err != nil {
+		t.Fatal("Failed to generate user key - ", err)
+	}
+	harness.passphraseKey = []byte("passphrase key")
+	handle.EncryptedKey, err = c.Seal(harness.passphraseKey, userKey[:])
+	if err != nil {
+		t.Fatal("Failed to seal user key - ", err)
+	}
+}
+
+func teardown(t *testing.T) {
+	for _, handle := range harness.registry.Handles {
+		err := handle.Close()
+		if err != nil {
+			t.Error("Failed to close db - ", err)
+		}
+		err = os.Remove(handle.Info.Filename)
+		if err != nil {
+			t.Error("Failed to cleanup db - ", err)
+		}
+	}
+
+	harness.hook.Reset()
+}
+
+func createTag(t *testing.T, name string, parent string) *Tag {
+	newTag, err := New(title.New(name), ScopeUser, harness.registry, harness.logger)
+	if err != nil {
+		t.Fatal("Expected to create tag - ", err)
+	}
+	newTag.OwnerID = harness.ownerID
+	if parent != "" {
+		newTag.ParentID = harness.tags[parent].ID
+	}
+	err = newTag.Save(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to save tag - ", err)
+	}
+	harness.tags[name] = newTag
+	return newTag
+}
+
+func proxy(name string) *Tag {
+	p, _ := New(nil, ScopeUser, harness.registry, harness.logger)
+	p.ID = harness.tags[name].ID
+	p.OwnerID = harness.ownerID
+	return p
+}
+
+func expectCode(t *testing.T, err error, code codes.Code) {
+	if err == nil {
+		t.Error("Expected error code [", code, "] but got no error")
+		return
+	}
+	if codes.ToInternalError(err).Code != code {
+		t.Error("Expected error code [", code, "] but got [", err, "]")
+	}
+}
+
 func TestTag(t *testing.T) {
+	setup(t)
+
+	createTag(t, "project", "")
+	createTag(t, "alpha", "project")
+	createTag(t, "design", "alpha")
+	createTag(t, "other", "")
+
+	testHierarchy(t)
+	testMove(t)
+	testAssignments(t)
+	testMerge(t)
+	testDelete(t)
+
+	teardown(t)
+}
+
+func testHierarchy(t *testing.T) {
+	tags, err := proxy("project").LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load tags - ", err)
+	}
+	if len(tags) != 4 {
+		t.Error("Expected 4 tags but got ", len(tags))
+	}
+
+	design := findTag(tags, harness.tags["design"].ID)
+	if path := Path(tags, design); path != "project/alpha/design" {
+		t.Error("Unexpected tag path - ", path)
+	}
+
+	descendants := Descendants(tags, harness.tags["project"].ID)
+	if len(descendants) != 2 {
+		t.Error("Expected project to have 2 descendants but got ", len(descendants))
+	}
+
+	ancestors := Ancestors(tags, design)
+	if len(ancestors) != 2 || ancestors[0].ID != harness.tags["alpha"].ID {
+		t.Error("Expected design ancestors to start with alpha")
+	}
+
+	subtree, err := proxy("alpha").LoadDescendants(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load descendants - ", err)
+	}
+	if len(subtree) != 2 || subtree[0].ID != harness.tags["alpha"].ID {
+		t.Error("Expected alpha subtree to contain alpha & design")
+	}
+}
+
+func testMove(t *testing.T) {
+	// moving a tag below itself or one of its descendants must be rejected
+	err := proxy("project").Move(harness.tags["project"].ID, harness.passphraseKey)
+	expectCode(t, err, codes.ErrorCycle)
+
+	err = proxy("project").Move(harness.tags["design"].ID, harness.passphraseKey)
+	expectCode(t, err, codes.ErrorCycle)
+
+	err = proxy("project").Move(uuid.NewV4(), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorRecordMissing)
+
+	// a valid move
+	err = proxy("other").Move(harness.tags["project"].ID, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to move tag - ", err)
+	}
+	moved := proxy("other")
+	err = moved.Load(harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load moved tag - ", err)
+	}
+	if moved.ParentID != harness.tags["project"].ID {
+		t.Error("Expected moved tag to have a new parent")
+	}
+
+	// and back to the top level
+	err = proxy("other").Move(uuid.Nil, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to move tag to top level - ", err)
+	}
+}
+
+func testAssignments(t *testing.T) {
+	note1 := uuid.NewV4()
+	note2 := uuid.NewV4()
+
+	err := proxy("design").Assign(&Assignment{ObjectID: note1, ObjectType: ObjectNote}, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to assign tag - ", err)
+	}
+	err = proxy("other").Assign(&Assignment{ObjectID: note2, ObjectType: ObjectNote}, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to assign tag - ", err)
+	}
+
+	direct, err := proxy("project").Assignments(false, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load assignments - ", err)
+	}
+	if len(direct) != 0 {
+		t.Error("Expected no direct project assignments but got ", len(direct))
+	}
+
+	nested, err := proxy("project").Assignments(true, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load nested assignments - ", err)
+	}
+	if len(nested) != 1 || nested[0].ObjectID != note1 {
+		t.Error("Expected project to include the design assignment")
+	}
+}
+
+func testMerge(t *testing.T) {
+	// merging into a descendant would orphan the subtree in a loop
+	err := proxy("project").Merge(proxy("design"), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorCycle)
+
+	err = proxy("alpha").Merge(proxy("alpha"), harness.passphraseKey)
+	expectCode(t, err, codes.ErrorCycle)
+
+	// merge alpha into other - design becomes a child of other
+	err = proxy("alpha").Merge(proxy("other"), harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to merge tags - ", err)
+	}
+
+	tags, err := proxy("other").LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load tags - ", err)
+	}
+	if findTag(tags, harness.tags["alpha"].ID) != nil {
+		t.Error("Expected merged tag to be deleted")
+	}
+	design := findTag(tags, harness.tags["design"].ID)
+	if design == nil || design.ParentID != harness.tags["other"].ID {
+		t.Error("Expected design to be re-parented onto other")
+	}
+
+	assignments, err := proxy("other").Assignments(true, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load assignments - ", err)
+	}
+	if len(assignments) != 2 {
+		t.Error("Expected other to have 2 assignments but got ", len(assignments))
+	}
+	project, err := proxy("project").Assignments(true, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load assignments - ", err)
+	}
+	if len(project) != 0 {
+		t.Error("Expected project to have no assignments left but got ", len(project))
+	}
+}
+
+func testDelete(t *testing.T) {
+	err := proxy("alpha").Delete(harness.passphraseKey)
+	expectCode(t, err, codes.ErrorRecordMissing)
+
+	// delete other - design moves up to the top level & other's assignment goes
+	err = proxy("other").Delete(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to delete tag - ", err)
+	}
+
+	tags, err := proxy("project").LoadAll(harness.passphraseKey)
+	if err != nil {
+		t.Fatal("Expected to load tags - ", err)
+	}
+	if findTag(tags, harness.tags["other"].ID) != nil {
+		t.Error("Expected deleted tag to be gone")
+	}
+	design := findTag(tags, harness.tags["design"].ID)
+	if design == nil || design.ParentID != uuid.Nil {
+		t.Error("Expected design to move up to the top level")
+	}
 
+	assignments, err := proxy("other").Assignments(false, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load assignments - ", err)
+	}
+	if len(assignments) != 0 {
+		t.Error("Expected deleted tag to have no assignments but got ", len(assignments))
+	}
+	assignments, err = proxy("design").Assignments(false, harness.passphraseKey)
+	if err != nil {
+		t.Error("Expected to load assignments - ", err)
+	}
+	if len(assignments) != 1 {
+		t.Error("Expected design to keep its assignment but got ", len(assignments))
+	}
 }
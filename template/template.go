@@ -3,6 +3,7 @@ package template
 import (
 	"time"
 
+	"notekeeper-electron-backend/codes"
 	"notekeeper-electron-backend/tag"
 	"notekeeper-electron-backend/title"
 
@@ -26,10 +27,7 @@ type Template struct {
 func New() (*Template, error) {
 	now := time.Now()
 
-	id, err := uuid.NewV4()
-	if err != nil {
-		return nil, err
-	}
+	id := uuid.NewV4()
 
 	template := &Template{
 		ID:      id,
@@ -39,3 +37,13 @@ func New() (*Template, error) {
 
 	return template, nil
 }
+
+// CheckUnlocked fails with ErrorLocked when the template can't be modified
+// Templates aren't stored yet - this is the check for their save & delete paths.
+func (template *Template) CheckUnlocked() error {
+	if template.Locked {
+		code := codes.NewApplication(codes.ScopeTemplate, codes.ErrorLocked)
+		return code
+	}
+	return nil
+}
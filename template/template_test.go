@@ -1,9 +1,24 @@
 package template
 
-import(
+import (
 	"testing"
+
+	"notekeeper-electron-backend/codes"
 )
 
 func TestTemplate(t *testing.T) {
-	
-}
\ No newline at end of file
+	template, err := New()
+	if err != nil {
+		t.Fatal("Expected to create template - ", err)
+	}
+	err = template.CheckUnlocked()
+	if err != nil {
+		t.Error("Expected a new template to be unlocked - ", err)
+	}
+
+	template.Locked = true
+	err = template.CheckUnlocked()
+	if !codes.IsApplicationError(err) || codes.ToInternalError(err).Code != codes.ErrorLocked {
+		t.Error("Expected a locked template to fail with ErrorLocked - ", err)
+	}
+}
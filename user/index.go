@@ -77,6 +77,45 @@ func (index *Index) Save(user *User, passphraseKey []byte) error {
 	return nil
 }
 
+// Remove removes the mapping for a user that was saved with the given salt
+// The mapping is keyed by the salt, so it has to be replaced when the user's
+// passphrase (and with it the salt) changes.
+func (index *Index) Remove(user *User, salt []byte) error {
+	c := crypto.New(index.Logger)
+	accountKey := db.Key{
+		Type: db.TypeAccount,
+		ID:   user.AccountID,
+	}
+	accountDBHandle, err := index.DBRegistry.GetHandle(accountKey)
+	if err != nil {
+		return err
+	}
+	encryptedEmail, err := c.DeriveKey([]byte(user.Profile.Email), salt)
+	if err != nil {
+		index.Logger.Warn("Error deriving user index key - ", err)
+		code := codes.New(codes.ScopeUser, codes.ErrorDeriveKey)
+		return code
+	}
+	saltedKey := c.EmbedSalt(encryptedEmail, salt)
+	err = accountDBHandle.DB.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte("user_index"))
+		if bucket == nil {
+			code := codes.New(codes.ScopeUser, codes.ErrorBucketMissing)
+			return code
+		}
+		return bucket.Delete(saltedKey)
+	})
+	if err != nil {
+		if codes.IsInternalError(err) {
+			return err
+		}
+		index.Logger.Warn("Error removing user index entry - ", err)
+		code := codes.New(codes.ScopeUser, codes.ErrorDelete)
+		return code
+	}
+	return nil
+}
+
 // Lookup a user id in the account database
 func (index *Index) Lookup(user *User) error {
 	originalID := user.ID
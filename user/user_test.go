@@ -85,10 +85,7 @@ func TestUser(t *testing.T) {
 func testSaveUser(t *testing.T) {
 	email := "bob@notekeeper.io"
 	userPassphrase := "password"
-	accountID, err := uuid.NewV4()
-	if err != nil {
-		t.Error("Expected to create an account id - ", err)
-	}
+	accountID := uuid.NewV4()
 
 	newUser, err := New(harness.registry, harness.logger, accountID, email)
 	if err != nil {
@@ -0,0 +1,320 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// KeyLength is the length of the public key & proof of a key exchange
+const KeyLength = 32
+
+// rule checks the fields of one type of request
+type rule func(v *Validator, request proto.Message)
+
+// rules holds the rule for every request type
+// A request type without a rule is rejected, so a method can't be added
+// without deciding what its request may hold.
+var rules = map[reflect.Type]rule{
+	reflect.TypeOf(&messages.EmptyRequest{}):        func(v *Validator, request proto.Message) {},
+	reflect.TypeOf(&messages.AccountStatsRequest{}): func(v *Validator, request proto.Message) {},
+	reflect.TypeOf(&messages.SaveUIStateRequest{}):  func(v *Validator, request proto.Message) {},
+	reflect.TypeOf(&messages.IdRequest{}): func(v *Validator, request proto.Message) {
+		v.ID("id", request.(*messages.IdRequest).Id)
+	},
+	reflect.TypeOf(&messages.ServiceLogsRequest{}): func(v *Validator, request proto.Message) {
+		if request.(*messages.ServiceLogsRequest).Lines < 0 {
+			v.Fail("lines", codes.FieldInvalid, "lines can't be negative")
+		}
+	},
+	reflect.TypeOf(&messages.KeyExchangeRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.KeyExchangeRequest)
+		v.Bytes("publicKey", r.PublicKey, KeyLength)
+		v.Bytes("proof", r.Proof, KeyLength)
+	},
+	reflect.TypeOf(&messages.OpenMasterDbRequest{}): func(v *Validator, request proto.Message) {
+		path := request.(*messages.OpenMasterDbRequest).Path
+		if v.MaxLength("path", path, MaxPathLength) && strings.ContainsRune(path, 0) {
+			v.Fail("path", codes.FieldInvalid, "path can't contain a NUL character")
+		}
+	},
+
+	reflect.TypeOf(&messages.CreateAccountRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CreateAccountRequest)
+		v.Name("name", r.Name)
+		v.Email("email", r.Email)
+		v.Passphrase("passphrase", r.Passphrase)
+	},
+	reflect.TypeOf(&messages.SigninAccountRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.SigninAccountRequest)
+		v.Name("name", r.Name)
+		v.Email("email", r.Email)
+		v.Passphrase("passphrase", r.Passphrase)
+	},
+	reflect.TypeOf(&messages.ChangePassphraseRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.ChangePassphraseRequest)
+		v.Passphrase("passphrase", r.Passphrase)
+		v.Passphrase("newPassphrase", r.NewPassphrase)
+	},
+	reflect.TypeOf(&messages.UnlockAccountRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.UnlockAccountRequest)
+		v.OptionalID("id", r.Id)
+		v.Passphrase("passphrase", r.Passphrase)
+	},
+
+	reflect.TypeOf(&messages.GetShelvesRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.GetShelvesRequest)
+		v.Owner("id", r.Id, "scope", r.Scope)
+		v.ListOptions(r.Options)
+	},
+	reflect.TypeOf(&messages.CreateShelfRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CreateShelfRequest)
+		v.Owner("id", r.Id, "scope", r.Scope)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.SaveShelfRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.SaveShelfRequest)
+		v.ID("id", r.Id)
+		v.Owner("ownerId", r.OwnerId, "scope", r.Scope)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.DeleteShelfRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.DeleteShelfRequest)
+		v.ID("id", r.Id)
+		v.Owner("ownerId", r.OwnerId, "scope", r.Scope)
+	},
+
+	reflect.TypeOf(&messages.GetCollectionsRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.GetCollectionsRequest)
+		v.ID("shelfId", r.ShelfId)
+		v.OptionalEnum("scope", r.Scope, Scopes...)
+	},
+	reflect.TypeOf(&messages.CreateCollectionRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CreateCollectionRequest)
+		v.ID("shelfId", r.ShelfId)
+		v.OptionalEnum("scope", r.Scope, Scopes...)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.SaveCollectionRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.SaveCollectionRequest)
+		v.ID("id", r.Id)
+		v.ID("shelfId", r.ShelfId)
+		v.OptionalEnum("scope", r.Scope, Scopes...)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.DeleteCollectionRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.DeleteCollectionRequest)
+		v.ID("id", r.Id)
+		v.ID("shelfId", r.ShelfId)
+		v.OptionalEnum("scope", r.Scope, Scopes...)
+	},
+
+	reflect.TypeOf(&messages.GetTagsRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.GetTagsRequest)
+		v.Owner("id", r.Id, "scope", r.Scope)
+		v.OptionalID("parentId", r.ParentId)
+		v.ListOptions(r.Options)
+	},
+	reflect.TypeOf(&messages.CreateTagRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CreateTagRequest)
+		v.Owner("id", r.Id, "scope", r.Scope)
+		v.OptionalID("parentId", r.ParentId)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.SaveTagRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.SaveTagRequest)
+		v.ID("id", r.Id)
+		v.Owner("ownerId", r.OwnerId, "scope", r.Scope)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.DeleteTagRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.DeleteTagRequest)
+		v.ID("id", r.Id)
+		v.Owner("ownerId", r.OwnerId, "scope", r.Scope)
+	},
+	reflect.TypeOf(&messages.MoveTagRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.MoveTagRequest)
+		v.ID("id", r.Id)
+		v.Owner("ownerId", r.OwnerId, "scope", r.Scope)
+		v.OptionalID("parentId", r.ParentId)
+	},
+	reflect.TypeOf(&messages.MergeTagRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.MergeTagRequest)
+		v.ID("id", r.Id)
+		v.Owner("ownerId", r.OwnerId, "scope", r.Scope)
+		v.ID("targetId", r.TargetId)
+	},
+	reflect.TypeOf(&messages.AssignTagRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.AssignTagRequest)
+		v.ID("id", r.Id)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+		v.ID("objectId", r.ObjectId)
+		v.Enum("objectType", r.ObjectType, ObjectTypes...)
+	},
+	reflect.TypeOf(&messages.UnassignTagRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.UnassignTagRequest)
+		v.ID("id", r.Id)
+		v.Owner("ownerId", r.OwnerId, "scope", r.Scope)
+		v.ID("objectId", r.ObjectId)
+	},
+
+	reflect.TypeOf(&messages.GetNotebooksRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.GetNotebooksRequest)
+		v.Container(r.Scope, r.OwnerId, r.Container, r.ContainerId)
+		v.ListOptions(r.Options)
+	},
+	reflect.TypeOf(&messages.CreateNotebookRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CreateNotebookRequest)
+		v.Container(r.Scope, r.OwnerId, r.Container, r.ContainerId)
+		v.OptionalID("parentId", r.ParentId)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.SaveNotebookRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.SaveNotebookRequest)
+		v.ID("id", r.Id)
+		v.Container(r.Scope, r.OwnerId, r.Container, r.ContainerId)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.DeleteNotebookRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.DeleteNotebookRequest)
+		v.ID("id", r.Id)
+		v.Container(r.Scope, r.OwnerId, r.Container, r.ContainerId)
+	},
+	reflect.TypeOf(&messages.MoveNotebookRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.MoveNotebookRequest)
+		v.ID("id", r.Id)
+		v.Container(r.Scope, r.OwnerId, r.Container, r.ContainerId)
+		v.OptionalID("parentId", r.ParentId)
+		v.Destination(r.DestinationScope, r.DestinationOwnerId, "destinationContainer", r.DestinationContainer, "destinationContainerId", r.DestinationContainerId)
+	},
+	reflect.TypeOf(&messages.CopyNotebookRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CopyNotebookRequest)
+		v.ID("id", r.Id)
+		v.Container(r.Scope, r.OwnerId, r.Container, r.ContainerId)
+		v.OptionalID("parentId", r.ParentId)
+		v.Destination(r.DestinationScope, r.DestinationOwnerId, "destinationContainer", r.DestinationContainer, "destinationContainerId", r.DestinationContainerId)
+	},
+
+	reflect.TypeOf(&messages.GetNotesRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.GetNotesRequest)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+		v.OptionalID("notebookId", r.NotebookId)
+		v.ListOptions(r.Options)
+	},
+	reflect.TypeOf(&messages.LoadNoteRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.LoadNoteRequest)
+		v.ID("id", r.Id)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+	},
+	reflect.TypeOf(&messages.CreateNoteRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CreateNoteRequest)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+		v.ID("notebookId", r.NotebookId)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.SaveNoteRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.SaveNoteRequest)
+		v.ID("id", r.Id)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+		v.ID("notebookId", r.NotebookId)
+		v.Title("name", r.Name)
+	},
+	reflect.TypeOf(&messages.DeleteNoteRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.DeleteNoteRequest)
+		v.ID("id", r.Id)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+		v.OptionalID("notebookId", r.NotebookId)
+	},
+	reflect.TypeOf(&messages.LockNoteRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.LockNoteRequest)
+		v.ID("id", r.Id)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+	},
+	reflect.TypeOf(&messages.MoveNoteRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.MoveNoteRequest)
+		v.ID("id", r.Id)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+		v.ID("destinationNotebookId", r.DestinationNotebookId)
+		v.Destination(r.DestinationScope, r.DestinationOwnerId, "destinationStore", r.DestinationStore, "destinationStoreId", r.DestinationStoreId)
+	},
+	reflect.TypeOf(&messages.CopyNoteRequest{}): func(v *Validator, request proto.Message) {
+		r := request.(*messages.CopyNoteRequest)
+		v.ID("id", r.Id)
+		v.Store(r.Scope, r.OwnerId, r.Store, r.StoreId)
+		v.ID("destinationNotebookId", r.DestinationNotebookId)
+		v.Destination(r.DestinationScope, r.DestinationOwnerId, "destinationStore", r.DestinationStore, "destinationStoreId", r.DestinationStoreId)
+	},
+}
+
+// Request validates a request message, returning an invalid request error listing every field that failed
+func Request(request proto.Message) error {
+	check, ok := rules[reflect.TypeOf(request)]
+	if !ok {
+		return codes.Invalid(codes.ScopeRPC, codes.FieldError{
+			Field:   "request",
+			Reason:  codes.FieldUnknown,
+			Message: "no validation rule for " + proto.MessageName(request),
+		})
+	}
+	v := &Validator{}
+	check(v, request)
+	return v.Err()
+}
+
+// Known is whether there's a validation rule for a request message
+func Known(request proto.Message) bool {
+	_, ok := rules[reflect.TypeOf(request)]
+	return ok
+}
+
+// Name checks an account name
+func (v *Validator) Name(field string, value string) {
+	if v.Required(field, value) {
+		v.MaxLength(field, value, MaxNameLength)
+	}
+}
+
+// Bytes checks that a field holds exactly length bytes
+func (v *Validator) Bytes(field string, value []byte, length int) {
+	if len(value) == 0 {
+		v.Fail(field, codes.FieldRequired, field+" is required")
+	} else if len(value) != length {
+		v.Fail(field, codes.FieldInvalid, fmt.Sprintf("%s must be %d bytes", field, length))
+	}
+}
+
+// Owner checks the owner id & scope that pick out a user or account
+// The scope of a scoped method comes from its name, so the scope field of the
+// request is optional but has to be a known scope when it's given.
+func (v *Validator) Owner(idField string, id string, scopeField string, scope string) {
+	v.ID(idField, id)
+	v.OptionalEnum(scopeField, scope, Scopes...)
+}
+
+// Container checks the fields that pick out the shelf or collection holding a notebook
+func (v *Validator) Container(scope string, ownerID string, container string, containerID string) {
+	v.Owner("ownerId", ownerID, "scope", scope)
+	v.Enum("container", container, Containers...)
+	v.ID("containerId", containerID)
+}
+
+// Store checks the fields that pick out the shelf or collection holding a note
+func (v *Validator) Store(scope string, ownerID string, store string, storeID string) {
+	v.Owner("ownerId", ownerID, "scope", scope)
+	v.Enum("store", store, Containers...)
+	v.ID("storeId", storeID)
+}
+
+// Destination checks the destination of a move or copy
+// Every part of a destination is optional as it defaults to the source.
+func (v *Validator) Destination(scope string, ownerID string, containerField string, container string, idField string, id string) {
+	v.OptionalEnum("destinationScope", scope, Scopes...)
+	v.OptionalID("destinationOwnerId", ownerID)
+	v.OptionalEnum(containerField, container, Containers...)
+	v.OptionalID(idField, id)
+}
@@ -0,0 +1,197 @@
+// Package validate checks request messages before they reach their handlers
+// Each request type has a rule listing what its fields may hold.  Every field
+// that fails is reported, so the frontend can point at all of them at once,
+// in an ErrorInvalid application error with a FieldError for each field.
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"notekeeper-electron-backend/codes"
+	"notekeeper-electron-backend/note"
+	messages "notekeeper-electron-backend/proto"
+	"notekeeper-electron-backend/title"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Limits on request fields, in characters
+const (
+	MaxTitleLength      = 500
+	MaxNameLength       = 200
+	MaxEmailLength      = 254
+	MaxPathLength       = 4096
+	MaxPassphraseLength = 1024
+)
+
+// Allowed values of the enum fields
+var (
+	Scopes      = []string{"user", "account"}
+	Containers  = []string{"shelf", "collection"}
+	Sorts       = []string{"title", "created", "updated"}
+	LockStates  = []string{"locked", "unlocked"}
+	ObjectTypes = []string{"note", "notebook", "collection", "shelf"}
+)
+
+// Validator collects the fields of a request that fail validation
+type Validator struct {
+	Details []codes.FieldError
+}
+
+// Err returns an invalid request error for the failed fields, or nil if every field passed
+func (v *Validator) Err() error {
+	if len(v.Details) == 0 {
+		return nil
+	}
+	return codes.Invalid(codes.ScopeRPC, v.Details...)
+}
+
+// Fail records a field that failed validation
+func (v *Validator) Fail(field string, reason string, message string) {
+	v.Details = append(v.Details, codes.FieldError{Field: field, Reason: reason, Message: message})
+}
+
+// Required checks that a field isn't empty
+func (v *Validator) Required(field string, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.Fail(field, codes.FieldRequired, field+" is required")
+		return false
+	}
+	return true
+}
+
+// MaxLength checks that a field is valid UTF-8 with no more than max characters
+func (v *Validator) MaxLength(field string, value string, max int) bool {
+	if !utf8.ValidString(value) {
+		v.Fail(field, codes.FieldInvalid, field+" isn't valid UTF-8")
+		return false
+	}
+	if utf8.RuneCountInString(value) > max {
+		v.Fail(field, codes.FieldTooLong, fmt.Sprintf("%s is longer than %d characters", field, max))
+		return false
+	}
+	return true
+}
+
+// ID checks that a field holds a UUID
+func (v *Validator) ID(field string, value string) {
+	if v.Required(field, value) {
+		v.OptionalID(field, value)
+	}
+}
+
+// OptionalID checks that a field is either empty or holds a UUID
+func (v *Validator) OptionalID(field string, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := uuid.FromString(value); err != nil {
+		v.Fail(field, codes.FieldInvalid, field+" isn't a valid id")
+	}
+}
+
+// Enum checks that a field holds one of the allowed values
+func (v *Validator) Enum(field string, value string, allowed ...string) {
+	if v.Required(field, value) {
+		v.OptionalEnum(field, value, allowed...)
+	}
+}
+
+// OptionalEnum checks that a field is either empty or holds one of the allowed values
+func (v *Validator) OptionalEnum(field string, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.Fail(field, codes.FieldUnknown, fmt.Sprintf("%s must be one of %s", field, strings.Join(allowed, ", ")))
+}
+
+// Time checks that a field is either empty or holds an RFC3339 time
+func (v *Validator) Time(field string, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		v.Fail(field, codes.FieldInvalid, field+" isn't an RFC3339 time")
+	}
+}
+
+// Title checks the text & colours of a title, which has to be given
+func (v *Validator) Title(field string, t *messages.Title) {
+	if t == nil {
+		v.Fail(field, codes.FieldRequired, field+" is required")
+		return
+	}
+	if v.Required(field+".text", t.Text) {
+		v.MaxLength(field+".text", t.Text, MaxTitleLength)
+	}
+	formatting := &title.Formatting{}
+	colors := []struct {
+		name  string
+		value string
+	}{
+		{"color", t.Color},
+		{"background", t.Background},
+	}
+	for _, color := range colors {
+		if color.value == "" {
+			continue
+		}
+		if ok, err := formatting.ValidateColor(color.value); !ok || err != nil {
+			v.Fail(field+"."+color.name, codes.FieldInvalid, color.name+" must be a hex colour such as #fff or #ffffff")
+		}
+	}
+}
+
+// Email checks that a field holds something shaped like an email address
+// Addresses are only used to tell the users of an account apart, so this only
+// checks the shape rather than whether the address can receive mail.
+func (v *Validator) Email(field string, value string) {
+	if !v.Required(field, value) || !v.MaxLength(field, value, MaxEmailLength) {
+		return
+	}
+	at := strings.LastIndex(value, "@")
+	if at < 1 || at == len(value)-1 || strings.ContainsAny(value, " \t\r\n") {
+		v.Fail(field, codes.FieldInvalid, field+" isn't an email address")
+	}
+}
+
+// Passphrase checks that a field holds a passphrase
+// The strength of a new passphrase is checked by the configured passphrase
+// policy when the account is created or the passphrase changed.
+func (v *Validator) Passphrase(field string, value string) bool {
+	if value == "" {
+		v.Fail(field, codes.FieldRequired, field+" is required")
+		return false
+	}
+	return v.MaxLength(field, value, MaxPassphraseLength)
+}
+
+// ListOptions checks the sorting, filtering & paging options of a list request, which are optional
+// The options are reported by their own names (e.g. sort) as they were before
+// requests were validated up front.
+func (v *Validator) ListOptions(options *messages.ListOptions) {
+	if options == nil {
+		return
+	}
+	v.OptionalEnum("sort", options.Sort, Sorts...)
+	v.OptionalEnum("locked", options.Locked, LockStates...)
+	if options.Type != "" {
+		if _, ok := note.TypeFromString(options.Type); !ok {
+			v.Fail("type", codes.FieldUnknown, "type isn't a note type")
+		}
+	}
+	if options.Limit < 0 {
+		v.Fail("limit", codes.FieldInvalid, "limit can't be negative")
+	}
+	v.OptionalID("tagId", options.TagId)
+	v.Time("from", options.From)
+	v.Time("until", options.Until)
+}
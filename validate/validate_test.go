@@ -0,0 +1,108 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"notekeeper-electron-backend/codes"
+	messages "notekeeper-electron-backend/proto"
+
+	"github.com/golang/protobuf/proto"
+	uuid "github.com/satori/go.uuid"
+)
+
+// details returns the field errors of a validation error
+func details(t *testing.T, err error) []codes.FieldError {
+	if err == nil {
+		return nil
+	}
+	internal, ok := err.(*codes.InternalError)
+	if !ok || internal.Code != codes.ErrorInvalid || internal.Scope != codes.ScopeRPC {
+		t.Fatal("Expected an invalid request error but got ", err)
+	}
+	return internal.Details
+}
+
+// reasons maps each failed field to its reason
+func reasons(t *testing.T, err error) map[string]string {
+	r := make(map[string]string)
+	for _, detail := range details(t, err) {
+		r[detail.Field] = detail.Reason
+	}
+	return r
+}
+
+func TestRequest(t *testing.T) {
+	id := uuid.NewV4().String()
+	tests := []struct {
+		name    string
+		request proto.Message
+		want    map[string]string
+	}{
+		{"valid shelf", &messages.CreateShelfRequest{Id: id, Scope: "user", Name: &messages.Title{Text: "Shelf", Color: "#fff"}}, map[string]string{}},
+		{"scope from method name", &messages.DeleteShelfRequest{Id: id, OwnerId: id}, map[string]string{}},
+		{"missing everything", &messages.SaveShelfRequest{}, map[string]string{
+			"id": codes.FieldRequired, "ownerId": codes.FieldRequired, "name": codes.FieldRequired,
+		}},
+		{"bad id & scope", &messages.DeleteTagRequest{Id: "abc", OwnerId: id, Scope: "everyone"}, map[string]string{
+			"id": codes.FieldInvalid, "scope": codes.FieldUnknown,
+		}},
+		{"title", &messages.CreateTagRequest{Id: id, Name: &messages.Title{Text: strings.Repeat("é", MaxTitleLength+1), Background: "red"}}, map[string]string{
+			"name.text": codes.FieldTooLong, "name.background": codes.FieldInvalid,
+		}},
+		{"blank title", &messages.CreateCollectionRequest{ShelfId: id, Name: &messages.Title{Text: "  "}}, map[string]string{
+			"name.text": codes.FieldRequired,
+		}},
+		{"container", &messages.GetNotebooksRequest{OwnerId: id, Container: "drawer", ContainerId: id}, map[string]string{
+			"container": codes.FieldUnknown,
+		}},
+		{"destination", &messages.MoveNoteRequest{Id: id, OwnerId: id, Store: "shelf", StoreId: id, DestinationNotebookId: id, DestinationStore: "bin", DestinationStoreId: "x"}, map[string]string{
+			"destinationStore": codes.FieldUnknown, "destinationStoreId": codes.FieldInvalid,
+		}},
+		{"destination notebook", &messages.CopyNoteRequest{Id: id, OwnerId: id, Store: "shelf", StoreId: id}, map[string]string{
+			"destinationNotebookId": codes.FieldRequired,
+		}},
+		{"list options", &messages.GetNotesRequest{OwnerId: id, Store: "collection", StoreId: id, Options: &messages.ListOptions{
+			Sort: "size", Type: "markdown", Limit: -1, TagId: "tag", From: "yesterday",
+		}}, map[string]string{
+			"sort": codes.FieldUnknown, "limit": codes.FieldInvalid, "tagId": codes.FieldInvalid, "from": codes.FieldInvalid,
+		}},
+		{"account", &messages.CreateAccountRequest{Name: "account", Email: "user.example.com", Passphrase: strings.Repeat("a", MaxPassphraseLength+1)}, map[string]string{
+			"email": codes.FieldInvalid, "passphrase": codes.FieldTooLong,
+		}},
+		{"change passphrase", &messages.ChangePassphraseRequest{Passphrase: "current"}, map[string]string{
+			"newPassphrase": codes.FieldRequired,
+		}},
+		{"signin", &messages.SigninAccountRequest{Name: "account", Email: "user@example.com"}, map[string]string{
+			"passphrase": codes.FieldRequired,
+		}},
+		{"key exchange", &messages.KeyExchangeRequest{PublicKey: make([]byte, 31)}, map[string]string{
+			"publicKey": codes.FieldInvalid, "proof": codes.FieldRequired,
+		}},
+		{"path", &messages.OpenMasterDbRequest{Path: "/tmp/a\x00b"}, map[string]string{"path": codes.FieldInvalid}},
+		{"logs", &messages.ServiceLogsRequest{Lines: -5}, map[string]string{"lines": codes.FieldInvalid}},
+		{"empty", &messages.EmptyRequest{}, map[string]string{}},
+	}
+	for _, test := range tests {
+		got := reasons(t, Request(test.request))
+		if len(got) != len(test.want) {
+			t.Error("Expected [", test.name, "] to fail on ", test.want, " but got ", got)
+			continue
+		}
+		for field, reason := range test.want {
+			if got[field] != reason {
+				t.Error("Expected [", test.name, "] field [", field, "] to fail with [", reason, "] but got ", got)
+			}
+		}
+	}
+}
+
+func TestUnknownRequest(t *testing.T) {
+	if Known(&messages.EmptyResponse{}) {
+		t.Error("Expected no rule for a response message")
+	}
+	got := reasons(t, Request(&messages.EmptyResponse{}))
+	if got["request"] != codes.FieldUnknown {
+		t.Error("Expected a request without a rule to be rejected but got ", got)
+	}
+}
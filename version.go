@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+
+	"notekeeper-electron-backend/db"
+	"notekeeper-electron-backend/rpc"
+
+	"github.com/urfave/cli"
+)
+
+// Version is the release version, set at build time with
+// -ldflags "-X main.Version=<version>"
+var Version = "dev"
+
+// printVersion reports the build along with the protocol & schema versions it supports
+func printVersion(c *cli.Context) {
+	fmt.Fprintf(c.App.Writer, "%s %s (%s build)\n", c.App.Name, c.App.Version, BuildType)
+	fmt.Fprintf(c.App.Writer, "protocol version %d\n", rpc.ProtocolVersion)
+	fmt.Fprintf(c.App.Writer, "schema version %d\n", db.SchemaVersion)
+}